@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// metadataCacheDirName is the cache_metadata directory under the runner
+// temp dir (RUNNER_TEMP, else the system temp dir)
+const metadataCacheDirName = "build-metadata-cache"
+
+// metadataCacheEntry is one cached extraction result: the project type
+// and the metadata as it stood before environment collection and output
+// emission.
+type metadataCacheEntry struct {
+	ProjectType string   `json:"project_type"`
+	Metadata    Metadata `json:"metadata"`
+}
+
+// metadataCacheKey returns the cache_metadata key for the run, or ""
+// when caching is disabled. The key covers the project path, the commit,
+// tag and clone depth (tag-derived versions follow them), every action
+// input, and the path, size and modification time of every file in the
+// repository. Extraction reads well beyond the manifests (version files,
+// LICENSE and README, sources, workflows, parent POMs and workspace
+// roots), so editing, adding or removing any of them misses the cache.
+func metadataCacheKey(cfg runConfig, metadata *Metadata) string {
+	if !cfg.cacheMetadata {
+		return ""
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s\n%t\n%#v\n", cfg.absPath, metadata.Common.GitSHA,
+		metadata.Common.GitTag, metadata.Common.GitShallow, cfg)
+	root := cacheFingerprintRoot(cfg.absPath)
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != root && cacheSkippedDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		fmt.Fprintf(hash, "%s\t%d\t%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// cacheSkippedDirs are directories left out of the cache fingerprint:
+// git internals and dependency or build output no extractor reads.
+var cacheSkippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"venv":         true,
+	".venv":        true,
+	"__pycache__":  true,
+	"target":       true,
+	"build":        true,
+	"dist":         true,
+	"bin":          true,
+	"obj":          true,
+	".terraform":   true,
+}
+
+// cacheFingerprintRoot returns the repository root above projectPath (the
+// nearest directory holding .git), or projectPath itself outside a
+// repository.
+func cacheFingerprintRoot(projectPath string) string {
+	dir := projectPath
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return projectPath
+		}
+		dir = parent
+	}
+}
+
+// metadataCachePath returns the cache file for key.
+func metadataCachePath(key string) string {
+	dir := os.Getenv("RUNNER_TEMP")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, metadataCacheDirName, key+".json")
+}
+
+// loadCachedMetadata restores a cached extraction for key into metadata,
+// keeping this run's timestamp, git refs and CI details. It returns the
+// cached project type and whether the cache was hit; any unreadable
+// entry is treated as a miss.
+func loadCachedMetadata(ctx *appContext, key string, metadata *Metadata) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	data, err := os.ReadFile(metadataCachePath(key))
+	if err != nil {
+		return "", false
+	}
+	var entry metadataCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", false
+	}
+
+	cached := entry.Metadata
+	cached.Common.BuildTimestamp = metadata.Common.BuildTimestamp
+	cached.Common.GitBranch = metadata.Common.GitBranch
+	cached.Common.GitTag = metadata.Common.GitTag
+	cached.Common.GitShallow = metadata.Common.GitShallow
+	cached.Build = metadata.Build
+	restoreCachedLanguageValues(cached.LanguageSpecific)
+	*metadata = cached
+
+	ctx.infof("Using cached metadata for project type %s", entry.ProjectType)
+	return entry.ProjectType, true
+}
+
+// storeCachedMetadata writes the extraction result for key. A failure
+// only warns: the cache is an optimization.
+func storeCachedMetadata(ctx *appContext, key string, metadata *Metadata, projectType string) {
+	if key == "" {
+		return
+	}
+	path := metadataCachePath(key)
+	data, err := json.Marshal(metadataCacheEntry{ProjectType: projectType, Metadata: *metadata})
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			err = os.WriteFile(path, data, 0o644)
+		}
+	}
+	if err != nil {
+		if ctx.isCI {
+			ctx.action.Warningf("Failed to write metadata cache %s: %v", path, err)
+		} else {
+			fmt.Printf("Warning: Failed to write metadata cache %s: %v\n", path, err)
+		}
+	}
+}
+
+// restoreCachedLanguageValues turns JSON-decoded string lists back into
+// []string, the type extractors store them as, so outputs and the
+// recommended version render exactly as on an uncached run.
+func restoreCachedLanguageValues(languageSpecific map[string]interface{}) {
+	for key, value := range languageSpecific {
+		items, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				break
+			}
+			values = append(values, s)
+		}
+		if len(values) == len(items) {
+			languageSpecific[key] = values
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// cachedProject writes a pyproject.toml project and returns a config
+// with cache_metadata enabled and the cache under a temporary
+// RUNNER_TEMP.
+func cachedProject(t *testing.T) runConfig {
+	t.Helper()
+	t.Setenv("RUNNER_TEMP", t.TempDir())
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte("[project]\nname = \"cached\"\nversion = \"1.0.0\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return runConfig{absPath: dir, projectPath: dir, cacheMetadata: true}
+}
+
+// storeFixture caches metadata for the project as an extraction would.
+func storeFixture(t *testing.T, cfg runConfig) {
+	t.Helper()
+	metadata := newMetadata(cfg.projectPath, cfg.absPath)
+	metadata.Common.ProjectType = "python-modern"
+	metadata.Common.ProjectName = "cached"
+	metadata.LanguageSpecific = map[string]interface{}{
+		"version_matrix":   []string{"3.11", "3.12"},
+		"dependency_count": 2,
+	}
+	storeCachedMetadata(&appContext{}, metadataCacheKey(cfg, metadata), metadata, "python-modern")
+}
+
+func TestMetadataCacheHit(t *testing.T) {
+	cfg := cachedProject(t)
+	storeFixture(t, cfg)
+
+	metadata := newMetadata(cfg.projectPath, cfg.absPath)
+	metadata.Build.CIRunID = "42"
+	projectType, hit := loadCachedMetadata(&appContext{}, metadataCacheKey(cfg, metadata), metadata)
+	if !hit || projectType != "python-modern" {
+		t.Fatalf("loadCachedMetadata() = %q, %v, want a python-modern hit", projectType, hit)
+	}
+	if metadata.Common.ProjectName != "cached" {
+		t.Errorf("ProjectName = %q, want the cached name", metadata.Common.ProjectName)
+	}
+	if metadata.Build.CIRunID != "42" {
+		t.Errorf("CIRunID = %q, want this run's value kept", metadata.Build.CIRunID)
+	}
+	if got := metadata.LanguageSpecific["version_matrix"]; !reflect.DeepEqual(got, []string{"3.11", "3.12"}) {
+		t.Errorf("version_matrix = %#v, want a restored []string", got)
+	}
+}
+
+func TestMetadataCacheMissOnModification(t *testing.T) {
+	cfg := cachedProject(t)
+	storeFixture(t, cfg)
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(cfg.absPath, "pyproject.toml"), later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := newMetadata(cfg.projectPath, cfg.absPath)
+	if _, hit := loadCachedMetadata(&appContext{}, metadataCacheKey(cfg, metadata), metadata); hit {
+		t.Error("cache hit after the manifest's mtime changed")
+	}
+}
+
+func TestMetadataCacheMissOnNewManifest(t *testing.T) {
+	cfg := cachedProject(t)
+	storeFixture(t, cfg)
+
+	if err := os.WriteFile(filepath.Join(cfg.absPath, "setup.cfg"), []byte("[metadata]\nname = cached\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := newMetadata(cfg.projectPath, cfg.absPath)
+	if _, hit := loadCachedMetadata(&appContext{}, metadataCacheKey(cfg, metadata), metadata); hit {
+		t.Error("cache hit after a new manifest appeared")
+	}
+}
+
+func TestMetadataCacheMissOnNonManifestFile(t *testing.T) {
+	cfg := cachedProject(t)
+	if err := os.WriteFile(filepath.Join(cfg.absPath, ".ruby-version"), []byte("3.2.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	storeFixture(t, cfg)
+
+	if err := os.WriteFile(filepath.Join(cfg.absPath, ".ruby-version"), []byte("3.3.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := newMetadata(cfg.projectPath, cfg.absPath)
+	if _, hit := loadCachedMetadata(&appContext{}, metadataCacheKey(cfg, metadata), metadata); hit {
+		t.Error("cache hit after .ruby-version changed")
+	}
+}
+
+func TestMetadataCacheMissOnRepositoryFile(t *testing.T) {
+	t.Setenv("RUNNER_TEMP", t.TempDir())
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(root, "module")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte("<project/>\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := runConfig{absPath: dir, projectPath: dir, cacheMetadata: true}
+	storeFixture(t, cfg)
+
+	// A parent POM at the repository root sits outside the project
+	if err := os.WriteFile(filepath.Join(root, "pom.xml"), []byte("<project/>\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := newMetadata(cfg.projectPath, cfg.absPath)
+	if _, hit := loadCachedMetadata(&appContext{}, metadataCacheKey(cfg, metadata), metadata); hit {
+		t.Error("cache hit after a file above the project changed")
+	}
+}
+
+func TestMetadataCacheDisabled(t *testing.T) {
+	cfg := cachedProject(t)
+	cfg.cacheMetadata = false
+	if key := metadataCacheKey(cfg, newMetadata(cfg.projectPath, cfg.absPath)); key != "" {
+		t.Errorf("metadataCacheKey() = %q with cache_metadata off, want empty", key)
+	}
+}
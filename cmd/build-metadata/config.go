@@ -11,36 +11,151 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 	"github.com/sethvargo/go-githubactions"
 )
 
 // runConfig holds the resolved action inputs for a single invocation.
 type runConfig struct {
-	verboseOutput      bool
-	absPath            string
-	outputFormats      []string
+	verboseOutput bool
+	// quiet drops informational and debug logging, keeping warnings and
+	// errors; it takes precedence over verbose.
+	quiet         bool
+	absPath       string
+	outputFormats []string
+	// addSummary posts the step summary for outputFormats other than
+	// summary and both, which post it anyway; it needs the step summary
+	// file GitHub Actions provides.
+	addSummary         bool
 	includeEnvironment bool
-	useVersionExtract  bool
-	artifactUpload     bool
-	artifactNamePrefix string
-	artifactFormats    []string
-	validateOutput     bool
-	exportEnvVars      bool
-	pythonOffline      bool
-	pythonTimeout      time.Duration
-	pythonRetries      int
+	// environmentSections names the environment metadata sections
+	// include_environment collects.
+	environmentSections []string
+	useVersionExtract   bool
+	artifactUpload      bool
+	artifactNamePrefix  string
+	artifactFormats     []string
+	validateOutput      bool
+	exportEnvVars       bool
+	pythonOffline       bool
+	pythonTimeout       time.Duration
+	pythonRetries       int
+	// failOnVersionTagMismatch fails the run when a tag build's project
+	// version disagrees with the tag.
+	failOnVersionTagMismatch bool
+	// requireSemver fails the run when a static project version is not
+	// semver; requireSemverStrict extends the check to dynamic versions.
+	requireSemver       bool
+	requireSemverStrict bool
+	// externalExtractors lists user-supplied extractor executables and
+	// externalExtractorTimeout bounds each invocation.
+	externalExtractors       []string
+	externalExtractorTimeout time.Duration
+	// artifactRetentionDays is passed through for the calling workflow's
+	// upload step (0 keeps the repository default); artifactCompress
+	// bundles the artifact files into a .tar.gz.
+	artifactRetentionDays int
+	artifactCompress      bool
+	// manifestFile, when set, forces manifestProjectType and narrows
+	// absPath to the manifest's directory; projectPath keeps the
+	// resolved path_prefix.
+	manifestFile        string
+	manifestProjectType string
+	projectPath         string
+	// includeRuntimeVersions probes the detected project type's
+	// toolchain (e.g. `go version`) into the environment metadata.
+	includeRuntimeVersions bool
+	// staticMatrices restricts version-matrix generation to the static
+	// fallback tables without disabling other network features.
+	staticMatrices bool
+	// useGitVersion lets Go modules take their version from the newest
+	// reachable semver tag.
+	useGitVersion bool
+	// useChangelogVersion falls back to the newest release heading of
+	// CHANGELOG.md when no manifest declares a version.
+	useChangelogVersion bool
+	// descriptionFromReadme falls back to the README's first paragraph
+	// when the manifest has no description.
+	descriptionFromReadme bool
+	// strictManifest makes a malformed manifest fail the run instead of
+	// only warning.
+	strictManifest bool
+	// dryRun collects outputs and environment variables and prints them
+	// instead of writing GITHUB_OUTPUT and GITHUB_ENV; dryRunFile
+	// optionally receives the same list.
+	dryRun     bool
+	dryRunFile string
+	// outputFile receives the full metadata in the first requested
+	// output format; "{format}" in it names that format.
+	outputFile string
+	// jsonIndent is the metadata_json indentation per level; empty
+	// renders the document on one line.
+	jsonIndent string
+	// captureEnvVars names environment variables recorded under
+	// environment.captured.
+	captureEnvVars []string
+	// includeHygiene records the project's hygiene files (pre-commit,
+	// license, code owners, ...) in the metadata.
+	includeHygiene bool
+	// includeManifestHashes records the SHA-256 of each manifest the
+	// extractor read.
+	includeManifestHashes bool
+	// analyzeWorkflows records the actions and reusable workflows the
+	// repository's GitHub Actions workflows use.
+	analyzeWorkflows bool
+	// depsChangedSince is the git ref whose lockfile the current one is
+	// compared with; empty disables the comparison.
+	depsChangedSince string
+	// originalPath is the absolute path_prefix before symlinks were
+	// resolved (resolve_symlinks); it equals projectPath when the path
+	// holds no symlink or resolution is disabled.
+	originalPath string
+	// inputProjectType is the validated project_type input; when set it
+	// replaces auto-detection and the manifest_file type.
+	inputProjectType string
+	// cacheMetadata reuses a previous run's extraction from the runner
+	// temp dir while the manifests are unchanged.
+	cacheMetadata bool
+	// inputs holds every recognized input's effective value for the
+	// verbose input echo.
+	inputs inputValues
+	// onlyChanged withholds the outputs of fields unchanged since the
+	// metadata JSON in previousMetadataFile.
+	onlyChanged          bool
+	previousMetadataFile string
+	// includeDependencies is the include_dependencies mode: all,
+	// counts-only or none.
+	includeDependencies string
+	// maxDependencies caps each dependency list or map; 0 disables
+	// the cap.
+	maxDependencies int
+	// metadataOverrides replaces common fields after extraction.
+	metadataOverrides metadataOverrides
+	// suppressWarnings lists the warning categories not to log.
+	suppressWarnings []string
+}
+
+// extractorLogLevel maps the quiet and verbose inputs onto the level of
+// the extractors' debug logger.
+func (cfg runConfig) extractorLogLevel() extractor.LogLevel {
+	switch {
+	case cfg.quiet:
+		return extractor.LogQuiet
+	case cfg.verboseOutput:
+		return extractor.LogVerbose
+	}
+	return extractor.LogNormal
 }
 
 // parseFlags resolves every action input. Failure to resolve the
 // project path is fatal and terminates the process, matching the
 // original behavior (action.Fatalf in CI, os.Exit(1) locally).
 func parseFlags(action *githubactions.Action, isCI bool) runConfig {
-	verboseOutput := action.GetInput("verbose") == "true"
+	inputs := collectInputs(action)
+	quiet := inputs.get("quiet") == "true"
+	verboseOutput := inputs.get("verbose") == "true" && !quiet
 
-	projectPath := action.GetInput("path_prefix")
-	if projectPath == "" {
-		projectPath = "."
-	}
+	projectPath := inputs.get("path_prefix")
 
 	// Convert to absolute path
 	absPath, err := filepath.Abs(projectPath)
@@ -53,35 +168,180 @@ func parseFlags(action *githubactions.Action, isCI bool) runConfig {
 		}
 	}
 
-	// Artifact upload inputs
-	artifactNamePrefix := action.GetInput("artifact_name_prefix")
-	if artifactNamePrefix == "" {
-		artifactNamePrefix = "build-metadata"
+	// Detect against the real directory when path_prefix is a symlink
+	originalPath := absPath
+	if inputs.get("resolve_symlinks") != "false" {
+		absPath, err = resolveProjectSymlinks(absPath)
+		if err != nil {
+			if isCI {
+				action.Fatalf("Failed to resolve project path: %v", err)
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: Failed to resolve project path: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	// An explicit manifest overrides auto-detection and relocates the
+	// project path to the manifest's directory
+	inputPath := absPath
+	manifestFile := inputs.get("manifest_file")
+	manifestProjectType := ""
+	if manifestFile != "" {
+		manifestProjectType, absPath, err = resolveManifestFile(absPath, manifestFile)
+		if err != nil {
+			if isCI {
+				action.Fatalf("Invalid manifest_file: %v", err)
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: Invalid manifest_file: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	// An explicit project type overrides detection by name
+	inputProjectType := ""
+	if raw := inputs.get("project_type"); raw != "" {
+		inputProjectType, err = resolveProjectTypeInput(raw)
+		if err != nil {
+			if isCI {
+				action.Fatalf("Invalid project_type: %v", err)
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: Invalid project_type: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	includeDependencies, err := parseIncludeDependencies(inputs.get("include_dependencies"))
+	if err != nil {
+		if isCI {
+			action.Fatalf("Invalid include_dependencies: %v", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: Invalid include_dependencies: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	maxDependencies, err := parseMaxDependencies(inputs.get("max_dependencies"))
+	if err != nil {
+		if isCI {
+			action.Fatalf("Invalid max_dependencies: %v", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: Invalid max_dependencies: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	overrides, err := parseMetadataOverrides(inputs.get("metadata_overrides"))
+	if err != nil {
+		if isCI {
+			action.Fatalf("Invalid metadata_overrides: %v", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: Invalid metadata_overrides: %v\n", err)
+			os.Exit(1)
+		}
 	}
-	artifactFormatsInput := action.GetInput("artifact_formats")
-	if artifactFormatsInput == "" {
-		artifactFormatsInput = "json"
+
+	environmentSections, err := parseEnvironmentSections(inputs.get("environment_sections"))
+	if err != nil {
+		if isCI {
+			action.Fatalf("Invalid environment_sections: %v", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: Invalid environment_sections: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	pythonTimeout, pythonRetries := parsePythonEOLSettings(action)
+	jsonIndent, err := parseJSONIndent(inputs.get("json_indent"))
+	if err != nil {
+		if isCI {
+			action.Fatalf("Invalid json_indent: %v", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: Invalid json_indent: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	suppressWarnings, err := parseSuppressWarnings(inputs.get("suppress_warnings"))
+	if err != nil {
+		if isCI {
+			action.Fatalf("Invalid suppress_warnings: %v", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: Invalid suppress_warnings: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Artifact upload inputs
+	artifactNamePrefix := inputs.get("artifact_name_prefix")
+	artifactFormatsInput := inputs.get("artifact_formats")
+
+	pythonTimeout, pythonRetries := parsePythonEOLSettings(inputs)
+
+	artifactRetentionDays := 0
+	if raw := inputs.get("artifact_retention_days"); raw != "" {
+		if parsed, perr := strconv.Atoi(raw); perr == nil && parsed >= 0 {
+			artifactRetentionDays = parsed
+		}
+	}
 
 	return runConfig{
 		verboseOutput: verboseOutput,
+		quiet:         quiet,
 		absPath:       absPath,
 		// Output formats can be comma, space, or newline separated. An
 		// explicit empty string disables output; when unset the
 		// action.yaml default ("summary") is already applied upstream.
-		outputFormats:      parseMultiSeparatorInput(action.GetInput("output_format")),
-		includeEnvironment: action.GetInput("include_environment") != "false",
-		useVersionExtract:  action.GetInput("use_version_extract") != "false",
-		artifactUpload:     action.GetInput("artifact_upload") != "false",
-		artifactNamePrefix: artifactNamePrefix,
-		artifactFormats:    parseMultiSeparatorInput(artifactFormatsInput),
-		validateOutput:     action.GetInput("validate_output") != "false",
-		exportEnvVars:      action.GetInput("export_env_vars") == "true",
-		pythonOffline:      action.GetInput("python_offline_mode") == "true",
-		pythonTimeout:      pythonTimeout,
-		pythonRetries:      pythonRetries,
+		outputFormats:       parseMultiSeparatorInput(inputs.get("output_format")),
+		addSummary:          inputs.get("add_summary") != "false" && os.Getenv("GITHUB_STEP_SUMMARY") != "",
+		includeEnvironment:  inputs.get("include_environment") != "false",
+		environmentSections: environmentSections,
+		useVersionExtract:   inputs.get("use_version_extract") != "false",
+		artifactUpload:      inputs.get("artifact_upload") != "false",
+		artifactNamePrefix:  artifactNamePrefix,
+		artifactFormats:     parseMultiSeparatorInput(artifactFormatsInput),
+		validateOutput:      inputs.get("validate_output") != "false",
+		exportEnvVars:       inputs.get("export_env_vars") == "true",
+		pythonOffline:       inputs.get("python_offline_mode") == "true",
+		pythonTimeout:       pythonTimeout,
+		pythonRetries:       pythonRetries,
+
+		failOnVersionTagMismatch: inputs.get("fail_on_version_tag_mismatch") == "true",
+		requireSemver:            inputs.get("require_semver") == "true",
+		requireSemverStrict:      inputs.get("require_semver_strict") == "true",
+		externalExtractors:       parseMultiSeparatorInput(inputs.get("external_extractors")),
+		externalExtractorTimeout: parseExternalExtractorTimeout(inputs),
+		artifactRetentionDays:    artifactRetentionDays,
+		artifactCompress:         inputs.get("artifact_compress") == "true",
+		manifestFile:             manifestFile,
+		manifestProjectType:      manifestProjectType,
+		projectPath:              inputPath,
+		includeRuntimeVersions:   inputs.get("include_runtime_versions") == "true",
+		staticMatrices:           inputs.get("static_matrices") == "true",
+		useGitVersion:            inputs.get("use_git_version") != "false",
+		useChangelogVersion:      inputs.get("use_changelog_version") == "true",
+		descriptionFromReadme:    inputs.get("description_from_readme") == "true",
+		strictManifest:           inputs.get("strict_manifest") == "true",
+		dryRun:                   inputs.get("dry_run") == "true",
+		dryRunFile:               inputs.get("dry_run_file"),
+		outputFile:               inputs.get("output_file"),
+		jsonIndent:               jsonIndent,
+		captureEnvVars:           parseMultiSeparatorInput(inputs.get("capture_env_vars")),
+		includeHygiene:           inputs.get("include_hygiene") == "true",
+		includeManifestHashes:    inputs.get("include_manifest_hashes") == "true",
+		analyzeWorkflows:         inputs.get("analyze_workflows") == "true",
+		depsChangedSince:         strings.TrimSpace(inputs.get("deps_changed_since")),
+		originalPath:             originalPath,
+		inputProjectType:         inputProjectType,
+		cacheMetadata:            inputs.get("cache_metadata") == "true",
+		inputs:                   inputs,
+		onlyChanged:              inputs.get("only_changed") == "true",
+		previousMetadataFile:     inputs.get("previous_metadata_file"),
+		includeDependencies:      includeDependencies,
+		maxDependencies:          maxDependencies,
+		metadataOverrides:        overrides,
+		suppressWarnings:         suppressWarnings,
 	}
 }
 
@@ -91,21 +351,21 @@ func parseFlags(action *githubactions.Action, isCI bool) runConfig {
 // truth for user-facing defaults; these are only consulted when the
 // action runs outside GitHub Actions or the supplied input is
 // unparsable.
-func parsePythonEOLSettings(action *githubactions.Action) (time.Duration, int) {
+func parsePythonEOLSettings(inputs inputValues) (time.Duration, int) {
 	const (
 		defaultPythonEOLTimeoutSeconds = 5 // matches action.yaml
 		defaultPythonEOLMaxRetries     = 2 // matches action.yaml
 	)
 
 	timeout := time.Duration(defaultPythonEOLTimeoutSeconds) * time.Second
-	if raw := action.GetInput("python_eol_timeout"); raw != "" {
+	if raw := inputs.get("python_eol_timeout"); raw != "" {
 		if parsed, perr := strconv.Atoi(raw); perr == nil && parsed > 0 {
 			timeout = time.Duration(parsed) * time.Second
 		}
 	}
 
 	retries := defaultPythonEOLMaxRetries
-	if raw := action.GetInput("python_eol_max_retries"); raw != "" {
+	if raw := inputs.get("python_eol_max_retries"); raw != "" {
 		if parsed, perr := strconv.Atoi(raw); perr == nil && parsed >= 0 {
 			retries = parsed
 		}
@@ -114,6 +374,20 @@ func parsePythonEOLSettings(action *githubactions.Action) (time.Duration, int) {
 	return timeout, retries
 }
 
+// parseExternalExtractorTimeout parses the per-executable timeout for
+// external extractors. As with the Python settings, the fallback MUST
+// match the action.yaml default.
+func parseExternalExtractorTimeout(inputs inputValues) time.Duration {
+	const defaultExternalExtractorTimeoutSeconds = 30 // matches action.yaml
+
+	if raw := inputs.get("external_extractor_timeout"); raw != "" {
+		if parsed, perr := strconv.Atoi(raw); perr == nil && parsed > 0 {
+			return time.Duration(parsed) * time.Second
+		}
+	}
+	return time.Duration(defaultExternalExtractorTimeoutSeconds) * time.Second
+}
+
 // parseMultiSeparatorInput normalizes input that can be comma, space, or newline separated
 // into a slice of trimmed strings. Empty strings are filtered out.
 func parseMultiSeparatorInput(input string) []string {
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// applyDefaultBranch records the repository's default branch and
+// whether the run is on it. GitHub names the default branch in the
+// event payload (GITHUB_DEFAULT_BRANCH overrides it); elsewhere, and
+// when GitHub gives none, the clone's origin/HEAD is read, which is only
+// set for clones made with a plain git clone. IsDefaultBranch stays
+// empty when the default branch or the current ref is unknown.
+func applyDefaultBranch(metadata *Metadata, absPath string) {
+	platform := detectCIPlatform()
+	defaultBranch := ""
+	if platform == "github" {
+		defaultBranch = gitHubDefaultBranch()
+	}
+	if defaultBranch == "" {
+		defaultBranch = gitOriginDefaultBranch(absPath)
+	}
+	metadata.Common.DefaultBranch = defaultBranch
+
+	current := metadata.Common.GitBranch
+	switch {
+	case current != "":
+	case metadata.Common.GitTag != "":
+		// A tag build is on no branch; the full tag ref cannot match
+		current = "refs/tags/" + metadata.Common.GitTag
+	case platform == "github":
+		// A pull request carries no branch but still has a ref name
+		// ("42/merge"), which never equals a branch name
+		current = os.Getenv("GITHUB_REF_NAME")
+	case platform == "":
+		current = gitCurrentBranch(absPath)
+	}
+	metadata.Common.IsDefaultBranch = isDefaultBranch(current, defaultBranch)
+}
+
+// isDefaultBranch returns "true"/"false" comparing the current ref name
+// against the default branch, or "" when either is unknown.
+func isDefaultBranch(current, defaultBranch string) string {
+	if current == "" || defaultBranch == "" {
+		return ""
+	}
+	return fmt.Sprintf("%t", current == defaultBranch)
+}
+
+// gitHubDefaultBranch returns GITHUB_DEFAULT_BRANCH, else the
+// repository.default_branch of the event payload at GITHUB_EVENT_PATH.
+func gitHubDefaultBranch() string {
+	if branch := strings.TrimSpace(os.Getenv("GITHUB_DEFAULT_BRANCH")); branch != "" {
+		return branch
+	}
+	path := os.Getenv("GITHUB_EVENT_PATH")
+	if path == "" {
+		return ""
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var event struct {
+		Repository struct {
+			DefaultBranch string `json:"default_branch"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(content, &event); err != nil {
+		return ""
+	}
+	return event.Repository.DefaultBranch
+}
+
+// gitOriginDefaultBranch returns the branch refs/remotes/origin/HEAD
+// points at, or "" when the clone has no such ref.
+func gitOriginDefaultBranch(absPath string) string {
+	output, err := exec.Command("git", "-C", absPath, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/")
+}
+
+// gitCurrentBranch returns the branch checked out in absPath, or ""
+// for a detached HEAD or outside a repository.
+func gitCurrentBranch(absPath string) string {
+	output, err := exec.Command("git", "-C", absPath, "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
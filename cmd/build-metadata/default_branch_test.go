@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// clearCIEnv empties the platform markers and default branch sources,
+// so the CI running the tests cannot leak into a case.
+func clearCIEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"GITHUB_ACTIONS", "TF_BUILD", "BITBUCKET_BUILD_NUMBER",
+		"GITHUB_DEFAULT_BRANCH", "GITHUB_EVENT_PATH", "GITHUB_REF_NAME"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestApplyDefaultBranchGitHub(t *testing.T) {
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(eventPath, []byte(`{"ref": "refs/heads/main", "repository": {"default_branch": "main"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		branch  string
+		tag     string
+		refName string
+		want    string
+	}{
+		{name: "push to the default branch", branch: "main", refName: "main", want: "true"},
+		{name: "push to another branch", branch: "feature/x", refName: "feature/x", want: "false"},
+		{name: "pull request", refName: "42/merge", want: "false"},
+		{name: "tag", tag: "main", refName: "main", want: "false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearCIEnv(t)
+			t.Setenv("GITHUB_ACTIONS", "true")
+			t.Setenv("GITHUB_EVENT_PATH", eventPath)
+			t.Setenv("GITHUB_REF_NAME", tt.refName)
+
+			metadata := &Metadata{Common: CommonMetadata{GitBranch: tt.branch, GitTag: tt.tag}}
+			applyDefaultBranch(metadata, t.TempDir())
+			if metadata.Common.DefaultBranch != "main" {
+				t.Errorf("DefaultBranch = %q, want main", metadata.Common.DefaultBranch)
+			}
+			if metadata.Common.IsDefaultBranch != tt.want {
+				t.Errorf("IsDefaultBranch = %q, want %q", metadata.Common.IsDefaultBranch, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyDefaultBranchOverride(t *testing.T) {
+	clearCIEnv(t)
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_DEFAULT_BRANCH", "develop")
+
+	metadata := &Metadata{Common: CommonMetadata{GitBranch: "develop"}}
+	applyDefaultBranch(metadata, t.TempDir())
+	if metadata.Common.DefaultBranch != "develop" || metadata.Common.IsDefaultBranch != "true" {
+		t.Errorf("DefaultBranch, IsDefaultBranch = %q, %q, want develop, true",
+			metadata.Common.DefaultBranch, metadata.Common.IsDefaultBranch)
+	}
+}
+
+func TestApplyDefaultBranchUnknown(t *testing.T) {
+	clearCIEnv(t)
+	metadata := &Metadata{Common: CommonMetadata{GitBranch: "main"}}
+	applyDefaultBranch(metadata, t.TempDir())
+	if metadata.Common.DefaultBranch != "" || metadata.Common.IsDefaultBranch != "" {
+		t.Errorf("DefaultBranch, IsDefaultBranch = %q, %q, want both empty",
+			metadata.Common.DefaultBranch, metadata.Common.IsDefaultBranch)
+	}
+}
+
+// TestApplyDefaultBranchOriginHead reads the default branch of a local
+// run from the clone's origin/HEAD and the branch from HEAD.
+func TestApplyDefaultBranchOriginHead(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	clearCIEnv(t)
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=trunk"},
+		{"symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/trunk"},
+	} {
+		if output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	metadata := &Metadata{}
+	applyDefaultBranch(metadata, dir)
+	if metadata.Common.DefaultBranch != "trunk" || metadata.Common.IsDefaultBranch != "true" {
+		t.Errorf("DefaultBranch, IsDefaultBranch = %q, %q, want trunk, true",
+			metadata.Common.DefaultBranch, metadata.Common.IsDefaultBranch)
+	}
+
+	metadata = &Metadata{Common: CommonMetadata{GitBranch: "release-1.x"}}
+	applyDefaultBranch(metadata, dir)
+	if metadata.Common.IsDefaultBranch != "false" {
+		t.Errorf("IsDefaultBranch = %q on release-1.x, want false", metadata.Common.IsDefaultBranch)
+	}
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Values of the include_dependencies input
+const (
+	includeDependenciesAll        = "all"
+	includeDependenciesCountsOnly = "counts-only"
+	includeDependenciesNone       = "none"
+)
+
+// parseIncludeDependencies validates the include_dependencies input; an
+// empty value selects all.
+func parseIncludeDependencies(raw string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(raw)); mode {
+	case "":
+		return includeDependenciesAll, nil
+	case includeDependenciesAll, includeDependenciesCountsOnly, includeDependenciesNone:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%q is not one of all, counts-only, none", raw)
+	}
+}
+
+// defaultMaxDependencies is the max_dependencies fallback, matching the
+// action.yaml default
+const defaultMaxDependencies = 500
+
+// parseMaxDependencies validates the max_dependencies input: a
+// non-negative entry count, where 0 disables the cap and an empty value
+// selects defaultMaxDependencies.
+func parseMaxDependencies(raw string) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return defaultMaxDependencies, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("%q is not a non-negative integer", raw)
+	}
+	return limit, nil
+}
+
+// filterDependencies applies include_dependencies to the extracted
+// language-specific fields. A field is a dependency field when its key
+// mentions dependencies; counts-only drops those holding a list or map
+// (e.g. dependencies, dev_dependencies, dependency_map) and keeps the
+// counts, none drops every dependency field.
+func filterDependencies(cfg runConfig, metadata *Metadata) {
+	if cfg.includeDependencies == includeDependenciesAll || cfg.includeDependencies == "" {
+		return
+	}
+	for key, value := range metadata.LanguageSpecific {
+		if !strings.Contains(key, "dependenc") {
+			continue
+		}
+		if cfg.includeDependencies == includeDependenciesNone || isCollection(value) {
+			delete(metadata.LanguageSpecific, key)
+		}
+	}
+}
+
+// isCollection reports whether value is a slice, array or map.
+func isCollection(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// truncateDependencies caps every dependency list or map in the
+// language-specific fields at max_dependencies entries, keeping the
+// first entries of a list and the alphabetically first keys of a map,
+// and sets dependencies_truncated when anything was cut. The counts are
+// left as extracted, so they still report the full number.
+func truncateDependencies(cfg runConfig, metadata *Metadata) {
+	if cfg.maxDependencies <= 0 {
+		return
+	}
+	truncated := false
+	for key, value := range metadata.LanguageSpecific {
+		if !strings.Contains(key, "dependenc") {
+			continue
+		}
+		if capped, ok := capCollection(value, cfg.maxDependencies); ok {
+			metadata.LanguageSpecific[key] = capped
+			truncated = true
+		}
+	}
+	if truncated {
+		metadata.LanguageSpecific["dependencies_truncated"] = true
+	}
+}
+
+// capCollection returns value cut to limit entries, and false when it is
+// not a slice, array or string-keyed map longer than limit.
+func capCollection(value interface{}, limit int) (interface{}, bool) {
+	if !isCollection(value) {
+		return nil, false
+	}
+	v := reflect.ValueOf(value)
+	if v.Len() <= limit {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v.Slice(0, limit).Interface(), true
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		capped := reflect.MakeMapWithSize(v.Type(), limit)
+		for _, key := range keys[:limit] {
+			capped.SetMapIndex(key, v.MapIndex(key))
+		}
+		return capped.Interface(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// dependencyFixture returns language-specific fields as a Python
+// extraction leaves them.
+func dependencyFixture() *Metadata {
+	return &Metadata{LanguageSpecific: map[string]interface{}{
+		"dependencies":             []string{"requests>=2.28", "click>=8"},
+		"optional_dependencies":    map[string][]string{"test": {"pytest"}},
+		"dependency_count":         2,
+		"runtime_dependency_count": 2,
+		"total_dependency_count":   3,
+		"dependencies_source":      "pyproject.toml",
+		"version_matrix":           []string{"3.12", "3.13"},
+	}}
+}
+
+func TestFilterDependenciesCountsOnly(t *testing.T) {
+	metadata := dependencyFixture()
+	filterDependencies(runConfig{includeDependencies: includeDependenciesCountsOnly}, metadata)
+
+	for _, key := range []string{"dependencies", "optional_dependencies"} {
+		if _, ok := metadata.LanguageSpecific[key]; ok {
+			t.Errorf("%s kept in counts-only mode", key)
+		}
+	}
+	for key, want := range map[string]interface{}{
+		"dependency_count":         2,
+		"runtime_dependency_count": 2,
+		"total_dependency_count":   3,
+		"dependencies_source":      "pyproject.toml",
+		"version_matrix":           []string{"3.12", "3.13"},
+	} {
+		if got := metadata.LanguageSpecific[key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %#v, want %#v", key, got, want)
+		}
+	}
+}
+
+func TestFilterDependenciesNone(t *testing.T) {
+	metadata := dependencyFixture()
+	filterDependencies(runConfig{includeDependencies: includeDependenciesNone}, metadata)
+
+	if want := map[string]interface{}{"version_matrix": []string{"3.12", "3.13"}}; !reflect.DeepEqual(metadata.LanguageSpecific, want) {
+		t.Errorf("LanguageSpecific = %#v, want only the non-dependency fields", metadata.LanguageSpecific)
+	}
+}
+
+func TestFilterDependenciesAll(t *testing.T) {
+	metadata := dependencyFixture()
+	filterDependencies(runConfig{includeDependencies: includeDependenciesAll}, metadata)
+	if !reflect.DeepEqual(metadata, dependencyFixture()) {
+		t.Errorf("all mode changed the metadata: %#v", metadata.LanguageSpecific)
+	}
+}
+
+func TestParseIncludeDependencies(t *testing.T) {
+	for raw, want := range map[string]string{"": "all", "all": "all", "Counts-Only": "counts-only", " none ": "none"} {
+		if got, err := parseIncludeDependencies(raw); err != nil || got != want {
+			t.Errorf("parseIncludeDependencies(%q) = %q, %v, want %q", raw, got, err, want)
+		}
+	}
+	if _, err := parseIncludeDependencies("lists"); err == nil {
+		t.Error("parseIncludeDependencies(\"lists\") succeeded, want an error")
+	}
+}
+
+func TestTruncateDependencies(t *testing.T) {
+	metadata := dependencyFixture()
+	metadata.LanguageSpecific["dependency_map"] = map[string]string{"c": "3", "a": "1", "b": "2"}
+	truncateDependencies(runConfig{maxDependencies: 1}, metadata)
+
+	for key, want := range map[string]interface{}{
+		"dependencies":           []string{"requests>=2.28"},
+		"dependency_map":         map[string]string{"a": "1"},
+		"optional_dependencies":  map[string][]string{"test": {"pytest"}},
+		"dependency_count":       2,
+		"total_dependency_count": 3,
+		"version_matrix":         []string{"3.12", "3.13"},
+		"dependencies_truncated": true,
+	} {
+		if got := metadata.LanguageSpecific[key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %#v, want %#v", key, got, want)
+		}
+	}
+}
+
+func TestTruncateDependenciesWithinCap(t *testing.T) {
+	for _, limit := range []int{0, 2} {
+		metadata := dependencyFixture()
+		truncateDependencies(runConfig{maxDependencies: limit}, metadata)
+		if !reflect.DeepEqual(metadata, dependencyFixture()) {
+			t.Errorf("max_dependencies=%d changed the metadata: %#v", limit, metadata.LanguageSpecific)
+		}
+	}
+}
+
+func TestParseMaxDependencies(t *testing.T) {
+	for raw, want := range map[string]int{"": 500, "0": 0, " 25 ": 25} {
+		if got, err := parseMaxDependencies(raw); err != nil || got != want {
+			t.Errorf("parseMaxDependencies(%q) = %d, %v, want %d", raw, got, err, want)
+		}
+	}
+	for _, raw := range []string{"-1", "many"} {
+		if _, err := parseMaxDependencies(raw); err == nil {
+			t.Errorf("parseMaxDependencies(%q) succeeded, want an error", raw)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Values of description_source
+const (
+	descriptionSourceManifest = "manifest"
+	descriptionSourceReadme   = "README"
+)
+
+// readmeDescriptionMaxLength caps a README-derived description, in
+// characters, cut back to a word boundary
+const readmeDescriptionMaxLength = 300
+
+// readmeFiles are the READMEs description_from_readme reads, in order
+// of preference; README.MD and readme.md match too
+var readmeFiles = []string{"README.md", "README.rst"}
+
+var (
+	// markdownImageRe matches an inline image, badges included
+	markdownImageRe = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	// markdownLinkRe matches an inline link, capturing its text
+	markdownLinkRe = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	// rstLinkRe matches an RST hyperlink reference, capturing its text
+	rstLinkRe = regexp.MustCompile("`([^`<]+?)\\s*<[^>]*>`__?")
+	// htmlTagRe matches an inline HTML tag
+	htmlTagRe = regexp.MustCompile(`<[^>]+>`)
+	// rstFieldRe matches an RST field list line such as ":Version: 1.0"
+	// or a directive option such as ":target: https://..."
+	rstFieldRe = regexp.MustCompile(`^:[^:\s][^:]*:(\s|$)`)
+)
+
+// adornmentChars are the punctuation characters of setext and RST
+// section underlines
+const adornmentChars = "=-~^\"'*+#:.`"
+
+// applyReadmeDescription fills an empty description from the first
+// prose paragraph of README.md or README.rst when
+// description_from_readme is set.
+func applyReadmeDescription(cfg runConfig, metadata *Metadata) {
+	if !cfg.descriptionFromReadme || metadata.Common.Description != "" {
+		return
+	}
+	if description := readmeDescription(cfg.absPath); description != "" {
+		metadata.Common.Description = description
+		metadata.Common.DescriptionSource = descriptionSourceReadme
+	}
+}
+
+// readmeDescription returns the first paragraph of the project's README
+// that is neither a heading nor only badges, images or markup, with the
+// inline formatting removed and the length capped.
+func readmeDescription(projectPath string) string {
+	entries, err := os.ReadDir(projectPath)
+	if err != nil {
+		return ""
+	}
+	for _, name := range readmeFiles {
+		path := findFileFold(projectPath, entries, name)
+		if path == "" {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, paragraph := range readmeParagraphs(string(content)) {
+			if text := plainReadmeText(paragraph); text != "" {
+				return truncateDescription(text, readmeDescriptionMaxLength)
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// findFileFold returns the path of the regular file among dir's entries
+// named name in any case, preferring the exact spelling, or "".
+func findFileFold(dir string, entries []os.DirEntry, name string) string {
+	found := ""
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(entry.Name(), name) {
+			continue
+		}
+		if entry.Name() == name {
+			return filepath.Join(dir, name)
+		}
+		if found == "" {
+			found = filepath.Join(dir, entry.Name())
+		}
+	}
+	return found
+}
+
+// readmeParagraphs splits README content into paragraphs of joined
+// lines, dropping headings (ATX, setext and RST adorned titles), RST
+// directives and comments with their indented options and content, RST
+// field lists, and fenced code blocks.
+func readmeParagraphs(content string) []string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	var paragraphs []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, strings.Join(current, " "))
+			current = nil
+		}
+	}
+
+	inFence, inDirective := false, false
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		indented := line != "" && strings.TrimLeft(lines[i], " \t") != lines[i]
+		if inDirective && (indented || line == "") {
+			// A directive's block runs over its indented lines
+			continue
+		}
+		inDirective = false
+		switch {
+		case strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~"):
+			flush()
+			inFence = !inFence
+		case inFence:
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#") || isAdornmentLine(line):
+			flush()
+		case i+1 < len(lines) && isAdornmentLine(strings.TrimSpace(lines[i+1])):
+			// A title over its adornment line
+			flush()
+			i++
+		case strings.HasPrefix(line, ".."):
+			flush()
+			inDirective = true
+		case rstFieldRe.MatchString(line):
+			flush()
+		default:
+			current = append(current, line)
+		}
+	}
+	flush()
+	return paragraphs
+}
+
+// isAdornmentLine reports a heading underline or overline: three or
+// more repetitions of one punctuation character.
+func isAdornmentLine(line string) bool {
+	if len(line) < 3 || !strings.ContainsRune(adornmentChars, rune(line[0])) {
+		return false
+	}
+	return strings.Count(line, line[:1]) == len(line)
+}
+
+// plainReadmeText strips Markdown and RST inline markup from a
+// paragraph, returning "" when nothing but markup remains.
+func plainReadmeText(paragraph string) string {
+	text := markdownImageRe.ReplaceAllString(paragraph, "")
+	text = markdownLinkRe.ReplaceAllString(text, "$1")
+	text = rstLinkRe.ReplaceAllString(text, "$1")
+	text = htmlTagRe.ReplaceAllString(text, "")
+	text = strings.NewReplacer("**", "", "__", "", "``", "", "`", "", "*", "").Replace(text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// truncateDescription cuts text to at most limit characters at the last
+// word boundary, marking the cut with "...".
+func truncateDescription(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	cut := string(runes[:limit-3])
+	if space := strings.LastIndex(cut, " "); space > 0 {
+		cut = cut[:space]
+	}
+	return strings.TrimRight(cut, " ,;:.") + "..."
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadmeDescription(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{
+			name: "paragraph after the H1 title and badges",
+			file: "README.md",
+			content: `# widget
+
+[![CI](https://example.com/ci.svg)](https://example.com/ci)
+[![License](https://example.com/license.svg)](LICENSE)
+
+A **fast** widget library for [Go](https://go.dev), with ` + "`zero`" + `
+dependencies.
+
+## Installation
+`,
+			want: "A fast widget library for Go, with zero dependencies.",
+		},
+		{
+			name: "setext title",
+			file: "README.md",
+			content: `widget
+======
+
+Widgets for everyone.
+`,
+			want: "Widgets for everyone.",
+		},
+		{
+			name: "reStructuredText",
+			file: "README.rst",
+			content: `======
+widget
+======
+
+.. image:: https://example.com/ci.svg
+
+Widgets built on ` + "`Sphinx <https://sphinx-doc.org>`_" + ` and ` + "``docutils``" + `.
+`,
+			want: "Widgets built on Sphinx and docutils.",
+		},
+		{
+			name: "badge-led reStructuredText",
+			file: "README.rst",
+			content: `.. image:: https://example.com/ci.svg
+   :target: https://example.com/ci
+   :alt: CI status
+
+.. image:: https://example.com/pypi.svg
+    :target: https://pypi.org/project/widget
+
+widget
+======
+
+:Author: Example Org
+:Version: 1.0
+
+Widgets for reStructuredText users.
+`,
+			want: "Widgets for reStructuredText users.",
+		},
+		{
+			name:    "upper case file name",
+			file:    "README.MD",
+			content: "# widget\n\nWidgets for everyone.\n",
+			want:    "Widgets for everyone.",
+		},
+		{
+			name:    "headings only",
+			file:    "README.md",
+			content: "# widget\n\n## Usage\n",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if got := readmeDescription(dir); got != tt.want {
+				t.Errorf("readmeDescription() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadmeDescriptionTruncates(t *testing.T) {
+	dir := t.TempDir()
+	long := strings.Repeat("widget ", 100)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# widget\n\n"+long+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got := readmeDescription(dir)
+	if len(got) > readmeDescriptionMaxLength || !strings.HasSuffix(got, "widget...") {
+		t.Errorf("readmeDescription() = %q (%d chars), want at most %d ending at a word", got, len(got), readmeDescriptionMaxLength)
+	}
+}
+
+func TestApplyReadmeDescription(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# widget\n\nWidgets for everyone.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := runConfig{absPath: dir, descriptionFromReadme: true}
+
+	metadata := &Metadata{}
+	applyReadmeDescription(cfg, metadata)
+	if metadata.Common.Description != "Widgets for everyone." || metadata.Common.DescriptionSource != "README" {
+		t.Errorf("description = %q from %q, want the README paragraph", metadata.Common.Description, metadata.Common.DescriptionSource)
+	}
+
+	// A manifest description wins
+	metadata = &Metadata{Common: CommonMetadata{Description: "From pyproject", DescriptionSource: "manifest"}}
+	applyReadmeDescription(cfg, metadata)
+	if metadata.Common.Description != "From pyproject" || metadata.Common.DescriptionSource != "manifest" {
+		t.Errorf("manifest description replaced: %+v", metadata.Common)
+	}
+
+	// Off by default
+	metadata = &Metadata{}
+	applyReadmeDescription(runConfig{absPath: dir}, metadata)
+	if metadata.Common.Description != "" {
+		t.Errorf("description_from_readme=false still set %q", metadata.Common.Description)
+	}
+}
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/output"
+)
+
+// diffIgnoredCommonFields lists common fields that differ on every run and
+// so carry no signal when comparing two metadata documents.
+var diffIgnoredCommonFields = map[string]bool{
+	"build_timestamp":       true,
+	"project_path":          true,
+	"project_root":          true,
+	"project_path_original": true,
+}
+
+// diffCollectionKeys lists the language-specific collections compared
+// entry by entry rather than as opaque values.
+var diffCollectionKeys = []string{"dependencies", "dev_dependencies", "frameworks"}
+
+// MetadataDiff is the machine-readable result of comparing two metadata
+// documents (the diff_json payload).
+type MetadataDiff struct {
+	Changed          bool                      `json:"changed"`
+	Common           []FieldChange             `json:"common,omitempty"`
+	LanguageSpecific map[string]CollectionDiff `json:"language_specific,omitempty"`
+}
+
+// FieldChange records a single value that differs between the documents.
+// Empty Old or New means the field was absent on that side.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// CollectionDiff records the entries added to, removed from, or changed
+// within a language-specific collection such as dependencies.
+type CollectionDiff struct {
+	Added   []string      `json:"added,omitempty"`
+	Removed []string      `json:"removed,omitempty"`
+	Changed []FieldChange `json:"changed,omitempty"`
+}
+
+// runDiff implements the --diff mode: it loads two previously emitted
+// metadata_json documents, writes a human summary followed by the
+// diff_json document to w, and returns the diff_json payload.
+func runDiff(args []string, w io.Writer) (string, error) {
+	if len(args) != 2 {
+		return "", fmt.Errorf("usage: build-metadata --diff <old.json> <new.json>")
+	}
+
+	oldDoc, err := loadMetadataDocument(args[0])
+	if err != nil {
+		return "", err
+	}
+	newDoc, err := loadMetadataDocument(args[1])
+	if err != nil {
+		return "", err
+	}
+
+	diff := diffMetadata(oldDoc, newDoc)
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal diff: %w", err)
+	}
+
+	fmt.Fprint(w, formatDiffSummary(diff))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, string(diffJSON))
+	return string(diffJSON), nil
+}
+
+// loadMetadataDocument reads a metadata JSON file into a generic map.
+func loadMetadataDocument(path string) (map[string]interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// diffMetadata compares the common section field by field and the
+// dependency-style collections in language_specific entry by entry.
+func diffMetadata(oldDoc, newDoc map[string]interface{}) MetadataDiff {
+	diff := MetadataDiff{}
+
+	oldCommon, _ := oldDoc["common"].(map[string]interface{})
+	newCommon, _ := newDoc["common"].(map[string]interface{})
+	for _, field := range unionKeys(oldCommon, newCommon) {
+		if diffIgnoredCommonFields[field] {
+			continue
+		}
+		oldValue, newValue := diffValueString(oldCommon[field]), diffValueString(newCommon[field])
+		if oldValue != newValue {
+			diff.Common = append(diff.Common, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	oldLang, _ := oldDoc["language_specific"].(map[string]interface{})
+	newLang, _ := newDoc["language_specific"].(map[string]interface{})
+	for _, key := range diffCollectionKeys {
+		collection := diffCollection(collectionEntries(oldLang[key]), collectionEntries(newLang[key]))
+		if len(collection.Added)+len(collection.Removed)+len(collection.Changed) == 0 {
+			continue
+		}
+		if diff.LanguageSpecific == nil {
+			diff.LanguageSpecific = make(map[string]CollectionDiff)
+		}
+		diff.LanguageSpecific[key] = collection
+	}
+
+	diff.Changed = len(diff.Common) > 0 || len(diff.LanguageSpecific) > 0
+	return diff
+}
+
+// diffCollection compares two name -> version entry maps.
+func diffCollection(oldEntries, newEntries map[string]string) CollectionDiff {
+	names := make(map[string]bool, len(oldEntries)+len(newEntries))
+	for name := range oldEntries {
+		names[name] = true
+	}
+	for name := range newEntries {
+		names[name] = true
+	}
+
+	var collection CollectionDiff
+	for _, name := range sortedSetKeys(names) {
+		oldVersion, inOld := oldEntries[name]
+		newVersion, inNew := newEntries[name]
+		switch {
+		case !inOld:
+			collection.Added = append(collection.Added, joinEntry(name, newVersion))
+		case !inNew:
+			collection.Removed = append(collection.Removed, joinEntry(name, oldVersion))
+		case oldVersion != newVersion:
+			collection.Changed = append(collection.Changed, FieldChange{Field: name, Old: oldVersion, New: newVersion})
+		}
+	}
+	return collection
+}
+
+// collectionEntries normalizes the shapes extractors use for dependency
+// lists into a name -> version map: a name -> version object, a list of
+// "name@version", PEP 508 or bare name strings, or a list of objects
+// named as in the SBOM, so Maven and Gradle coordinates keep their group.
+func collectionEntries(value interface{}) map[string]string {
+	entries := make(map[string]string)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, version := range v {
+			entries[name] = diffValueString(version)
+		}
+	case []interface{}:
+		for _, item := range v {
+			switch entry := item.(type) {
+			case string:
+				if name, version := output.SplitDependencySpec(entry); name != "" {
+					entries[name] = version
+				} else {
+					entries[entry] = ""
+				}
+			case map[string]interface{}:
+				if name := output.DependencyName(entry); name != "" {
+					entries[name] = diffValueString(entry["version"])
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// formatDiffSummary renders the diff for humans, one change per line.
+func formatDiffSummary(diff MetadataDiff) string {
+	if !diff.Changed {
+		return "No metadata differences\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Metadata differences:\n")
+	for _, change := range diff.Common {
+		fmt.Fprintf(&sb, "  %s: %s -> %s\n", change.Field, orNone(change.Old), orNone(change.New))
+	}
+	for _, key := range diffCollectionKeys {
+		collection, ok := diff.LanguageSpecific[key]
+		if !ok {
+			continue
+		}
+		for _, entry := range collection.Added {
+			fmt.Fprintf(&sb, "  %s: + %s\n", key, entry)
+		}
+		for _, entry := range collection.Removed {
+			fmt.Fprintf(&sb, "  %s: - %s\n", key, entry)
+		}
+		for _, change := range collection.Changed {
+			fmt.Fprintf(&sb, "  %s: ~ %s %s -> %s\n", key, change.Field, orNone(change.Old), orNone(change.New))
+		}
+	}
+	return sb.String()
+}
+
+// diffValueString renders a JSON-decoded value for comparison: strings
+// as-is, composites as JSON, and absent values as "".
+func diffValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		if jsonBytes, err := json.Marshal(v); err == nil {
+			return string(jsonBytes)
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// joinEntry renders a collection entry as name@version, or the bare name
+// when no version is recorded.
+func joinEntry(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return name + "@" + version
+}
+
+// orNone substitutes a placeholder for an absent value in the summary.
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// unionKeys returns the sorted union of the keys of two maps.
+func unionKeys(a, b map[string]interface{}) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		seen[k] = true
+	}
+	for k := range b {
+		seen[k] = true
+	}
+	return sortedSetKeys(seen)
+}
+
+// sortedSetKeys returns the keys of a set in sorted order.
+func sortedSetKeys(seen map[string]bool) []string {
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestRunDiff compares two fixtures differing in version and one
+// dependency, and checks both the human summary and diff_json payload.
+func TestRunDiff(t *testing.T) {
+	var out bytes.Buffer
+	diffJSON, err := runDiff([]string{
+		filepath.Join("testdata", "diff", "old.json"),
+		filepath.Join("testdata", "diff", "new.json"),
+	}, &out)
+	if err != nil {
+		t.Fatalf("runDiff() error = %v", err)
+	}
+
+	var diff MetadataDiff
+	if err := json.Unmarshal([]byte(diffJSON), &diff); err != nil {
+		t.Fatalf("diff_json is not valid JSON: %v", err)
+	}
+
+	if !diff.Changed {
+		t.Error("Changed = false, want true")
+	}
+	// build_timestamp and the project paths are ignored, so only the
+	// version differs in the common section
+	if len(diff.Common) != 1 || diff.Common[0] != (FieldChange{Field: "project_version", Old: "1.4.0", New: "1.5.0"}) {
+		t.Errorf("Common = %+v, want only the project_version change", diff.Common)
+	}
+
+	deps, ok := diff.LanguageSpecific["dependencies"]
+	if !ok {
+		t.Fatalf("LanguageSpecific missing dependencies: %+v", diff.LanguageSpecific)
+	}
+	if len(deps.Added) != 1 || deps.Added[0] != "zod@^3.22.4" {
+		t.Errorf("Added = %v, want [zod@^3.22.4]", deps.Added)
+	}
+	if len(deps.Removed) != 1 || deps.Removed[0] != "lodash@^4.17.21" {
+		t.Errorf("Removed = %v, want [lodash@^4.17.21]", deps.Removed)
+	}
+	if _, ok := diff.LanguageSpecific["frameworks"]; ok {
+		t.Error("unchanged frameworks should not be reported")
+	}
+
+	summary := out.String()
+	for _, want := range []string{"project_version: 1.4.0 -> 1.5.0", "dependencies: + zod@^3.22.4", "dependencies: - lodash@^4.17.21"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+}
+
+// TestRunDiffIdentical verifies that comparing a document with itself
+// reports no differences.
+func TestRunDiffIdentical(t *testing.T) {
+	path := filepath.Join("testdata", "diff", "old.json")
+
+	var out bytes.Buffer
+	diffJSON, err := runDiff([]string{path, path}, &out)
+	if err != nil {
+		t.Fatalf("runDiff() error = %v", err)
+	}
+	if diffJSON != `{"changed":false}` {
+		t.Errorf("diff_json = %s, want {\"changed\":false}", diffJSON)
+	}
+	if !strings.HasPrefix(out.String(), "No metadata differences") {
+		t.Errorf("unexpected summary: %s", out.String())
+	}
+}
+
+// TestCollectionEntries verifies the dependency list shapes used by the
+// extractors normalize to name -> version entries.
+func TestDiffCollectionRequirementBump(t *testing.T) {
+	collection := diffCollection(
+		collectionEntries([]interface{}{"requests>=2.28"}),
+		collectionEntries([]interface{}{"requests>=2.31"}),
+	)
+	if len(collection.Added)+len(collection.Removed) != 0 {
+		t.Errorf("Added = %v, Removed = %v, want none", collection.Added, collection.Removed)
+	}
+	want := []FieldChange{{Field: "requests", Old: ">=2.28", New: ">=2.31"}}
+	if !reflect.DeepEqual(collection.Changed, want) {
+		t.Errorf("Changed = %+v, want %+v", collection.Changed, want)
+	}
+}
+
+func TestCollectionEntries(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  map[string]string
+	}{
+		{
+			name:  "name to version object",
+			value: map[string]interface{}{"serde": "1.0"},
+			want:  map[string]string{"serde": "1.0"},
+		},
+		{
+			name:  "module@version strings",
+			value: []interface{}{"github.com/spf13/cobra@v1.8.0", "bare-name"},
+			want:  map[string]string{"github.com/spf13/cobra": "v1.8.0", "bare-name": ""},
+		},
+		{
+			name: "maven coordinate objects",
+			value: []interface{}{map[string]interface{}{
+				"group_id": "junit", "artifact_id": "junit", "version": "4.13.2",
+			}},
+			want: map[string]string{"junit:junit": "4.13.2"},
+		},
+		{
+			name:  "pep 508 strings",
+			value: []interface{}{"requests>=2.28", "urllib3[socks]==2.0.7; python_version >= '3.8'"},
+			want:  map[string]string{"requests": ">=2.28", "urllib3": "2.0.7"},
+		},
+		{
+			name: "gradle coordinate objects",
+			value: []interface{}{
+				map[string]interface{}{"group": "org.slf4j", "name": "slf4j-api", "version": "2.0.9"},
+				map[string]interface{}{"group": "ch.qos.logback", "name": "slf4j-api", "version": "1.4.11"},
+			},
+			want: map[string]string{"org.slf4j:slf4j-api": "2.0.9", "ch.qos.logback:slf4j-api": "1.4.11"},
+		},
+		{
+			name:  "absent",
+			value: nil,
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectionEntries(tt.value)
+			if len(got) != len(tt.want) {
+				t.Fatalf("collectionEntries() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("collectionEntries()[%q] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
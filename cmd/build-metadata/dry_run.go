@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// recordEnv notes an environment variable export_env_vars would set, so
+// a dry run can list it alongside the outputs.
+func (c *appContext) recordEnv(name, value string) {
+	c.env = append(c.env, actionOutput{name: name, value: value})
+}
+
+// emitDryRun prints every output (and exported environment variable) the
+// run collected instead of writing them to GITHUB_OUTPUT and GITHUB_ENV,
+// so users can discover what the action provides. The same list is
+// written to the dry_run_file input when set.
+func emitDryRun(ctx *appContext, cfg runConfig) {
+	if !ctx.dryRun {
+		return
+	}
+
+	var sb strings.Builder
+	writeDryRunReport(&sb, ctx.outputs, ctx.env)
+	fmt.Print(sb.String())
+
+	if cfg.dryRunFile == "" {
+		return
+	}
+	if err := os.WriteFile(cfg.dryRunFile, []byte(sb.String()), 0o644); err != nil {
+		if ctx.isCI {
+			ctx.action.Warningf("Failed to write dry run file %s: %v", cfg.dryRunFile, err)
+		} else {
+			fmt.Printf("Warning: Failed to write dry run file %s: %v\n", cfg.dryRunFile, err)
+		}
+	}
+}
+
+// writeDryRunReport writes the collected outputs, then the environment
+// variables, in the GITHUB_OUTPUT file syntax under a heading each.
+func writeDryRunReport(w io.Writer, outputs, env []actionOutput) {
+	fmt.Fprintf(w, "Dry run: %d outputs would be set\n", len(outputs))
+	if err := writeGitHubOutput(w, outputs); err != nil {
+		fmt.Fprintf(w, "Warning: Failed to render outputs: %v\n", err)
+	}
+	if len(env) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Dry run: %d environment variables would be exported\n", len(env))
+	if err := writeGitHubOutput(w, env); err != nil {
+		fmt.Fprintf(w, "Warning: Failed to render environment variables: %v\n", err)
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+// dryRunFixture returns metadata exercising common, language-specific
+// and derived outputs.
+func dryRunFixture() *Metadata {
+	metadata := newMetadata(".", "/tmp/sample")
+	metadata.Common.ProjectType = "python-modern"
+	metadata.Common.ProjectName = "sample"
+	metadata.Common.ProjectVersion = "1.2.3"
+	metadata.LanguageSpecific = map[string]interface{}{
+		"build_version":  "3.12",
+		"version_matrix": []string{"3.11", "3.12"},
+		"dependencies":   []string{"requests"},
+	}
+	return metadata
+}
+
+// emitDryRunFixture runs the output emitters main uses on the fixture.
+func emitDryRunFixture(ctx *appContext) {
+	metadata := dryRunFixture()
+	emitCommonOutputs(ctx, metadata)
+	emitLanguageSpecificOutputs(ctx, metadata, metadata.Common.ProjectType)
+	emitRecommendedVersion(ctx, metadata, metadata.Common.ProjectType)
+	emitMetadataJSON(ctx, metadata, defaultJSONIndent)
+	ctx.setOutput("success", "true")
+}
+
+// ciAction returns an Action writing its file commands to the files in
+// dir, as the runner provides them.
+func ciAction(dir string) *githubactions.Action {
+	files := map[string]string{
+		"GITHUB_OUTPUT": filepath.Join(dir, "output"),
+		"GITHUB_ENV":    filepath.Join(dir, "env"),
+	}
+	return githubactions.New(
+		githubactions.WithWriter(io.Discard),
+		githubactions.WithGetenv(func(key string) string { return files[key] }),
+	)
+}
+
+// fileCommandNames returns the sorted names in a GitHub Actions file
+// command file, or nil when it was never written.
+func fileCommandNames(t *testing.T, path string) []string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, match := range regexp.MustCompile(`(?m)^([A-Za-z0-9_]+)<<`).FindAllStringSubmatch(string(content), -1) {
+		names = append(names, match[1])
+	}
+	sort.Strings(names)
+	return names
+}
+
+func collectedNames(outputs []actionOutput) []string {
+	names := make([]string, 0, len(outputs))
+	for _, out := range outputs {
+		names = append(names, out.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestDryRunCollectsEveryOutput(t *testing.T) {
+	normalDir := t.TempDir()
+	normal := &appContext{action: ciAction(normalDir), isCI: true, exportEnvVars: true}
+	emitDryRunFixture(normal)
+	wantOutputs := fileCommandNames(t, filepath.Join(normalDir, "output"))
+	wantEnv := fileCommandNames(t, filepath.Join(normalDir, "env"))
+	if len(wantOutputs) == 0 || len(wantEnv) == 0 {
+		t.Fatal("normal run wrote no outputs or environment variables")
+	}
+
+	dryDir := t.TempDir()
+	dry := &appContext{action: ciAction(dryDir), isCI: true, exportEnvVars: true, dryRun: true}
+	emitDryRunFixture(dry)
+
+	if got := collectedNames(dry.outputs); strings.Join(got, ",") != strings.Join(wantOutputs, ",") {
+		t.Errorf("dry run collected outputs %v, want %v", got, wantOutputs)
+	}
+	if got := collectedNames(dry.env); strings.Join(got, ",") != strings.Join(wantEnv, ",") {
+		t.Errorf("dry run collected env %v, want %v", got, wantEnv)
+	}
+	for _, name := range []string{"output", "env"} {
+		if _, err := os.Stat(filepath.Join(dryDir, name)); !os.IsNotExist(err) {
+			t.Errorf("dry run wrote GITHUB_%s", strings.ToUpper(name))
+		}
+	}
+}
+
+func TestEmitDryRunWritesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dry-run.txt")
+	ctx := &appContext{action: ciAction(t.TempDir()), isCI: true, exportEnvVars: true, dryRun: true}
+	ctx.setOutput("project_name", "sample")
+	ctx.setOutput("project_version", "")
+	emitDryRun(ctx, runConfig{dryRunFile: path})
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Dry run: 2 outputs would be set\n" +
+		"project_name=sample\n" +
+		"project_version=\n" +
+		"Dry run: 1 environment variables would be exported\n" +
+		"PROJECT_NAME=sample\n"
+	if string(content) != want {
+		t.Errorf("dry run file = %q, want %q", content, want)
+	}
+}
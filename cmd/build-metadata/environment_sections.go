@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/environment"
+)
+
+// parseEnvironmentSections validates the environment_sections input, a
+// comma, space or newline separated list of environment metadata
+// sections, returning them lowercased; an empty value selects them all.
+func parseEnvironmentSections(raw string) ([]string, error) {
+	known := make(map[string]bool, len(environment.Sections))
+	for _, section := range environment.Sections {
+		known[section] = true
+	}
+	sections := parseMultiSeparatorInput(raw)
+	if len(sections) == 0 {
+		return environment.Sections, nil
+	}
+	for i, section := range sections {
+		section = strings.ToLower(section)
+		if !known[section] {
+			return nil, fmt.Errorf("%q is not one of %s", section, strings.Join(environment.Sections, ", "))
+		}
+		sections[i] = section
+	}
+	return sections, nil
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/environment"
+)
+
+func TestParseEnvironmentSections(t *testing.T) {
+	got, err := parseEnvironmentSections("OS, arch\nci")
+	if err != nil {
+		t.Fatalf("parseEnvironmentSections() error = %v", err)
+	}
+	if want := []string{"os", "arch", "ci"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseEnvironmentSections() = %v, want %v", got, want)
+	}
+
+	if got, err := parseEnvironmentSections(""); err != nil || !reflect.DeepEqual(got, environment.Sections) {
+		t.Errorf("parseEnvironmentSections(\"\") = %v, %v, want every section", got, err)
+	}
+
+	if _, err := parseEnvironmentSections("os,secrets"); err == nil {
+		t.Error("parseEnvironmentSections() accepted an unknown section")
+	}
+}
+
+func TestCollectEnvironmentMetadataSections(t *testing.T) {
+	metadata := newMetadata(t.TempDir(), "")
+	cfg := runConfig{includeEnvironment: true, environmentSections: []string{environment.SectionOS}}
+	collectEnvironmentMetadata(&appContext{}, cfg, metadata, "go")
+
+	if metadata.Environment.Runtime.OS == "" {
+		t.Error("Runtime.OS is empty with the os section selected")
+	}
+	if metadata.Environment.Runtime.Arch != "" || len(metadata.Environment.Runtime.Environment) != 0 {
+		t.Errorf("Runtime = %+v, want only the OS", metadata.Environment.Runtime)
+	}
+	if metadata.Environment.CI.Platform != "" {
+		t.Errorf("CI.Platform = %q, want empty without the ci section", metadata.Environment.CI.Platform)
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// externalKeyPrefix namespaces each external extractor's fields in
+// LanguageSpecific so they never collide with built-in extractor keys.
+const externalKeyPrefix = "external_"
+
+// externalNameSanitizer collapses anything outside [a-z0-9] so the
+// namespaced key is usable as an action output name.
+var externalNameSanitizer = regexp.MustCompile(`[^a-z0-9]+`)
+
+// applyExternalExtractors runs each user-supplied extractor executable
+// against the project and merges its JSON object into LanguageSpecific
+// under "external_<name>". A top-level "project_type" string replaces
+// an auto-detected project type (later extractors win), never one the
+// project_type or manifest_file input chose. Failures, timeouts, and
+// malformed output are surfaced as warnings so a broken extension never
+// fails the build. Returns the effective project type.
+func applyExternalExtractors(ctx *appContext, cfg runConfig, metadata *Metadata, projectType string) string {
+	for _, executable := range cfg.externalExtractors {
+		fields, err := runExternalExtractor(executable, cfg.absPath, cfg.externalExtractorTimeout)
+		if err != nil {
+			if ctx.isCI {
+				ctx.action.Warningf("External extractor %s failed: %v", executable, err)
+			} else {
+				fmt.Printf("Warning: External extractor %s failed: %v\n", executable, err)
+			}
+			continue
+		}
+
+		if declared, ok := fields["project_type"].(string); ok && strings.TrimSpace(declared) != "" {
+			switch metadata.Common.ProjectTypeSource {
+			case projectTypeSourceDetected, projectTypeSourceExternal:
+				projectType = strings.TrimSpace(declared)
+				metadata.Common.ProjectType = projectType
+				metadata.Common.ProjectTypeSource = projectTypeSourceExternal
+			default:
+				ctx.infof("Ignoring project type %s from external extractor %s: %s chose %s",
+					strings.TrimSpace(declared), executable, metadata.Common.ProjectTypeSource, projectType)
+			}
+			delete(fields, "project_type")
+		}
+
+		if metadata.LanguageSpecific == nil {
+			metadata.LanguageSpecific = make(map[string]interface{})
+		}
+		metadata.LanguageSpecific[externalExtractorKey(executable)] = fields
+	}
+	return projectType
+}
+
+// runExternalExtractor invokes executable with the project path as its
+// sole argument and decodes the JSON object it prints on stdout. The
+// process is killed once timeout elapses; a nonzero exit reports the
+// trimmed stderr to aid debugging.
+func runExternalExtractor(executable, projectPath string, timeout time.Duration) (map[string]interface{}, error) {
+	runCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(runCtx, executable, projectPath)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return nil, fmt.Errorf("%w: %s", err, detail)
+		}
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &fields); err != nil {
+		return nil, fmt.Errorf("invalid JSON object on stdout: %w", err)
+	}
+	if fields == nil {
+		return nil, fmt.Errorf("invalid JSON object on stdout: got null")
+	}
+	return fields, nil
+}
+
+// externalExtractorKey derives the LanguageSpecific key from the
+// executable's base name, dropping any extension
+// (e.g. "./tools/acme-meta.sh" -> "external_acme_meta").
+func externalExtractorKey(executable string) string {
+	name := filepath.Base(executable)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	name = externalNameSanitizer.ReplaceAllString(strings.ToLower(name), "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		name = "extractor"
+	}
+	return externalKeyPrefix + name
+}
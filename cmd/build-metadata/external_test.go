@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func externalFixture(t *testing.T, name string) string {
+	t.Helper()
+	path, err := filepath.Abs(filepath.Join("testdata", "external", name))
+	if err != nil {
+		t.Fatalf("failed to resolve fixture %s: %v", name, err)
+	}
+	return path
+}
+
+func TestApplyExternalExtractors(t *testing.T) {
+	projectDir := t.TempDir()
+	cfg := runConfig{
+		absPath: projectDir,
+		externalExtractors: []string{
+			externalFixture(t, "failing.sh"),
+			externalFixture(t, "acme-meta.sh"),
+		},
+		externalExtractorTimeout: 5 * time.Second,
+	}
+	metadata := &Metadata{Common: CommonMetadata{ProjectType: "unknown", ProjectTypeSource: projectTypeSourceDetected}}
+
+	got := applyExternalExtractors(&appContext{}, cfg, metadata, "unknown")
+
+	if got != "acme" {
+		t.Errorf("project type = %q, want acme", got)
+	}
+	if metadata.Common.ProjectType != "acme" {
+		t.Errorf("Common.ProjectType = %q, want acme", metadata.Common.ProjectType)
+	}
+	if metadata.Common.ProjectTypeSource != projectTypeSourceExternal {
+		t.Errorf("Common.ProjectTypeSource = %q, want %s", metadata.Common.ProjectTypeSource, projectTypeSourceExternal)
+	}
+	if _, ok := metadata.LanguageSpecific["external_failing"]; ok {
+		t.Error("failing extractor should not contribute metadata")
+	}
+
+	fields, ok := metadata.LanguageSpecific["external_acme_meta"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("external_acme_meta = %#v, want a JSON object", metadata.LanguageSpecific["external_acme_meta"])
+	}
+	if fields["toolchain"] != "4.2" {
+		t.Errorf("toolchain = %v, want 4.2", fields["toolchain"])
+	}
+	if fields["project_path"] != projectDir {
+		t.Errorf("project_path = %v, want %s", fields["project_path"], projectDir)
+	}
+	if _, ok := fields["project_type"]; ok {
+		t.Error("project_type should be consumed rather than merged")
+	}
+}
+
+func TestApplyExternalExtractorsKeepsInputProjectType(t *testing.T) {
+	cfg := runConfig{
+		absPath:                  t.TempDir(),
+		externalExtractors:       []string{externalFixture(t, "acme-meta.sh")},
+		externalExtractorTimeout: 5 * time.Second,
+	}
+	for _, source := range []string{projectTypeSourceInput, projectTypeSourceManifest} {
+		metadata := &Metadata{Common: CommonMetadata{ProjectType: "go", ProjectTypeSource: source}}
+
+		got := applyExternalExtractors(&appContext{quiet: true}, cfg, metadata, "go")
+
+		if got != "go" || metadata.Common.ProjectType != "go" {
+			t.Errorf("%s: project type = %q (common %q), want go", source, got, metadata.Common.ProjectType)
+		}
+		if metadata.Common.ProjectTypeSource != source {
+			t.Errorf("%s: Common.ProjectTypeSource = %q, want it unchanged", source, metadata.Common.ProjectTypeSource)
+		}
+		if _, ok := metadata.LanguageSpecific["external_acme_meta"].(map[string]interface{})["project_type"]; ok {
+			t.Errorf("%s: project_type should be consumed rather than merged", source)
+		}
+	}
+}
+
+func TestRunExternalExtractorErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		timeout time.Duration
+		wantErr string
+	}{
+		{
+			name:    "nonzero exit reports stderr",
+			fixture: "failing.sh",
+			timeout: 5 * time.Second,
+			wantErr: "acme manifest not found",
+		},
+		{
+			name:    "timeout",
+			fixture: "slow.sh",
+			timeout: 100 * time.Millisecond,
+			wantErr: "timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runExternalExtractor(externalFixture(t, tt.fixture), t.TempDir(), tt.timeout)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runExternalExtractor() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExternalExtractorKey(t *testing.T) {
+	tests := map[string]string{
+		"./tools/acme-meta.sh": "external_acme_meta",
+		"/usr/bin/AcmeInfo":    "external_acmeinfo",
+		"---":                  "external_extractor",
+	}
+	for input, want := range tests {
+		if got := externalExtractorKey(input); got != want {
+			t.Errorf("externalExtractorKey(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// githubOutputFormat is the output format that replays every action
+// output as GITHUB_OUTPUT-style name=value lines, so the tool can feed
+// other CI systems and scripts.
+const githubOutputFormat = "github-output"
+
+// actionOutput is one output recorded by appContext.setOutput
+type actionOutput struct {
+	name  string
+	value string
+}
+
+// wantsGitHubOutputFormat reports whether the github-output format was
+// requested.
+func wantsGitHubOutputFormat(cfg runConfig) bool {
+	for _, format := range cfg.outputFormats {
+		if strings.ToLower(strings.TrimSpace(format)) == githubOutputFormat {
+			return true
+		}
+	}
+	return false
+}
+
+// emitGitHubOutputFormat writes the recorded outputs for the
+// github-output format once every output has been set. In GitHub Actions
+// SetOutput has already written them to GITHUB_OUTPUT, so nothing more
+// is done there. Elsewhere the outputs are appended to the file named by
+// GITHUB_OUTPUT when set, or printed to stdout.
+func emitGitHubOutputFormat(ctx *appContext, cfg runConfig) {
+	if ctx.isCI || !wantsGitHubOutputFormat(cfg) {
+		return
+	}
+
+	var w io.Writer = os.Stdout
+	if path := os.Getenv("GITHUB_OUTPUT"); path != "" {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			fmt.Printf("Warning: Failed to open GITHUB_OUTPUT file %s: %v\n", path, err)
+			return
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := writeGitHubOutput(w, ctx.outputs); err != nil {
+		fmt.Printf("Warning: Failed to write github-output format: %v\n", err)
+	}
+}
+
+// writeGitHubOutput writes outputs in the GITHUB_OUTPUT file syntax:
+// name=value for single-line values and the name<<DELIMITER heredoc form
+// for values containing newlines (such as metadata_json). A later output
+// with the same name replaces the earlier one, matching how the runner
+// reads the file.
+func writeGitHubOutput(w io.Writer, outputs []actionOutput) error {
+	for _, out := range outputs {
+		if !strings.ContainsAny(out.value, "\r\n") {
+			if _, err := fmt.Fprintf(w, "%s=%s\n", out.name, out.value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		delimiter, err := githubOutputDelimiter(out.value)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s<<%s\n%s\n%s\n", out.name, delimiter, out.value, delimiter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubOutputDelimiter returns a random heredoc delimiter that does not
+// occur in value.
+func githubOutputDelimiter(value string) (string, error) {
+	for {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to generate output delimiter: %w", err)
+		}
+		delimiter := "ghadelimiter_" + hex.EncodeToString(buf)
+		if !strings.Contains(value, delimiter) {
+			return delimiter, nil
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWriteGitHubOutputUsesDelimiterForMultilineValues(t *testing.T) {
+	metadataJSON := "{\n  \"common\": {}\n}"
+	var sb strings.Builder
+	err := writeGitHubOutput(&sb, []actionOutput{
+		{name: "project_name", value: "sample"},
+		{name: "metadata_json", value: metadataJSON},
+		{name: "git_tag", value: ""},
+	})
+	if err != nil {
+		t.Fatalf("writeGitHubOutput() error = %v", err)
+	}
+
+	heredoc := regexp.MustCompile(`(?s)^project_name=sample\nmetadata_json<<(ghadelimiter_[0-9a-f]+)\n(.*)\n(ghadelimiter_[0-9a-f]+)\ngit_tag=\n$`)
+	matches := heredoc.FindStringSubmatch(sb.String())
+	if matches == nil {
+		t.Fatalf("unexpected github-output content:\n%s", sb.String())
+	}
+	if matches[1] != matches[3] {
+		t.Errorf("opening delimiter %q does not match closing delimiter %q", matches[1], matches[3])
+	}
+	if matches[2] != metadataJSON {
+		t.Errorf("multiline value = %q, want %q", matches[2], metadataJSON)
+	}
+}
+
+func TestEmitGitHubOutputFormatAppendsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output")
+	if err := os.WriteFile(path, []byte("existing=1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_OUTPUT", path)
+
+	ctx := &appContext{}
+	ctx.setOutput("project_type", "go-module")
+	ctx.setOutput("success", "true")
+	emitGitHubOutputFormat(ctx, runConfig{outputFormats: []string{"json", "github-output"}})
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "existing=1\nproject_type=go-module\nsuccess=true\n"; string(content) != want {
+		t.Errorf("GITHUB_OUTPUT content = %q, want %q", content, want)
+	}
+}
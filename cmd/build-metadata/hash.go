@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// volatileCommonFields are the common metadata keys excluded from the
+// metadata hash: they change on every run or commit (or with the
+// checkout location, clone depth or source edits) without the project
+// itself changing.
+var volatileCommonFields = []string{
+	"build_timestamp",
+	"git_sha",
+	"git_branch",
+	"git_tag",
+	"git_shallow",
+	"default_branch",
+	"is_default_branch",
+	"version_matches_tag",
+	"primary_language",
+	"language_breakdown",
+	"project_path",
+	"project_root",
+	"project_path_original",
+	"extraction_error_message",
+}
+
+// volatileLanguageKeys are dependency-named language-specific keys left
+// out of the metadata hash: the deps_changed_since diff depends
+// on the compared ref, not on the project's dependencies.
+var volatileLanguageKeys = map[string]bool{
+	"dependencies_added":   true,
+	"dependencies_removed": true,
+	"dependencies_updated": true,
+}
+
+// hashedLanguageKeyMarkers select the dependency-relevant
+// language-specific keys: declared dependencies, version requirements,
+// providers, lock files, and toolchain versions.
+var hashedLanguageKeyMarkers = []string{"depend", "require", "provider", "pods", "lock", "version"}
+
+// metadataHash returns the hex SHA-256 of a canonical JSON document made
+// of the common metadata (minus volatileCommonFields) and the
+// dependency-relevant language-specific values. encoding/json writes map
+// keys sorted, so equal metadata always hashes equally. Build metadata
+// (CI run ID and URL, runner) is never included.
+func metadataHash(metadata *Metadata) (string, error) {
+	commonJSON, err := json.Marshal(metadata.Common)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal common metadata: %w", err)
+	}
+	var common map[string]interface{}
+	if err := json.Unmarshal(commonJSON, &common); err != nil {
+		return "", fmt.Errorf("failed to canonicalize common metadata: %w", err)
+	}
+	for _, field := range volatileCommonFields {
+		delete(common, field)
+	}
+
+	languageSpecific := make(map[string]interface{})
+	for key, value := range metadata.LanguageSpecific {
+		if isHashedLanguageKey(key) {
+			languageSpecific[key] = value
+		}
+	}
+
+	canonical, err := json.Marshal(map[string]interface{}{
+		"common":            common,
+		"language_specific": languageSpecific,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal language-specific metadata: %w", err)
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isHashedLanguageKey reports whether a language-specific key feeds the
+// metadata hash. Version matrices are skipped: they follow upstream
+// release schedules rather than the project's own dependencies. So are
+// volatileLanguageKeys.
+func isHashedLanguageKey(key string) bool {
+	if strings.Contains(key, "matrix") || volatileLanguageKeys[key] {
+		return false
+	}
+	for _, marker := range hashedLanguageKeyMarkers {
+		if strings.Contains(key, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// emitMetadataHash publishes metadata_hash, a cache key that only
+// changes when the project's meaningful metadata does.
+func emitMetadataHash(ctx *appContext, metadata *Metadata) {
+	hash, err := metadataHash(metadata)
+	if err != nil {
+		if ctx.isCI {
+			ctx.action.Warningf("Failed to compute metadata hash: %v", err)
+		} else {
+			fmt.Printf("Warning: Failed to compute metadata hash: %v\n", err)
+		}
+		return
+	}
+	ctx.setOutput("metadata_hash", hash)
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func hashFixture(timestamp time.Time, runID string, dependencies []string) *Metadata {
+	return &Metadata{
+		Common: CommonMetadata{
+			ProjectType:    "python-modern",
+			ProjectName:    "sample",
+			ProjectVersion: "1.2.3",
+			BuildTimestamp: timestamp,
+		},
+		LanguageSpecific: map[string]interface{}{
+			"dependencies":     dependencies,
+			"requires_python":  ">=3.10",
+			"version_matrix":   []string{"3.10", "3.11"},
+			"metadata_source":  "pyproject.toml",
+			"dependency_count": len(dependencies),
+		},
+		Build: BuildMetadata{
+			CIRunID:  runID,
+			CIRunURL: "https://github.com/example/sample/actions/runs/" + runID,
+		},
+	}
+}
+
+func TestMetadataHashStableAcrossRuns(t *testing.T) {
+	first := hashFixture(time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), "100", []string{"requests", "click"})
+	second := hashFixture(time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC), "200", []string{"requests", "click"})
+	second.Common.GitSHA = "0123abcd"
+
+	firstHash, err := metadataHash(first)
+	if err != nil {
+		t.Fatalf("metadataHash() error = %v", err)
+	}
+	secondHash, err := metadataHash(second)
+	if err != nil {
+		t.Fatalf("metadataHash() error = %v", err)
+	}
+	if firstHash != secondHash {
+		t.Errorf("hash changed between runs differing only in volatile fields: %s != %s", firstHash, secondHash)
+	}
+	if len(firstHash) != 64 {
+		t.Errorf("hash %q is not a hex SHA-256", firstHash)
+	}
+
+	// Matrices follow upstream releases and must not invalidate caches
+	second.LanguageSpecific["version_matrix"] = []string{"3.10", "3.11", "3.12"}
+	if matrixHash, _ := metadataHash(second); matrixHash != firstHash {
+		t.Error("hash changed when only the version matrix changed")
+	}
+}
+
+func TestMetadataHashIgnoresRunDependentFields(t *testing.T) {
+	timestamp := time.Now().UTC()
+	base, err := metadataHash(hashFixture(timestamp, "1", []string{"requests"}))
+	if err != nil {
+		t.Fatalf("metadataHash() error = %v", err)
+	}
+
+	varied := hashFixture(timestamp, "1", []string{"requests"})
+	varied.Common.VersionMatchesTag = "true"
+	varied.Common.GitShallow = true
+	varied.Common.DefaultBranch = "main"
+	varied.Common.IsDefaultBranch = "true"
+	varied.Common.PrimaryLanguage = "Python"
+	varied.Common.LanguageBreakdown = map[string]int{"Python": 90, "Shell": 10}
+	varied.LanguageSpecific["dependencies_added"] = []string{"httpx"}
+	varied.LanguageSpecific["dependencies_removed"] = []string{"urllib3"}
+	varied.LanguageSpecific["dependencies_updated"] = []string{"click"}
+	if variedHash, _ := metadataHash(varied); variedHash != base {
+		t.Error("hash changed when only tag, clone, branch, language or dependency diff fields changed")
+	}
+}
+
+func TestMetadataHashChangesWithDependencies(t *testing.T) {
+	timestamp := time.Now().UTC()
+	before, err := metadataHash(hashFixture(timestamp, "1", []string{"requests"}))
+	if err != nil {
+		t.Fatalf("metadataHash() error = %v", err)
+	}
+	after, err := metadataHash(hashFixture(timestamp, "1", []string{"requests", "httpx"}))
+	if err != nil {
+		t.Fatalf("metadataHash() error = %v", err)
+	}
+	if before == after {
+		t.Error("hash did not change when a dependency was added")
+	}
+
+	bumped := hashFixture(timestamp, "1", []string{"requests"})
+	bumped.Common.ProjectVersion = "1.2.4"
+	if bumpedHash, _ := metadataHash(bumped); bumpedHash == before {
+		t.Error("hash did not change with the project version")
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"github.com/lfreleng-actions/build-metadata-action/internal/environment"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// actionInput is one recognized action input and its default
+type actionInput struct {
+	name         string
+	defaultValue string
+}
+
+// actionInputs lists every input the action reads, in action.yaml order.
+// The defaults MUST match action.yaml: the runner applies those in CI,
+// these cover local runs and inputs passed as empty strings. The one
+// exception is output_format, whose empty value disables output.
+var actionInputs = []actionInput{
+	{"path_prefix", "."},
+	{"resolve_symlinks", "true"},
+	{"project_type", ""},
+	{"manifest_file", ""},
+	{"output_format", ""},
+	{"add_summary", "true"},
+	{"include_environment", "true"},
+	{"environment_sections", ""},
+	{"include_runtime_versions", "false"},
+	{"use_git_version", "true"},
+	{"use_changelog_version", "false"},
+	{"description_from_readme", "false"},
+	{"static_matrices", "false"},
+	{"strict_manifest", "false"},
+	{"dry_run", "false"},
+	{"dry_run_file", ""},
+	{"output_file", ""},
+	{"json_indent", "2"},
+	{"capture_env_vars", ""},
+	{"include_hygiene", "false"},
+	{"include_manifest_hashes", "false"},
+	{"analyze_workflows", "false"},
+	{"deps_changed_since", ""},
+	{"cache_metadata", "false"},
+	{"only_changed", "false"},
+	{"previous_metadata_file", ""},
+	{"include_dependencies", "all"},
+	{"max_dependencies", "500"},
+	{"metadata_overrides", ""},
+	{"use_version_extract", "true"},
+	{"verbose", "false"},
+	{"quiet", "false"},
+	{"suppress_warnings", ""},
+	{"artifact_upload", "true"},
+	{"artifact_name_prefix", "build-metadata"},
+	{"artifact_formats", "json"},
+	{"artifact_retention_days", "0"},
+	{"artifact_compress", "false"},
+	{"validate_output", "true"},
+	{"strict_validation", "true"},
+	{"export_env_vars", "false"},
+	{"fail_on_version_tag_mismatch", "false"},
+	{"require_semver", "false"},
+	{"require_semver_strict", "false"},
+	{"external_extractors", ""},
+	{"external_extractor_timeout", "30"},
+	{"python_offline_mode", "false"},
+	{"python_eol_timeout", "5"},
+	{"python_eol_max_retries", "2"},
+}
+
+// inputValues holds the effective value of every recognized input
+type inputValues map[string]string
+
+// collectInputs reads every recognized input, applying its default when
+// the input is unset or empty.
+func collectInputs(action *githubactions.Action) inputValues {
+	inputs := make(inputValues, len(actionInputs))
+	for _, input := range actionInputs {
+		value := action.GetInput(input.name)
+		if value == "" {
+			value = input.defaultValue
+		}
+		inputs[input.name] = value
+	}
+	return inputs
+}
+
+// get returns the effective value of a recognized input
+func (v inputValues) get(name string) string {
+	return v[name]
+}
+
+// redactInputValue returns value as it may appear in logs: secret-like
+// inputs with a value are masked.
+func redactInputValue(name, value string) string {
+	if value != "" && environment.IsSecretName(name) {
+		return environment.RedactedValue
+	}
+	return value
+}
+
+// logInputs prints every recognized input with its effective value so
+// misconfigured workflows can see what the action received. It only
+// runs when verbose is enabled.
+func logInputs(ctx *appContext, inputs inputValues) {
+	if !ctx.verboseOutput {
+		return
+	}
+	for _, input := range actionInputs {
+		ctx.infof("Input %s=%q", input.name, redactInputValue(input.name, inputs.get(input.name)))
+	}
+}
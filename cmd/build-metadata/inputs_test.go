@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/environment"
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/sethvargo/go-githubactions"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCollectInputsDefaults(t *testing.T) {
+	for _, input := range actionInputs {
+		t.Setenv("INPUT_"+strings.ToUpper(input.name), "")
+	}
+	t.Setenv("INPUT_ARTIFACT_FORMATS", "json yaml")
+
+	inputs := collectInputs(githubactions.New())
+
+	if len(inputs) != len(actionInputs) {
+		t.Fatalf("collectInputs() returned %d inputs, want %d", len(inputs), len(actionInputs))
+	}
+	for name, want := range map[string]string{
+		"path_prefix":                ".",
+		"artifact_upload":            "true",
+		"artifact_name_prefix":       "build-metadata",
+		"artifact_formats":           "json yaml",
+		"external_extractor_timeout": "30",
+		"output_format":              "",
+	} {
+		if got := inputs.get(name); got != want {
+			t.Errorf("inputs.get(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRedactInputValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"github_token", "ghp_abc123", environment.RedactedValue},
+		{"registry_password", "hunter2", environment.RedactedValue},
+		{"api_key", "k-1", environment.RedactedValue},
+		{"registry_credentials", "user:pass", environment.RedactedValue},
+		{"github_token", "", ""},
+		{"path_prefix", "src/app", "src/app"},
+		{"artifact_name_prefix", "build-metadata", "build-metadata"},
+	}
+	for _, tt := range tests {
+		if got := redactInputValue(tt.name, tt.value); got != tt.want {
+			t.Errorf("redactInputValue(%q, %q) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestActionInputsMatchActionYAML(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "action.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var action struct {
+		Inputs map[string]struct {
+			Default string `yaml:"default"`
+		} `yaml:"inputs"`
+	}
+	if err := yaml.Unmarshal(data, &action); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(action.Inputs) != len(actionInputs) {
+		t.Errorf("action.yaml declares %d inputs, actionInputs lists %d", len(action.Inputs), len(actionInputs))
+	}
+	for _, input := range actionInputs {
+		declared, ok := action.Inputs[input.name]
+		switch {
+		case !ok:
+			t.Errorf("input %s is not declared in action.yaml", input.name)
+		case input.name == "output_format":
+			// An empty output_format disables output rather than
+			// selecting the default
+		case declared.Default != input.defaultValue:
+			t.Errorf("input %s defaults to %q, action.yaml declares %q", input.name, input.defaultValue, declared.Default)
+		}
+	}
+}
+
+func TestQuietOverridesVerbose(t *testing.T) {
+	t.Setenv("INPUT_PATH_PREFIX", t.TempDir())
+	t.Setenv("INPUT_VERBOSE", "true")
+
+	t.Setenv("INPUT_QUIET", "true")
+	cfg := parseFlags(githubactions.New(), false)
+	if !cfg.quiet || cfg.verboseOutput {
+		t.Errorf("quiet = %v, verbose = %v, want quiet to disable verbose", cfg.quiet, cfg.verboseOutput)
+	}
+	if got := cfg.extractorLogLevel(); got != extractor.LogQuiet {
+		t.Errorf("extractorLogLevel() = %d, want LogQuiet", got)
+	}
+
+	t.Setenv("INPUT_QUIET", "false")
+	cfg = parseFlags(githubactions.New(), false)
+	if got := cfg.extractorLogLevel(); got != extractor.LogVerbose {
+		t.Errorf("extractorLogLevel() = %d without quiet, want LogVerbose", got)
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultJSONIndent is the metadata_json indentation when json_indent is
+// unset, matching the action.yaml default of 2
+const defaultJSONIndent = "  "
+
+// maxJSONIndent bounds the json_indent space count
+const maxJSONIndent = 8
+
+// parseJSONIndent resolves the json_indent input to the indentation
+// string for metadata_json: a space count up to maxJSONIndent, "tab"
+// for one tab per level, or "none" (equivalently 0) for an empty
+// string, which emits the document on one line. An empty value
+// selects defaultJSONIndent.
+func parseJSONIndent(raw string) (string, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	switch raw {
+	case "":
+		return defaultJSONIndent, nil
+	case "none":
+		return "", nil
+	case "tab":
+		return "\t", nil
+	}
+	width, err := strconv.Atoi(raw)
+	if err != nil || width < 0 || width > maxJSONIndent {
+		return "", fmt.Errorf("%q is not none, tab or a space count from 0 to %d", raw, maxJSONIndent)
+	}
+	return strings.Repeat(" ", width), nil
+}
+
+// reindentMetadataJSON re-renders the defaultJSONIndent metadata
+// document with indent, compacting it when indent is empty.
+func reindentMetadataJSON(metadataJSON []byte, indent string) ([]byte, error) {
+	switch indent {
+	case defaultJSONIndent:
+		return metadataJSON, nil
+	case "":
+		return compactMetadataJSON(metadataJSON)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, metadataJSON, "", indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
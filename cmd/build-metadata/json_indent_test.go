@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+func TestParseJSONIndent(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"", "  "},
+		{"none", ""},
+		{"None", ""},
+		{"0", ""},
+		{"2", "  "},
+		{"4", "    "},
+		{"tab", "\t"},
+	}
+	for _, tt := range tests {
+		got, err := parseJSONIndent(tt.raw)
+		if err != nil {
+			t.Errorf("parseJSONIndent(%q) error = %v", tt.raw, err)
+		} else if got != tt.want {
+			t.Errorf("parseJSONIndent(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+
+	for _, raw := range []string{"-1", "9", "two"} {
+		if _, err := parseJSONIndent(raw); err == nil {
+			t.Errorf("parseJSONIndent(%q) accepted an invalid indent", raw)
+		}
+	}
+}
+
+func TestEmitMetadataJSONIndent(t *testing.T) {
+	tests := []struct {
+		input string
+		// prefix is how the first field line of the document starts;
+		// empty means the document is on one line
+		prefix string
+	}{
+		{"none", ""},
+		{"2", "\n  \""},
+		{"4", "\n    \""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			indent, err := parseJSONIndent(tt.input)
+			if err != nil {
+				t.Fatalf("parseJSONIndent() error = %v", err)
+			}
+			ctx := &appContext{action: githubactions.New(), isCI: true, dryRun: true}
+			metadata := dryRunFixture()
+			returned := emitMetadataJSON(ctx, metadata, indent)
+
+			values := make(map[string]string)
+			for _, out := range ctx.outputs {
+				values[out.name] = out.value
+			}
+			document := values["metadata_json"]
+			if tt.prefix == "" {
+				if document != values["json_compact"] {
+					t.Errorf("metadata_json = %s, want the json_compact form", document)
+				}
+			} else if !strings.HasPrefix(document, "{"+tt.prefix) || strings.Contains(document, tt.prefix+" ") {
+				t.Errorf("metadata_json is not indented by %q:\n%s", tt.input, document)
+			}
+
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(document), &decoded); err != nil {
+				t.Fatalf("metadata_json is not valid JSON: %v", err)
+			}
+			// The output-format writers keep the default indentation
+			if !strings.HasPrefix(string(returned), "{\n  \"") || strings.HasPrefix(string(returned), "{\n   ") {
+				t.Errorf("returned document does not use the default indentation:\n%s", returned)
+			}
+		})
+	}
+}
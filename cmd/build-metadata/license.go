@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Values of license_source
+const (
+	licenseSourceManifest = "manifest"
+	licenseSourceFile     = "LICENSE file"
+)
+
+// Values of license_confidence: high when every marker phrase of the
+// license text is present, medium when most are
+const (
+	licenseConfidenceHigh   = "high"
+	licenseConfidenceMedium = "medium"
+)
+
+// licenseFiles are the license files read when the manifest declares no
+// license, in order of preference
+var licenseFiles = []string{"LICENSE", "LICENSE.txt", "LICENSE.md", "COPYING"}
+
+// licenseFileReadLimit is how much of a license file is compared, in
+// bytes; the identifying text sits at the top
+const licenseFileReadLimit = 2048
+
+// licenseText identifies a license by phrases of its text. A file
+// lacking one of requires, or containing any of excludes, is a related
+// license (BSD-2-Clause for BSD-3-Clause, LGPL for GPL) and no match.
+// orLater, when set, is the identifier used instead of spdx when the
+// text grants "any later version".
+type licenseText struct {
+	spdx     string
+	orLater  string
+	markers  []string
+	requires []string
+	excludes []string
+}
+
+// knownLicenseTexts are the licenses a license file is matched against,
+// their markers normalized as normalizeLicenseText does
+var knownLicenseTexts = []licenseText{
+	{
+		spdx: "MIT",
+		markers: []string{
+			"permission is hereby granted free of charge to any person obtaining a copy",
+			"the above copyright notice and this permission notice shall be included in all copies or substantial portions of the software",
+			"the software is provided as is without warranty of any kind",
+		},
+	},
+	{
+		spdx: "Apache-2.0",
+		markers: []string{
+			"apache license",
+			"version 2 0 january 2004",
+			"terms and conditions for use reproduction and distribution",
+		},
+	},
+	{
+		spdx: "BSD-3-Clause",
+		markers: []string{
+			"redistribution and use in source and binary forms with or without modification are permitted provided that the following conditions are met",
+			"redistributions of source code must retain the above copyright notice",
+			"redistributions in binary form must reproduce the above copyright notice",
+			"neither the name of",
+		},
+		requires: []string{"neither the name of"},
+	},
+	{
+		spdx: "BSD-2-Clause",
+		markers: []string{
+			"redistribution and use in source and binary forms with or without modification are permitted provided that the following conditions are met",
+			"redistributions of source code must retain the above copyright notice",
+			"redistributions in binary form must reproduce the above copyright notice",
+		},
+		excludes: []string{"neither the name of"},
+	},
+	{
+		spdx:    "GPL-3.0-only",
+		orLater: "GPL-3.0-or-later",
+		markers: []string{
+			"gnu general public license",
+			"version 3 29 june 2007",
+			"everyone is permitted to copy and distribute verbatim copies of this license document",
+		},
+		excludes: []string{"gnu lesser general public license", "gnu affero general public license"},
+	},
+}
+
+// nonWordRe matches the runs of punctuation and whitespace that
+// normalizeLicenseText collapses
+var nonWordRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// applyLicenseFile fills an empty license from the project's license
+// file when its text matches one of knownLicenseTexts, recording the
+// source and how confident the match is.
+func applyLicenseFile(metadata *Metadata, projectPath string) {
+	if metadata.Common.License != "" {
+		return
+	}
+	spdx, confidence := detectLicenseFile(projectPath)
+	if spdx == "" {
+		return
+	}
+	metadata.Common.License = spdx
+	metadata.Common.LicenseSource = licenseSourceFile
+	metadata.Common.LicenseConfidence = confidence
+}
+
+// detectLicenseFile returns the SPDX identifier and confidence of the
+// first license file in projectPath, or empty strings when there is
+// none or its text is not recognized.
+func detectLicenseFile(projectPath string) (spdx, confidence string) {
+	for _, name := range licenseFiles {
+		file, err := os.Open(filepath.Join(projectPath, name))
+		if err != nil {
+			continue
+		}
+		head, err := io.ReadAll(io.LimitReader(file, licenseFileReadLimit))
+		file.Close()
+		if err != nil {
+			continue
+		}
+		return matchLicenseText(string(head))
+	}
+	return "", ""
+}
+
+// matchLicenseText compares license text with knownLicenseTexts and
+// returns the best match: one with every marker present, else one with
+// more than half of them.
+func matchLicenseText(text string) (spdx, confidence string) {
+	normalized := normalizeLicenseText(text)
+	best, bestScore := "", 0.0
+	for _, known := range knownLicenseTexts {
+		if containsAny(normalized, known.excludes) || !containsAll(normalized, known.requires) {
+			continue
+		}
+		found := 0
+		for _, marker := range known.markers {
+			if strings.Contains(normalized, marker) {
+				found++
+			}
+		}
+		if score := float64(found) / float64(len(known.markers)); score > bestScore {
+			best, bestScore = known.spdx, score
+			if known.orLater != "" && strings.Contains(normalized, "any later version") {
+				best = known.orLater
+			}
+		}
+	}
+	switch {
+	case bestScore == 1:
+		return best, licenseConfidenceHigh
+	case bestScore > 0.5:
+		return best, licenseConfidenceMedium
+	}
+	return "", ""
+}
+
+// normalizeLicenseText lower-cases text and reduces every run of
+// punctuation and whitespace to one space, so line wrapping, quoting
+// and markup do not affect matching.
+func normalizeLicenseText(text string) string {
+	return strings.TrimSpace(nonWordRe.ReplaceAllString(strings.ToLower(text), " "))
+}
+
+// containsAll reports whether text contains every one of phrases.
+func containsAll(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if !strings.Contains(text, phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAny reports whether text contains one of phrases.
+func containsAny(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if strings.Contains(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
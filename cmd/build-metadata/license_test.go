@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const mitLicenseText = `MIT License
+
+Copyright (c) 2026 Example Org
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+`
+
+func TestApplyLicenseFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "LICENSE"), []byte(mitLicenseText), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := &Metadata{}
+	applyLicenseFile(metadata, dir)
+	if got := metadata.Common; got.License != "MIT" || got.LicenseSource != "LICENSE file" || got.LicenseConfidence != "high" {
+		t.Errorf("license = %q from %q (%q), want MIT from the LICENSE file with high confidence",
+			got.License, got.LicenseSource, got.LicenseConfidence)
+	}
+
+	// A manifest license wins
+	metadata = &Metadata{Common: CommonMetadata{License: "Apache-2.0", LicenseSource: "manifest"}}
+	applyLicenseFile(metadata, dir)
+	if metadata.Common.License != "Apache-2.0" || metadata.Common.LicenseSource != "manifest" {
+		t.Errorf("manifest license replaced: %+v", metadata.Common)
+	}
+}
+
+func TestApplyLicenseFileUnrecognized(t *testing.T) {
+	dir := t.TempDir()
+	text := "Copyright (c) 2026 Example Org. All rights reserved.\n\nNo part of this software may be redistributed.\n"
+	if err := os.WriteFile(filepath.Join(dir, "COPYING"), []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := &Metadata{}
+	applyLicenseFile(metadata, dir)
+	if got := metadata.Common; got.License != "" || got.LicenseSource != "" || got.LicenseConfidence != "" {
+		t.Errorf("unrecognized license text set %+v", got)
+	}
+}
+
+func TestMatchLicenseText(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		spdx       string
+		confidence string
+	}{
+		{
+			name:       "apache",
+			text:       "                                 Apache License\n                           Version 2.0, January 2004\n                        http://www.apache.org/licenses/\n\n   TERMS AND CONDITIONS FOR USE, REPRODUCTION, AND DISTRIBUTION\n",
+			spdx:       "Apache-2.0",
+			confidence: "high",
+		},
+		{
+			name:       "gpl",
+			text:       "                    GNU GENERAL PUBLIC LICENSE\n                       Version 3, 29 June 2007\n\n Everyone is permitted to copy and distribute verbatim copies\n of this license document, but changing it is not allowed.\n",
+			spdx:       "GPL-3.0-only",
+			confidence: "high",
+		},
+		{
+			name:       "gpl or later",
+			text:       "This program is free software: you can redistribute it and/or modify it under the terms of the GNU General Public License as published by\nthe Free Software Foundation, either version 3 of the License, or\n(at your option) any later version.\n\n                    GNU GENERAL PUBLIC LICENSE\n                       Version 3, 29 June 2007\n\n Everyone is permitted to copy and distribute verbatim copies\n of this license document, but changing it is not allowed.\n",
+			spdx:       "GPL-3.0-or-later",
+			confidence: "high",
+		},
+		{
+			name: "lgpl is not gpl",
+			text: "                   GNU LESSER GENERAL PUBLIC LICENSE\n                       Version 3, 29 June 2007\n\n Everyone is permitted to copy and distribute verbatim copies\n of this license document, but changing it is not allowed.\n\n  This version of the GNU Lesser General Public License incorporates\nthe terms and conditions of version 3 of the GNU General Public\nLicense.\n",
+		},
+		{
+			name:       "bsd 3-clause",
+			text:       "Redistribution and use in source and binary forms, with or without\nmodification, are permitted provided that the following conditions are met:\n\n1. Redistributions of source code must retain the above copyright notice.\n2. Redistributions in binary form must reproduce the above copyright notice.\n3. Neither the name of the copyright holder nor the names of its\n   contributors may be used to endorse or promote products.\n",
+			spdx:       "BSD-3-Clause",
+			confidence: "high",
+		},
+		{
+			name:       "bsd 2-clause",
+			text:       "Redistribution and use in source and binary forms, with or without\nmodification, are permitted provided that the following conditions are met:\n\n1. Redistributions of source code must retain the above copyright notice.\n2. Redistributions in binary form must reproduce the above copyright notice.\n",
+			spdx:       "BSD-2-Clause",
+			confidence: "high",
+		},
+		{
+			name:       "reworded bsd",
+			text:       "Redistribution and use in source and binary forms is permitted when:\n\n1. Redistributions of source code must retain the above copyright notice.\n2. Redistributions in binary form must reproduce the above copyright notice.\n3. Neither the name of the copyright holder may be used to endorse products.\n",
+			spdx:       "BSD-3-Clause",
+			confidence: "medium",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spdx, confidence := matchLicenseText(tt.text)
+			if spdx != tt.spdx || confidence != tt.confidence {
+				t.Errorf("matchLicenseText() = %q, %q, want %q, %q", spdx, confidence, tt.spdx, tt.confidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// unlistedMetadataFields are the common metadata fields recorded in
+// metadata_json without an output of their own.
+var unlistedMetadataFields = map[string]bool{
+	"license":               true,
+	"project_path_original": true,
+}
+
+// documentOutputs are the outputs not backed by a metadata field:
+// rendered documents, derived values, artifact details and the run
+// status.
+var documentOutputs = []string{
+	"metadata_json",
+	"json_compact",
+	"outputs_json",
+	"metadata_yaml",
+	"markdown_output",
+	"html_output",
+	"metadata_hash",
+	"recommended_version",
+	"test_matrix_json",
+	"build_matrix_json",
+	"changed_fields",
+	"artifact_name",
+	"artifact_path",
+	"artifact_files",
+	"artifact_archive_path",
+	"artifact_retention_days",
+	"success",
+}
+
+// listOutputs returns every output name the action can set: the common
+// and build outputs named after the CommonMetadata and BuildMetadata
+// JSON fields, then the document outputs, then a "<prefix>_*" pattern
+// per language, since language-specific keys depend on the manifest.
+func listOutputs() []string {
+	var names []string
+	for _, value := range []interface{}{CommonMetadata{}, BuildMetadata{}} {
+		for _, name := range jsonFieldNames(reflect.TypeOf(value)) {
+			if !unlistedMetadataFields[name] {
+				names = append(names, name)
+			}
+		}
+	}
+	names = append(names, documentOutputs...)
+
+	prefixes := make(map[string]bool)
+	for _, e := range extractor.GetAllExtractors() {
+		prefixes[normalizeProjectTypeToLanguage(e.Name())] = true
+	}
+	patterns := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		patterns = append(patterns, prefix+"_*")
+	}
+	sort.Strings(patterns)
+	return append(names, patterns...)
+}
+
+// jsonFieldNames returns the JSON names of the struct's fields, in
+// declaration order.
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// runListOutputs implements --list-outputs, writing listOutputs as a
+// JSON array without extracting anything.
+func runListOutputs(w io.Writer) error {
+	data, err := json.MarshalIndent(listOutputs(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
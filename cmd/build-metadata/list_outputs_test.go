@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRunListOutputs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runListOutputs(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	if err := json.Unmarshal(buf.Bytes(), &names); err != nil {
+		t.Fatalf("--list-outputs is not a JSON array: %v", err)
+	}
+
+	listed := make(map[string]bool)
+	for _, name := range names {
+		listed[name] = true
+	}
+	for _, want := range []string{"project_type", "git_sha", "runner_os", "metadata_json", "success", "python_*", "go_*"} {
+		if !listed[want] {
+			t.Errorf("--list-outputs is missing %s", want)
+		}
+	}
+	if listed["license"] || listed["project_path_original"] {
+		t.Error("--list-outputs names a metadata field that has no output")
+	}
+}
+
+func TestListOutputsMatchActionYAML(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "action.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var action struct {
+		Outputs map[string]interface{} `yaml:"outputs"`
+	}
+	if err := yaml.Unmarshal(data, &action); err != nil {
+		t.Fatal(err)
+	}
+
+	names := make(map[string]bool)
+	var prefixes []string
+	for _, name := range listOutputs() {
+		if prefix, ok := strings.CutSuffix(name, "*"); ok {
+			prefixes = append(prefixes, prefix)
+			continue
+		}
+		names[name] = true
+		if _, ok := action.Outputs[name]; !ok {
+			t.Errorf("listed output %s is not declared in action.yaml", name)
+		}
+	}
+
+	for name := range action.Outputs {
+		matched := names[name]
+		for _, prefix := range prefixes {
+			matched = matched || strings.HasPrefix(name, prefix)
+		}
+		if !matched {
+			t.Errorf("action.yaml output %s is missing from listOutputs", name)
+		}
+	}
+}
@@ -4,8 +4,13 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 	"github.com/sethvargo/go-githubactions"
 )
 
@@ -22,33 +27,125 @@ func main() {
 	// Detect if running in CI environment
 	isCI := os.Getenv("GITHUB_ACTIONS") == "true" || os.Getenv("CI") == "true"
 
+	// --list-outputs prints every output name the action can set
+	if len(os.Args) > 1 && os.Args[1] == "--list-outputs" {
+		if err := runListOutputs(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// --diff compares two previously emitted metadata_json documents
+	// instead of extracting metadata
+	if len(os.Args) > 1 && os.Args[1] == "--diff" {
+		diffJSON, err := runDiff(os.Args[2:], os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if isCI {
+			action.SetOutput("diff_json", diffJSON)
+		}
+		return
+	}
+
+	// --watch re-emits metadata whenever the manifests change; it is a
+	// local development aid and never runs in CI
+	if len(os.Args) > 1 && os.Args[1] == "--watch" {
+		if isCI {
+			fmt.Fprintln(os.Stderr, "Error: --watch is not supported in CI")
+			os.Exit(1)
+		}
+		absPath, err := parseWatchArgs(os.Args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		watchCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := runWatch(watchCtx, absPath, watchPollInterval, watchDebounce, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg := parseFlags(action, isCI)
 	ctx := &appContext{
 		action:        action,
 		isCI:          isCI,
 		verboseOutput: cfg.verboseOutput,
+		quiet:         cfg.quiet,
 		exportEnvVars: cfg.exportEnvVars,
+		dryRun:        cfg.dryRun,
 	}
+	extractor.SetLogLevel(cfg.extractorLogLevel())
+	extractor.SetSuppressedWarnings(cfg.suppressWarnings)
+	logInputs(ctx, cfg.inputs)
 
-	metadata := newMetadata(cfg.absPath)
+	metadata := newMetadata(cfg.projectPath, cfg.absPath)
+	if cfg.originalPath != cfg.projectPath {
+		metadata.Common.ProjectPathOriginal = cfg.originalPath
+	}
 	populateCIMetadata(metadata)
+	applyGitShallow(ctx, cfg, metadata)
 
-	projectType := detectProjectType(ctx, metadata, cfg.absPath)
-	configureExtractorPolicies(projectType, cfg)
-	extractVersionInfo(ctx, cfg, metadata, projectType)
-	extractProjectMetadata(ctx, metadata, projectType, cfg.absPath)
-	applyVersionProperties(metadata, cfg.absPath)
-	applyReleaseFiles(metadata, cfg.absPath)
-	collectEnvironmentMetadata(ctx, cfg, metadata)
+	cacheKey := metadataCacheKey(cfg, metadata)
+	projectType, cached := loadCachedMetadata(ctx, cacheKey, metadata)
+	if !cached {
+		projectType = selectProjectType(ctx, cfg, metadata)
+		configureExtractorPolicies(projectType, cfg)
+		extractVersionInfo(ctx, cfg, metadata, projectType)
+		if err := extractProjectMetadata(ctx, cfg, metadata, projectType); err != nil {
+			if isCI {
+				action.Fatalf("%v", err)
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		projectType = applyExternalExtractors(ctx, cfg, metadata, projectType)
+		applyGoGitVersion(ctx, cfg, metadata, projectType)
+		applyChangelogVersion(cfg, metadata)
+		applyReadmeDescription(cfg, metadata)
+		applyLicenseFile(metadata, cfg.absPath)
+		applyVersionProperties(metadata, cfg.absPath)
+		applyReleaseFiles(metadata, cfg.absPath)
+		applyBuildSystems(ctx, metadata, cfg.absPath)
+		applyHygiene(cfg, metadata)
+		applyLanguageBreakdown(ctx, cfg, metadata)
+		applySourceLayout(metadata)
+		applyWorkflowAnalysis(ctx, cfg, metadata)
+		storeCachedMetadata(ctx, cacheKey, metadata, projectType)
+	}
+	applyMetadataOverrides(ctx, cfg, metadata)
+	applyVersionTagMatch(metadata)
+	applyDefaultBranch(metadata, cfg.absPath)
+	applyDependencyChanges(ctx, cfg, metadata)
+	filterDependencies(cfg, metadata)
+	truncateDependencies(cfg, metadata)
+	collectEnvironmentMetadata(ctx, cfg, metadata, projectType)
+	applyOnlyChanged(ctx, cfg, metadata, projectType)
 
 	emitCommonOutputs(ctx, metadata)
+	enforceVersionTagMatch(ctx, cfg, metadata)
+	enforceRequireSemver(ctx, cfg, metadata)
 	emitProjectMatchRepo(ctx, metadata)
 	emitLanguageSpecificOutputs(ctx, metadata, projectType)
-	metadataJSON := emitMetadataJSON(ctx, metadata)
+	emitRecommendedVersion(ctx, metadata, projectType)
+	emitMatrixSplit(ctx, metadata, projectType)
+	emitMetadataHash(ctx, metadata)
+	metadataJSON := emitMetadataJSON(ctx, metadata, cfg.jsonIndent)
+	addStepSummary(ctx, cfg, metadata)
 	writeOutputFormats(ctx, cfg, metadata, metadataJSON)
+	writeOutputFile(ctx, cfg, metadata, metadataJSON)
 	uploadArtifacts(ctx, cfg, metadata)
 	printCompletionSummary(ctx, metadata)
 
 	// Set success indicator
 	ctx.setOutput("success", "true")
+	emitOutputsJSON(ctx)
+	emitGitHubOutputFormat(ctx, cfg)
+	emitDryRun(ctx, cfg)
 }
@@ -3,7 +3,16 @@
 
 package main
 
-import "testing"
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
 
 // TestVersionPropertiesMatch locks in the comparator semantics: a
 // "true"/"false" string when both sides are present, and "" (not
@@ -117,3 +126,442 @@ func TestSynthesizeSnapshotVersion(t *testing.T) {
 		})
 	}
 }
+
+// TestPopulateCIMetadata drives each supported platform through its
+// predefined environment variables. Every platform marker is cleared
+// first so the host CI running the tests cannot leak into a case.
+func TestPopulateCIMetadata(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantPlatform string
+		wantRunID    string
+		wantRunURL   string
+		wantSHA      string
+		wantBranch   string
+		wantTag      string
+		wantEvent    string
+		wantActor    string
+		wantWorkflow string
+	}{
+		{
+			name: "github branch build",
+			env: map[string]string{
+				"GITHUB_ACTIONS":    "true",
+				"GITHUB_RUN_ID":     "42",
+				"GITHUB_REPOSITORY": "org/repo",
+				"GITHUB_SHA":        "abc123",
+				"GITHUB_REF":        "refs/heads/main",
+				"GITHUB_EVENT_NAME": "push",
+				"GITHUB_ACTOR":      "octocat",
+				"GITHUB_WORKFLOW":   "CI",
+			},
+			wantPlatform: "github",
+			wantRunID:    "42",
+			wantRunURL:   "https://github.com/org/repo/actions/runs/42",
+			wantSHA:      "abc123",
+			wantBranch:   "main",
+			wantEvent:    "push",
+			wantActor:    "octocat",
+			wantWorkflow: "CI",
+		},
+		{
+			name: "azure branch build",
+			env: map[string]string{
+				"TF_BUILD":                           "True",
+				"BUILD_BUILDID":                      "1234",
+				"SYSTEM_TEAMFOUNDATIONCOLLECTIONURI": "https://dev.azure.com/org/",
+				"SYSTEM_TEAMPROJECT":                 "project",
+				"BUILD_SOURCEVERSION":                "def456",
+				"BUILD_SOURCEBRANCH":                 "refs/heads/feature/x",
+				"BUILD_SOURCEBRANCHNAME":             "x",
+				"BUILD_REASON":                       "IndividualCI",
+				"BUILD_REQUESTEDFOR":                 "Jane Doe",
+				"BUILD_DEFINITIONNAME":               "project-ci",
+			},
+			wantPlatform: "azure",
+			wantRunID:    "1234",
+			wantRunURL:   "https://dev.azure.com/org/project/_build/results?buildId=1234",
+			wantSHA:      "def456",
+			wantBranch:   "x",
+			wantEvent:    "IndividualCI",
+			wantActor:    "Jane Doe",
+			wantWorkflow: "project-ci",
+		},
+		{
+			name: "azure tag build",
+			env: map[string]string{
+				"TF_BUILD":               "True",
+				"BUILD_BUILDID":          "99",
+				"BUILD_SOURCEVERSION":    "def456",
+				"BUILD_SOURCEBRANCH":     "refs/tags/v1.0.0",
+				"BUILD_SOURCEBRANCHNAME": "v1.0.0",
+			},
+			wantPlatform: "azure",
+			wantRunID:    "99",
+			wantSHA:      "def456",
+			wantTag:      "v1.0.0",
+		},
+		{
+			name: "bitbucket tag build",
+			env: map[string]string{
+				"BITBUCKET_BUILD_NUMBER":    "17",
+				"BITBUCKET_GIT_HTTP_ORIGIN": "http://bitbucket.org/org/repo",
+				"BITBUCKET_COMMIT":          "fed321",
+				"BITBUCKET_TAG":             "v2.1.0",
+			},
+			wantPlatform: "bitbucket",
+			wantRunID:    "17",
+			wantRunURL:   "http://bitbucket.org/org/repo/pipelines/results/17",
+			wantSHA:      "fed321",
+			wantTag:      "v2.1.0",
+		},
+		{
+			name: "bitbucket branch build",
+			env: map[string]string{
+				"BITBUCKET_BUILD_NUMBER":        "18",
+				"BITBUCKET_GIT_HTTP_ORIGIN":     "http://bitbucket.org/org/repo",
+				"BITBUCKET_COMMIT":              "fed321",
+				"BITBUCKET_BRANCH":              "develop",
+				"BITBUCKET_STEP_TRIGGERER_UUID": "{2a4b6c8d-0000-4000-8000-000000000001}",
+			},
+			wantPlatform: "bitbucket",
+			wantRunID:    "18",
+			wantRunURL:   "http://bitbucket.org/org/repo/pipelines/results/18",
+			wantSHA:      "fed321",
+			wantBranch:   "develop",
+			wantActor:    "{2a4b6c8d-0000-4000-8000-000000000001}",
+		},
+		{
+			name: "no CI platform",
+			env:  map[string]string{},
+		},
+	}
+
+	markers := []string{"GITHUB_ACTIONS", "TF_BUILD", "BITBUCKET_BUILD_NUMBER", "BITBUCKET_BRANCH", "BITBUCKET_TAG",
+		"GITHUB_EVENT_NAME", "GITHUB_ACTOR", "GITHUB_WORKFLOW",
+		"BUILD_REASON", "BUILD_REQUESTEDFOR", "BUILD_DEFINITIONNAME", "BITBUCKET_STEP_TRIGGERER_UUID"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range markers {
+				t.Setenv(key, "")
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			metadata := &Metadata{}
+			populateCIMetadata(metadata)
+
+			if metadata.Build.CIPlatform != tt.wantPlatform {
+				t.Errorf("CIPlatform = %q, want %q", metadata.Build.CIPlatform, tt.wantPlatform)
+			}
+			if metadata.Build.CIRunID != tt.wantRunID {
+				t.Errorf("CIRunID = %q, want %q", metadata.Build.CIRunID, tt.wantRunID)
+			}
+			if metadata.Build.CIRunURL != tt.wantRunURL {
+				t.Errorf("CIRunURL = %q, want %q", metadata.Build.CIRunURL, tt.wantRunURL)
+			}
+			if metadata.Common.GitSHA != tt.wantSHA {
+				t.Errorf("GitSHA = %q, want %q", metadata.Common.GitSHA, tt.wantSHA)
+			}
+			if metadata.Common.GitBranch != tt.wantBranch {
+				t.Errorf("GitBranch = %q, want %q", metadata.Common.GitBranch, tt.wantBranch)
+			}
+			if metadata.Common.GitTag != tt.wantTag {
+				t.Errorf("GitTag = %q, want %q", metadata.Common.GitTag, tt.wantTag)
+			}
+			if metadata.Build.CIEvent != tt.wantEvent {
+				t.Errorf("CIEvent = %q, want %q", metadata.Build.CIEvent, tt.wantEvent)
+			}
+			if metadata.Build.CIActor != tt.wantActor {
+				t.Errorf("CIActor = %q, want %q", metadata.Build.CIActor, tt.wantActor)
+			}
+			if metadata.Build.CIWorkflow != tt.wantWorkflow {
+				t.Errorf("CIWorkflow = %q, want %q", metadata.Build.CIWorkflow, tt.wantWorkflow)
+			}
+		})
+	}
+}
+
+func TestApplyGoGitVersionRespectsSourceAndInput(t *testing.T) {
+	goMetadata := func(source string) *Metadata {
+		return &Metadata{
+			Common:           CommonMetadata{ProjectVersion: "0.9.0", VersionSource: source},
+			LanguageSpecific: map[string]interface{}{"module_path": "example.com/mod"},
+		}
+	}
+	ctx := &appContext{}
+	dir := t.TempDir()
+
+	// version.properties is authoritative and must never be replaced
+	metadata := goMetadata("version.properties")
+	applyGoGitVersion(ctx, runConfig{absPath: dir, useGitVersion: true}, metadata, "go-module")
+	if metadata.Common.ProjectVersion != "0.9.0" || metadata.Common.VersionSource != "version.properties" {
+		t.Errorf("version.properties version replaced: %+v", metadata.Common)
+	}
+
+	// With use_git_version off nothing runs, even for a git-derived source
+	metadata = goMetadata("git-commit")
+	applyGoGitVersion(ctx, runConfig{absPath: dir}, metadata, "go-module")
+	if metadata.Common.VersionSource != "git-commit" {
+		t.Errorf("use_git_version=false still changed the version: %+v", metadata.Common)
+	}
+
+	// Outside a git repository the existing version stays
+	metadata = goMetadata("git-commit")
+	applyGoGitVersion(ctx, runConfig{absPath: dir, useGitVersion: true}, metadata, "go-module")
+	if metadata.Common.ProjectVersion != "0.9.0" {
+		t.Errorf("version changed without any tags: %+v", metadata.Common)
+	}
+}
+
+func TestApplyChangelogVersion(t *testing.T) {
+	dir := t.TempDir()
+	changelog := "# Changelog\n\n## [Unreleased]\n\n## [1.4.0] - 2026-03-02\n"
+	if err := os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte(changelog), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := runConfig{absPath: dir, useChangelogVersion: true}
+
+	// No manifest version: the changelog's newest release is used
+	metadata := &Metadata{Common: CommonMetadata{ProjectVersion: "abc1234", VersionSource: "git-commit"}}
+	applyChangelogVersion(cfg, metadata)
+	if metadata.Common.ProjectVersion != "1.4.0" || metadata.Common.VersionSource != "CHANGELOG.md" {
+		t.Errorf("version = %q from %q, want 1.4.0 from CHANGELOG.md", metadata.Common.ProjectVersion, metadata.Common.VersionSource)
+	}
+
+	// A manifest version wins
+	metadata = &Metadata{Common: CommonMetadata{ProjectVersion: "2.0.0", VersionSource: "pyproject.toml"}}
+	applyChangelogVersion(cfg, metadata)
+	if metadata.Common.ProjectVersion != "2.0.0" {
+		t.Errorf("manifest version replaced: %+v", metadata.Common)
+	}
+
+	// Off by default
+	metadata = &Metadata{}
+	applyChangelogVersion(runConfig{absPath: dir}, metadata)
+	if metadata.Common.ProjectVersion != "" {
+		t.Errorf("use_changelog_version=false still set the version: %+v", metadata.Common)
+	}
+}
+
+func TestApplySourceLayout(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"tests", "src"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metadata := newMetadata(dir, dir)
+	applySourceLayout(metadata)
+	if got := metadata.LanguageSpecific["test_dirs"]; !reflect.DeepEqual(got, []string{"tests"}) {
+		t.Errorf("test_dirs = %#v, want [tests]", got)
+	}
+	if got := metadata.LanguageSpecific["source_dirs"]; !reflect.DeepEqual(got, []string{"src"}) {
+		t.Errorf("source_dirs = %#v, want [src]", got)
+	}
+
+	// Nothing recorded without either kind of directory
+	metadata = newMetadata(t.TempDir(), t.TempDir())
+	applySourceLayout(metadata)
+	if metadata.LanguageSpecific != nil {
+		t.Errorf("empty project set %#v", metadata.LanguageSpecific)
+	}
+}
+
+func TestApplyWorkflowAnalysis(t *testing.T) {
+	dir := t.TempDir()
+	workflow := "jobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - uses: actions/checkout@v4\n"
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".github", "workflows"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".github", "workflows", "ci.yml"), []byte(workflow), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &appContext{quiet: true}
+
+	metadata := &Metadata{}
+	applyWorkflowAnalysis(ctx, runConfig{absPath: dir, analyzeWorkflows: true}, metadata)
+	if got := metadata.LanguageSpecific["ci_actions_used"]; !reflect.DeepEqual(got, []string{"actions/checkout@v4"}) {
+		t.Errorf("ci_actions_used = %#v, want [actions/checkout@v4]", got)
+	}
+	if got := metadata.LanguageSpecific["ci_workflow_count"]; got != 1 {
+		t.Errorf("ci_workflow_count = %#v, want 1", got)
+	}
+
+	// Off by default
+	metadata = &Metadata{}
+	applyWorkflowAnalysis(ctx, runConfig{absPath: dir}, metadata)
+	if metadata.LanguageSpecific != nil {
+		t.Errorf("analyze_workflows=false still set %#v", metadata.LanguageSpecific)
+	}
+}
+
+func TestApplyLanguageBreakdown(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app/__init__.py", "app/main.py", "app/models.py", "tests/test_main.py", "static/site.js", "package.json"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := &appContext{quiet: true}
+
+	metadata := &Metadata{}
+	applyLanguageBreakdown(ctx, runConfig{absPath: dir}, metadata)
+	if metadata.Common.PrimaryLanguage != "python" {
+		t.Errorf("PrimaryLanguage = %q, want python", metadata.Common.PrimaryLanguage)
+	}
+	if want := map[string]int{"python": 4, "javascript": 1}; !reflect.DeepEqual(metadata.Common.LanguageBreakdown, want) {
+		t.Errorf("LanguageBreakdown = %v, want %v", metadata.Common.LanguageBreakdown, want)
+	}
+}
+
+func TestApplyGitShallow(t *testing.T) {
+	ctx := &appContext{quiet: true}
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := &Metadata{}
+	applyGitShallow(ctx, runConfig{absPath: dir}, metadata)
+	if metadata.Common.GitShallow {
+		t.Error("GitShallow set for a full clone")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".git", "shallow"), []byte("0123456789abcdef0123456789abcdef01234567\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	applyGitShallow(ctx, runConfig{absPath: dir}, metadata)
+	if !metadata.Common.GitShallow {
+		t.Fatal("GitShallow not set with a .git/shallow marker")
+	}
+
+	// Without a tag on HEAD the shallow clone keeps the commit version
+	metadata.Common.VersionSource = "git-commit"
+	metadata.Common.ProjectVersion = "0.0.0-dev+abc1234"
+	metadata.LanguageSpecific = map[string]interface{}{"module_path": "example.com/mod"}
+	applyGoGitVersion(ctx, runConfig{absPath: dir, useGitVersion: true}, metadata, "go-module")
+	if metadata.Common.ProjectVersion != "0.0.0-dev+abc1234" || metadata.Common.VersionSource != "git-commit" {
+		t.Errorf("shallow clone still resolved a tag version: %+v", metadata.Common)
+	}
+}
+
+func TestApplyDependencyChanges(t *testing.T) {
+	ctx := &appContext{quiet: true}
+
+	// Off by default
+	metadata := &Metadata{}
+	applyDependencyChanges(ctx, runConfig{absPath: t.TempDir()}, metadata)
+	if metadata.LanguageSpecific != nil {
+		t.Errorf("empty deps_changed_since still set %#v", metadata.LanguageSpecific)
+	}
+
+	// A project without a lockfile only warns
+	applyDependencyChanges(ctx, runConfig{absPath: t.TempDir(), depsChangedSince: "HEAD"}, metadata)
+	if _, ok := metadata.LanguageSpecific["dependencies_added"]; ok {
+		t.Errorf("dependencies_added set without a lockfile: %#v", metadata.LanguageSpecific)
+	}
+}
+
+func TestExtractProjectMetadataStrictManifest(t *testing.T) {
+	dir := t.TempDir()
+	pyproject := "[project]\nname = \"sample\"\n<<<<<<< HEAD\nversion = \"1.0.0\"\n=======\nversion = \"1.1.0\"\n>>>>>>> feature\n"
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte(pyproject), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &appContext{}
+
+	// Without strict_manifest the parse failure is only a warning
+	metadata := &Metadata{}
+	if err := extractProjectMetadata(ctx, runConfig{absPath: dir}, metadata, "python-modern"); err != nil {
+		t.Fatalf("extractProjectMetadata() error = %v, want a warning only", err)
+	}
+	if metadata.Common.ProjectName != "" {
+		t.Errorf("ProjectName = %q from a corrupt manifest", metadata.Common.ProjectName)
+	}
+
+	err := extractProjectMetadata(ctx, runConfig{absPath: dir, strictManifest: true}, &Metadata{}, "python-modern")
+	var manifestErr *extractor.ManifestError
+	if !errors.As(err, &manifestErr) {
+		t.Fatalf("extractProjectMetadata() error = %v, want a ManifestError", err)
+	}
+	if manifestErr.Line != 3 || filepath.Base(manifestErr.File) != "pyproject.toml" {
+		t.Errorf("ManifestError at %s:%d, want pyproject.toml:3", manifestErr.File, manifestErr.Line)
+	}
+	if !strings.Contains(err.Error(), "pyproject.toml:3: unresolved git merge conflict marker") {
+		t.Errorf("error %q lacks the file and line context", err)
+	}
+}
+
+// TestExtractProjectMetadataErrorCode checks that a missing manifest and
+// a corrupt one surface different extraction_error_code values.
+func TestExtractProjectMetadataErrorCode(t *testing.T) {
+	ctx := &appContext{}
+
+	metadata := &Metadata{}
+	if err := extractProjectMetadata(ctx, runConfig{absPath: t.TempDir()}, metadata, "rust-cargo"); err != nil {
+		t.Fatalf("extractProjectMetadata() error = %v", err)
+	}
+	if metadata.Common.ExtractionErrorCode != extractor.ErrorCodeNoManifest {
+		t.Errorf("ExtractionErrorCode = %q, want %q", metadata.Common.ExtractionErrorCode, extractor.ErrorCodeNoManifest)
+	}
+	if !strings.Contains(metadata.Common.ExtractionErrorMessage, "Cargo.toml") {
+		t.Errorf("ExtractionErrorMessage = %q, want it to name Cargo.toml", metadata.Common.ExtractionErrorMessage)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("[package\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	metadata = &Metadata{}
+	if err := extractProjectMetadata(ctx, runConfig{absPath: dir}, metadata, "rust-cargo"); err != nil {
+		t.Fatalf("extractProjectMetadata() error = %v", err)
+	}
+	if metadata.Common.ExtractionErrorCode != extractor.ErrorCodeParseFailure {
+		t.Errorf("ExtractionErrorCode = %q, want %q", metadata.Common.ExtractionErrorCode, extractor.ErrorCodeParseFailure)
+	}
+
+	dir = t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("[package]\nname = \"ok\"\nversion = \"0.1.0\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	metadata = &Metadata{}
+	if err := extractProjectMetadata(ctx, runConfig{absPath: dir}, metadata, "rust-cargo"); err != nil {
+		t.Fatalf("extractProjectMetadata() error = %v", err)
+	}
+	if metadata.Common.ExtractionErrorCode != "" || metadata.Common.ExtractionErrorMessage != "" {
+		t.Errorf("extraction error = %q/%q on success, want empty", metadata.Common.ExtractionErrorCode, metadata.Common.ExtractionErrorMessage)
+	}
+}
+
+func TestApplyBuildSystemsCoexisting(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"pom.xml", "build.gradle"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(""), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metadata := &Metadata{}
+	applyBuildSystems(&appContext{}, metadata, dir)
+	if got := strings.Join(metadata.Common.CoexistingBuildSystems, ","); got != "maven,gradle" {
+		t.Errorf("CoexistingBuildSystems = %q, want %q", got, "maven,gradle")
+	}
+}
+
+func TestExtractorRegistryWiring(t *testing.T) {
+	if err := extractor.Validate(normalizeProjectTypeToLanguage); err != nil {
+		t.Errorf("extractor.Validate():\n%v", err)
+	}
+}
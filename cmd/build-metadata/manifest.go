@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/detector"
+)
+
+// resolveManifestFile validates the manifest_file input, resolved
+// relative to the project path, and returns the project type implied by
+// its name together with its directory, which becomes the effective
+// project path. This bypasses auto-detection for layouts where several
+// markers coexist and the highest-priority one is the wrong choice.
+func resolveManifestFile(absPath, manifestFile string) (projectType, manifestDir string, err error) {
+	manifestPath := manifestFile
+	if !filepath.IsAbs(manifestPath) {
+		manifestPath = filepath.Join(absPath, manifestPath)
+	}
+
+	info, err := os.Stat(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", "", fmt.Errorf("manifest file %s does not exist", manifestPath)
+		}
+		return "", "", fmt.Errorf("failed to read manifest file %s: %w", manifestPath, err)
+	}
+	if info.IsDir() {
+		return "", "", fmt.Errorf("manifest file %s is a directory", manifestPath)
+	}
+
+	projectType, err = detector.DetectManifestType(manifestPath)
+	if err != nil {
+		return "", "", err
+	}
+	return projectType, filepath.Dir(manifestPath), nil
+}
+
+// useManifestProjectType records the project type forced through
+// manifest_file in place of auto-detection.
+func useManifestProjectType(ctx *appContext, metadata *Metadata, projectType, manifestFile string) {
+	metadata.Common.ProjectType = projectType
+	metadata.Common.ProjectTypeSource = projectTypeSourceManifest
+	ctx.infof("Using project type %s from manifest_file %s", projectType, manifestFile)
+}
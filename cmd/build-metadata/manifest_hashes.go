@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// applyManifestHashes records the SHA-256 of each manifest the extractor
+// read as manifest_hashes, keyed by the path relative to the project,
+// when include_manifest_hashes is set. Nothing is hashed when the
+// extractor reports no consumed files, rather than guessing at them.
+func applyManifestHashes(ctx *appContext, cfg runConfig, metadata *Metadata, projectType string, consumed []string) {
+	if !cfg.includeManifestHashes {
+		return
+	}
+	if len(consumed) == 0 {
+		ctx.infof("Extractor for %s reports no consumed files; no manifest hashes recorded", projectType)
+		return
+	}
+	hashes := manifestHashes(cfg.absPath, consumed)
+	if len(hashes) == 0 {
+		ctx.infof("No manifest files to hash")
+		return
+	}
+	if metadata.LanguageSpecific == nil {
+		metadata.LanguageSpecific = make(map[string]interface{})
+	}
+	metadata.LanguageSpecific["manifest_hashes"] = hashes
+	ctx.infof("Manifest hashes: %d file(s)", len(hashes))
+}
+
+// manifestHashes returns the hex SHA-256 of each readable file, keyed by
+// its slash-separated path relative to projectPath.
+func manifestHashes(projectPath string, files []string) map[string]string {
+	hashes := make(map[string]string, len(files))
+	for _, file := range files {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(projectPath, file)
+		}
+		sum, err := fileSHA256(file)
+		if err != nil {
+			continue
+		}
+		name, err := filepath.Rel(projectPath, file)
+		if err != nil {
+			name = file
+		}
+		hashes[filepath.ToSlash(name)] = sum
+	}
+	return hashes
+}
+
+// fileSHA256 returns the hex SHA-256 digest of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestApplyManifestHashes(t *testing.T) {
+	dir := t.TempDir()
+	pyproject := "[project]\nname = \"demo\"\nversion = \"1.0.0\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte(pyproject), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Present but not read by the pyproject.toml extraction path
+	if err := os.WriteFile(filepath.Join(dir, "setup.py"), []byte("from setuptools import setup\nsetup()\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &appContext{quiet: true}
+
+	metadata := &Metadata{}
+	if err := extractProjectMetadata(ctx, runConfig{absPath: dir, includeManifestHashes: true}, metadata, "python-modern"); err != nil {
+		t.Fatalf("extractProjectMetadata() error = %v", err)
+	}
+	want := map[string]string{
+		"pyproject.toml": "f16f17a37c6006895e3d86aac989e5b0cfd24040ce9c7ca0f50c1e96c210729e",
+	}
+	if got := metadata.LanguageSpecific["manifest_hashes"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest_hashes = %#v, want %#v", got, want)
+	}
+
+	// Off by default
+	metadata = &Metadata{}
+	if err := extractProjectMetadata(ctx, runConfig{absPath: dir}, metadata, "python-modern"); err != nil {
+		t.Fatalf("extractProjectMetadata() error = %v", err)
+	}
+	if _, ok := metadata.LanguageSpecific["manifest_hashes"]; ok {
+		t.Error("manifest_hashes set without include_manifest_hashes")
+	}
+}
+
+func TestApplyManifestHashesWithoutConsumedFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n\ngo 1.24\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &appContext{quiet: true}
+
+	metadata := &Metadata{}
+	applyManifestHashes(ctx, runConfig{absPath: dir, includeManifestHashes: true}, metadata, "go-module", nil)
+	if got, ok := metadata.LanguageSpecific["manifest_hashes"]; ok {
+		t.Errorf("manifest_hashes = %#v, want none without consumed files", got)
+	}
+}
+
+func TestApplyManifestHashesIncludesSecondaryFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectType string
+		project     string
+		files       map[string]string
+		want        []string
+	}{
+		{
+			name:        "maven parent pom and java version file",
+			projectType: "java-maven",
+			project:     "module",
+			files: map[string]string{
+				"pom.xml":              "<project><groupId>org.example</groupId><artifactId>parent</artifactId><version>1.0.0</version><packaging>pom</packaging></project>",
+				"module/pom.xml":       "<project><parent><groupId>org.example</groupId><artifactId>parent</artifactId><version>1.0.0</version></parent><artifactId>module</artifactId></project>",
+				"module/.java-version": "17\n",
+			},
+			want: []string{"pom.xml", "../pom.xml", ".java-version"},
+		},
+		{
+			name:        "maven tool-versions java",
+			projectType: "java-maven",
+			files: map[string]string{
+				"pom.xml":        "<project><groupId>org.example</groupId><artifactId>app</artifactId><version>1.0.0</version></project>",
+				".tool-versions": "java temurin-21.0.2\n",
+			},
+			want: []string{"pom.xml", ".tool-versions"},
+		},
+		{
+			name:        "go version file and tool-versions",
+			projectType: "go-module",
+			files: map[string]string{
+				"go.mod":         "module example.com/demo\n",
+				".tool-versions": "golang 1.24.1\n",
+				"VERSION":        "1.2.3\n",
+			},
+			want: []string{"go.mod", ".tool-versions", "VERSION"},
+		},
+		{
+			name:        "go version constant",
+			projectType: "go-module",
+			files: map[string]string{
+				"go.mod":     "module example.com/demo\n\ngo 1.24\n",
+				"version.go": "package main\n\nconst Version = \"1.2.3\"\n",
+			},
+			want: []string{"go.mod", "version.go"},
+		},
+		{
+			name:        "javascript workspace members",
+			projectType: "javascript-npm",
+			files: map[string]string{
+				"package.json":            `{"name": "root", "private": true, "workspaces": ["packages/*"]}`,
+				"packages/a/package.json": `{"name": "a", "version": "1.0.0"}`,
+			},
+			want: []string{"package.json", "packages/a/package.json"},
+		},
+		{
+			name:        "ruby lockfile and version files",
+			projectType: "ruby-bundler",
+			files: map[string]string{
+				"Gemfile":       "source \"https://rubygems.org\"\ngem \"rake\"\n",
+				"Gemfile.lock":  "GEM\n  remote: https://rubygems.org/\n  specs:\n    rake (13.1.0)\n",
+				".ruby-version": "3.3.0\n",
+				"config.ru":     "run App\n",
+			},
+			want: []string{"Gemfile", "Gemfile.lock", ".ruby-version"},
+		},
+		{
+			name:        "terraform configuration and lock file",
+			projectType: "terraform-module",
+			files: map[string]string{
+				"main.tf":             "resource \"null_resource\" \"x\" {}\n",
+				"variables.tf":        "variable \"name\" {}\n",
+				".terraform.lock.hcl": "provider \"registry.terraform.io/hashicorp/null\" {\n  version = \"3.2.2\"\n}\n",
+				"README.md":           "# module\n",
+			},
+			want: []string{"main.tf", "variables.tf", ".terraform.lock.hcl"},
+		},
+		{
+			name:        "elm application",
+			projectType: "elm",
+			files: map[string]string{
+				"elm.json": `{"type": "application", "elm-version": "0.19.1", "dependencies": {"direct": {}, "indirect": {}}}`,
+			},
+			want: []string{"elm.json"},
+		},
+	}
+
+	ctx := &appContext{quiet: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(root, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			metadata := &Metadata{}
+			cfg := runConfig{absPath: filepath.Join(root, tt.project), includeManifestHashes: true}
+			if err := extractProjectMetadata(ctx, cfg, metadata, tt.projectType); err != nil {
+				t.Fatalf("extractProjectMetadata() error = %v", err)
+			}
+			hashes, _ := metadata.LanguageSpecific["manifest_hashes"].(map[string]string)
+			for _, name := range tt.want {
+				if _, ok := hashes[name]; !ok {
+					t.Errorf("manifest_hashes lacks %s: %v", name, hashes)
+				}
+			}
+			if len(hashes) != len(tt.want) {
+				t.Errorf("manifest_hashes = %v, want only %v", hashes, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// TestResolveManifestFileForcesExtractor uses a root package.json (which
+// auto-detection would pick) alongside app/pyproject.toml and checks the
+// manifest selects the Python extractor and the app directory.
+func TestResolveManifestFileForcesExtractor(t *testing.T) {
+	root := t.TempDir()
+	appDir := filepath.Join(root, "app")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatalf("failed to create app dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name": "ci-tools"}`), 0644); err != nil {
+		t.Fatalf("failed to write package.json: %v", err)
+	}
+	pyproject := "[project]\nname = \"real-app\"\nversion = \"2.3.4\"\n"
+	if err := os.WriteFile(filepath.Join(appDir, "pyproject.toml"), []byte(pyproject), 0644); err != nil {
+		t.Fatalf("failed to write pyproject.toml: %v", err)
+	}
+
+	projectType, dir, err := resolveManifestFile(root, "app/pyproject.toml")
+	if err != nil {
+		t.Fatalf("resolveManifestFile() error = %v", err)
+	}
+	if projectType != "python-modern" {
+		t.Errorf("project type = %q, want python-modern", projectType)
+	}
+	if dir != appDir {
+		t.Errorf("dir = %q, want %q", dir, appDir)
+	}
+
+	impl, err := extractor.GetExtractor(projectType)
+	if err != nil {
+		t.Fatalf("GetExtractor(%q) error = %v", projectType, err)
+	}
+	projectMetadata, err := impl.Extract(dir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if projectMetadata.Name != "real-app" {
+		t.Errorf("Name = %q, want real-app", projectMetadata.Name)
+	}
+}
+
+func TestResolveManifestFileErrors(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "notes.txt"), []byte("notes"), 0644); err != nil {
+		t.Fatalf("failed to write notes.txt: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "pom.xml"), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		manifestFile string
+		wantErr      string
+	}{
+		{name: "missing file", manifestFile: "app/pyproject.toml", wantErr: "does not exist"},
+		{name: "directory", manifestFile: "pom.xml", wantErr: "is a directory"},
+		{name: "unrecognized", manifestFile: "notes.txt", wantErr: "unrecognized manifest file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := resolveManifestFile(root, tt.manifestFile)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveManifestFile() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestParseFlagsProjectRoot checks that a nested manifest_file moves the
+// extraction root while project_path keeps reporting path_prefix.
+func TestParseFlagsProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	appDir := filepath.Join(root, "app")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatalf("failed to create app dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "pyproject.toml"), []byte("[project]\nname = \"app\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write pyproject.toml: %v", err)
+	}
+
+	t.Setenv("INPUT_PATH_PREFIX", root)
+	t.Setenv("INPUT_MANIFEST_FILE", "app/pyproject.toml")
+	cfg := parseFlags(githubactions.New(), false)
+
+	metadata := newMetadata(cfg.projectPath, cfg.absPath)
+	if metadata.Common.ProjectPath != root {
+		t.Errorf("ProjectPath = %q, want %q", metadata.Common.ProjectPath, root)
+	}
+	if metadata.Common.ProjectRoot != appDir {
+		t.Errorf("ProjectRoot = %q, want %q", metadata.Common.ProjectRoot, appDir)
+	}
+
+	// Without a manifest the root is the given path itself
+	t.Setenv("INPUT_MANIFEST_FILE", "")
+	cfg = parseFlags(githubactions.New(), false)
+	if cfg.projectPath != root || cfg.absPath != root {
+		t.Errorf("projectPath = %q, absPath = %q, want both %q", cfg.projectPath, cfg.absPath, root)
+	}
+}
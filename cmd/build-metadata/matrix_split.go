@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// splitMatrixJSON derives the test and build matrices from the
+// extractor's matrix_json: the test matrix keeps every version (e.g.
+// MSRV through stable), while the build matrix holds only the version
+// of buildMatrixVersions on the same axis, for workflows that test
+// broadly but build once. Both are "" when the project has no matrix.
+func splitMatrixJSON(projectType string, languageSpecific map[string]interface{}) (string, string) {
+	matrixJSON, _ := languageSpecific["matrix_json"].(string)
+	if matrixJSON == "" {
+		return "", ""
+	}
+
+	axis, ok := matrixAxis(matrixJSON)
+	if !ok {
+		return matrixJSON, ""
+	}
+	versions := buildMatrixVersions(projectType, languageSpecific)
+	if len(versions) == 0 {
+		return matrixJSON, ""
+	}
+	buildJSON, err := json.Marshal(map[string][]string{axis: versions})
+	if err != nil {
+		return matrixJSON, ""
+	}
+	return matrixJSON, string(buildJSON)
+}
+
+// buildMatrixVersions returns the single build matrix version: stable
+// for Rust, whose matrix ends with the stable channel rather than a
+// numbered release, and the recommended version for other languages.
+func buildMatrixVersions(projectType string, languageSpecific map[string]interface{}) []string {
+	if normalizeProjectTypeToLanguage(projectType) == "rust" {
+		return []string{"stable"}
+	}
+	if version := recommendedVersion(projectType, languageSpecific); version != "" {
+		return []string{version}
+	}
+	return nil
+}
+
+// matrixAxis returns the name of the version axis in a matrix_json
+// document such as {"rust-version": [...]}: the first key, in sorted
+// order, holding a list.
+func matrixAxis(matrixJSON string) (string, bool) {
+	var matrix map[string]interface{}
+	if err := json.Unmarshal([]byte(matrixJSON), &matrix); err != nil {
+		return "", false
+	}
+	keys := make([]string, 0, len(matrix))
+	for key, value := range matrix {
+		if _, ok := value.([]interface{}); ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+	sort.Strings(keys)
+	return keys[0], true
+}
+
+// emitMatrixSplit publishes the test_matrix_json and build_matrix_json
+// outputs.
+func emitMatrixSplit(ctx *appContext, metadata *Metadata, projectType string) {
+	testJSON, buildJSON := splitMatrixJSON(projectType, metadata.LanguageSpecific)
+	ctx.setOutput("test_matrix_json", testJSON)
+	ctx.setOutput("build_matrix_json", buildJSON)
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import "testing"
+
+func TestSplitMatrixJSON(t *testing.T) {
+	rustMatrix := `{"rust-version": ["1.70", "1.71", "1.72", "stable"]}`
+	tests := []struct {
+		name             string
+		projectType      string
+		languageSpecific map[string]interface{}
+		build            string
+	}{
+		{
+			name:        "rust with msrv",
+			projectType: "rust-cargo",
+			languageSpecific: map[string]interface{}{
+				"msrv":                "1.70",
+				"rust_version_matrix": []string{"1.70", "1.71", "1.72", "stable"},
+				"matrix_json":         rustMatrix,
+			},
+			build: `{"rust-version":["stable"]}`,
+		},
+		{
+			name:        "rust from edition",
+			projectType: "rust-cargo",
+			languageSpecific: map[string]interface{}{
+				"rust_version_matrix": []string{"1.70", "1.71", "1.72", "stable"},
+				"matrix_json":         rustMatrix,
+			},
+			build: `{"rust-version":["stable"]}`,
+		},
+		{
+			name:        "python",
+			projectType: "python-modern",
+			languageSpecific: map[string]interface{}{
+				"build_version":  "3.12",
+				"version_matrix": []string{"3.10", "3.11", "3.12"},
+				"matrix_json":    `{"python-version": ["3.10", "3.11", "3.12"]}`,
+			},
+			build: `{"python-version":["3.12"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testJSON, buildJSON := splitMatrixJSON(tt.projectType, tt.languageSpecific)
+			if want := tt.languageSpecific["matrix_json"]; testJSON != want {
+				t.Errorf("test matrix = %s, want the full matrix %s", testJSON, want)
+			}
+			if buildJSON != tt.build {
+				t.Errorf("build matrix = %s, want %s", buildJSON, tt.build)
+			}
+		})
+	}
+}
+
+func TestSplitMatrixJSONWithoutMatrix(t *testing.T) {
+	testJSON, buildJSON := splitMatrixJSON("java-maven", map[string]interface{}{"version": "17"})
+	if testJSON != "" || buildJSON != "" {
+		t.Errorf("splitMatrixJSON() = %q, %q, want empty matrices", testJSON, buildJSON)
+	}
+}
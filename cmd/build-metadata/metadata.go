@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/lfreleng-actions/build-metadata-action/internal/environment"
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/lfreleng-actions/build-metadata-action/internal/hygiene"
 )
 
 // Metadata represents the complete metadata collected
@@ -23,6 +25,9 @@ type Metadata struct {
 	// Language-specific metadata
 	LanguageSpecific map[string]interface{} `json:"language_specific,omitempty"`
 
+	// Project hygiene files, collected when include_hygiene is set
+	Hygiene *hygiene.Report `json:"hygiene,omitempty"`
+
 	Build BuildMetadata `json:"build"`
 }
 
@@ -32,12 +37,25 @@ type CommonMetadata struct {
 	ProjectName    string    `json:"project_name"`
 	ProjectVersion string    `json:"project_version"`
 	ProjectPath    string    `json:"project_path"`
+	License        string    `json:"license,omitempty"`
+	ProjectRoot    string    `json:"project_root"`
 	VersionSource  string    `json:"version_source"`
 	VersioningType string    `json:"versioning_type"`
 	BuildTimestamp time.Time `json:"build_timestamp"`
 	GitSHA         string    `json:"git_sha,omitempty"`
 	GitBranch      string    `json:"git_branch,omitempty"`
 	GitTag         string    `json:"git_tag,omitempty"`
+	// Description is the manifest's project description, or with
+	// description_from_readme the README's first paragraph;
+	// DescriptionSource says which ("manifest" or "README").
+	Description       string `json:"description,omitempty"`
+	DescriptionSource string `json:"description_source,omitempty"`
+	// ArtifactKind says whether the project builds a library, an
+	// application, both, or "unknown" when its extractor cannot tell.
+	ArtifactKind string `json:"artifact_kind"`
+	// AuthorsStructured lists the manifest's authors as name/email
+	// pairs, for the extractors that report authors.
+	AuthorsStructured []extractor.Author `json:"authors_structured,omitempty"`
 	// VersionPropertiesVersion is the version parsed from a
 	// version.properties file (the Linux Foundation / ONAP release
 	// convention), extracted independently of whichever source won
@@ -69,6 +87,53 @@ type CommonMetadata struct {
 	// exist, since the caller then disambiguates via the changed files.
 	ReleaseVersion string `json:"release_version,omitempty"`
 	ReleaseRef     string `json:"release_ref,omitempty"`
+	// VersionMatchesTag reports ("true"/"false") whether ProjectVersion
+	// equals GitTag (leading "v" stripped) on tag builds; empty when
+	// there is no tag, no project version, or versioning is dynamic.
+	VersionMatchesTag string `json:"version_matches_tag,omitempty"`
+	// ProjectPathOriginal is path_prefix as given when it reached the
+	// project through a symlink; ProjectPath is then the resolved path.
+	ProjectPathOriginal string `json:"project_path_original,omitempty"`
+	// ExtractionErrorCode classifies a failed extraction (no_manifest,
+	// parse_failure, unsupported, extraction_failed) and
+	// ExtractionErrorMessage carries its text; both are empty on success.
+	ExtractionErrorCode    string `json:"extraction_error_code,omitempty"`
+	ExtractionErrorMessage string `json:"extraction_error_message,omitempty"`
+	// CoexistingBuildSystems lists every JVM build system (maven,
+	// gradle, sbt, bazel) with a marker at the project root, including
+	// those detection did not pick.
+	CoexistingBuildSystems []string `json:"coexisting_build_systems,omitempty"`
+	// ProjectTypeSource names what chose ProjectType: "input" (the
+	// project_type input), "manifest_file", "detected" or "external" (an
+	// external extractor).
+	ProjectTypeSource string `json:"project_type_source,omitempty"`
+	// OverriddenFields lists the fields metadata_overrides replaced.
+	OverriddenFields []string `json:"overridden_fields,omitempty"`
+	// PrimaryLanguage is the language with the most source files under
+	// the project path, and LanguageBreakdown the file count of each
+	// recognized language; unlike ProjectType neither looks at manifests.
+	PrimaryLanguage   string         `json:"primary_language,omitempty"`
+	LanguageBreakdown map[string]int `json:"language_breakdown,omitempty"`
+	// GitShallow reports whether the project is in a shallow git clone,
+	// where history-derived values are incomplete or skipped.
+	GitShallow bool `json:"git_shallow,omitempty"`
+	// DefaultBranch is the repository's default branch where the CI
+	// platform or the clone names it, and IsDefaultBranch
+	// ("true"/"false") whether the run is on it; empty when unknown.
+	DefaultBranch   string `json:"default_branch,omitempty"`
+	IsDefaultBranch string `json:"is_default_branch,omitempty"`
+	// Publishable reports ("true"/"false") whether the manifest allows
+	// publishing to PublishTarget, the ecosystem registry (crates.io,
+	// npm, pypi, nuget, maven-central); both are empty for project types
+	// without a publishability rule.
+	Publishable   string `json:"publishable,omitempty"`
+	PublishTarget string `json:"publish_target,omitempty"`
+	// LicenseSource says where License came from: "manifest", or
+	// "LICENSE file" when it was recognized from the license file's
+	// text, with LicenseConfidence ("high" or "medium") rating that
+	// match.
+	LicenseSource     string `json:"license_source,omitempty"`
+	LicenseConfidence string `json:"license_confidence,omitempty"`
 }
 
 // BuildMetadata contains build-specific metadata
@@ -78,15 +143,23 @@ type BuildMetadata struct {
 	CIRunURL   string `json:"ci_run_url"`
 	RunnerOS   string `json:"runner_os"`
 	RunnerArch string `json:"runner_arch"`
+	// CIEvent, CIActor and CIWorkflow record what triggered the run,
+	// who triggered it and the pipeline definition it ran, for audit
+	// trails; each stays empty where the platform does not expose it.
+	CIEvent    string `json:"ci_event"`
+	CIActor    string `json:"ci_actor"`
+	CIWorkflow string `json:"ci_workflow"`
 }
 
-// newMetadata seeds the metadata with the resolved project path and a
-// UTC build timestamp, plus any CI platform values available from the
-// environment.
-func newMetadata(absPath string) *Metadata {
+// newMetadata seeds the metadata with the resolved project path and
+// extraction root, a UTC build timestamp, plus any CI platform values
+// available from the environment.
+func newMetadata(projectPath, projectRoot string) *Metadata {
 	return &Metadata{
 		Common: CommonMetadata{
-			ProjectPath:    absPath,
+			ProjectPath:    projectPath,
+			ProjectRoot:    projectRoot,
+			ArtifactKind:   extractor.ArtifactKindUnknown,
 			BuildTimestamp: time.Now().UTC(),
 		},
 		Build: BuildMetadata{
@@ -97,18 +170,43 @@ func newMetadata(absPath string) *Metadata {
 	}
 }
 
-// populateCIMetadata fills in GitHub-specific build and git fields when
-// running under GitHub Actions.
+// detectCIPlatform identifies the CI platform from its well-known
+// environment markers, returning "" when none is recognised.
+func detectCIPlatform() string {
+	switch {
+	case os.Getenv("GITHUB_ACTIONS") == "true":
+		return "github"
+	case os.Getenv("TF_BUILD") == "True":
+		return "azure"
+	case os.Getenv("BITBUCKET_BUILD_NUMBER") != "":
+		return "bitbucket"
+	}
+	return ""
+}
+
+// populateCIMetadata fills in platform-specific build and git fields
+// for the CI platform reported by detectCIPlatform.
 func populateCIMetadata(metadata *Metadata) {
-	if os.Getenv("GITHUB_ACTIONS") != "true" {
-		return
+	switch detectCIPlatform() {
+	case "github":
+		populateGitHubMetadata(metadata)
+	case "azure":
+		populateAzureMetadata(metadata)
+	case "bitbucket":
+		populateBitbucketMetadata(metadata)
 	}
+}
 
+// populateGitHubMetadata maps the GitHub Actions run and ref variables.
+func populateGitHubMetadata(metadata *Metadata) {
 	metadata.Build.CIPlatform = "github"
 	metadata.Build.CIRunID = os.Getenv("GITHUB_RUN_ID")
 	metadata.Build.CIRunURL = fmt.Sprintf("https://github.com/%s/actions/runs/%s",
 		os.Getenv("GITHUB_REPOSITORY"),
 		os.Getenv("GITHUB_RUN_ID"))
+	metadata.Build.CIEvent = os.Getenv("GITHUB_EVENT_NAME")
+	metadata.Build.CIActor = os.Getenv("GITHUB_ACTOR")
+	metadata.Build.CIWorkflow = os.Getenv("GITHUB_WORKFLOW")
 
 	// Git information from GitHub context
 	metadata.Common.GitSHA = os.Getenv("GITHUB_SHA")
@@ -120,6 +218,50 @@ func populateCIMetadata(metadata *Metadata) {
 	}
 }
 
+// populateAzureMetadata maps the Azure Pipelines predefined variables.
+// BUILD_SOURCEBRANCHNAME is only the last ref segment, so the full
+// BUILD_SOURCEBRANCH decides whether it names a branch or a tag.
+func populateAzureMetadata(metadata *Metadata) {
+	buildID := os.Getenv("BUILD_BUILDID")
+	metadata.Build.CIPlatform = "azure"
+	metadata.Build.CIRunID = buildID
+	if collection := strings.TrimSuffix(os.Getenv("SYSTEM_TEAMFOUNDATIONCOLLECTIONURI"), "/"); collection != "" && buildID != "" {
+		metadata.Build.CIRunURL = fmt.Sprintf("%s/%s/_build/results?buildId=%s",
+			collection,
+			os.Getenv("SYSTEM_TEAMPROJECT"),
+			buildID)
+	}
+	metadata.Build.CIEvent = os.Getenv("BUILD_REASON")
+	metadata.Build.CIActor = os.Getenv("BUILD_REQUESTEDFOR")
+	metadata.Build.CIWorkflow = os.Getenv("BUILD_DEFINITIONNAME")
+
+	metadata.Common.GitSHA = os.Getenv("BUILD_SOURCEVERSION")
+	name := os.Getenv("BUILD_SOURCEBRANCHNAME")
+	if strings.HasPrefix(os.Getenv("BUILD_SOURCEBRANCH"), "refs/tags/") {
+		metadata.Common.GitTag = name
+	} else {
+		metadata.Common.GitBranch = name
+	}
+}
+
+// populateBitbucketMetadata maps the Bitbucket Pipelines default
+// variables; BITBUCKET_TAG is only set on tag pipelines. Bitbucket names
+// neither the trigger event nor the pipeline, and identifies the actor
+// only by the UUID of the user who ran the step.
+func populateBitbucketMetadata(metadata *Metadata) {
+	buildNumber := os.Getenv("BITBUCKET_BUILD_NUMBER")
+	metadata.Build.CIPlatform = "bitbucket"
+	metadata.Build.CIRunID = buildNumber
+	if origin := strings.TrimSuffix(os.Getenv("BITBUCKET_GIT_HTTP_ORIGIN"), "/"); origin != "" {
+		metadata.Build.CIRunURL = fmt.Sprintf("%s/pipelines/results/%s", origin, buildNumber)
+	}
+	metadata.Build.CIActor = os.Getenv("BITBUCKET_STEP_TRIGGERER_UUID")
+
+	metadata.Common.GitSHA = os.Getenv("BITBUCKET_COMMIT")
+	metadata.Common.GitBranch = os.Getenv("BITBUCKET_BRANCH")
+	metadata.Common.GitTag = os.Getenv("BITBUCKET_TAG")
+}
+
 // versionPropertiesMatch returns "true"/"false" comparing the
 // version.properties version against the resolved project version,
 // or "" when either side is empty (not comparable).
@@ -184,6 +326,10 @@ func normalizeProjectTypeToLanguage(projectType string) string {
 		"terraform-opentofu": "terraform",
 		"c-cmake":            "c",
 		"c-autoconf":         "c",
+		// Extractor names accepted by the project_type input share the
+		// prefix of the detected types they handle
+		"cpp":    "c",
+		"dotnet": "csharp",
 	}
 
 	if normalized, ok := typeMap[projectType]; ok {
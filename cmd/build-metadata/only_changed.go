@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// onlyChangedAlwaysEmitted lists the common fields, besides those the
+// diff ignores, whose outputs only_changed never suppresses:
+// project_match_repo is only computed while outputs are emitted.
+var onlyChangedAlwaysEmitted = map[string]bool{
+	"project_match_repo": true,
+}
+
+// applyOnlyChanged implements the only_changed input: it compares the
+// metadata against previous_metadata_file, suppresses the outputs of
+// common and language-specific fields whose value is unchanged, and sets
+// changed_fields to the sorted, comma-separated output names of the ones
+// that differ. Build metadata and documents such as metadata_json are
+// always emitted. Without a readable previous file every output is
+// emitted and changed_fields stays empty.
+func applyOnlyChanged(ctx *appContext, cfg runConfig, metadata *Metadata, projectType string) {
+	if !cfg.onlyChanged {
+		return
+	}
+
+	var previous map[string]interface{}
+	err := fmt.Errorf("previous_metadata_file is not set")
+	if cfg.previousMetadataFile != "" {
+		previous, err = loadMetadataDocument(cfg.previousMetadataFile)
+	}
+	var current map[string]interface{}
+	if err == nil {
+		current, err = metadataDocument(metadata)
+	}
+	if err != nil {
+		if ctx.isCI {
+			ctx.action.Warningf("only_changed: emitting every output: %v", err)
+		} else {
+			fmt.Printf("Warning: only_changed: emitting every output: %v\n", err)
+		}
+		return
+	}
+
+	changed, unchanged := changedOutputFields(previous, current, normalizeProjectTypeToLanguage(projectType))
+	ctx.suppressedOutputs = unchanged
+	ctx.setOutput("changed_fields", strings.Join(changed, ","))
+}
+
+// metadataDocument renders the metadata as the generic map a
+// metadata_json document decodes to, so it compares like for like with
+// a previous run's file.
+func metadataDocument(metadata *Metadata) (map[string]interface{}, error) {
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(metadataJSON, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	return doc, nil
+}
+
+// changedOutputFields compares the common and language_specific sections
+// of two metadata documents and returns the output names of the fields
+// that differ, sorted, and the set of those that do not. Common fields
+// are named as their outputs; language-specific keys carry the output
+// prefix.
+func changedOutputFields(previous, current map[string]interface{}, prefix string) ([]string, map[string]bool) {
+	var changed []string
+	unchanged := make(map[string]bool)
+	compare := func(section, outputPrefix string, skip map[string]bool) {
+		oldSection, _ := previous[section].(map[string]interface{})
+		newSection, _ := current[section].(map[string]interface{})
+		for _, key := range unionKeys(oldSection, newSection) {
+			if skip[key] {
+				continue
+			}
+			name := outputPrefix + key
+			if diffValueString(oldSection[key]) != diffValueString(newSection[key]) {
+				changed = append(changed, name)
+			} else {
+				unchanged[name] = true
+			}
+		}
+	}
+
+	skipCommon := make(map[string]bool, len(diffIgnoredCommonFields)+len(onlyChangedAlwaysEmitted))
+	for field := range diffIgnoredCommonFields {
+		skipCommon[field] = true
+	}
+	for field := range onlyChangedAlwaysEmitted {
+		skipCommon[field] = true
+	}
+	compare("common", "", skipCommon)
+	compare("language_specific", prefix+"_", nil)
+
+	sort.Strings(changed)
+	return changed, unchanged
+}
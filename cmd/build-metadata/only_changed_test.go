@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+func TestChangedOutputFields(t *testing.T) {
+	previous := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_name":    "demo",
+			"project_version": "1.0.0",
+			"build_timestamp": "2026-01-01T00:00:00Z",
+			"git_tag":         "v1.0.0",
+		},
+		"language_specific": map[string]interface{}{
+			"go_version":   "1.22",
+			"dependencies": []interface{}{"a@1", "b@2"},
+		},
+	}
+	current := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_name":    "demo",
+			"project_version": "1.1.0",
+			"build_timestamp": "2026-02-01T00:00:00Z",
+		},
+		"language_specific": map[string]interface{}{
+			"go_version":   "1.22",
+			"dependencies": []interface{}{"a@1", "b@3"},
+			"module_count": 2,
+		},
+	}
+
+	changed, unchanged := changedOutputFields(previous, current, "go")
+
+	wantChanged := []string{"git_tag", "go_dependencies", "go_module_count", "project_version"}
+	if !reflect.DeepEqual(changed, wantChanged) {
+		t.Errorf("changed = %v, want %v", changed, wantChanged)
+	}
+	wantUnchanged := map[string]bool{"project_name": true, "go_go_version": true}
+	if !reflect.DeepEqual(unchanged, wantUnchanged) {
+		t.Errorf("unchanged = %v, want %v", unchanged, wantUnchanged)
+	}
+}
+
+func TestApplyOnlyChangedSuppressesUnchangedOutputs(t *testing.T) {
+	metadata := newMetadata("/src/demo", "/src/demo")
+	metadata.Common.ProjectName = "demo"
+	metadata.Common.ProjectVersion = "1.1.0"
+	metadata.Common.BuildTimestamp = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	metadata.LanguageSpecific = map[string]interface{}{"go_version": "1.22"}
+
+	previous := *metadata
+	previous.Common.ProjectVersion = "1.0.0"
+	previousJSON, err := json.Marshal(&previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previousFile := filepath.Join(t.TempDir(), "metadata.json")
+	if err := os.WriteFile(previousFile, previousJSON, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &appContext{action: githubactions.New(), dryRun: true}
+	cfg := runConfig{onlyChanged: true, previousMetadataFile: previousFile}
+	applyOnlyChanged(ctx, cfg, metadata, "go-module")
+	emitCommonOutputs(ctx, metadata)
+	emitLanguageSpecificOutputs(ctx, metadata, "go-module")
+
+	outputs := make(map[string]string)
+	for _, output := range ctx.outputs {
+		outputs[output.name] = output.value
+	}
+	if outputs["changed_fields"] != "project_version" {
+		t.Errorf("changed_fields = %q, want project_version", outputs["changed_fields"])
+	}
+	if outputs["project_version"] != "1.1.0" {
+		t.Errorf("project_version = %q, want the changed value emitted", outputs["project_version"])
+	}
+	for _, name := range []string{"project_name", "go_go_version"} {
+		if _, ok := outputs[name]; ok {
+			t.Errorf("unchanged output %s was emitted", name)
+		}
+	}
+	if _, ok := outputs["build_timestamp"]; !ok {
+		t.Error("build_timestamp should always be emitted")
+	}
+	if _, ok := outputs["ci_platform"]; !ok {
+		t.Error("build metadata should always be emitted")
+	}
+}
+
+func TestApplyOnlyChangedWithoutPreviousFile(t *testing.T) {
+	ctx := &appContext{action: githubactions.New(), dryRun: true}
+	cfg := runConfig{onlyChanged: true, previousMetadataFile: filepath.Join(t.TempDir(), "missing.json")}
+	metadata := newMetadata("/src/demo", "/src/demo")
+	applyOnlyChanged(ctx, cfg, metadata, "go-module")
+
+	if ctx.suppressedOutputs != nil || len(ctx.outputs) != 0 {
+		t.Errorf("applyOnlyChanged() without a previous file suppressed %v and set %v", ctx.suppressedOutputs, ctx.outputs)
+	}
+}
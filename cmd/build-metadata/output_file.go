@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/output"
+)
+
+// outputFileFormatPlaceholder is replaced by the rendered format's name
+// in the output_file path, e.g. "build/metadata.{format}".
+const outputFileFormatPlaceholder = "{format}"
+
+// outputFileFormat returns the first requested output format that renders
+// a whole document, defaulting to json. "both" writes its JSON half, and
+// formats with no document form (github-output) are skipped.
+func outputFileFormat(formats []string) string {
+	for _, format := range formats {
+		switch format = strings.ToLower(strings.TrimSpace(format)); format {
+		case "json", "compact_json", "yaml", "jsonl", "spdx", "markdown", "html", "summary":
+			return format
+		case "both":
+			return "json"
+		}
+	}
+	return "json"
+}
+
+// renderOutputFile renders metadata in format for the output_file input.
+// YAML is converted from the JSON document so its keys match
+// metadata_json; the Go structs carry no YAML field names.
+func renderOutputFile(format string, metadata *Metadata, metadataJSON []byte, validateOutput bool) (string, error) {
+	switch format {
+	case "yaml":
+		var document interface{}
+		if err := json.Unmarshal(metadataJSON, &document); err != nil {
+			return "", fmt.Errorf("metadata JSON is unavailable: %w", err)
+		}
+		return output.GetMetadataYAML(document, validateOutput)
+	case "jsonl":
+		return output.GenerateJSONL(metadata)
+	case "compact_json":
+		compact, err := compactMetadataJSON(metadataJSON)
+		if err != nil {
+			return "", fmt.Errorf("metadata JSON is unavailable: %w", err)
+		}
+		return string(compact) + "\n", nil
+	case "spdx":
+		return output.GenerateSPDX(metadata)
+	case "markdown":
+		return output.GenerateMarkdown(metadata), nil
+	case "html":
+		return output.GenerateHTML(metadata), nil
+	case "summary":
+		return output.GenerateSummary(metadata), nil
+	}
+	if metadataJSON == nil {
+		return "", fmt.Errorf("metadata JSON is unavailable")
+	}
+	return string(metadataJSON) + "\n", nil
+}
+
+// writeOutputFile writes the full metadata to the output_file input, in
+// the first requested output format, creating parent directories as
+// needed. It runs in addition to the normal outputs, and a failure only
+// warns.
+func writeOutputFile(ctx *appContext, cfg runConfig, metadata *Metadata, metadataJSON []byte) {
+	if cfg.outputFile == "" {
+		return
+	}
+
+	format := outputFileFormat(cfg.outputFormats)
+	path := strings.ReplaceAll(cfg.outputFile, outputFileFormatPlaceholder, format)
+	if err := writeMetadataFile(path, format, metadata, metadataJSON, cfg.validateOutput); err != nil {
+		if ctx.isCI {
+			ctx.action.Warningf("Failed to write output file %s: %v", path, err)
+		} else {
+			fmt.Printf("Warning: Failed to write output file %s: %v\n", path, err)
+		}
+		return
+	}
+	if ctx.verboseOutput {
+		fmt.Printf("Wrote %s metadata to %s\n", format, path)
+	}
+}
+
+// writeMetadataFile renders metadata in format and writes it to path.
+func writeMetadataFile(path, format string, metadata *Metadata, metadataJSON []byte, validateOutput bool) error {
+	content, err := renderOutputFile(format, metadata, metadataJSON, validateOutput)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outputFileFixture returns metadata and its marshaled JSON as main
+// produces them.
+func outputFileFixture(t *testing.T) (*Metadata, []byte) {
+	t.Helper()
+	metadata := newMetadata(".", "/tmp/sample")
+	metadata.Common.ProjectType = "go-module"
+	metadata.Common.ProjectName = "sample"
+	metadata.Common.ProjectVersion = "1.2.3"
+	metadata.LanguageSpecific = map[string]interface{}{"go_version": "1.24"}
+	metadataJSON, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata, metadataJSON
+}
+
+func TestWriteOutputFileJSON(t *testing.T) {
+	metadata, metadataJSON := outputFileFixture(t)
+	dir := t.TempDir()
+	cfg := runConfig{
+		outputFormats: []string{"summary", "json"},
+		outputFile:    filepath.Join(dir, "nested", "metadata.{format}"),
+	}
+
+	writeOutputFile(&appContext{}, cfg, metadata, metadataJSON)
+
+	content, err := os.ReadFile(filepath.Join(dir, "nested", "metadata.summary"))
+	if err != nil {
+		t.Fatalf("output file not written for the first format: %v", err)
+	}
+	if len(content) == 0 {
+		t.Error("summary output file is empty")
+	}
+
+	cfg.outputFormats = []string{"github-output", "json"}
+	writeOutputFile(&appContext{}, cfg, metadata, metadataJSON)
+
+	content, err = os.ReadFile(filepath.Join(dir, "nested", "metadata.json"))
+	if err != nil {
+		t.Fatalf("output file not written: %v", err)
+	}
+	var decoded Metadata
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("output file is not JSON: %v", err)
+	}
+	if decoded.Common.ProjectName != "sample" || decoded.Common.ProjectVersion != "1.2.3" {
+		t.Errorf("decoded common = %+v, want sample 1.2.3", decoded.Common)
+	}
+}
+
+func TestWriteOutputFileYAML(t *testing.T) {
+	metadata, metadataJSON := outputFileFixture(t)
+	path := filepath.Join(t.TempDir(), "out", "build-metadata.yaml")
+	cfg := runConfig{outputFormats: []string{"yaml"}, outputFile: path, validateOutput: true}
+
+	writeOutputFile(&appContext{}, cfg, metadata, metadataJSON)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("output file not written: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := yaml.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("output file is not YAML: %v", err)
+	}
+	common, ok := decoded["common"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("YAML has no common section:\n%s", content)
+	}
+	if common["project_name"] != "sample" || common["project_version"] != "1.2.3" {
+		t.Errorf("common = %v, want project sample 1.2.3", common)
+	}
+	if json.Valid(content) {
+		t.Error("yaml output file holds JSON")
+	}
+}
+
+func TestOutputFileFormat(t *testing.T) {
+	tests := []struct {
+		formats []string
+		want    string
+	}{
+		{nil, "json"},
+		{[]string{"github-output"}, "json"},
+		{[]string{"both"}, "json"},
+		{[]string{" YAML ", "json"}, "yaml"},
+		{[]string{"", "markdown"}, "markdown"},
+	}
+	for _, tt := range tests {
+		if got := outputFileFormat(tt.formats); got != tt.want {
+			t.Errorf("outputFileFormat(%q) = %q, want %q", tt.formats, got, tt.want)
+		}
+	}
+}
@@ -4,10 +4,12 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,23 +18,58 @@ import (
 )
 
 // appContext carries the runtime wiring shared across output emission:
-// the GitHub Actions client and the flags that decide how outputs are
-// surfaced (CI vs. local, verbosity, environment export).
+// the GitHub Actions client, the flags that decide how outputs are
+// surfaced (CI vs. local, verbosity, environment export, dry run), and
+// every output set so far for the github-output format and dry runs.
 type appContext struct {
 	action        *githubactions.Action
 	isCI          bool
 	verboseOutput bool
+	quiet         bool
 	exportEnvVars bool
+	dryRun        bool
+	outputs       []actionOutput
+	env           []actionOutput
+	// suppressedOutputs names the outputs only_changed withholds because
+	// their field matches the previous run.
+	suppressedOutputs map[string]bool
+}
+
+// infof logs an informational line: through the workflow log in CI,
+// to stdout locally. Quiet runs drop it; warnings and errors are logged
+// directly and always surface.
+func (c *appContext) infof(format string, args ...interface{}) {
+	if c.quiet {
+		return
+	}
+	if c.isCI {
+		c.action.Infof(format, args...)
+	} else {
+		fmt.Printf(format+"\n", args...)
+	}
 }
 
 // setOutput sets an action output. In CI it writes to the GitHub
 // Actions output file (optionally also exporting an environment
-// variable); locally it prints to stdout only when verbose.
+// variable); locally it prints to stdout only when verbose. A dry run
+// only records the output and variable for emitDryRun. Outputs withheld
+// by only_changed are dropped.
 func (c *appContext) setOutput(name, value string) {
+	if c.suppressedOutputs[name] {
+		return
+	}
+	c.outputs = append(c.outputs, actionOutput{name: name, value: value})
 	if c.isCI {
+		envName := strings.ToUpper(name)
+		exportEnv := c.exportEnvVars && value != ""
+		if c.dryRun {
+			if exportEnv {
+				c.recordEnv(envName, value)
+			}
+			return
+		}
 		c.action.SetOutput(name, value)
-		if c.exportEnvVars && value != "" {
-			envName := strings.ToUpper(name)
+		if exportEnv {
 			if c.verboseOutput {
 				c.action.Infof("Exporting environment variable: %s", envName)
 			}
@@ -46,11 +83,33 @@ func (c *appContext) setOutput(name, value string) {
 	}
 }
 
+// setFormatOutput sets an output carrying a rendered output format. It
+// reaches GITHUB_OUTPUT (or the dry run collector) like any other output
+// but is never echoed locally, since the format is printed already.
+func (c *appContext) setFormatOutput(name, value string) {
+	c.outputs = append(c.outputs, actionOutput{name: name, value: value})
+	if !c.dryRun {
+		c.action.SetOutput(name, value)
+	}
+}
+
 func emitCommonOutputs(ctx *appContext, metadata *Metadata) {
 	ctx.setOutput("project_type", metadata.Common.ProjectType)
+	ctx.setOutput("project_type_source", metadata.Common.ProjectTypeSource)
 	ctx.setOutput("project_name", metadata.Common.ProjectName)
 	ctx.setOutput("project_version", metadata.Common.ProjectVersion)
 	ctx.setOutput("project_path", metadata.Common.ProjectPath)
+	ctx.setOutput("project_root", metadata.Common.ProjectRoot)
+	ctx.setOutput("description", metadata.Common.Description)
+	ctx.setOutput("description_source", metadata.Common.DescriptionSource)
+	ctx.setOutput("artifact_kind", metadata.Common.ArtifactKind)
+	if len(metadata.Common.AuthorsStructured) > 0 {
+		ctx.setOutput("authors_structured", formatComplexValue(metadata.Common.AuthorsStructured))
+	}
+	ctx.setOutput("publishable", metadata.Common.Publishable)
+	ctx.setOutput("publish_target", metadata.Common.PublishTarget)
+	ctx.setOutput("license_source", metadata.Common.LicenseSource)
+	ctx.setOutput("license_confidence", metadata.Common.LicenseConfidence)
 	ctx.setOutput("version_source", metadata.Common.VersionSource)
 	ctx.setOutput("versioning_type", metadata.Common.VersioningType)
 	ctx.setOutput("version_properties_version", metadata.Common.VersionPropertiesVersion)
@@ -65,12 +124,27 @@ func emitCommonOutputs(ctx *appContext, metadata *Metadata) {
 	ctx.setOutput("git_sha", metadata.Common.GitSHA)
 	ctx.setOutput("git_branch", metadata.Common.GitBranch)
 	ctx.setOutput("git_tag", metadata.Common.GitTag)
+	ctx.setOutput("git_shallow", fmt.Sprintf("%t", metadata.Common.GitShallow))
+	ctx.setOutput("default_branch", metadata.Common.DefaultBranch)
+	ctx.setOutput("is_default_branch", metadata.Common.IsDefaultBranch)
+	ctx.setOutput("version_matches_tag", metadata.Common.VersionMatchesTag)
+	ctx.setOutput("extraction_error_code", metadata.Common.ExtractionErrorCode)
+	ctx.setOutput("extraction_error_message", metadata.Common.ExtractionErrorMessage)
+	ctx.setOutput("coexisting_build_systems", strings.Join(metadata.Common.CoexistingBuildSystems, ","))
+	ctx.setOutput("overridden_fields", strings.Join(metadata.Common.OverriddenFields, ","))
+	ctx.setOutput("primary_language", metadata.Common.PrimaryLanguage)
+	if len(metadata.Common.LanguageBreakdown) > 0 {
+		ctx.setOutput("language_breakdown", formatComplexValue(metadata.Common.LanguageBreakdown))
+	}
 
 	ctx.setOutput("ci_platform", metadata.Build.CIPlatform)
 	ctx.setOutput("ci_run_id", metadata.Build.CIRunID)
 	ctx.setOutput("ci_run_url", metadata.Build.CIRunURL)
 	ctx.setOutput("runner_os", metadata.Build.RunnerOS)
 	ctx.setOutput("runner_arch", metadata.Build.RunnerArch)
+	ctx.setOutput("ci_event", metadata.Build.CIEvent)
+	ctx.setOutput("ci_actor", metadata.Build.CIActor)
+	ctx.setOutput("ci_workflow", metadata.Build.CIWorkflow)
 }
 
 // emitProjectMatchRepo compares the detected project name against the
@@ -151,10 +225,12 @@ func formatComplexValue(v interface{}) string {
 }
 
 // emitMetadataJSON marshals the full metadata document and publishes it
-// as the metadata_json output, returning the marshaled bytes (nil on
-// error) for reuse by the output-format writers.
-func emitMetadataJSON(ctx *appContext, metadata *Metadata) []byte {
-	metadataJSON, err := json.MarshalIndent(metadata, "", "  ")
+// as the metadata_json output, indented with indent (the json_indent
+// input), and the unindented json_compact output, returning the
+// defaultJSONIndent bytes (nil on error) for reuse by the output-format
+// writers.
+func emitMetadataJSON(ctx *appContext, metadata *Metadata, indent string) []byte {
+	metadataJSON, err := json.MarshalIndent(metadata, "", defaultJSONIndent)
 	if err != nil {
 		if ctx.isCI {
 			ctx.action.Warningf("Failed to marshal metadata to JSON: %v", err)
@@ -164,12 +240,79 @@ func emitMetadataJSON(ctx *appContext, metadata *Metadata) []byte {
 		return metadataJSON
 	}
 
-	ctx.setOutput("metadata_json", string(metadataJSON))
+	if indented, err := reindentMetadataJSON(metadataJSON, indent); err == nil {
+		ctx.setOutput("metadata_json", string(indented))
+	}
+	if compact, err := compactMetadataJSON(metadataJSON); err == nil {
+		ctx.setOutput("json_compact", string(compact))
+	}
 	return metadataJSON
 }
 
+// compactMetadataJSON strips the indentation from the metadata_json
+// document, yielding the json.Marshal form without marshaling twice.
+func compactMetadataJSON(metadataJSON []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, metadataJSON); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// metadataDocumentOutputs are the outputs carrying the whole metadata
+// document, raw or rendered by an output format, left out of
+// outputs_json, which would otherwise embed it
+var metadataDocumentOutputs = map[string]bool{
+	"metadata_json":   true,
+	"json_compact":    true,
+	"metadata_yaml":   true,
+	"markdown_output": true,
+	"html_output":     true,
+}
+
+// emitOutputsJSON sets outputs_json to a flat JSON object of every
+// output set so far, by name, so a downstream job can fromJSON the
+// whole output namespace at once. It runs after the last other output
+// (artifact details and success included); the metadata document
+// outputs are left out.
+func emitOutputsJSON(ctx *appContext) {
+	outputs := make(map[string]string, len(ctx.outputs))
+	for _, out := range ctx.outputs {
+		if !metadataDocumentOutputs[out.name] {
+			outputs[out.name] = out.value
+		}
+	}
+	outputsJSON, err := json.Marshal(outputs)
+	if err != nil {
+		if ctx.isCI {
+			ctx.action.Warningf("Failed to marshal outputs to JSON: %v", err)
+		} else {
+			fmt.Printf("Warning: Failed to marshal outputs to JSON: %v\n", err)
+		}
+		return
+	}
+	ctx.setOutput("outputs_json", string(outputsJSON))
+}
+
+// addStepSummary posts the metadata summary to the job's step summary
+// when add_summary is in effect and no selected output format posts it
+// already.
+func addStepSummary(ctx *appContext, cfg runConfig, metadata *Metadata) {
+	if !cfg.addSummary {
+		return
+	}
+	for _, format := range cfg.outputFormats {
+		switch strings.ToLower(strings.TrimSpace(format)) {
+		case "summary", "both":
+			return
+		}
+	}
+	ctx.action.AddStepSummary(output.GenerateSummary(metadata))
+}
+
 // writeOutputFormats renders each requested output format, supporting
-// multiple formats per invocation.
+// multiple formats per invocation. The summary and both formats post
+// the step summary themselves; addStepSummary covers the others.
 func writeOutputFormats(ctx *appContext, cfg runConfig, metadata *Metadata, metadataJSON []byte) {
 	for _, format := range cfg.outputFormats {
 		format = strings.ToLower(strings.TrimSpace(format))
@@ -185,15 +328,42 @@ func writeOutputFormats(ctx *appContext, cfg runConfig, metadata *Metadata, meta
 		case "json":
 			fmt.Println(string(metadataJSON))
 
+		case "compact_json":
+			compact, err := compactMetadataJSON(metadataJSON)
+			if err != nil {
+				ctx.action.Warningf("Failed to generate compact JSON output: %v", err)
+				continue
+			}
+			fmt.Println(string(compact))
+
+		case "jsonl":
+			lines, err := output.GenerateJSONL(metadata)
+			if err != nil {
+				ctx.action.Warningf("Failed to generate JSONL output: %v", err)
+				continue
+			}
+			fmt.Print(lines)
+
+		case "spdx":
+			document, err := output.GenerateSPDX(metadata)
+			if err != nil {
+				ctx.action.Warningf("Failed to generate SPDX output: %v", err)
+				continue
+			}
+			fmt.Print(document)
+
 		case "markdown":
 			markdown := output.GenerateMarkdown(metadata)
 			fmt.Println(markdown)
-			ctx.action.SetOutput("markdown_output", markdown)
+			ctx.setFormatOutput("markdown_output", markdown)
+
+		case "html":
+			ctx.setFormatOutput("html_output", output.GenerateHTML(metadata))
 
 		case "yaml":
 			// YAML output currently emits the JSON representation; native YAML
 			// serialisation is not yet implemented.
-			ctx.action.SetOutput("metadata_yaml", string(metadataJSON))
+			ctx.setFormatOutput("metadata_yaml", string(metadataJSON))
 			if ctx.verboseOutput {
 				ctx.action.Infof("YAML output format requested (using JSON for now)")
 			}
@@ -203,6 +373,10 @@ func writeOutputFormats(ctx *appContext, cfg runConfig, metadata *Metadata, meta
 			ctx.action.AddStepSummary(summary)
 			fmt.Println(string(metadataJSON))
 
+		case githubOutputFormat:
+			// Written by emitGitHubOutputFormat once all outputs are set
+			continue
+
 		case "":
 			// Empty string means disable output - skip silently
 			continue
@@ -218,7 +392,7 @@ func uploadArtifacts(ctx *appContext, cfg runConfig, metadata *Metadata) {
 		return
 	}
 
-	ctx.action.Infof("Uploading build metadata artifacts...")
+	ctx.infof("Uploading build metadata artifacts...")
 
 	uploader := output.NewArtifactUploader(
 		true,
@@ -227,6 +401,8 @@ func uploadArtifacts(ctx *appContext, cfg runConfig, metadata *Metadata) {
 		"", // Use temp dir
 		cfg.validateOutput,
 		true, // Strict mode
+		cfg.artifactRetentionDays,
+		cfg.artifactCompress,
 	)
 
 	// Generate job name from context
@@ -241,20 +417,30 @@ func uploadArtifacts(ctx *appContext, cfg runConfig, metadata *Metadata) {
 		return
 	}
 
-	ctx.action.Infof("✅ Artifacts uploaded to: %s", artifactResult.Path)
+	ctx.infof("✅ Artifacts uploaded to: %s", artifactResult.Path)
 	ctx.setOutput("artifact_name", artifactResult.Name)
 	ctx.setOutput("artifact_path", artifactResult.Path)
 	ctx.setOutput("artifact_files", strings.Join(artifactResult.Files, ","))
+	ctx.setOutput("artifact_archive_path", artifactResult.ArchivePath)
+	if artifactResult.RetentionDays > 0 {
+		ctx.setOutput("artifact_retention_days", strconv.Itoa(artifactResult.RetentionDays))
+	}
 
 	if ctx.verboseOutput {
 		ctx.action.Infof("Artifact details:")
 		ctx.action.Infof("  Name: %s", artifactResult.Name)
 		ctx.action.Infof("  Path: %s", artifactResult.Path)
 		ctx.action.Infof("  Files: %s", strings.Join(artifactResult.Files, ", "))
+		if artifactResult.ArchivePath != "" {
+			ctx.action.Infof("  Archive: %s", artifactResult.ArchivePath)
+		}
 	}
 }
 
 func printCompletionSummary(ctx *appContext, metadata *Metadata) {
+	if ctx.quiet {
+		return
+	}
 	if ctx.isCI {
 		ctx.action.Infof("✅ Build metadata extraction completed successfully")
 		return
@@ -274,6 +460,9 @@ func printCompletionSummary(ctx *appContext, metadata *Metadata) {
 		}
 	}
 	fmt.Printf("Project Path:    %s\n", metadata.Common.ProjectPath)
+	if metadata.Common.ProjectRoot != metadata.Common.ProjectPath {
+		fmt.Printf("Project Root:    %s\n", metadata.Common.ProjectRoot)
+	}
 	fmt.Println(strings.Repeat("=", 60))
 
 	// Offer to show full JSON
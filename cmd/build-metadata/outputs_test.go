@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+func TestEmitMetadataJSONCompact(t *testing.T) {
+	ctx := &appContext{action: githubactions.New(), isCI: true, dryRun: true}
+	metadata := dryRunFixture()
+	emitMetadataJSON(ctx, metadata, defaultJSONIndent)
+
+	values := make(map[string]string)
+	for _, out := range ctx.outputs {
+		values[out.name] = out.value
+	}
+	indented, compact := values["metadata_json"], values["json_compact"]
+	if indented == "" || compact == "" {
+		t.Fatalf("metadata_json = %q, json_compact = %q, want both set", indented, compact)
+	}
+
+	if len(compact) >= len(indented) {
+		t.Errorf("json_compact is %d bytes, want fewer than metadata_json's %d", len(compact), len(indented))
+	}
+	if strings.Contains(compact, "\n") {
+		t.Error("json_compact contains newlines")
+	}
+	marshaled, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if compact != string(marshaled) {
+		t.Errorf("json_compact differs from the json.Marshal form:\n%s\n%s", compact, marshaled)
+	}
+
+	var fromIndented, fromCompact interface{}
+	if err := json.Unmarshal([]byte(indented), &fromIndented); err != nil {
+		t.Fatalf("metadata_json is not valid JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(compact), &fromCompact); err != nil {
+		t.Fatalf("json_compact is not valid JSON: %v", err)
+	}
+	if !reflect.DeepEqual(fromIndented, fromCompact) {
+		t.Error("metadata_json and json_compact decode to different documents")
+	}
+}
+
+func TestEmitOutputsJSON(t *testing.T) {
+	ctx := &appContext{action: githubactions.New(), isCI: true, dryRun: true}
+	metadata := dryRunFixture()
+	emitCommonOutputs(ctx, metadata)
+	emitMetadataJSON(ctx, metadata, defaultJSONIndent)
+	emitOutputsJSON(ctx)
+
+	last := ctx.outputs[len(ctx.outputs)-1]
+	if last.name != "outputs_json" {
+		t.Fatalf("last output = %s, want outputs_json", last.name)
+	}
+	var outputs map[string]string
+	if err := json.Unmarshal([]byte(last.value), &outputs); err != nil {
+		t.Fatalf("outputs_json is not a JSON object of strings: %v", err)
+	}
+	if outputs["project_type"] != metadata.Common.ProjectType {
+		t.Errorf("outputs_json project_type = %q, want %q", outputs["project_type"], metadata.Common.ProjectType)
+	}
+	for _, name := range []string{"metadata_json", "json_compact"} {
+		if _, ok := outputs[name]; ok {
+			t.Errorf("outputs_json contains %s", name)
+		}
+	}
+}
+
+func TestEmitOutputsJSONIncludesArtifactOutputs(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	ctx := &appContext{action: githubactions.New(), isCI: true, dryRun: true, quiet: true}
+	cfg := runConfig{artifactUpload: true, artifactFormats: []string{"json"}, outputFormats: []string{"html"}}
+	metadata := dryRunFixture()
+	emitCommonOutputs(ctx, metadata)
+	writeOutputFormats(ctx, cfg, metadata, emitMetadataJSON(ctx, metadata, defaultJSONIndent))
+	uploadArtifacts(ctx, cfg, metadata)
+	ctx.setOutput("success", "true")
+	emitOutputsJSON(ctx)
+
+	var outputs map[string]string
+	if err := json.Unmarshal([]byte(ctx.outputs[len(ctx.outputs)-1].value), &outputs); err != nil {
+		t.Fatalf("outputs_json is not a JSON object of strings: %v", err)
+	}
+	if outputs["artifact_name"] == "" || outputs["artifact_path"] == "" {
+		t.Errorf("outputs_json lacks the artifact outputs: %v", outputs)
+	}
+	if outputs["success"] != "true" {
+		t.Errorf("outputs_json success = %q, want true", outputs["success"])
+	}
+	if _, ok := outputs["html_output"]; ok {
+		t.Error("outputs_json contains the rendered html_output")
+	}
+}
+
+func TestAddStepSummaryWithJSONFormat(t *testing.T) {
+	summaryFile := filepath.Join(t.TempDir(), "step_summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryFile)
+	t.Setenv("INPUT_PATH_PREFIX", t.TempDir())
+	t.Setenv("INPUT_OUTPUT_FORMAT", "json")
+	t.Setenv("INPUT_ADD_SUMMARY", "true")
+
+	cfg := parseFlags(githubactions.New(), true)
+	if !cfg.addSummary {
+		t.Fatal("addSummary = false, want true with add_summary set and a step summary file")
+	}
+	ctx := &appContext{action: githubactions.New(), isCI: true, dryRun: true}
+	metadata := dryRunFixture()
+	addStepSummary(ctx, cfg, metadata)
+	writeOutputFormats(ctx, cfg, metadata, emitMetadataJSON(ctx, metadata, defaultJSONIndent))
+
+	data, err := os.ReadFile(summaryFile)
+	if err != nil {
+		t.Fatalf("step summary not written: %v", err)
+	}
+	if strings.Count(string(data), "## 🔧 Build Metadata") != 1 {
+		t.Errorf("step summary = %q, want the metadata summary once", data)
+	}
+}
+
+func TestAddStepSummaryDisabled(t *testing.T) {
+	summaryFile := filepath.Join(t.TempDir(), "step_summary.md")
+	t.Setenv("INPUT_PATH_PREFIX", t.TempDir())
+	t.Setenv("INPUT_OUTPUT_FORMAT", "json")
+
+	// No step summary file outside GitHub Actions
+	if cfg := parseFlags(githubactions.New(), false); cfg.addSummary {
+		t.Error("addSummary = true without GITHUB_STEP_SUMMARY")
+	}
+
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryFile)
+	t.Setenv("INPUT_ADD_SUMMARY", "false")
+	cfg := parseFlags(githubactions.New(), true)
+	if cfg.addSummary {
+		t.Fatal("addSummary = true with add_summary false")
+	}
+	addStepSummary(&appContext{action: githubactions.New(), isCI: true, dryRun: true}, cfg, dryRunFixture())
+	if _, err := os.Stat(summaryFile); !os.IsNotExist(err) {
+		t.Errorf("step summary written with add_summary false (stat error %v)", err)
+	}
+}
+
+func TestSummaryFormatWithAddSummary(t *testing.T) {
+	for _, addSummary := range []string{"true", "false"} {
+		t.Run("add_summary="+addSummary, func(t *testing.T) {
+			summaryFile := filepath.Join(t.TempDir(), "step_summary.md")
+			t.Setenv("GITHUB_STEP_SUMMARY", summaryFile)
+			t.Setenv("INPUT_PATH_PREFIX", t.TempDir())
+			t.Setenv("INPUT_OUTPUT_FORMAT", "summary")
+			t.Setenv("INPUT_ADD_SUMMARY", addSummary)
+
+			cfg := parseFlags(githubactions.New(), true)
+			ctx := &appContext{action: githubactions.New(), isCI: true, dryRun: true}
+			metadata := dryRunFixture()
+			addStepSummary(ctx, cfg, metadata)
+			writeOutputFormats(ctx, cfg, metadata, emitMetadataJSON(ctx, metadata, defaultJSONIndent))
+
+			data, err := os.ReadFile(summaryFile)
+			if err != nil {
+				t.Fatalf("step summary not written: %v", err)
+			}
+			if strings.Count(string(data), "## 🔧 Build Metadata") != 1 {
+				t.Errorf("step summary = %q, want the metadata summary once", data)
+			}
+		})
+	}
+}
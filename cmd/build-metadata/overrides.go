@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// metadataOverrides is a validated metadata_overrides input: the JSON
+// object decoded on its own and its keys, sorted.
+type metadataOverrides struct {
+	values CommonMetadata
+	fields []string
+}
+
+// parseMetadataOverrides validates the metadata_overrides input: a JSON
+// object whose keys are common metadata fields (the names of their
+// outputs) and whose values have the field's type, e.g.
+// {"project_version": "1.2.3-build.42"}. A null value clears the field;
+// an empty input overrides nothing.
+func parseMetadataOverrides(raw string) (metadataOverrides, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return metadataOverrides{}, nil
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &values); err != nil {
+		return metadataOverrides{}, fmt.Errorf("not a JSON object: %w", err)
+	}
+
+	known := make(map[string]bool)
+	for _, name := range jsonFieldNames(reflect.TypeOf(CommonMetadata{})) {
+		known[name] = name != "overridden_fields"
+	}
+	fields := make([]string, 0, len(values))
+	var unknown []string
+	for key := range values {
+		if known[key] {
+			fields = append(fields, key)
+		} else {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return metadataOverrides{}, fmt.Errorf("unknown field(s) %s", strings.Join(unknown, ", "))
+	}
+	sort.Strings(fields)
+
+	// Decode into a value of its own so type mismatches fail here, not
+	// after extraction, and maps replace the extracted ones rather than
+	// merging into them
+	var decoded CommonMetadata
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		return metadataOverrides{}, fmt.Errorf("invalid value: %w", err)
+	}
+	return metadataOverrides{values: decoded, fields: fields}, nil
+}
+
+// applyMetadataOverrides replaces the overridden common fields with the
+// metadata_overrides values and records their names in
+// overridden_fields. Fields the input does not name are left as
+// extracted.
+func applyMetadataOverrides(ctx *appContext, cfg runConfig, metadata *Metadata) {
+	if len(cfg.metadataOverrides.fields) == 0 {
+		return
+	}
+	overridden := make(map[string]bool, len(cfg.metadataOverrides.fields))
+	for _, name := range cfg.metadataOverrides.fields {
+		overridden[name] = true
+	}
+	target := reflect.ValueOf(&metadata.Common).Elem()
+	values := reflect.ValueOf(cfg.metadataOverrides.values)
+	for i := 0; i < target.NumField(); i++ {
+		name, _, _ := strings.Cut(target.Type().Field(i).Tag.Get("json"), ",")
+		if overridden[name] {
+			target.Field(i).Set(values.Field(i))
+		}
+	}
+	metadata.Common.OverriddenFields = cfg.metadataOverrides.fields
+	ctx.infof("Overrode %s from metadata_overrides", strings.Join(cfg.metadataOverrides.fields, ", "))
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyMetadataOverridesVersion(t *testing.T) {
+	overrides, err := parseMetadataOverrides(`{"project_version": "1.2.3-build.42", "project_name": "widget"}`)
+	if err != nil {
+		t.Fatalf("parseMetadataOverrides() error = %v", err)
+	}
+	metadata := &Metadata{Common: CommonMetadata{
+		ProjectName:    "extracted",
+		ProjectVersion: "1.2.3",
+		VersionSource:  "pyproject.toml",
+	}}
+	applyMetadataOverrides(&appContext{quiet: true}, runConfig{metadataOverrides: overrides}, metadata)
+
+	if metadata.Common.ProjectVersion != "1.2.3-build.42" || metadata.Common.ProjectName != "widget" {
+		t.Errorf("overrides not applied: %+v", metadata.Common)
+	}
+	if metadata.Common.VersionSource != "pyproject.toml" {
+		t.Errorf("version_source = %q, want the untouched extracted value", metadata.Common.VersionSource)
+	}
+	if want := []string{"project_name", "project_version"}; !reflect.DeepEqual(metadata.Common.OverriddenFields, want) {
+		t.Errorf("overridden_fields = %v, want %v", metadata.Common.OverriddenFields, want)
+	}
+}
+
+func TestApplyMetadataOverridesReplacesValues(t *testing.T) {
+	overrides, err := parseMetadataOverrides(`{"language_breakdown": {"go": 3}, "description": null}`)
+	if err != nil {
+		t.Fatalf("parseMetadataOverrides() error = %v", err)
+	}
+	metadata := &Metadata{Common: CommonMetadata{
+		ProjectName:       "extracted",
+		Description:       "From the README",
+		LanguageBreakdown: map[string]int{"go": 10, "shell": 2},
+	}}
+	applyMetadataOverrides(&appContext{quiet: true}, runConfig{metadataOverrides: overrides}, metadata)
+
+	if want := map[string]int{"go": 3}; !reflect.DeepEqual(metadata.Common.LanguageBreakdown, want) {
+		t.Errorf("language_breakdown = %v, want %v replacing the extracted map", metadata.Common.LanguageBreakdown, want)
+	}
+	if metadata.Common.Description != "" {
+		t.Errorf("description = %q, want it cleared by null", metadata.Common.Description)
+	}
+	if metadata.Common.ProjectName != "extracted" {
+		t.Errorf("project_name = %q, want the untouched extracted value", metadata.Common.ProjectName)
+	}
+}
+
+func TestParseMetadataOverridesInvalid(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`{"project_verison": "1.2.3"}`, "unknown field(s) project_verison"},
+		{`{"overridden_fields": ["project_version"]}`, "unknown field(s) overridden_fields"},
+		{`{"release_file_count": "two"}`, "invalid value"},
+		{`["project_version"]`, "not a JSON object"},
+	}
+	for _, tt := range tests {
+		if _, err := parseMetadataOverrides(tt.raw); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseMetadataOverrides(%s) error = %v, want %q", tt.raw, err, tt.want)
+		}
+	}
+
+	if overrides, err := parseMetadataOverrides("  "); err != nil || len(overrides.fields) != 0 {
+		t.Errorf("parseMetadataOverrides(empty) = %+v, %v, want no overrides", overrides, err)
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// resolveProjectSymlinks returns absPath with symlinks evaluated, so a
+// path_prefix pointing at a symlinked checkout is detected against the
+// real project directory. A dangling symlink is reported with its
+// target rather than as a bare "no such file" error; a path that does
+// not exist at all is returned unchanged so the existing detection
+// errors still apply.
+func resolveProjectSymlinks(absPath string) (string, error) {
+	info, err := os.Lstat(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return absPath, nil
+		}
+		return "", fmt.Errorf("failed to inspect project path %s: %w", absPath, err)
+	}
+
+	resolved, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		if info.Mode()&os.ModeSymlink != 0 && os.IsNotExist(err) {
+			target, _ := os.Readlink(absPath)
+			return "", fmt.Errorf("project path %s is a broken symlink to %s", absPath, target)
+		}
+		return "", fmt.Errorf("failed to resolve symlinks in project path %s: %w", absPath, err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+// symlinkedProject creates a real project directory holding a
+// pyproject.toml and a symlink pointing at it, returning both paths.
+func symlinkedProject(t *testing.T) (realDir, link string) {
+	t.Helper()
+	root := t.TempDir()
+	realDir = filepath.Join(root, "real")
+	if err := os.MkdirAll(realDir, 0755); err != nil {
+		t.Fatalf("failed to create project dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(realDir, "pyproject.toml"), []byte("[project]\nname = \"linked\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write pyproject.toml: %v", err)
+	}
+	link = filepath.Join(root, "checkout")
+	if err := os.Symlink(realDir, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	return realDir, link
+}
+
+func TestResolveProjectSymlinks(t *testing.T) {
+	realDir, link := symlinkedProject(t)
+
+	resolved, err := resolveProjectSymlinks(link)
+	if err != nil {
+		t.Fatalf("resolveProjectSymlinks() error = %v", err)
+	}
+	if resolved != realDir {
+		t.Errorf("resolveProjectSymlinks() = %q, want %q", resolved, realDir)
+	}
+
+	// A plain directory resolves to itself
+	if resolved, err := resolveProjectSymlinks(realDir); err != nil || resolved != realDir {
+		t.Errorf("resolveProjectSymlinks(real) = %q, %v, want %q", resolved, err, realDir)
+	}
+}
+
+func TestResolveProjectSymlinksBroken(t *testing.T) {
+	realDir, link := symlinkedProject(t)
+	if err := os.RemoveAll(realDir); err != nil {
+		t.Fatalf("failed to remove project dir: %v", err)
+	}
+
+	_, err := resolveProjectSymlinks(link)
+	if err == nil || !strings.Contains(err.Error(), "broken symlink") {
+		t.Fatalf("resolveProjectSymlinks() error = %v, want a broken symlink error", err)
+	}
+	if !strings.Contains(err.Error(), realDir) {
+		t.Errorf("error %q does not name the missing target %s", err, realDir)
+	}
+}
+
+func TestResolveProjectSymlinksMissingPath(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "absent")
+
+	resolved, err := resolveProjectSymlinks(missing)
+	if err != nil || resolved != missing {
+		t.Errorf("resolveProjectSymlinks() = %q, %v, want the missing path unchanged", resolved, err)
+	}
+}
+
+// TestParseFlagsResolveSymlinks checks that a symlinked path_prefix is
+// detected through its target while the given path is kept.
+func TestParseFlagsResolveSymlinks(t *testing.T) {
+	realDir, link := symlinkedProject(t)
+
+	t.Setenv("INPUT_PATH_PREFIX", link)
+	cfg := parseFlags(githubactions.New(), false)
+	if cfg.absPath != realDir || cfg.projectPath != realDir {
+		t.Errorf("absPath = %q, projectPath = %q, want both %q", cfg.absPath, cfg.projectPath, realDir)
+	}
+	if cfg.originalPath != link {
+		t.Errorf("originalPath = %q, want %q", cfg.originalPath, link)
+	}
+
+	t.Setenv("INPUT_RESOLVE_SYMLINKS", "false")
+	cfg = parseFlags(githubactions.New(), false)
+	if cfg.absPath != link || cfg.originalPath != link {
+		t.Errorf("absPath = %q, originalPath = %q, want both %q with resolution disabled", cfg.absPath, cfg.originalPath, link)
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// Values of project_type_source, naming what chose the project type
+const (
+	projectTypeSourceInput    = "input"
+	projectTypeSourceManifest = "manifest_file"
+	projectTypeSourceDetected = "detected"
+	projectTypeSourceExternal = "external"
+)
+
+// resolveProjectTypeInput validates the project_type input against the
+// registered extractors. Both detector project types ("python-modern")
+// and extractor names ("python") are accepted; an unknown type is an
+// error listing the extractors that are available.
+func resolveProjectTypeInput(projectType string) (string, error) {
+	projectType = strings.ToLower(strings.TrimSpace(projectType))
+	if _, err := extractor.GetExtractor(projectType); err != nil {
+		var names []string
+		for _, e := range extractor.GetAllExtractors() {
+			names = append(names, e.Name())
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("unknown project type %q (available: %s)", projectType, strings.Join(names, ", "))
+	}
+	return projectType, nil
+}
+
+// useInputProjectType records the project type forced through the
+// project_type input in place of auto-detection.
+func useInputProjectType(ctx *appContext, metadata *Metadata, projectType string) {
+	metadata.Common.ProjectType = projectType
+	metadata.Common.ProjectTypeSource = projectTypeSourceInput
+	ctx.infof("Using project type %s from project_type input", projectType)
+}
+
+// selectProjectType picks the project type by precedence: the
+// project_type input, then the type implied by manifest_file, then
+// auto-detection of the project path.
+func selectProjectType(ctx *appContext, cfg runConfig, metadata *Metadata) string {
+	switch {
+	case cfg.inputProjectType != "":
+		useInputProjectType(ctx, metadata, cfg.inputProjectType)
+		return cfg.inputProjectType
+	case cfg.manifestProjectType != "":
+		useManifestProjectType(ctx, metadata, cfg.manifestProjectType, cfg.manifestFile)
+		return cfg.manifestProjectType
+	}
+	metadata.Common.ProjectTypeSource = projectTypeSourceDetected
+	return detectProjectType(ctx, metadata, cfg.absPath)
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sethvargo/go-githubactions"
+)
+
+func TestResolveProjectTypeInput(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "python-modern", want: "python-modern"},
+		{input: " Java-Gradle ", want: "java-gradle"},
+		{input: "python", want: "python"},
+	}
+	for _, tt := range tests {
+		got, err := resolveProjectTypeInput(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("resolveProjectTypeInput(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolveProjectTypeInputUnknown(t *testing.T) {
+	_, err := resolveProjectTypeInput("cobol-legacy")
+	if err == nil {
+		t.Fatal("resolveProjectTypeInput() accepted an unknown project type")
+	}
+	if !strings.Contains(err.Error(), `"cobol-legacy"`) || !strings.Contains(err.Error(), "python") {
+		t.Errorf("error %q does not name the type and the available extractors", err)
+	}
+}
+
+func TestSelectProjectType(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte("[project]\nname = \"sample\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "build.gradle"), []byte(""), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &appContext{}
+
+	// Forced through the input: detection would pick python-modern
+	t.Setenv("INPUT_PATH_PREFIX", dir)
+	t.Setenv("INPUT_PROJECT_TYPE", "java-gradle")
+	cfg := parseFlags(githubactions.New(), false)
+	metadata := &Metadata{}
+	if got := selectProjectType(ctx, cfg, metadata); got != "java-gradle" {
+		t.Errorf("selectProjectType() = %q, want java-gradle from the input", got)
+	}
+	if metadata.Common.ProjectType != "java-gradle" || metadata.Common.ProjectTypeSource != projectTypeSourceInput {
+		t.Errorf("ProjectType = %q from %q, want java-gradle from input", metadata.Common.ProjectType, metadata.Common.ProjectTypeSource)
+	}
+
+	// Default: auto-detection
+	t.Setenv("INPUT_PROJECT_TYPE", "")
+	cfg = parseFlags(githubactions.New(), false)
+	metadata = &Metadata{}
+	if got := selectProjectType(ctx, cfg, metadata); got != "python-modern" {
+		t.Errorf("selectProjectType() = %q, want the detected python-modern", got)
+	}
+	if metadata.Common.ProjectTypeSource != projectTypeSourceDetected {
+		t.Errorf("ProjectTypeSource = %q, want %q", metadata.Common.ProjectTypeSource, projectTypeSourceDetected)
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// recommendedVersionKeys lists, per base language (see
+// normalizeProjectTypeToLanguage), the language-specific keys the
+// recommended toolchain version is read from, in order of preference.
+// A string value is used as is; a version matrix contributes its newest
+// numeric entry.
+var recommendedVersionKeys = map[string][]string{
+	"python":     {"build_version", "version_matrix"},
+	"java":       {"version"},
+	"kotlin":     {"version"},
+	"javascript": {"node_version_matrix", "node_version"},
+	"go":         {"go_version_matrix", "go_version"},
+	"rust":       {"rust_version_matrix"},
+	"csharp":     {"dotnet_version_matrix"},
+	"dotnet":     {"dotnet_version_matrix"},
+	"php":        {"php_version_matrix"},
+	"ruby":       {"ruby_version"},
+	"swift":      {"swift_version_matrix"},
+	"dart":       {"dart_version_matrix"},
+	"elixir":     {"elixir_version_matrix", "elixir_version"},
+	"scala":      {"scala_version_matrix", "scala_version"},
+	"haskell":    {"ghc_version_matrix", "ghc_version"},
+	"julia":      {"julia_version_matrix"},
+	"terraform":  {"terraform_version_matrix"},
+	"helm":       {"kubernetes_version_matrix"},
+}
+
+// recommendedVersion returns the single toolchain version a workflow
+// should build with for the project type, or "" when the extractor
+// recorded none.
+func recommendedVersion(projectType string, languageSpecific map[string]interface{}) string {
+	for _, key := range recommendedVersionKeys[normalizeProjectTypeToLanguage(projectType)] {
+		switch v := languageSpecific[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case []string:
+			if newest := newestMatrixVersion(v); newest != "" {
+				return newest
+			}
+		}
+	}
+	return ""
+}
+
+// newestMatrixVersion returns the highest dotted-numeric entry of a
+// version matrix regardless of its order (the .NET matrix is newest
+// first). Channel names such as "stable" are skipped so the
+// recommendation is a concrete version; they are only returned when the
+// matrix holds nothing else.
+func newestMatrixVersion(matrix []string) string {
+	var newest string
+	var newestParts []int
+	for _, entry := range matrix {
+		parts, ok := numericVersionParts(entry)
+		if !ok {
+			continue
+		}
+		if newestParts == nil || compareVersionParts(parts, newestParts) > 0 {
+			newest, newestParts = entry, parts
+		}
+	}
+	if newest == "" && len(matrix) > 0 {
+		return matrix[len(matrix)-1]
+	}
+	return newest
+}
+
+// numericVersionParts splits a version such as "3.12" or "1.84.0" into
+// its numeric components.
+func numericVersionParts(version string) ([]int, bool) {
+	fields := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	parts := make([]int, 0, len(fields))
+	for _, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
+
+// compareVersionParts orders numeric versions component by component, a
+// missing component counting as zero.
+func compareVersionParts(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
+
+// emitRecommendedVersion publishes the recommended_version output.
+func emitRecommendedVersion(ctx *appContext, metadata *Metadata, projectType string) {
+	ctx.setOutput("recommended_version", recommendedVersion(projectType, metadata.LanguageSpecific))
+}
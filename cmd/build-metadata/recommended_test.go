@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import "testing"
+
+func TestRecommendedVersion(t *testing.T) {
+	tests := []struct {
+		name             string
+		projectType      string
+		languageSpecific map[string]interface{}
+		want             string
+	}{
+		{
+			name:        "python uses the newest matrix entry",
+			projectType: "python-modern",
+			languageSpecific: map[string]interface{}{
+				"version_matrix": []string{"3.10", "3.11", "3.12", "3.9"},
+			},
+			want: "3.12",
+		},
+		{
+			name:        "python prefers build_version",
+			projectType: "python-legacy",
+			languageSpecific: map[string]interface{}{
+				"build_version":  "3.13",
+				"version_matrix": []string{"3.12", "3.13"},
+			},
+			want: "3.13",
+		},
+		{
+			name:             "java uses the parsed java version",
+			projectType:      "java-maven",
+			languageSpecific: map[string]interface{}{"version": "17", "version_source": "maven.compiler.release"},
+			want:             "17",
+		},
+		{
+			name:             "dotnet matrix is newest first",
+			projectType:      "csharp-project",
+			languageSpecific: map[string]interface{}{"dotnet_version_matrix": []string{"8.0", "10.0", "6.0"}},
+			want:             "10.0",
+		},
+		{
+			name:             "rust skips the stable channel",
+			projectType:      "rust-cargo",
+			languageSpecific: map[string]interface{}{"rust_version_matrix": []string{"1.82", "1.84", "stable"}},
+			want:             "1.84",
+		},
+		{
+			name:             "node falls back to the declared version",
+			projectType:      "javascript-npm",
+			languageSpecific: map[string]interface{}{"node_version": ">=20"},
+			want:             ">=20",
+		},
+		{
+			name:             "no version recorded",
+			projectType:      "docker",
+			languageSpecific: map[string]interface{}{"base_images": []string{"alpine:3.20"}},
+			want:             "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recommendedVersion(tt.projectType, tt.languageSpecific); got != tt.want {
+				t.Errorf("recommendedVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -22,7 +22,7 @@ func writeReleaseFile(t *testing.T, dir, name, content string) {
 }
 
 func TestApplyReleaseFilesNone(t *testing.T) {
-	metadata := newMetadata(t.TempDir())
+	metadata := newMetadata(t.TempDir(), t.TempDir())
 	applyReleaseFiles(metadata, metadata.Common.ProjectPath)
 
 	if metadata.Common.IsReleaseReady {
@@ -42,7 +42,7 @@ project: cps
 ref: abcdef1234567890abcdef1234567890abcdef12
 `)
 
-	metadata := newMetadata(tmpDir)
+	metadata := newMetadata(tmpDir, tmpDir)
 	applyReleaseFiles(metadata, tmpDir)
 
 	if !metadata.Common.IsReleaseReady {
@@ -67,7 +67,7 @@ func TestApplyReleaseFilesMultipleLeavesVersionEmpty(t *testing.T) {
 	writeReleaseFile(t, tmpDir, "3.8.1.yaml", "version: 3.8.1\nref: aaa\n")
 	writeReleaseFile(t, tmpDir, "3.8.2.yml", "version: 3.8.2\nref: bbb\n")
 
-	metadata := newMetadata(tmpDir)
+	metadata := newMetadata(tmpDir, tmpDir)
 	applyReleaseFiles(metadata, tmpDir)
 
 	if metadata.Common.ReleaseFileCount != 2 {
@@ -90,7 +90,7 @@ func TestApplyReleaseFilesStripsQuotesFromScalars(t *testing.T) {
 	tmpDir := t.TempDir()
 	writeReleaseFile(t, tmpDir, "release.yaml", "version: \"1.2.3\"\nref: 'deadbeef'\n")
 
-	metadata := newMetadata(tmpDir)
+	metadata := newMetadata(tmpDir, tmpDir)
 	applyReleaseFiles(metadata, tmpDir)
 
 	if metadata.Common.ReleaseVersion != "1.2.3" {
@@ -4,16 +4,24 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/lfreleng-actions/build-metadata-action/internal/detector"
 	"github.com/lfreleng-actions/build-metadata-action/internal/environment"
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/cobol"
+	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/cocoapods"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/cpp"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/dart"
+	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/dlang"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/docker"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/dotnet"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/elixir"
+	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/elm"
+	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/gleam"
 	golang "github.com/lfreleng-actions/build-metadata-action/internal/extractor/golang"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/haskell"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/helm"
@@ -23,19 +31,20 @@ import (
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/php"
 	python "github.com/lfreleng-actions/build-metadata-action/internal/extractor/python"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/ruby"
-	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/rust"
+	rust "github.com/lfreleng-actions/build-metadata-action/internal/extractor/rust"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/scala"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/swift"
 	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/terraform"
+	_ "github.com/lfreleng-actions/build-metadata-action/internal/extractor/vlang"
+	"github.com/lfreleng-actions/build-metadata-action/internal/hygiene"
+	"github.com/lfreleng-actions/build-metadata-action/internal/languages"
+	"github.com/lfreleng-actions/build-metadata-action/internal/lockdiff"
 	"github.com/lfreleng-actions/build-metadata-action/internal/version"
+	"github.com/lfreleng-actions/build-metadata-action/internal/workflows"
 )
 
 func detectProjectType(ctx *appContext, metadata *Metadata, absPath string) string {
-	if ctx.isCI {
-		ctx.action.Infof("Detecting project type in: %s", absPath)
-	} else {
-		fmt.Printf("Detecting project type in: %s\n", absPath)
-	}
+	ctx.infof("Detecting project type in: %s", absPath)
 
 	projectType, err := detector.DetectProjectType(absPath)
 	if err != nil {
@@ -48,11 +57,7 @@ func detectProjectType(ctx *appContext, metadata *Metadata, absPath string) stri
 	}
 
 	metadata.Common.ProjectType = projectType
-	if ctx.isCI {
-		ctx.action.Infof("Detected project type: %s", projectType)
-	} else {
-		fmt.Printf("Detected project type: %s\n", projectType)
-	}
+	ctx.infof("Detected project type: %s", projectType)
 	return projectType
 }
 
@@ -61,16 +66,29 @@ func detectProjectType(ctx *appContext, metadata *Metadata, absPath string) stri
 // type detection so that non-Python / non-Go projects never pay the
 // endoflife.date network round-trip (nor surface unrelated EOL-fetch
 // warnings) just to satisfy defaults they will never use.
+//
+// With static_matrices (implied by python_offline_mode) every matrix
+// comes from the committed fallback tables: Python resolves its offline
+// policy, Go keeps the static goversions list, and Rust skips the
+// rust-lang.org release fetch.
 func configureExtractorPolicies(projectType string, cfg runConfig) {
 	language := normalizeProjectTypeToLanguage(projectType)
+	staticMatrices := cfg.staticMatrices || cfg.pythonOffline
 	if language == "python" {
-		python.SetActivePolicy(python.ResolvePolicy(cfg.pythonOffline, cfg.pythonTimeout, cfg.pythonRetries))
+		python.SetActivePolicy(python.ResolvePolicy(staticMatrices, cfg.pythonTimeout, cfg.pythonRetries))
 	}
 	// ResolveSupportedVersions falls back to the static goversions list
 	// when the live API is unreachable, so offline runners degrade
 	// gracefully.
 	if language == "go" {
-		golang.SetSupportedVersions(golang.ResolveSupportedVersions())
+		if staticMatrices {
+			golang.SetSupportedVersions(nil)
+		} else {
+			golang.SetSupportedVersions(golang.ResolveSupportedVersions())
+		}
+	}
+	if language == "rust" {
+		rust.SetStaticMatrices(staticMatrices)
 	}
 }
 
@@ -79,19 +97,11 @@ func extractVersionInfo(ctx *appContext, cfg runConfig, metadata *Metadata, proj
 		return
 	}
 
-	if ctx.isCI {
-		ctx.action.Infof("Extracting version information...")
-	} else {
-		fmt.Println("Extracting version information...")
-	}
+	ctx.infof("Extracting version information...")
 
 	versionInfo, err := version.ExtractVersion(cfg.absPath, projectType)
 	if err != nil {
-		if ctx.isCI {
-			ctx.action.Warningf("Failed to extract version: %v", err)
-		} else {
-			fmt.Printf("Warning: Failed to extract version: %v\n", err)
-		}
+		ctx.warnf(extractor.WarnVersionEmpty, "Failed to extract version: %v", err)
 		return
 	}
 
@@ -104,36 +114,78 @@ func extractVersionInfo(ctx *appContext, cfg runConfig, metadata *Metadata, proj
 	}
 }
 
-func extractProjectMetadata(ctx *appContext, metadata *Metadata, projectType, absPath string) {
+// extractProjectMetadata runs the project type's extractor and merges its
+// result. Extraction failures are warnings, except that with
+// strict_manifest a malformed manifest is returned as an error so the
+// caller can fail the run.
+func extractProjectMetadata(ctx *appContext, cfg runConfig, metadata *Metadata, projectType string) error {
 	extractorImpl, err := extractor.GetExtractor(projectType)
 	if err != nil {
-		if ctx.isCI {
-			ctx.action.Warningf("No specific extractor for project type %s: %v", projectType, err)
-		} else {
-			fmt.Printf("Warning: No specific extractor for project type %s: %v\n", projectType, err)
+		// An undetected project has no manifest at all; a detected type
+		// without an extractor is unsupported
+		code := extractor.ErrUnsupported
+		if projectType == "unknown" {
+			code = extractor.ErrNoManifest
 		}
-		return
+		recordExtractionError(metadata, extractor.WithCode(code, err))
+		ctx.warnf(extractor.WarnNoExtractor, "No specific extractor for project type %s: %v", projectType, err)
+		return nil
 	}
 
-	if ctx.isCI {
-		ctx.action.Infof("Extracting %s project metadata...", projectType)
-	} else {
-		fmt.Printf("Extracting %s project metadata...\n", projectType)
-	}
+	ctx.infof("Extracting %s project metadata...", projectType)
 
-	projectMetadata, err := extractorImpl.Extract(absPath)
+	projectMetadata, err := extractorImpl.Extract(cfg.absPath)
 	if err != nil {
+		recordExtractionError(metadata, err)
+		var manifestErr *extractor.ManifestError
+		if cfg.strictManifest && errors.As(err, &manifestErr) {
+			return fmt.Errorf("malformed manifest (strict_manifest): %w", manifestErr)
+		}
 		if ctx.isCI {
 			ctx.action.Warningf("Failed to extract project metadata: %v", err)
 		} else {
 			fmt.Printf("Warning: Failed to extract project metadata: %v\n", err)
 		}
-		return
+		return nil
 	}
 
+	mergeProjectMetadata(metadata, projectMetadata)
+	applyManifestHashes(ctx, cfg, metadata, projectType, projectMetadata.ConsumedFiles)
+	return nil
+}
+
+// recordExtractionError stores the failure class and message of a failed
+// extraction for the extraction_error_code and extraction_error_message
+// outputs.
+func recordExtractionError(metadata *Metadata, err error) {
+	metadata.Common.ExtractionErrorCode = extractor.ErrorCode(err)
+	metadata.Common.ExtractionErrorMessage = err.Error()
+}
+
+// mergeProjectMetadata copies an extractor's result onto the common
+// metadata without overriding a version already resolved elsewhere.
+func mergeProjectMetadata(metadata *Metadata, projectMetadata *extractor.ProjectMetadata) {
 	if projectMetadata.Name != "" {
 		metadata.Common.ProjectName = projectMetadata.Name
 	}
+	if projectMetadata.License != "" {
+		metadata.Common.License = projectMetadata.License
+		metadata.Common.LicenseSource = licenseSourceManifest
+	}
+	if projectMetadata.Description != "" {
+		metadata.Common.Description = projectMetadata.Description
+		metadata.Common.DescriptionSource = descriptionSourceManifest
+	}
+	if projectMetadata.ArtifactKind != "" {
+		metadata.Common.ArtifactKind = projectMetadata.ArtifactKind
+	}
+	if len(projectMetadata.AuthorsStructured) > 0 {
+		metadata.Common.AuthorsStructured = projectMetadata.AuthorsStructured
+	}
+	if projectMetadata.Publishable != nil {
+		metadata.Common.Publishable = fmt.Sprintf("%t", *projectMetadata.Publishable)
+		metadata.Common.PublishTarget = projectMetadata.PublishTarget
+	}
 	if projectMetadata.Version != "" && metadata.Common.ProjectVersion == "" {
 		metadata.Common.ProjectVersion = projectMetadata.Version
 		metadata.Common.VersionSource = projectMetadata.VersionSource
@@ -154,6 +206,80 @@ func extractProjectMetadata(ctx *appContext, metadata *Metadata, projectType, ab
 	}
 }
 
+// gitDerivedVersionSources are the version sources a Go module's git tag
+// may replace: nothing found, or the generic git describe fallback that
+// ignores semver and module major versions.
+var gitDerivedVersionSources = map[string]bool{
+	"":           true,
+	"none":       true,
+	"git-tag":    true,
+	"git-commit": true,
+}
+
+// applyGoGitVersion sets a Go module's version from the newest semver
+// tag reachable from HEAD that matches the module's major version, since
+// go.mod never records one. Versions from version.properties or a
+// version file are left alone, and shallow clones only use a tag on HEAD.
+func applyGoGitVersion(ctx *appContext, cfg runConfig, metadata *Metadata, projectType string) {
+	if !cfg.useGitVersion || normalizeProjectTypeToLanguage(projectType) != "go" {
+		return
+	}
+	if !gitDerivedVersionSources[metadata.Common.VersionSource] {
+		return
+	}
+	modulePath, _ := metadata.LanguageSpecific["module_path"].(string)
+	if modulePath == "" {
+		return
+	}
+
+	// Tags below the shallow cut-off are not reachable from HEAD, so the
+	// newest reachable one would understate the module version; a tag on
+	// HEAD itself, as in a tag-triggered release build, is still exact
+	lookup := version.GoModuleVersionFromTags
+	if metadata.Common.GitShallow {
+		ctx.infof("Shallow clone: only using a Go module version tag on HEAD")
+		lookup = version.GoModuleVersionFromHeadTags
+	}
+	info, err := lookup(cfg.absPath, modulePath)
+	if err != nil {
+		if ctx.verboseOutput {
+			ctx.infof("No Go module version from git tags: %v", err)
+		}
+		return
+	}
+
+	metadata.Common.ProjectVersion = info.Version
+	metadata.Common.VersionSource = info.Source
+	metadata.Common.VersioningType = "static"
+}
+
+// applyGitShallow records whether the project sits in a shallow clone
+// and warns that git-derived values may then be incomplete. It runs
+// before extraction so the steps needing full history can skip.
+func applyGitShallow(ctx *appContext, cfg runConfig, metadata *Metadata) {
+	if !version.IsShallowClone(cfg.absPath) {
+		return
+	}
+	metadata.Common.GitShallow = true
+	ctx.warnf(extractor.WarnShallowClone, "Shallow git clone detected; git-derived version and tag data may be incomplete (use fetch-depth: 0 for full history)")
+}
+
+// applyChangelogVersion takes the version of the newest release in
+// CHANGELOG.md or CHANGES.md when use_changelog_version is set and no
+// manifest supplied one, i.e. the version source is still git-derived.
+func applyChangelogVersion(cfg runConfig, metadata *Metadata) {
+	if !cfg.useChangelogVersion || !gitDerivedVersionSources[metadata.Common.VersionSource] {
+		return
+	}
+	info, ok := version.ExtractChangelogVersion(cfg.absPath)
+	if !ok {
+		return
+	}
+	metadata.Common.ProjectVersion = info.Version
+	metadata.Common.VersionSource = info.Source
+	metadata.Common.VersioningType = "static"
+}
+
 // applyVersionProperties surfaces version.properties (the Linux
 // Foundation / ONAP release convention) explicitly even when a language
 // manifest won the version_source selection, then synthesizes the
@@ -171,26 +297,140 @@ func applyVersionProperties(metadata *Metadata, absPath string) {
 		metadata.Common.ProjectVersion)
 }
 
-func collectEnvironmentMetadata(ctx *appContext, cfg runConfig, metadata *Metadata) {
-	if !cfg.includeEnvironment {
+// runtimeProbeTimeout bounds each include_runtime_versions toolchain
+// invocation so a hung tool cannot stall the run.
+const runtimeProbeTimeout = 5 * time.Second
+
+// applyHygiene records which hygiene files the project carries when
+// include_hygiene is set.
+func applyHygiene(cfg runConfig, metadata *Metadata) {
+	if !cfg.includeHygiene {
 		return
 	}
+	report := hygiene.Collect(cfg.absPath)
+	metadata.Hygiene = &report
+}
 
-	if ctx.isCI {
-		ctx.action.Infof("Collecting environment metadata...")
-	} else {
-		fmt.Println("Collecting environment metadata...")
+// applyLanguageBreakdown counts the project's source files per language
+// and records the most prevalent one as primary_language. Unlike
+// project_type it ignores manifests, so a Python repository carrying a
+// package.json for its docs tooling still reports python.
+func applyLanguageBreakdown(ctx *appContext, cfg runConfig, metadata *Metadata) {
+	breakdown := languages.Scan(cfg.absPath)
+	if len(breakdown) == 0 {
+		return
 	}
+	metadata.Common.LanguageBreakdown = breakdown
+	metadata.Common.PrimaryLanguage = languages.Primary(breakdown)
+	ctx.infof("Primary language: %s", metadata.Common.PrimaryLanguage)
+}
 
-	envMetadata, err := environment.Collect()
+// applySourceLayout records the conventional test and source
+// directories found at the extraction root as test_dirs and source_dirs,
+// for coverage and test tooling to configure itself from.
+func applySourceLayout(metadata *Metadata) {
+	layout := extractor.DetectSourceLayout(metadata.Common.ProjectRoot)
+	if len(layout.TestDirs) == 0 && len(layout.SourceDirs) == 0 {
+		return
+	}
+	if metadata.LanguageSpecific == nil {
+		metadata.LanguageSpecific = make(map[string]interface{})
+	}
+	if len(layout.TestDirs) > 0 {
+		metadata.LanguageSpecific["test_dirs"] = layout.TestDirs
+	}
+	if len(layout.SourceDirs) > 0 {
+		metadata.LanguageSpecific["source_dirs"] = layout.SourceDirs
+	}
+}
+
+// applyWorkflowAnalysis records the deduplicated "uses:" references of
+// the repository's GitHub Actions workflows as ci_actions_used, with the
+// number of workflow files as ci_workflow_count, when analyze_workflows
+// is set.
+func applyWorkflowAnalysis(ctx *appContext, cfg runConfig, metadata *Metadata) {
+	if !cfg.analyzeWorkflows {
+		return
+	}
+	report := workflows.Collect(cfg.absPath)
+	if report.Dir == "" {
+		ctx.infof("No .github/workflows directory found")
+		return
+	}
+	if metadata.LanguageSpecific == nil {
+		metadata.LanguageSpecific = make(map[string]interface{})
+	}
+	metadata.LanguageSpecific["ci_actions_used"] = report.ActionsUsed
+	metadata.LanguageSpecific["ci_workflow_count"] = report.WorkflowCount
+	ctx.infof("Workflows: %d file(s) using %d action(s) or reusable workflow(s)", report.WorkflowCount, len(report.ActionsUsed))
+}
+
+// applyDependencyChanges compares the project's lockfile with its blob
+// at deps_changed_since, recording the dependencies added, removed and
+// updated since that ref. It runs after the metadata cache since the
+// ref may move between runs.
+func applyDependencyChanges(ctx *appContext, cfg runConfig, metadata *Metadata) {
+	if cfg.depsChangedSince == "" {
+		return
+	}
+	result, err := lockdiff.Compare(cfg.absPath, cfg.depsChangedSince)
 	if err != nil {
 		if ctx.isCI {
-			ctx.action.Warningf("Failed to collect environment metadata: %v", err)
+			ctx.action.Warningf("Failed to compare dependencies with %s: %v", cfg.depsChangedSince, err)
 		} else {
-			fmt.Printf("Warning: Failed to collect environment metadata: %v\n", err)
+			fmt.Printf("Warning: Failed to compare dependencies with %s: %v\n", cfg.depsChangedSince, err)
 		}
 		return
 	}
+	if metadata.LanguageSpecific == nil {
+		metadata.LanguageSpecific = make(map[string]interface{})
+	}
+	metadata.LanguageSpecific["dependencies_added"] = result.Added
+	metadata.LanguageSpecific["dependencies_removed"] = result.Removed
+	metadata.LanguageSpecific["dependencies_updated"] = result.Updated
+	ctx.infof("Dependencies since %s (%s): %d added, %d removed, %d updated",
+		cfg.depsChangedSince, result.Lockfile, len(result.Added), len(result.Removed), len(result.Updated))
+}
+
+// applyBuildSystems records every build system marker at the project
+// root, noting when several coexist since detection only picks one.
+func applyBuildSystems(ctx *appContext, metadata *Metadata, absPath string) {
+	buildSystems := detector.DetectBuildSystems(absPath)
+	metadata.Common.CoexistingBuildSystems = buildSystems
+	if len(buildSystems) < 2 {
+		return
+	}
+	ctx.infof("Coexisting build systems: %s", strings.Join(buildSystems, ", "))
+}
 
-	metadata.Environment = *envMetadata
+// collectEnvironmentMetadata gathers the runner environment and, when
+// requested, the detected project type's toolchain version.
+func collectEnvironmentMetadata(ctx *appContext, cfg runConfig, metadata *Metadata, projectType string) {
+	if cfg.includeEnvironment {
+		ctx.infof("Collecting environment metadata...")
+
+		envMetadata, err := environment.Collect(cfg.environmentSections)
+		if err != nil {
+			if ctx.isCI {
+				ctx.action.Warningf("Failed to collect environment metadata: %v", err)
+			} else {
+				fmt.Printf("Warning: Failed to collect environment metadata: %v\n", err)
+			}
+		} else {
+			metadata.Environment = *envMetadata
+		}
+	}
+
+	// Explicitly named variables are captured even without
+	// include_environment
+	if len(cfg.captureEnvVars) > 0 {
+		environment.CaptureVariables(&metadata.Environment, cfg.captureEnvVars)
+	}
+
+	// Probing executes the project's toolchain, so it stays opt-in and
+	// independent of include_environment
+	if cfg.includeRuntimeVersions {
+		environment.ProbeRuntimeVersion(&metadata.Environment,
+			normalizeProjectTypeToLanguage(projectType), runtimeProbeTimeout)
+	}
 }
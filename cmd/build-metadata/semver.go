@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// semverPattern is the Semantic Versioning 2.0.0 grammar: three
+// numeric components without leading zeros, then optional pre-release
+// and build metadata
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// isSemver reports whether version is valid semver after tag-style
+// normalization; lowercasing never changes validity, as the grammar
+// accepts both cases.
+func isSemver(version string) bool {
+	return semverPattern.MatchString(normalizeTagVersion(version))
+}
+
+// semverViolation returns why the project version breaks require_semver,
+// or "" when it passes or the check does not apply: the input is off, no
+// version was extracted, or versioning is dynamic without
+// require_semver_strict.
+func semverViolation(cfg runConfig, metadata *Metadata) string {
+	if !cfg.requireSemver || metadata.Common.ProjectVersion == "" {
+		return ""
+	}
+	if metadata.Common.VersioningType == "dynamic" && !cfg.requireSemverStrict {
+		return ""
+	}
+	if isSemver(metadata.Common.ProjectVersion) {
+		return ""
+	}
+	source := metadata.Common.VersionSource
+	if source == "" {
+		source = "unknown source"
+	}
+	return fmt.Sprintf("Project version %q from %s is not valid semver (MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD])",
+		metadata.Common.ProjectVersion, source)
+}
+
+// enforceRequireSemver fails the run when require_semver is set and the
+// project version is not valid semver.
+func enforceRequireSemver(ctx *appContext, cfg runConfig, metadata *Metadata) {
+	msg := semverViolation(cfg, metadata)
+	if msg == "" {
+		return
+	}
+	if ctx.isCI {
+		ctx.action.Fatalf("%s", msg)
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
+		os.Exit(1)
+	}
+}
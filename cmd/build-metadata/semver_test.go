@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsSemver(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.2.3", true},
+		{"v1.2.3", true},
+		{"0.0.0", true},
+		{"2.0.0-rc.1", true},
+		{"1.0.0-alpha+build.5", true},
+		{"1.0.0+20260101", true},
+		{"1.0", false},
+		{"1", false},
+		{"01.2.3", false},
+		{"1.2.3-01", false},
+		{"1.2.3.4", false},
+		{"1.2.3-SNAPSHOT", true},
+		{"vv1.2.3", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isSemver(tt.version); got != tt.want {
+			t.Errorf("isSemver(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestSemverViolation(t *testing.T) {
+	withVersion := func(version, source, versioningType string) *Metadata {
+		return &Metadata{Common: CommonMetadata{ProjectVersion: version, VersionSource: source, VersioningType: versioningType}}
+	}
+
+	// Valid semver passes
+	if msg := semverViolation(runConfig{requireSemver: true}, withVersion("1.4.0", "pyproject.toml", "static")); msg != "" {
+		t.Errorf("semverViolation(1.4.0) = %q, want pass", msg)
+	}
+
+	// 1.0 fails, naming the value and its source
+	msg := semverViolation(runConfig{requireSemver: true}, withVersion("1.0", "pom.xml", "static"))
+	if !strings.Contains(msg, `"1.0"`) || !strings.Contains(msg, "pom.xml") {
+		t.Errorf("semverViolation(1.0) = %q, want the version and source named", msg)
+	}
+
+	// Off by default
+	if msg := semverViolation(runConfig{}, withVersion("1.0", "pom.xml", "static")); msg != "" {
+		t.Errorf("semverViolation() without require_semver = %q", msg)
+	}
+
+	// Nothing extracted, nothing to check
+	if msg := semverViolation(runConfig{requireSemver: true}, withVersion("", "", "")); msg != "" {
+		t.Errorf("semverViolation() without a version = %q", msg)
+	}
+
+	// Dynamic versions are exempt unless require_semver_strict is set
+	dynamic := withVersion("1.0.dev3", "setuptools_scm", "dynamic")
+	if msg := semverViolation(runConfig{requireSemver: true}, dynamic); msg != "" {
+		t.Errorf("semverViolation() for a dynamic version = %q, want exempt", msg)
+	}
+	if msg := semverViolation(runConfig{requireSemver: true, requireSemverStrict: true}, dynamic); msg == "" {
+		t.Error("semverViolation() with require_semver_strict passed 1.0.dev3")
+	}
+}
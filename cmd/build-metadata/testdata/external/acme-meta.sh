@@ -0,0 +1,7 @@
+#!/bin/sh
+# SPDX-License-Identifier: Apache-2.0
+# SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+# Fixture external extractor: echoes the project path back with a
+# declared project type and a couple of language-specific fields.
+printf '{"project_type": "acme", "toolchain": "4.2", "project_path": "%s"}\n' "$1"
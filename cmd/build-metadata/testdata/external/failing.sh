@@ -0,0 +1,7 @@
+#!/bin/sh
+# SPDX-License-Identifier: Apache-2.0
+# SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+# Fixture external extractor that exits nonzero.
+echo "acme manifest not found" >&2
+exit 3
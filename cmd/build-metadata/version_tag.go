@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// applyVersionTagMatch compares the resolved project version against
+// the pushed git tag on tag-triggered runs, so a release whose in-repo
+// version (e.g. pyproject's version) was never bumped is caught before
+// it ships. Dynamic versions are derived from the tag itself, so the
+// comparison is skipped for them and the field stays empty.
+func applyVersionTagMatch(metadata *Metadata) {
+	metadata.Common.VersionMatchesTag = versionTagMatch(
+		metadata.Common.GitTag,
+		metadata.Common.ProjectVersion,
+		metadata.Common.VersioningType)
+}
+
+// versionTagMatch returns "true"/"false" comparing the tag against the
+// project version after normalization, or "" when either side is empty
+// or the project uses dynamic versioning (not comparable).
+func versionTagMatch(tag, projectVersion, versioningType string) string {
+	if tag == "" || projectVersion == "" || versioningType == "dynamic" {
+		return ""
+	}
+	return fmt.Sprintf("%t", normalizeTagVersion(tag) == normalizeTagVersion(projectVersion))
+}
+
+// normalizeTagVersion strips surrounding whitespace and a single leading
+// "v"/"V" (the common tag prefix) and lowercases the remainder, so
+// "v1.2.3-RC1" and "1.2.3-rc1" compare equal.
+func normalizeTagVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if strings.HasPrefix(version, "v") || strings.HasPrefix(version, "V") {
+		version = version[1:]
+	}
+	return strings.ToLower(version)
+}
+
+// enforceVersionTagMatch fails the run when fail_on_version_tag_mismatch
+// is set and the project version does not match the tag. A skipped
+// comparison (no tag, no version, dynamic versioning) never fails.
+func enforceVersionTagMatch(ctx *appContext, cfg runConfig, metadata *Metadata) {
+	if !cfg.failOnVersionTagMismatch || metadata.Common.VersionMatchesTag != "false" {
+		return
+	}
+
+	msg := fmt.Sprintf("Project version %s does not match git tag %s",
+		metadata.Common.ProjectVersion, metadata.Common.GitTag)
+	if ctx.isCI {
+		ctx.action.Fatalf("%s", msg)
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import "testing"
+
+// TestVersionTagMatch locks in the tag comparator: the leading "v" is
+// ignored, a differing version reports "false", and the check is skipped
+// ("") without a tag, without a version, or under dynamic versioning.
+func TestVersionTagMatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		tag            string
+		projectVersion string
+		versioningType string
+		want           string
+	}{
+		{
+			name:           "exact match",
+			tag:            "1.2.3",
+			projectVersion: "1.2.3",
+			versioningType: "static",
+			want:           "true",
+		},
+		{
+			name:           "v-prefixed tag matches",
+			tag:            "v1.2.3",
+			projectVersion: "1.2.3",
+			versioningType: "static",
+			want:           "true",
+		},
+		{
+			name:           "case-insensitive pre-release",
+			tag:            "V2.0.0-RC1",
+			projectVersion: "2.0.0-rc1",
+			versioningType: "static",
+			want:           "true",
+		},
+		{
+			name:           "mismatch",
+			tag:            "v1.2.4",
+			projectVersion: "1.2.3",
+			versioningType: "static",
+			want:           "false",
+		},
+		{
+			name:           "dynamic versioning skipped",
+			tag:            "v1.2.4",
+			projectVersion: "1.2.3",
+			versioningType: "dynamic",
+			want:           "",
+		},
+		{
+			name:           "no tag",
+			tag:            "",
+			projectVersion: "1.2.3",
+			versioningType: "static",
+			want:           "",
+		},
+		{
+			name:           "no project version",
+			tag:            "v1.2.3",
+			projectVersion: "",
+			versioningType: "static",
+			want:           "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := versionTagMatch(tt.tag, tt.projectVersion, tt.versioningType)
+			if got != tt.want {
+				t.Errorf("versionTagMatch(%q, %q, %q) = %q, want %q",
+					tt.tag, tt.projectVersion, tt.versioningType, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// parseSuppressWarnings validates the suppress_warnings input, a comma,
+// space or newline separated list of warning categories, returning them
+// lowercased.
+func parseSuppressWarnings(raw string) ([]string, error) {
+	known := make(map[string]bool, len(extractor.WarningCategories))
+	for _, category := range extractor.WarningCategories {
+		known[category] = true
+	}
+	var categories []string
+	for _, category := range parseMultiSeparatorInput(raw) {
+		category = strings.ToLower(category)
+		if !known[category] {
+			return nil, fmt.Errorf("%q is not one of %s", category, strings.Join(extractor.WarningCategories, ", "))
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+// warnf logs a warning in category: through the workflow log in CI, to
+// stdout locally. Categories listed in suppress_warnings are dropped;
+// uncategorized warnings and errors are logged directly and always
+// surface.
+func (c *appContext) warnf(category, format string, args ...interface{}) {
+	if extractor.WarningSuppressed(category) {
+		return
+	}
+	if c.isCI {
+		c.action.Warningf(format, args...)
+	} else {
+		fmt.Printf("Warning: "+format+"\n", args...)
+	}
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSuppressWarnings(t *testing.T) {
+	got, err := parseSuppressWarnings("no-extractor, Empty-Requires-Python\nshallow-clone")
+	if err != nil {
+		t.Fatalf("parseSuppressWarnings() error = %v", err)
+	}
+	if want := []string{"no-extractor", "empty-requires-python", "shallow-clone"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSuppressWarnings() = %v, want %v", got, want)
+	}
+
+	if got, err := parseSuppressWarnings(""); err != nil || len(got) != 0 {
+		t.Errorf("parseSuppressWarnings(\"\") = %v, %v, want nothing", got, err)
+	}
+
+	if _, err := parseSuppressWarnings("no-extractor,everything"); err == nil {
+		t.Error("parseSuppressWarnings() accepted an unknown category")
+	}
+}
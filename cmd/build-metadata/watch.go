@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/detector"
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+const (
+	// watchPollInterval is how often --watch re-stats the manifests
+	watchPollInterval = 500 * time.Millisecond
+	// watchDebounce is how long the manifests must stay unchanged before
+	// a burst of writes (editors, version-bump tools) triggers extraction
+	watchDebounce = 300 * time.Millisecond
+)
+
+// watchEvent is the JSON line --watch prints for the initial extraction
+// and after every settled change.
+type watchEvent struct {
+	Timestamp    string    `json:"timestamp"`
+	Trigger      string    `json:"trigger"`
+	ChangedFiles []string  `json:"changed_files,omitempty"`
+	Metadata     *Metadata `json:"metadata,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// fileStamp identifies a manifest revision without reading its content.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// parseWatchArgs resolves the optional project path given to --watch.
+func parseWatchArgs(args []string) (string, error) {
+	if len(args) > 1 {
+		return "", fmt.Errorf("usage: build-metadata --watch [path]")
+	}
+	projectPath := "."
+	if len(args) == 1 {
+		projectPath = args[0]
+	}
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve project path: %w", err)
+	}
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", absPath)
+	}
+	return absPath, nil
+}
+
+// runWatch implements the --watch mode for local development: it prints
+// one timestamped JSON line with the extracted metadata, then polls the
+// project's manifest files and prints another line each time they
+// change. The manifest set is re-resolved on every poll so created and
+// deleted manifests are noticed too. Rapid successive writes are
+// debounced into a single re-extraction. Runs until ctx is cancelled.
+func runWatch(ctx context.Context, absPath string, interval, debounce time.Duration, w io.Writer) error {
+	current := statManifests(absPath)
+	if err := writeWatchEvent(w, watchSnapshot(absPath, "initial", nil)); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var pending []string
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			next := statManifests(absPath)
+			if changed := changedManifests(current, next); len(changed) > 0 {
+				pending = mergeChangedFiles(pending, changed)
+				lastChange = now
+				current = next
+				continue
+			}
+			if len(pending) == 0 || now.Sub(lastChange) < debounce {
+				continue
+			}
+			if err := writeWatchEvent(w, watchSnapshot(absPath, "change", pending)); err != nil {
+				return err
+			}
+			pending = nil
+		}
+	}
+}
+
+// watchSnapshot re-runs detection and extraction quietly, so extractor
+// progress messages never interleave with the JSON stream.
+func watchSnapshot(absPath, trigger string, changed []string) watchEvent {
+	event := watchEvent{
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Trigger:      trigger,
+		ChangedFiles: changed,
+	}
+
+	projectType, err := detector.DetectProjectType(absPath)
+	if err != nil {
+		event.Error = err.Error()
+		return event
+	}
+
+	metadata := newMetadata(absPath, absPath)
+	metadata.Common.ProjectType = projectType
+	event.Metadata = metadata
+
+	extractorImpl, err := extractor.GetExtractor(projectType)
+	if err != nil {
+		event.Error = err.Error()
+		return event
+	}
+	projectMetadata, err := extractorImpl.Extract(absPath)
+	if err != nil {
+		event.Error = err.Error()
+		return event
+	}
+	mergeProjectMetadata(metadata, projectMetadata)
+	return event
+}
+
+// statManifests records the current revision of every manifest file.
+func statManifests(absPath string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	for _, path := range detector.ManifestFiles(absPath) {
+		if info, err := os.Stat(path); err == nil {
+			stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return stamps
+}
+
+// changedManifests returns the base names of manifests that were
+// created, modified, or deleted between two polls, sorted.
+func changedManifests(before, after map[string]fileStamp) []string {
+	var changed []string
+	for path, stamp := range after {
+		if previous, ok := before[path]; !ok || previous != stamp {
+			changed = append(changed, filepath.Base(path))
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, filepath.Base(path))
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// mergeChangedFiles adds newly changed names to the pending set, keeping
+// it sorted and free of duplicates across a debounced burst.
+func mergeChangedFiles(pending, changed []string) []string {
+	for _, name := range changed {
+		index := sort.SearchStrings(pending, name)
+		if index < len(pending) && pending[index] == name {
+			continue
+		}
+		pending = append(pending, "")
+		copy(pending[index+1:], pending[index:])
+		pending[index] = name
+	}
+	return pending
+}
+
+// writeWatchEvent prints event as a single JSON line.
+func writeWatchEvent(w io.Writer, event watchEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal watch event: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(line))
+	return err
+}
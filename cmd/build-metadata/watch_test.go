@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writePackageJSON(t *testing.T, dir, version string) {
+	t.Helper()
+	content := `{"name": "watched", "version": "` + version + `"}`
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write package.json: %v", err)
+	}
+}
+
+func nextWatchEvent(t *testing.T, events <-chan watchEvent) watchEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+		return watchEvent{}
+	}
+}
+
+func TestRunWatchReextractsOnChange(t *testing.T) {
+	projectDir := t.TempDir()
+	writePackageJSON(t, projectDir, "1.0.0")
+
+	reader, writer := io.Pipe()
+	events := make(chan watchEvent, 4)
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var event watchEvent
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				t.Errorf("watch line is not JSON: %v", err)
+				continue
+			}
+			events <- event
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runWatch(ctx, projectDir, 10*time.Millisecond, 100*time.Millisecond, writer)
+		writer.Close()
+	}()
+
+	initial := nextWatchEvent(t, events)
+	if initial.Trigger != "initial" || initial.Metadata == nil {
+		t.Fatalf("initial event = %+v, want trigger initial with metadata", initial)
+	}
+	if initial.Metadata.Common.ProjectVersion != "1.0.0" {
+		t.Errorf("initial version = %q, want 1.0.0", initial.Metadata.Common.ProjectVersion)
+	}
+	if _, err := time.Parse(time.RFC3339, initial.Timestamp); err != nil {
+		t.Errorf("timestamp %q is not RFC3339: %v", initial.Timestamp, err)
+	}
+
+	// A burst of writes inside the debounce window yields one event
+	// carrying the final content
+	writePackageJSON(t, projectDir, "1.0.10")
+	time.Sleep(20 * time.Millisecond)
+	writePackageJSON(t, projectDir, "1.0.100")
+
+	changed := nextWatchEvent(t, events)
+	if changed.Trigger != "change" || changed.Metadata == nil {
+		t.Fatalf("change event = %+v, want trigger change with metadata", changed)
+	}
+	if changed.Metadata.Common.ProjectVersion != "1.0.100" {
+		t.Errorf("re-extracted version = %q, want 1.0.100", changed.Metadata.Common.ProjectVersion)
+	}
+	if !reflect.DeepEqual(changed.ChangedFiles, []string{"package.json"}) {
+		t.Errorf("changed_files = %v, want [package.json]", changed.ChangedFiles)
+	}
+
+	select {
+	case extra := <-events:
+		t.Errorf("unexpected extra event after debounce: %+v", extra)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("runWatch() error = %v", err)
+	}
+}
+
+func TestChangedManifests(t *testing.T) {
+	now := time.Now()
+	before := map[string]fileStamp{
+		"/p/package.json": {modTime: now, size: 10},
+		"/p/setup.py":     {modTime: now, size: 5},
+	}
+	after := map[string]fileStamp{
+		"/p/package.json":   {modTime: now.Add(time.Second), size: 10},
+		"/p/pyproject.toml": {modTime: now, size: 3},
+	}
+
+	got := changedManifests(before, after)
+	want := []string{"package.json", "pyproject.toml", "setup.py"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changedManifests() = %v, want %v", got, want)
+	}
+	if got := changedManifests(after, after); len(got) != 0 {
+		t.Errorf("changedManifests() with no change = %v, want none", got)
+	}
+}
+
+func TestParseWatchArgs(t *testing.T) {
+	dir := t.TempDir()
+	if got, err := parseWatchArgs([]string{dir}); err != nil || got != dir {
+		t.Errorf("parseWatchArgs(%q) = %q, %v", dir, got, err)
+	}
+	if _, err := parseWatchArgs([]string{dir, dir}); err == nil {
+		t.Error("expected usage error for extra arguments")
+	}
+	if _, err := parseWatchArgs([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
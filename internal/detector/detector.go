@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 )
 
 // ProjectType represents a detected project type
@@ -32,6 +33,7 @@ type DetectionRule struct {
 	Subtype  string
 	Files    []string // Files that must exist
 	Priority int      // Higher priority types are checked first
+	FoldCase bool     // Match Files ignoring case
 }
 
 // Common detection rules based on file presence
@@ -41,6 +43,10 @@ var detectionRules = []DetectionRule{
 	{Type: "python", Subtype: "legacy", Files: []string{"setup.py"}, Priority: 9},
 	{Type: "python", Subtype: "setup-cfg", Files: []string{"setup.cfg"}, Priority: 9},
 
+	// Elm (ahead of JavaScript: Elm front-ends usually also carry a
+	// package.json for their tooling)
+	{Type: "elm", Subtype: "", Files: []string{"elm.json"}, Priority: 0},
+
 	// JavaScript/Node.js
 	{Type: "javascript", Subtype: "npm", Files: []string{"package.json"}, Priority: 1},
 
@@ -64,6 +70,11 @@ var detectionRules = []DetectionRule{
 	{Type: "ruby", Subtype: "gemspec", Files: []string{"*.gemspec"}, Priority: 8},
 	{Type: "ruby", Subtype: "bundler", Files: []string{"Gemfile"}, Priority: 8},
 
+	// CocoaPods (checked before Ruby, since CocoaPods apps often carry
+	// a Gemfile pinning the cocoapods gem)
+	{Type: "cocoapods", Subtype: "", Files: []string{"Podfile"}, Priority: 7},
+	{Type: "cocoapods", Subtype: "", Files: []string{"*.podspec"}, Priority: 7},
+
 	// PHP
 	{Type: "php", Subtype: "composer", Files: []string{"composer.json"}, Priority: 7},
 
@@ -118,10 +129,36 @@ var detectionRules = []DetectionRule{
 	// Helm
 	{Type: "helm", Subtype: "chart", Files: []string{"Chart.yaml"}, Priority: 24},
 
+	// D (dub)
+	{Type: "d", Subtype: "", Files: []string{"dub.json"}, Priority: 19},
+	{Type: "d", Subtype: "", Files: []string{"dub.sdl"}, Priority: 19},
+
+	// V
+	{Type: "vlang", Subtype: "", Files: []string{"v.mod"}, Priority: 19},
+
+	// Gleam
+	{Type: "gleam", Subtype: "", Files: []string{"gleam.toml"}, Priority: 19},
+
 	// Terraform/OpenTofu
 	{Type: "terraform", Subtype: "module", Files: []string{"main.tf"}, Priority: 25},
 	{Type: "terraform", Subtype: "module", Files: []string{"variables.tf"}, Priority: 25},
 	{Type: "terraform", Subtype: "module", Files: []string{"*.tf"}, Priority: 26},
+	{Type: "terraform", Subtype: "module", Files: []string{"main.tofu"}, Priority: 25},
+	{Type: "terraform", Subtype: "module", Files: []string{"*.tofu"}, Priority: 26},
+
+	// Scala without a build file: scala-cli, or a pinned Scala version
+	{Type: "scala", Subtype: "cli", Files: []string{"project.scala"}, Priority: 27},
+	{Type: "scala", Subtype: "", Files: []string{".scala-version"}, Priority: 27},
+
+	// COBOL has no manifest; detect by program and copybook sources, at
+	// the top level or one directory down, in any case since mainframe
+	// exports are often upper case
+	{Type: "cobol", Subtype: "", Files: []string{"*.cbl"}, Priority: 28, FoldCase: true},
+	{Type: "cobol", Subtype: "", Files: []string{"*.cob"}, Priority: 28, FoldCase: true},
+	{Type: "cobol", Subtype: "", Files: []string{"*.cpy"}, Priority: 28, FoldCase: true},
+	{Type: "cobol", Subtype: "", Files: []string{"*/*.cbl"}, Priority: 28, FoldCase: true},
+	{Type: "cobol", Subtype: "", Files: []string{"*/*.cob"}, Priority: 28, FoldCase: true},
+	{Type: "cobol", Subtype: "", Files: []string{"*/*.cpy"}, Priority: 28, FoldCase: true},
 }
 
 // DetectProjectType attempts to detect the project type at the given path
@@ -183,22 +220,125 @@ func DetectAllProjectTypes(projectPath string) ([]string, error) {
 	return projectTypes, nil
 }
 
+// DetectManifestType returns the project type implied by a single
+// manifest file name (e.g. "app/pyproject.toml" -> "python-modern").
+// Only single-file rules are considered, and when several match the
+// highest-priority rule wins, mirroring DetectProjectType.
+func DetectManifestType(manifestPath string) (string, error) {
+	name := filepath.Base(manifestPath)
+
+	sortedRules := make([]DetectionRule, len(detectionRules))
+	copy(sortedRules, detectionRules)
+	sort.Slice(sortedRules, func(i, j int) bool {
+		return sortedRules[i].Priority < sortedRules[j].Priority
+	})
+
+	for _, rule := range sortedRules {
+		if len(rule.Files) != 1 {
+			continue
+		}
+		if matched, err := filepath.Match(rule.Files[0], name); err == nil && matched {
+			pt := &ProjectType{
+				Type:     rule.Type,
+				Subtype:  rule.Subtype,
+				File:     name,
+				Priority: rule.Priority,
+			}
+			return pt.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("unrecognized manifest file: %s", name)
+}
+
+// ProjectTypes returns every project type the detection rules can
+// report, sorted and without duplicates.
+func ProjectTypes() []string {
+	seen := make(map[string]bool)
+	var types []string
+	for _, rule := range detectionRules {
+		pt := ProjectType{Type: rule.Type, Subtype: rule.Subtype}
+		if name := pt.String(); !seen[name] {
+			seen[name] = true
+			types = append(types, name)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ManifestFiles returns the sorted paths of every file in projectPath
+// named by any detection rule, i.e. the manifests whose presence or
+// content can change the detected project type or its metadata.
+func ManifestFiles(projectPath string) []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, rule := range detectionRules {
+		for _, pattern := range rule.Files {
+			matches, err := glob(projectPath, pattern, rule.FoldCase)
+			if err != nil {
+				continue
+			}
+			for _, match := range matches {
+				if info, err := os.Stat(match); err != nil || info.IsDir() || seen[match] {
+					continue
+				}
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// buildSystemMarkers lists the build systems reported by
+// DetectBuildSystems with the root files that declare each, in output
+// order. Unlike detectionRules they do not compete on priority.
+var buildSystemMarkers = []struct {
+	name  string
+	files []string
+}{
+	{name: "maven", files: []string{"pom.xml"}},
+	{name: "gradle", files: []string{"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"}},
+	{name: "sbt", files: []string{"build.sbt"}},
+	{name: "bazel", files: []string{"MODULE.bazel", "WORKSPACE", "WORKSPACE.bazel"}},
+}
+
+// DetectBuildSystems returns every build system with a marker file in
+// projectPath, whichever project type detection picks. More than one
+// entry means the build systems coexist, e.g. during a Maven to Gradle
+// migration.
+func DetectBuildSystems(projectPath string) []string {
+	var found []string
+	for _, marker := range buildSystemMarkers {
+		for _, file := range marker.files {
+			if fileExists(projectPath, file, false) {
+				found = append(found, marker.name)
+				break
+			}
+		}
+	}
+	return found
+}
+
 // matchesRule checks if the given path matches the detection rule
 func matchesRule(projectPath string, rule DetectionRule) bool {
 	// All files must exist for the rule to match
 	for _, filePattern := range rule.Files {
-		if !fileExists(projectPath, filePattern) {
+		if !fileExists(projectPath, filePattern, rule.FoldCase) {
 			return false
 		}
 	}
 	return true
 }
 
-// fileExists checks if a file or pattern exists in the given path
-func fileExists(projectPath, pattern string) bool {
+// fileExists checks if a file or pattern exists in the given path,
+// matching wildcard patterns ignoring case when foldCase is set
+func fileExists(projectPath, pattern string, foldCase bool) bool {
 	// Check if pattern contains wildcards
 	if containsWildcard(pattern) {
-		matches, err := filepath.Glob(filepath.Join(projectPath, pattern))
+		matches, err := glob(projectPath, pattern, foldCase)
 		return err == nil && len(matches) > 0
 	}
 
@@ -208,6 +348,52 @@ func fileExists(projectPath, pattern string) bool {
 	return err == nil
 }
 
+// glob is filepath.Glob below projectPath, or globFold when foldCase is
+// set
+func glob(projectPath, pattern string, foldCase bool) ([]string, error) {
+	if foldCase {
+		return globFold(projectPath, pattern)
+	}
+	return filepath.Glob(filepath.Join(projectPath, pattern))
+}
+
+// globFold returns the paths below projectPath matching pattern, like
+// filepath.Glob but ignoring case, so PAYROLL.CBL matches *.cbl as
+// mainframe exports name it. Each "/"-separated segment of the pattern
+// matches one directory level; symlinked directories are followed, as
+// filepath.Glob does.
+func globFold(projectPath, pattern string) ([]string, error) {
+	dirs := []string{projectPath}
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	for i, segment := range segments {
+		segment = strings.ToLower(segment)
+		if _, err := filepath.Match(segment, ""); err != nil {
+			return nil, err
+		}
+		var next []string
+		for _, dir := range dirs {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				continue
+			}
+			for _, entry := range entries {
+				if ok, _ := filepath.Match(segment, strings.ToLower(entry.Name())); !ok {
+					continue
+				}
+				path := filepath.Join(dir, entry.Name())
+				if i < len(segments)-1 {
+					if info, err := os.Stat(path); err != nil || !info.IsDir() {
+						continue
+					}
+				}
+				next = append(next, path)
+			}
+		}
+		dirs = next
+	}
+	return dirs, nil
+}
+
 // containsWildcard checks if a pattern contains wildcard characters
 func containsWildcard(pattern string) bool {
 	return filepath.Base(pattern) != pattern ||
@@ -6,6 +6,7 @@ package detector
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -154,6 +155,80 @@ func TestDetectProjectType(t *testing.T) {
 			expectedType: "scala-sbt",
 			expectError:  false,
 		},
+		{
+			name: "CocoaPods Podfile",
+			setupFiles: map[string]string{
+				"Podfile": "platform :ios, '13.0'",
+				"Gemfile": "gem 'cocoapods'",
+			},
+			expectedType: "cocoapods",
+			expectError:  false,
+		},
+		{
+			name: "CocoaPods podspec",
+			setupFiles: map[string]string{
+				"MyPod.podspec": "Pod::Spec.new do |s|\nend",
+			},
+			expectedType: "cocoapods",
+			expectError:  false,
+		},
+		{
+			name: "Elm elm.json",
+			setupFiles: map[string]string{
+				"elm.json":     `{"type": "application"}`,
+				"package.json": `{"name": "test"}`,
+			},
+			expectedType: "elm",
+			expectError:  false,
+		},
+		{
+			name: "D dub.json",
+			setupFiles: map[string]string{
+				"dub.json": `{"name": "test"}`,
+			},
+			expectedType: "d",
+			expectError:  false,
+		},
+		{
+			name: "D dub.sdl",
+			setupFiles: map[string]string{
+				"dub.sdl": `name "test"`,
+			},
+			expectedType: "d",
+			expectError:  false,
+		},
+		{
+			name: "V v.mod",
+			setupFiles: map[string]string{
+				"v.mod": "Module {\n\tname: 'test'\n}",
+			},
+			expectedType: "vlang",
+			expectError:  false,
+		},
+		{
+			name: "Gleam gleam.toml",
+			setupFiles: map[string]string{
+				"gleam.toml": "name = \"test\"\nversion = \"1.0.0\"\n",
+			},
+			expectedType: "gleam",
+			expectError:  false,
+		},
+		{
+			name: "Scala scala-cli project.scala",
+			setupFiles: map[string]string{
+				"project.scala": "//> using scala 3.3.1\n",
+			},
+			expectedType: "scala-cli",
+			expectError:  false,
+		},
+		{
+			name: "COBOL sources",
+			setupFiles: map[string]string{
+				"PAYROLL.cbl": "       IDENTIFICATION DIVISION.\n",
+			},
+			expectedType: "cobol",
+			expectError:  false,
+		},
 		{
 			name:         "Empty directory",
 			setupFiles:   map[string]string{},
@@ -201,6 +276,42 @@ func TestDetectProjectType(t *testing.T) {
 	}
 }
 
+// TestDetectManifestType tests mapping a single manifest name to a type
+func TestDetectManifestType(t *testing.T) {
+	tests := []struct {
+		manifest     string
+		expectedType string
+		expectError  bool
+	}{
+		{manifest: "app/pyproject.toml", expectedType: "python-modern"},
+		{manifest: "package.json", expectedType: "javascript-npm"},
+		{manifest: "service/pom.xml", expectedType: "java-maven"},
+		{manifest: "build.gradle.kts", expectedType: "kotlin-gradle"},
+		{manifest: "lib/mylib.gemspec", expectedType: "ruby-gemspec"},
+		{manifest: "MyApp.csproj", expectedType: "csharp-project"},
+		{manifest: "notes.txt", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.manifest, func(t *testing.T) {
+			result, err := DetectManifestType(tt.manifest)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expectedType {
+				t.Errorf("DetectManifestType(%q) = %v, want %v", tt.manifest, result, tt.expectedType)
+			}
+		})
+	}
+}
+
 // TestDetectAllProjectTypes tests detection of multiple project types
 func TestDetectAllProjectTypes(t *testing.T) {
 	tests := []struct {
@@ -393,6 +504,27 @@ func TestWildcardMatching(t *testing.T) {
 			},
 			expectedType: "haskell-cabal",
 		},
+		{
+			name: "OpenTofu configuration only",
+			setupFiles: map[string]string{
+				"main.tofu": "resource \"null_resource\" \"this\" {}",
+			},
+			expectedType: "terraform-module",
+		},
+		{
+			name: "COBOL sources in upper case",
+			setupFiles: map[string]string{
+				"PAYROLL.CBL": "       IDENTIFICATION DIVISION.\n",
+			},
+			expectedType: "cobol",
+		},
+		{
+			name: "OpenTofu files by wildcard",
+			setupFiles: map[string]string{
+				"network.tofu": "variable \"cidr\" {}",
+			},
+			expectedType: "terraform-module",
+		},
 	}
 
 	for _, tt := range tests {
@@ -615,3 +747,105 @@ func TestCaseInsensitivity(t *testing.T) {
 		t.Errorf("DetectProjectType() = %v, want rust-cargo", result)
 	}
 }
+
+func TestManifestFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"package.json", "pyproject.toml", "README.md"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(""), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", name, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(tmpDir, "Dockerfile"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	got := ManifestFiles(tmpDir)
+	want := []string{filepath.Join(tmpDir, "package.json"), filepath.Join(tmpDir, "pyproject.toml")}
+	if len(got) != len(want) {
+		t.Fatalf("ManifestFiles() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ManifestFiles()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDetectBuildSystems(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{
+			name:  "maven and gradle",
+			files: []string{"pom.xml", "build.gradle"},
+			want:  []string{"maven", "gradle"},
+		},
+		{
+			name:  "gradle kotlin DSL only",
+			files: []string{"build.gradle.kts", "settings.gradle.kts"},
+			want:  []string{"gradle"},
+		},
+		{
+			name:  "sbt and bazel",
+			files: []string{"build.sbt", "MODULE.bazel"},
+			want:  []string{"sbt", "bazel"},
+		},
+		{
+			name:  "no build system",
+			files: []string{"package.json"},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for _, name := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(""), 0644); err != nil {
+					t.Fatalf("Failed to create file %s: %v", name, err)
+				}
+			}
+
+			got := DetectBuildSystems(tmpDir)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectBuildSystems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCOBOLDetectionFoldsCase(t *testing.T) {
+	root := t.TempDir()
+	sources := filepath.Join(root, "sources")
+	if err := os.MkdirAll(filepath.Join(sources, "COPY"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sources, "COPY", "PAYREC.CPY"), []byte("       01 PAY-RECORD.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// An upper-case copybook one directory down, reached through a
+	// symlinked directory
+	project := t.TempDir()
+	if err := os.Symlink(filepath.Join(sources, "COPY"), filepath.Join(project, "copy")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	result, err := DetectProjectType(project)
+	if err != nil {
+		t.Fatalf("Detection failed: %v", err)
+	}
+	if result != "cobol" {
+		t.Errorf("Type = %q, want cobol", result)
+	}
+
+	// Other rules stay case-sensitive
+	other := t.TempDir()
+	if err := os.WriteFile(filepath.Join(other, "MyApp.CSPROJ"), []byte(`<Project Sdk="Microsoft.NET.Sdk">`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if result, err := DetectProjectType(other); err == nil {
+		t.Errorf("MyApp.CSPROJ detected as %q, want no match", result)
+	}
+}
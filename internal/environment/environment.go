@@ -4,10 +4,12 @@
 package environment
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // Metadata contains environment information
@@ -22,6 +24,14 @@ type Metadata struct {
 
 	// Tool versions
 	Tools map[string]string `json:"tools,omitempty"`
+
+	// Toolchain probed for the detected project type (opt-in, see
+	// ProbeRuntimeVersion)
+	DetectedRuntime        string `json:"detected_runtime,omitempty"`
+	DetectedRuntimeVersion string `json:"detected_runtime_version,omitempty"`
+
+	// Variables requested through capture_env_vars (see CaptureVariables)
+	Captured map[string]string `json:"captured,omitempty"`
 }
 
 // CIEnvironment contains CI platform information
@@ -58,26 +68,70 @@ type SetupActionInfo struct {
 	Inputs  map[string]string `json:"inputs,omitempty"`
 }
 
-// Collect gathers environment metadata
-func Collect() (*Metadata, error) {
+// Sections of the environment metadata Collect can gather
+const (
+	// SectionOS is the runtime operating system
+	SectionOS = "os"
+	// SectionArch is the runtime CPU architecture
+	SectionArch = "arch"
+	// SectionRuntime is the Go version and shell running the action
+	SectionRuntime = "runtime"
+	// SectionEnv is the dump of common environment variables (PATH,
+	// HOME, USER and the like)
+	SectionEnv = "env"
+	// SectionCI is the CI platform, runner and workflow details
+	SectionCI = "ci"
+	// SectionTools is the detected setup actions and tool versions
+	SectionTools = "tools"
+)
+
+// Sections lists every section, the set gathered by default
+var Sections = []string{SectionOS, SectionArch, SectionRuntime, SectionEnv, SectionCI, SectionTools}
+
+// Collect gathers the named sections of the environment metadata,
+// leaving the others empty. Tool detection runs the tools, so skipping
+// the tools section also saves those processes.
+func Collect(sections []string) (*Metadata, error) {
+	selected := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		selected[section] = true
+	}
+
 	metadata := &Metadata{
 		Tools:        make(map[string]string),
 		SetupActions: make(map[string]SetupActionInfo),
 	}
 
 	// Collect CI environment
-	metadata.CI = collectCIEnvironment()
+	if selected[SectionCI] {
+		metadata.CI = collectCIEnvironment()
+	}
 
 	// Collect runtime environment
-	metadata.Runtime = collectRuntimeEnvironment()
-
-	// Detect setup actions (GitHub Actions specific)
-	if metadata.CI.Platform == "github" {
-		detectSetupActions(metadata)
+	runtimeEnv := collectRuntimeEnvironment()
+	if selected[SectionOS] {
+		metadata.Runtime.OS = runtimeEnv.OS
+	}
+	if selected[SectionArch] {
+		metadata.Runtime.Arch = runtimeEnv.Arch
+	}
+	if selected[SectionRuntime] {
+		metadata.Runtime.GoVersion = runtimeEnv.GoVersion
+		metadata.Runtime.Shell = runtimeEnv.Shell
 	}
+	if selected[SectionEnv] {
+		metadata.Runtime.Environment = runtimeEnv.Environment
+	}
+
+	if selected[SectionTools] {
+		// Detect setup actions (GitHub Actions specific)
+		if os.Getenv("GITHUB_ACTIONS") == "true" {
+			detectSetupActions(metadata)
+		}
 
-	// Detect tool versions
-	detectToolVersions(metadata)
+		// Detect tool versions
+		detectToolVersions(metadata)
+	}
 
 	return metadata, nil
 }
@@ -237,6 +291,71 @@ func detectToolVersions(metadata *Metadata) {
 	}
 }
 
+// RuntimeProbe names the command that reports a language toolchain's
+// version
+type RuntimeProbe struct {
+	Tool string
+	Args []string
+}
+
+// runtimeProbes maps a base language (as produced by the project type
+// normalization) to the toolchain that builds or runs it
+var runtimeProbes = map[string]RuntimeProbe{
+	"python":     {Tool: "python3", Args: []string{"--version"}},
+	"javascript": {Tool: "node", Args: []string{"--version"}},
+	"typescript": {Tool: "node", Args: []string{"--version"}},
+	"java":       {Tool: "java", Args: []string{"-version"}},
+	"kotlin":     {Tool: "java", Args: []string{"-version"}},
+	"go":         {Tool: "go", Args: []string{"version"}},
+	"rust":       {Tool: "rustc", Args: []string{"--version"}},
+	"ruby":       {Tool: "ruby", Args: []string{"--version"}},
+	"php":        {Tool: "php", Args: []string{"--version"}},
+	"csharp":     {Tool: "dotnet", Args: []string{"--version"}},
+	"dotnet":     {Tool: "dotnet", Args: []string{"--version"}},
+	"swift":      {Tool: "swift", Args: []string{"--version"}},
+	"dart":       {Tool: "dart", Args: []string{"--version"}},
+	"elixir":     {Tool: "elixir", Args: []string{"--version"}},
+	"scala":      {Tool: "scala", Args: []string{"-version"}},
+	"haskell":    {Tool: "ghc", Args: []string{"--numeric-version"}},
+	"julia":      {Tool: "julia", Args: []string{"--version"}},
+}
+
+// ProbeRuntimeVersion runs the toolchain for language and records its
+// name and version as DetectedRuntime/DetectedRuntimeVersion. Languages
+// without a known toolchain are ignored. A missing tool, a failing
+// command, or exceeding timeout leaves the version empty rather than
+// failing, since the runner simply may not have the toolchain installed.
+func ProbeRuntimeVersion(metadata *Metadata, language string, timeout time.Duration) {
+	probe, ok := runtimeProbes[strings.ToLower(language)]
+	if !ok {
+		return
+	}
+	metadata.DetectedRuntime = probe.Tool
+	metadata.DetectedRuntimeVersion = probeVersion(probe, timeout)
+}
+
+// probeVersion runs probe with a deadline and extracts the version from
+// its output, returning "" when the tool is absent, fails, or times out
+func probeVersion(probe RuntimeProbe, timeout time.Duration) string {
+	if _, err := exec.LookPath(probe.Tool); err != nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, probe.Tool, probe.Args...)
+	// Don't wait on output pipes held open by children of a killed
+	// wrapper script (e.g. a shim that forks the real tool)
+	cmd.WaitDelay = time.Second
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return ""
+	}
+	// java -version quotes its version (openjdk version "21.0.1")
+	return strings.Trim(extractVersion(strings.TrimSpace(string(output))), `"`)
+}
+
 // getToolVersion attempts to get the version of a tool
 func getToolVersion(tool string, args ...string) string {
 	cmd := exec.Command(tool, args...)
@@ -293,6 +412,47 @@ func isVersionLike(s string) bool {
 	return false
 }
 
+// RedactedValue replaces the value of a captured variable or logged
+// input whose name looks like it holds a secret
+const RedactedValue = "[REDACTED]"
+
+// secretNameMarkers are the name fragments that mark a variable as a
+// secret; matching is case-insensitive and anywhere in the name
+var secretNameMarkers = []string{"TOKEN", "SECRET", "KEY", "PASSWORD", "CREDENTIAL"}
+
+// CaptureVariables records the named environment variables in
+// metadata.Captured for traceability (e.g. IMAGE_TAG, DEPLOY_ENV). Unset
+// variables are skipped, and any IsSecretName matches is recorded as
+// RedactedValue so credentials never reach the outputs.
+func CaptureVariables(metadata *Metadata, names []string) {
+	for _, name := range names {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if IsSecretName(name) {
+			value = RedactedValue
+		}
+		if metadata.Captured == nil {
+			metadata.Captured = make(map[string]string)
+		}
+		metadata.Captured[name] = value
+	}
+}
+
+// IsSecretName reports whether name, of an environment variable or an
+// action input, contains TOKEN, SECRET, KEY, PASSWORD or CREDENTIAL in
+// any case.
+func IsSecretName(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range secretNameMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetEnvironmentVariable returns a specific environment variable value
 func GetEnvironmentVariable(key string) string {
 	return os.Getenv(key)
@@ -5,8 +5,11 @@ package environment
 
 import (
 	"os"
+	"os/exec"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCollect(t *testing.T) {
@@ -153,7 +156,7 @@ func TestCollect(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupEnv()
 
-			metadata, err := Collect()
+			metadata, err := Collect(Sections)
 			if err != nil {
 				t.Fatalf("Collect() error = %v", err)
 			}
@@ -341,6 +344,63 @@ func TestDetectSetupActions(t *testing.T) {
 	}
 }
 
+func TestProbeRuntimeVersion(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not installed")
+	}
+
+	metadata := &Metadata{}
+	ProbeRuntimeVersion(metadata, "go", 10*time.Second)
+
+	if metadata.DetectedRuntime != "go" {
+		t.Errorf("DetectedRuntime = %q, want go", metadata.DetectedRuntime)
+	}
+	if !strings.Contains(metadata.DetectedRuntimeVersion, "1.") {
+		t.Errorf("DetectedRuntimeVersion = %q, want a go1.x version", metadata.DetectedRuntimeVersion)
+	}
+}
+
+func TestProbeRuntimeVersionMissingTool(t *testing.T) {
+	original := runtimeProbes["python"]
+	runtimeProbes["python"] = RuntimeProbe{Tool: "definitely-not-a-python-binary", Args: []string{"--version"}}
+	defer func() { runtimeProbes["python"] = original }()
+
+	metadata := &Metadata{}
+	ProbeRuntimeVersion(metadata, "python", time.Second)
+
+	if metadata.DetectedRuntime != "definitely-not-a-python-binary" {
+		t.Errorf("DetectedRuntime = %q, want the probed tool", metadata.DetectedRuntime)
+	}
+	if metadata.DetectedRuntimeVersion != "" {
+		t.Errorf("DetectedRuntimeVersion = %q, want empty for a missing tool", metadata.DetectedRuntimeVersion)
+	}
+}
+
+func TestProbeRuntimeVersionUnknownLanguage(t *testing.T) {
+	metadata := &Metadata{}
+	ProbeRuntimeVersion(metadata, "docker", time.Second)
+
+	if metadata.DetectedRuntime != "" || metadata.DetectedRuntimeVersion != "" {
+		t.Errorf("expected no runtime for docker, got %q %q", metadata.DetectedRuntime, metadata.DetectedRuntimeVersion)
+	}
+}
+
+func TestProbeVersionTimeout(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	start := time.Now()
+	version := probeVersion(RuntimeProbe{Tool: "sh", Args: []string{"-c", "sleep 5; echo 1.2.3"}}, 100*time.Millisecond)
+
+	if version != "" {
+		t.Errorf("probeVersion() = %q, want empty after timeout", version)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("probeVersion() took %s, want it bounded by the timeout", elapsed)
+	}
+}
+
 func TestExtractVersion(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -437,6 +497,45 @@ func TestIsVersionLike(t *testing.T) {
 	}
 }
 
+func TestCaptureVariables(t *testing.T) {
+	t.Setenv("IMAGE_TAG", "1.2.3")
+	t.Setenv("DEPLOY_ENV", "")
+	t.Setenv("NPM_TOKEN", "npm_abc123")
+	t.Setenv("aws_secret_access_key", "hunter2")
+	os.Unsetenv("CAPTURE_UNSET_VAR")
+
+	metadata := &Metadata{}
+	CaptureVariables(metadata, []string{"IMAGE_TAG", "DEPLOY_ENV", "CAPTURE_UNSET_VAR", "NPM_TOKEN", "aws_secret_access_key"})
+
+	want := map[string]string{
+		"IMAGE_TAG":             "1.2.3",
+		"DEPLOY_ENV":            "",
+		"NPM_TOKEN":             RedactedValue,
+		"aws_secret_access_key": RedactedValue,
+	}
+	if len(metadata.Captured) != len(want) {
+		t.Errorf("Captured = %v, want %v", metadata.Captured, want)
+	}
+	for name, value := range want {
+		if got, ok := metadata.Captured[name]; !ok || got != value {
+			t.Errorf("Captured[%q] = %q, want %q", name, got, value)
+		}
+	}
+	if _, ok := metadata.Captured["CAPTURE_UNSET_VAR"]; ok {
+		t.Error("unset variable was captured")
+	}
+}
+
+func TestCaptureVariablesNoneSet(t *testing.T) {
+	os.Unsetenv("CAPTURE_UNSET_VAR")
+
+	metadata := &Metadata{}
+	CaptureVariables(metadata, []string{"CAPTURE_UNSET_VAR"})
+	if metadata.Captured != nil {
+		t.Errorf("Captured = %v, want nil so the field is omitted", metadata.Captured)
+	}
+}
+
 func TestGetEnvironmentVariable(t *testing.T) {
 	// Save original environment
 	originalValue := os.Getenv("TEST_VAR")
@@ -666,7 +765,7 @@ func TestGetCIPlatform(t *testing.T) {
 }
 
 func TestRuntimeEnvironmentFields(t *testing.T) {
-	metadata, err := Collect()
+	metadata, err := Collect(Sections)
 	if err != nil {
 		t.Fatalf("Collect() error = %v", err)
 	}
@@ -687,7 +786,7 @@ func TestRuntimeEnvironmentFields(t *testing.T) {
 }
 
 func TestMetadataInitialization(t *testing.T) {
-	metadata, err := Collect()
+	metadata, err := Collect(Sections)
 	if err != nil {
 		t.Fatalf("Collect() error = %v", err)
 	}
@@ -701,6 +800,35 @@ func TestMetadataInitialization(t *testing.T) {
 	}
 }
 
+func TestCollectSections(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("RUNNER_OS", "Linux")
+
+	metadata, err := Collect([]string{SectionOS})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if metadata.Runtime.OS == "" {
+		t.Error("Runtime.OS is empty with the os section requested")
+	}
+	if metadata.Runtime.Arch != "" {
+		t.Errorf("Runtime.Arch = %q, want empty without the arch section", metadata.Runtime.Arch)
+	}
+	if metadata.Runtime.GoVersion != "" || metadata.Runtime.Shell != "" {
+		t.Errorf("Runtime = %+v, want no Go version or shell without the runtime section", metadata.Runtime)
+	}
+	if len(metadata.Runtime.Environment) != 0 {
+		t.Errorf("Runtime.Environment = %v, want empty without the env section", metadata.Runtime.Environment)
+	}
+	if metadata.CI != (CIEnvironment{}) {
+		t.Errorf("CI = %+v, want empty without the ci section", metadata.CI)
+	}
+	if len(metadata.Tools) != 0 || len(metadata.SetupActions) != 0 {
+		t.Errorf("Tools = %v, SetupActions = %v, want empty without the tools section", metadata.Tools, metadata.SetupActions)
+	}
+}
+
 // Helper function to split environment variable strings
 func splitEnv(s string) []string {
 	idx := 0
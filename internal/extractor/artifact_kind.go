@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+// Values of ProjectMetadata.ArtifactKind, telling consumers whether the
+// project builds something to publish (a library), something to deploy
+// (an application), or both.
+const (
+	ArtifactKindLibrary     = "library"
+	ArtifactKindApplication = "application"
+	ArtifactKindBoth        = "both"
+	ArtifactKindUnknown     = "unknown"
+)
+
+// ClassifyArtifact returns the artifact kind for a project that does or
+// does not build a library and an application.
+func ClassifyArtifact(library, application bool) string {
+	switch {
+	case library && application:
+		return ArtifactKindBoth
+	case application:
+		return ArtifactKindApplication
+	case library:
+		return ArtifactKindLibrary
+	default:
+		return ArtifactKindUnknown
+	}
+}
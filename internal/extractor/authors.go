@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import "strings"
+
+// Author is a project author split into name and email; either may be
+// empty
+type Author struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// ParseAuthor splits an author string: "Name <email>", a bare name, or a
+// bare (or angle-bracketed) email address.
+func ParseAuthor(s string) Author {
+	s = strings.TrimSpace(s)
+	if open := strings.LastIndex(s, "<"); open >= 0 && strings.HasSuffix(s, ">") {
+		return Author{
+			Name:  strings.TrimSpace(s[:open]),
+			Email: strings.TrimSpace(s[open+1 : len(s)-1]),
+		}
+	}
+	if strings.Contains(s, "@") && !strings.ContainsAny(s, " \t") {
+		return Author{Email: s}
+	}
+	return Author{Name: s}
+}
+
+// ParseAuthors parses each author string, skipping empty ones.
+func ParseAuthors(authors []string) []Author {
+	parsed := make([]Author, 0, len(authors))
+	for _, author := range authors {
+		if a := ParseAuthor(author); a != (Author{}) {
+			parsed = append(parsed, a)
+		}
+	}
+	return parsed
+}
+
+// String formats the author the way Authors lists them: "Name <email>",
+// or whichever part is set. ParseAuthor reads the result back.
+func (a Author) String() string {
+	switch {
+	case a.Name != "" && a.Email != "":
+		return a.Name + " <" + a.Email + ">"
+	case a.Name != "":
+		return a.Name
+	default:
+		return a.Email
+	}
+}
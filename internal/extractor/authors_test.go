@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAuthor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Author
+		out  string
+	}{
+		{"Jane Doe <jane@example.com>", Author{Name: "Jane Doe", Email: "jane@example.com"}, "Jane Doe <jane@example.com>"},
+		{"Jane Doe", Author{Name: "Jane Doe"}, "Jane Doe"},
+		{"jane@example.com", Author{Email: "jane@example.com"}, "jane@example.com"},
+		{"<jane@example.com>", Author{Email: "jane@example.com"}, "jane@example.com"},
+		{"  The Linux Foundation  ", Author{Name: "The Linux Foundation"}, "The Linux Foundation"},
+	}
+	for _, tt := range tests {
+		got := ParseAuthor(tt.in)
+		if got != tt.want {
+			t.Errorf("ParseAuthor(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		if s := got.String(); s != tt.out {
+			t.Errorf("ParseAuthor(%q).String() = %q, want %q", tt.in, s, tt.out)
+		}
+	}
+}
+
+func TestParseAuthorsSkipsEmpty(t *testing.T) {
+	got := ParseAuthors([]string{"Jane Doe <jane@example.com>", " ", "John"})
+	want := []Author{{Name: "Jane Doe", Email: "jane@example.com"}, {Name: "John"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAuthors() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package cobol
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// Extractor extracts metadata from COBOL projects
+type Extractor struct {
+	extractor.BaseExtractor
+}
+
+// NewExtractor creates a new COBOL extractor
+func NewExtractor() *Extractor {
+	return &Extractor{
+		BaseExtractor: extractor.NewBaseExtractor("cobol", 1),
+	}
+}
+
+func init() {
+	extractor.RegisterExtractor(NewExtractor())
+}
+
+// projectJSONFile is the optional project descriptor some COBOL build
+// tools write; only its identity fields are read
+const projectJSONFile = "project.json"
+
+var (
+	// programExtensions are the COBOL program source suffixes
+	programExtensions = map[string]bool{".cbl": true, ".cob": true, ".cobol": true}
+	// copybookExtensions are the COBOL copybook suffixes
+	copybookExtensions = map[string]bool{".cpy": true}
+)
+
+// ProjectJSON holds the project.json fields used for metadata
+type ProjectJSON struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+// Detect checks if this is a COBOL project: any program or copybook
+// below the project path
+func (e *Extractor) Detect(projectPath string) bool {
+	programs, copybooks := countSources(projectPath)
+	return programs+copybooks > 0
+}
+
+// Extract retrieves metadata from a COBOL project. COBOL has no standard
+// manifest, so the project is named after its directory unless a
+// project.json names it.
+func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, error) {
+	programs, copybooks := countSources(projectPath)
+	if programs+copybooks == 0 {
+		return nil, extractor.WithCode(extractor.ErrNoManifest,
+			fmt.Errorf("no COBOL sources (*.cbl, *.cob, *.cpy) found in %s", projectPath))
+	}
+
+	metadata := &extractor.ProjectMetadata{
+		LanguageSpecific: make(map[string]interface{}),
+	}
+	ls := metadata.LanguageSpecific
+
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		absPath = projectPath
+	}
+	metadata.Name = filepath.Base(absPath)
+	ls["metadata_source"] = "directory"
+
+	if project, ok := readProjectJSON(projectPath); ok {
+		metadata.AddConsumedFile(filepath.Join(projectPath, projectJSONFile))
+		if project.Name != "" {
+			metadata.Name = project.Name
+		}
+		metadata.Description = project.Description
+		if project.Version != "" {
+			metadata.Version = project.Version
+			metadata.VersionSource = projectJSONFile
+		}
+		ls["metadata_source"] = projectJSONFile
+	}
+
+	ls["cobol_program_count"] = programs
+	ls["cobol_copybook_count"] = copybooks
+
+	return metadata, nil
+}
+
+// countSources walks projectPath, skipping hidden directories, and
+// counts COBOL programs and copybooks by suffix (case-insensitively,
+// since mainframe exports are often upper case).
+func countSources(projectPath string) (programs, copybooks int) {
+	_ = filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != projectPath && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(d.Name()))
+		switch {
+		case programExtensions[ext]:
+			programs++
+		case copybookExtensions[ext]:
+			copybooks++
+		}
+		return nil
+	})
+	return programs, copybooks
+}
+
+// readProjectJSON reads the optional project.json. A missing or
+// unparsable file is ignored: the name is shared with unrelated tools.
+func readProjectJSON(projectPath string) (ProjectJSON, bool) {
+	var project ProjectJSON
+	content, err := os.ReadFile(filepath.Join(projectPath, projectJSONFile))
+	if err != nil {
+		return project, false
+	}
+	if err := json.Unmarshal(content, &project); err != nil {
+		return project, false
+	}
+	return project, true
+}
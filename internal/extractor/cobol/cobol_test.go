@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package cobol
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCOBOLProject creates a directory named payroll holding files
+// (relative path to content).
+func writeCOBOLProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "payroll")
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+var sampleSources = map[string]string{
+	"PAYROLL.cbl":           "       IDENTIFICATION DIVISION.\n       PROGRAM-ID. PAYROLL.\n",
+	"src/TAXCALC.CBL":       "       IDENTIFICATION DIVISION.\n       PROGRAM-ID. TAXCALC.\n",
+	"copybook/EMPREC.cpy":   "       01  EMPLOYEE-RECORD.\n",
+	"copybook/TAXREC.cpy":   "       01  TAX-RECORD.\n",
+	".git/hooks/SAMPLE.cbl": "ignored",
+	"README.md":             "Payroll batch jobs\n",
+}
+
+func TestNewExtractor(t *testing.T) {
+	e := NewExtractor()
+	assert.Equal(t, "cobol", e.Name())
+	assert.Equal(t, 1, e.Priority())
+}
+
+func TestDetect(t *testing.T) {
+	e := NewExtractor()
+	assert.True(t, e.Detect(writeCOBOLProject(t, sampleSources)))
+	assert.False(t, e.Detect(writeCOBOLProject(t, map[string]string{"README.md": "no sources"})))
+}
+
+func TestExtract(t *testing.T) {
+	metadata, err := NewExtractor().Extract(writeCOBOLProject(t, sampleSources))
+	require.NoError(t, err)
+
+	assert.Equal(t, "payroll", metadata.Name)
+	assert.Empty(t, metadata.Version)
+	assert.Equal(t, "directory", metadata.LanguageSpecific["metadata_source"])
+	assert.Equal(t, 2, metadata.LanguageSpecific["cobol_program_count"])
+	assert.Equal(t, 2, metadata.LanguageSpecific["cobol_copybook_count"])
+}
+
+func TestExtractProjectJSON(t *testing.T) {
+	files := map[string]string{
+		"project.json": `{"name": "payroll-batch", "version": "3.1.0", "description": "Nightly payroll"}`,
+	}
+	for name, content := range sampleSources {
+		files[name] = content
+	}
+
+	metadata, err := NewExtractor().Extract(writeCOBOLProject(t, files))
+	require.NoError(t, err)
+
+	assert.Equal(t, "payroll-batch", metadata.Name)
+	assert.Equal(t, "3.1.0", metadata.Version)
+	assert.Equal(t, "project.json", metadata.VersionSource)
+	assert.Equal(t, "Nightly payroll", metadata.Description)
+	assert.Equal(t, "project.json", metadata.LanguageSpecific["metadata_source"])
+}
+
+func TestExtractNoSources(t *testing.T) {
+	_, err := NewExtractor().Extract(writeCOBOLProject(t, map[string]string{"project.json": `{"name": "x"}`}))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, extractor.ErrNoManifest))
+}
@@ -0,0 +1,313 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package cocoapods
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// Extractor extracts metadata from Objective-C/Swift projects managed
+// with CocoaPods
+type Extractor struct {
+	extractor.BaseExtractor
+}
+
+// NewExtractor creates a new CocoaPods extractor
+func NewExtractor() *Extractor {
+	return &Extractor{
+		BaseExtractor: extractor.NewBaseExtractor("cocoapods", 1),
+	}
+}
+
+func init() {
+	extractor.RegisterExtractor(NewExtractor())
+}
+
+// PodspecMetadata represents parsed .podspec metadata
+type PodspecMetadata struct {
+	Name         string
+	Version      string
+	License      string
+	Summary      string
+	Homepage     string
+	Dependencies []Dependency
+	Platforms    []Platform
+}
+
+// PodfileMetadata represents parsed Podfile metadata
+type PodfileMetadata struct {
+	Dependencies []Dependency
+	Platforms    []Platform
+	Targets      []string
+}
+
+// Dependency represents a pod dependency and its optional requirement
+type Dependency struct {
+	Name        string
+	Requirement string
+}
+
+// Platform represents a deployment platform and its minimum version
+type Platform struct {
+	Name    string
+	Version string
+}
+
+// podspecStringField pairs a pattern with the field it populates for the
+// single-value string attributes of a podspec.
+type podspecStringField struct {
+	re     *regexp.Regexp
+	assign func(spec *PodspecMetadata, value string)
+}
+
+// podspecRegexes holds every compiled pattern used to parse a podspec
+// line, following the gemspec parser in the ruby extractor.
+type podspecRegexes struct {
+	stringFields     []podspecStringField
+	dependency       *regexp.Regexp
+	platform         *regexp.Regexp
+	deploymentTarget *regexp.Regexp
+}
+
+func newPodspecRegexes() *podspecRegexes {
+	return &podspecRegexes{
+		stringFields: []podspecStringField{
+			{regexp.MustCompile(`(?:spec|s)\.name\s*=\s*["']([^"']+)["']`), func(s *PodspecMetadata, v string) { s.Name = v }},
+			{regexp.MustCompile(`(?:spec|s)\.version\s*=\s*["']([^"']+)["']`), func(s *PodspecMetadata, v string) { s.Version = v }},
+			{regexp.MustCompile(`(?:spec|s)\.summary\s*=\s*["']([^"']+)["']`), func(s *PodspecMetadata, v string) { s.Summary = v }},
+			{regexp.MustCompile(`(?:spec|s)\.homepage\s*=\s*["']([^"']+)["']`), func(s *PodspecMetadata, v string) { s.Homepage = v }},
+			// Both `s.license = 'MIT'` and the hash form
+			// `s.license = { :type => 'MIT', :file => 'LICENSE' }`
+			{regexp.MustCompile(`(?:spec|s)\.license\s*=\s*["']([^"']+)["']`), func(s *PodspecMetadata, v string) { s.License = v }},
+			{regexp.MustCompile(`(?:spec|s)\.license\s*=.*?(?::type\s*=>|type:)\s*["']([^"']+)["']`), func(s *PodspecMetadata, v string) { s.License = v }},
+		},
+		dependency:       regexp.MustCompile(`(?:spec|s)\.dependency\s+["']([^"']+)["'](?:\s*,\s*["']([^"']+)["'])?`),
+		platform:         regexp.MustCompile(`(?:spec|s)\.platform\s*=\s*:([a-z]+)(?:\s*,\s*["']([^"']+)["'])?`),
+		deploymentTarget: regexp.MustCompile(`(?:spec|s)\.([a-z]+)\.deployment_target\s*=\s*["']([^"']+)["']`),
+	}
+}
+
+var (
+	podRe      = regexp.MustCompile(`^pod\s+["']([^"']+)["'](?:\s*,\s*["']([^"']+)["'])?`)
+	platformRe = regexp.MustCompile(`^platform\s+:([a-z]+)(?:\s*,\s*["']([^"']+)["'])?`)
+	targetRe   = regexp.MustCompile(`^target\s+["']([^"']+)["']`)
+)
+
+// Detect checks if this is a CocoaPods project
+func (e *Extractor) Detect(projectPath string) bool {
+	if _, err := os.Stat(filepath.Join(projectPath, "Podfile")); err == nil {
+		return true
+	}
+	matches, err := filepath.Glob(filepath.Join(projectPath, "*.podspec"))
+	return err == nil && len(matches) > 0
+}
+
+// Extract retrieves metadata from a CocoaPods project. A .podspec (a
+// published pod) supplies the package identity; a Podfile (an app
+// consuming pods) contributes its pods and platform.
+func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, error) {
+	metadata := &extractor.ProjectMetadata{
+		LanguageSpecific: make(map[string]interface{}),
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(projectPath, "*.podspec"))
+	podfilePath := filepath.Join(projectPath, "Podfile")
+	_, podfileErr := os.Stat(podfilePath)
+
+	if len(matches) == 0 && podfileErr != nil {
+		return nil, fmt.Errorf("no Podfile or .podspec found in %s", projectPath)
+	}
+
+	if len(matches) > 0 {
+		if err := extractFromPodspec(matches[0], metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if podfileErr == nil {
+		if err := extractFromPodfile(podfilePath, metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(projectPath, "Podfile.lock")); err == nil {
+		metadata.LanguageSpecific["has_podfile_lock"] = true
+	}
+
+	metadata.LanguageSpecific["build_tool"] = "CocoaPods"
+
+	return metadata, nil
+}
+
+// extractFromPodspec parses a .podspec file
+func extractFromPodspec(podspecPath string, metadata *extractor.ProjectMetadata) error {
+	file, err := os.Open(podspecPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	spec, err := parsePodspec(file)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", filepath.Base(podspecPath), err)
+	}
+	metadata.AddConsumedFile(podspecPath)
+
+	metadata.Name = spec.Name
+	metadata.License = spec.License
+	metadata.Homepage = spec.Homepage
+	metadata.Description = spec.Summary
+	if spec.Version != "" {
+		metadata.Version = spec.Version
+		metadata.VersionSource = filepath.Base(podspecPath)
+	}
+
+	metadata.LanguageSpecific["podspec_file"] = filepath.Base(podspecPath)
+	if spec.Summary != "" {
+		metadata.LanguageSpecific["summary"] = spec.Summary
+	}
+	applyDependencies("dependencies", spec.Dependencies, metadata)
+	applyPlatforms(spec.Platforms, metadata)
+	return nil
+}
+
+// apply matches a single podspec line against every pattern and updates spec.
+func (r *podspecRegexes) apply(line string, spec *PodspecMetadata) {
+	for _, field := range r.stringFields {
+		if matches := field.re.FindStringSubmatch(line); len(matches) > 1 {
+			field.assign(spec, matches[1])
+		}
+	}
+
+	if matches := r.dependency.FindStringSubmatch(line); len(matches) > 1 {
+		spec.Dependencies = append(spec.Dependencies, Dependency{Name: matches[1], Requirement: matches[2]})
+	}
+
+	if matches := r.platform.FindStringSubmatch(line); len(matches) > 1 {
+		spec.Platforms = append(spec.Platforms, Platform{Name: matches[1], Version: matches[2]})
+	}
+
+	if matches := r.deploymentTarget.FindStringSubmatch(line); len(matches) > 2 {
+		spec.Platforms = append(spec.Platforms, Platform{Name: matches[1], Version: matches[2]})
+	}
+}
+
+func parsePodspec(r io.Reader) (PodspecMetadata, error) {
+	var spec PodspecMetadata
+	regexes := newPodspecRegexes()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") || line == "" {
+			continue
+		}
+		regexes.apply(line, &spec)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return spec, err
+	}
+	return spec, nil
+}
+
+// extractFromPodfile parses a Podfile
+func extractFromPodfile(podfilePath string, metadata *extractor.ProjectMetadata) error {
+	file, err := os.Open(podfilePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	podfile, err := parsePodfile(file)
+	if err != nil {
+		return fmt.Errorf("failed to parse Podfile: %w", err)
+	}
+	metadata.AddConsumedFile(podfilePath)
+
+	metadata.LanguageSpecific["has_podfile"] = true
+	applyDependencies("pods", podfile.Dependencies, metadata)
+	if len(podfile.Targets) > 0 {
+		metadata.LanguageSpecific["targets"] = podfile.Targets
+	}
+	// A podspec's platform describes the published pod and wins over
+	// the Podfile's, which only describes the example/test app
+	if _, exists := metadata.LanguageSpecific["platform"]; !exists {
+		applyPlatforms(podfile.Platforms, metadata)
+	}
+	return nil
+}
+
+func parsePodfile(r io.Reader) (PodfileMetadata, error) {
+	var podfile PodfileMetadata
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") || line == "" {
+			continue
+		}
+
+		if matches := podRe.FindStringSubmatch(line); len(matches) > 1 {
+			podfile.Dependencies = append(podfile.Dependencies, Dependency{Name: matches[1], Requirement: matches[2]})
+		}
+
+		if matches := platformRe.FindStringSubmatch(line); len(matches) > 1 {
+			podfile.Platforms = append(podfile.Platforms, Platform{Name: matches[1], Version: matches[2]})
+		}
+
+		if matches := targetRe.FindStringSubmatch(line); len(matches) > 1 {
+			podfile.Targets = append(podfile.Targets, matches[1])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return podfile, err
+	}
+	return podfile, nil
+}
+
+// applyDependencies records dependencies under key as a name ->
+// requirement map ("" when unconstrained) together with a count
+func applyDependencies(key string, deps []Dependency, metadata *extractor.ProjectMetadata) {
+	if len(deps) == 0 {
+		return
+	}
+
+	byName := make(map[string]string, len(deps))
+	for _, dep := range deps {
+		byName[dep.Name] = dep.Requirement
+	}
+	metadata.LanguageSpecific[key] = byName
+	metadata.LanguageSpecific[key+"_count"] = len(byName)
+}
+
+// applyPlatforms records the first declared platform as the primary
+// one and every platform's minimum version under "platforms"
+func applyPlatforms(platforms []Platform, metadata *extractor.ProjectMetadata) {
+	if len(platforms) == 0 {
+		return
+	}
+
+	metadata.LanguageSpecific["platform"] = platforms[0].Name
+	if platforms[0].Version != "" {
+		metadata.LanguageSpecific["platform_version"] = platforms[0].Version
+	}
+
+	versions := make(map[string]string, len(platforms))
+	for _, platform := range platforms {
+		if _, seen := versions[platform.Name]; !seen {
+			versions[platform.Name] = platform.Version
+		}
+	}
+	metadata.LanguageSpecific["platforms"] = versions
+}
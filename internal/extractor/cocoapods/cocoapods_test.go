@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package cocoapods
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+	}
+}
+
+func TestNewExtractor(t *testing.T) {
+	e := NewExtractor()
+	assert.NotNil(t, e)
+	assert.Equal(t, "cocoapods", e.Name())
+	assert.Equal(t, 1, e.Priority())
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected bool
+	}{
+		{name: "Podfile", files: map[string]string{"Podfile": "platform :ios, '13.0'"}, expected: true},
+		{name: "podspec", files: map[string]string{"MyPod.podspec": "Pod::Spec.new do |s|\nend"}, expected: true},
+		{name: "no CocoaPods files", files: map[string]string{"Gemfile": "gem 'rails'"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, tt.files)
+			assert.Equal(t, tt.expected, NewExtractor().Detect(tmpDir))
+		})
+	}
+}
+
+func TestExtractPodspec(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"NetKit.podspec": `Pod::Spec.new do |s|
+  s.name         = 'NetKit'
+  s.version      = '2.4.1'
+  s.summary      = 'Networking helpers for iOS and macOS'
+  s.homepage     = 'https://example.com/netkit'
+  s.license      = { :type => 'MIT', :file => 'LICENSE' }
+  s.ios.deployment_target = '12.0'
+  s.osx.deployment_target = '10.15'
+
+  s.dependency 'Alamofire', '~> 5.8'
+  s.dependency 'SwiftyJSON'
+end
+`,
+	})
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "NetKit", metadata.Name)
+	assert.Equal(t, "2.4.1", metadata.Version)
+	assert.Equal(t, "NetKit.podspec", metadata.VersionSource)
+	assert.Equal(t, "MIT", metadata.License)
+	assert.Equal(t, "https://example.com/netkit", metadata.Homepage)
+	assert.Equal(t, "Networking helpers for iOS and macOS", metadata.LanguageSpecific["summary"])
+	assert.Equal(t, map[string]string{"Alamofire": "~> 5.8", "SwiftyJSON": ""}, metadata.LanguageSpecific["dependencies"])
+	assert.Equal(t, 2, metadata.LanguageSpecific["dependencies_count"])
+	assert.Equal(t, "ios", metadata.LanguageSpecific["platform"])
+	assert.Equal(t, "12.0", metadata.LanguageSpecific["platform_version"])
+	assert.Equal(t, map[string]string{"ios": "12.0", "osx": "10.15"}, metadata.LanguageSpecific["platforms"])
+}
+
+func TestExtractPodspecStringLicense(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"Tiny.podspec": `Pod::Spec.new do |spec|
+  spec.name     = "Tiny"
+  spec.version  = "0.1.0"
+  spec.license  = "Apache-2.0"
+  spec.platform = :ios, "14.0"
+end
+`,
+	})
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Apache-2.0", metadata.License)
+	assert.Equal(t, "ios", metadata.LanguageSpecific["platform"])
+	assert.Equal(t, "14.0", metadata.LanguageSpecific["platform_version"])
+}
+
+func TestExtractPodfile(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"Podfile": `# Uncomment the next line to define a global platform
+platform :ios, '15.0'
+
+target 'MyApp' do
+  use_frameworks!
+  pod 'Alamofire', '~> 5.8'
+  pod 'Kingfisher'
+
+  target 'MyAppTests' do
+    inherit! :search_paths
+    pod 'Quick', '~> 7.0'
+  end
+end
+`,
+		"Podfile.lock": "PODS:\n  - Alamofire (5.8.1)\n",
+	})
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Empty(t, metadata.Name)
+	assert.Equal(t, true, metadata.LanguageSpecific["has_podfile"])
+	assert.Equal(t, true, metadata.LanguageSpecific["has_podfile_lock"])
+	assert.Equal(t, map[string]string{"Alamofire": "~> 5.8", "Kingfisher": "", "Quick": "~> 7.0"}, metadata.LanguageSpecific["pods"])
+	assert.Equal(t, 3, metadata.LanguageSpecific["pods_count"])
+	assert.Equal(t, []string{"MyApp", "MyAppTests"}, metadata.LanguageSpecific["targets"])
+	assert.Equal(t, "ios", metadata.LanguageSpecific["platform"])
+	assert.Equal(t, "15.0", metadata.LanguageSpecific["platform_version"])
+	assert.Equal(t, "CocoaPods", metadata.LanguageSpecific["build_tool"])
+}
+
+func TestExtractNoCocoaPodsFiles(t *testing.T) {
+	_, err := NewExtractor().Extract(t.TempDir())
+	assert.Error(t, err)
+}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+// AddConsumedFile records that the extraction read path, so
+// manifest_hashes can checksum exactly the files that fed the metadata.
+// Each path is recorded once, in the order first read.
+func (m *ProjectMetadata) AddConsumedFile(path string) {
+	for _, existing := range m.ConsumedFiles {
+		if existing == path {
+			return
+		}
+	}
+	m.ConsumedFiles = append(m.ConsumedFiles, path)
+}
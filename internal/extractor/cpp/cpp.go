@@ -100,6 +100,7 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 	if _, err := os.Stat(cmakePath); err == nil {
 		if err := e.extractFromCMake(cmakePath, metadata); err == nil {
 			metadata.LanguageSpecific["build_system"] = "CMake"
+			metadata.AddConsumedFile(cmakePath)
 			return metadata, nil
 		}
 	}
@@ -109,6 +110,7 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 	if _, err := os.Stat(qmakePath); err == nil {
 		if err := e.extractFromQmake(qmakePath, metadata); err == nil {
 			metadata.LanguageSpecific["build_system"] = "qmake"
+			metadata.AddConsumedFile(qmakePath)
 			return metadata, nil
 		}
 	}
@@ -118,6 +120,7 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 	if _, err := os.Stat(mesonPath); err == nil {
 		if err := e.extractFromMeson(mesonPath, metadata); err == nil {
 			metadata.LanguageSpecific["build_system"] = "Meson"
+			metadata.AddConsumedFile(mesonPath)
 			return metadata, nil
 		}
 	}
@@ -127,6 +130,7 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 	if _, err := os.Stat(configurePath); err == nil {
 		if err := e.extractFromAutotools(configurePath, metadata); err == nil {
 			metadata.LanguageSpecific["build_system"] = "Autotools"
+			metadata.AddConsumedFile(configurePath)
 			return metadata, nil
 		}
 	}
@@ -125,8 +125,9 @@ func (e *Extractor) extractFromPubspec(path string, metadata *extractor.ProjectM
 
 	var pubspec PubspecYAML
 	if err := yaml.Unmarshal(content, &pubspec); err != nil {
-		return fmt.Errorf("failed to parse pubspec.yaml: %w", err)
+		return fmt.Errorf("failed to parse pubspec.yaml: %w", extractor.NewManifestError(path, content, err))
 	}
+	metadata.AddConsumedFile(path)
 
 	metadata.Name = pubspec.Name
 	metadata.Version = pubspec.Version
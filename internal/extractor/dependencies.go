@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+// DependencyCounts holds the standardized dependency counts every
+// extractor that reports dependencies publishes, so consumers can compare
+// projects across languages regardless of what each ecosystem's own
+// "dependency_count" happens to include. Only directly declared
+// dependencies are counted; transitive dependencies are never resolved.
+type DependencyCounts struct {
+	// Runtime counts dependencies required to use the built artifact
+	// (e.g. npm "dependencies", Maven compile/runtime scope)
+	Runtime int
+	// Dev counts dependencies only needed to develop or test the project
+	// (e.g. npm "devDependencies", Maven test scope)
+	Dev int
+	// Other counts declared dependencies that are neither runtime nor
+	// dev, such as build, peer, or optional dependencies. They are only
+	// reflected in the total.
+	Other int
+}
+
+// Total returns every declared dependency: runtime + dev + other
+func (c DependencyCounts) Total() int {
+	return c.Runtime + c.Dev + c.Other
+}
+
+// SetDependencyCounts records runtime_dependency_count,
+// dev_dependency_count, and total_dependency_count in LanguageSpecific.
+// All three keys are always written, using 0 when a category is empty.
+func SetDependencyCounts(metadata *ProjectMetadata, counts DependencyCounts) {
+	if metadata.LanguageSpecific == nil {
+		metadata.LanguageSpecific = make(map[string]interface{})
+	}
+	metadata.LanguageSpecific["runtime_dependency_count"] = counts.Runtime
+	metadata.LanguageSpecific["dev_dependency_count"] = counts.Dev
+	metadata.LanguageSpecific["total_dependency_count"] = counts.Total()
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package dlang
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// Extractor extracts metadata from D projects built with dub
+type Extractor struct {
+	extractor.BaseExtractor
+}
+
+// NewExtractor creates a new D extractor
+func NewExtractor() *Extractor {
+	return &Extractor{
+		BaseExtractor: extractor.NewBaseExtractor("d", 1),
+	}
+}
+
+func init() {
+	extractor.RegisterExtractor(NewExtractor())
+}
+
+// DubJSON represents the subset of a dub.json recipe used for metadata.
+// Dependencies map a package name to either a version string or an
+// object carrying "version" and/or "path".
+type DubJSON struct {
+	Name         string                     `json:"name"`
+	Version      string                     `json:"version"`
+	Description  string                     `json:"description"`
+	License      string                     `json:"license"`
+	Homepage     string                     `json:"homepage"`
+	Authors      []string                   `json:"authors"`
+	Dependencies map[string]json.RawMessage `json:"dependencies"`
+}
+
+// dubRecipe is the format-neutral view of a dub package recipe, with
+// each dependency reduced to its version constraint
+type dubRecipe struct {
+	Name         string
+	Version      string
+	Description  string
+	License      string
+	Homepage     string
+	Authors      []string
+	Dependencies map[string]string
+}
+
+var (
+	// sdlValuePattern matches a single-value directive such as
+	// `name "vibe-app"`
+	sdlValuePattern = regexp.MustCompile(`^(name|version|description|license|homepage)\s+"([^"]*)"`)
+	// sdlStringPattern extracts each quoted string on an authors line
+	sdlStringPattern = regexp.MustCompile(`"([^"]*)"`)
+	// sdlDependencyPattern matches `dependency "x" version="..."`; the
+	// attributes are optional since path-only dependencies omit version
+	sdlDependencyPattern = regexp.MustCompile(`^dependency\s+"([^"]+)"(.*)$`)
+	sdlVersionAttr       = regexp.MustCompile(`\bversion\s*=\s*"([^"]*)"`)
+	sdlPathAttr          = regexp.MustCompile(`\bpath\s*=\s*"([^"]*)"`)
+)
+
+// Detect checks if this is a dub project
+func (e *Extractor) Detect(projectPath string) bool {
+	for _, name := range []string{"dub.json", "dub.sdl"} {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Extract retrieves metadata from a D project, preferring dub.json
+// when both recipe formats are present (matching dub itself)
+func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, error) {
+	metadata := &extractor.ProjectMetadata{
+		LanguageSpecific: make(map[string]interface{}),
+	}
+
+	jsonPath := filepath.Join(projectPath, "dub.json")
+	sdlPath := filepath.Join(projectPath, "dub.sdl")
+
+	if _, err := os.Stat(jsonPath); err == nil {
+		if err := extractFromDubJSON(jsonPath, metadata); err != nil {
+			return nil, err
+		}
+	} else if _, err := os.Stat(sdlPath); err == nil {
+		if err := extractFromDubSDL(sdlPath, metadata); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("no dub.json or dub.sdl found in %s", projectPath)
+	}
+
+	metadata.LanguageSpecific["build_tool"] = "dub"
+
+	return metadata, nil
+}
+
+// extractFromDubJSON parses a dub.json recipe
+func extractFromDubJSON(path string, metadata *extractor.ProjectMetadata) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var dub DubJSON
+	if err := json.Unmarshal(content, &dub); err != nil {
+		return fmt.Errorf("failed to parse dub.json: %w", extractor.NewManifestError(path, content, err))
+	}
+	metadata.AddConsumedFile(path)
+
+	recipe := dubRecipe{
+		Name:         dub.Name,
+		Version:      dub.Version,
+		Description:  dub.Description,
+		License:      dub.License,
+		Homepage:     dub.Homepage,
+		Authors:      dub.Authors,
+		Dependencies: make(map[string]string),
+	}
+	for name, raw := range dub.Dependencies {
+		recipe.Dependencies[name] = dubJSONDependencyVersion(raw)
+	}
+
+	applyDubMetadata(metadata, "dub.json", recipe)
+	return nil
+}
+
+// dubJSONDependencyVersion returns the version constraint of a dub.json
+// dependency, falling back to "path:<dir>" for local path dependencies
+func dubJSONDependencyVersion(raw json.RawMessage) string {
+	var version string
+	if err := json.Unmarshal(raw, &version); err == nil {
+		return version
+	}
+
+	var spec struct {
+		Version string `json:"version"`
+		Path    string `json:"path"`
+	}
+	if err := json.Unmarshal(raw, &spec); err == nil {
+		if spec.Version != "" {
+			return spec.Version
+		}
+		if spec.Path != "" {
+			return "path:" + spec.Path
+		}
+	}
+	return ""
+}
+
+// extractFromDubSDL parses a dub.sdl recipe with tolerant line matching.
+// Only top-level directives are read; nested configuration and
+// subPackage blocks are skipped so their names and dependencies do not
+// leak into the root package.
+func extractFromDubSDL(path string, metadata *extractor.ProjectMetadata) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	metadata.AddConsumedFile(path)
+
+	values := make(map[string]string)
+	recipe := dubRecipe{Dependencies: make(map[string]string)}
+	depth := 0
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if depth == 0 {
+			if matches := sdlValuePattern.FindStringSubmatch(line); matches != nil {
+				if _, seen := values[matches[1]]; !seen {
+					values[matches[1]] = matches[2]
+				}
+			} else if strings.HasPrefix(line, "authors ") {
+				for _, match := range sdlStringPattern.FindAllStringSubmatch(line, -1) {
+					recipe.Authors = append(recipe.Authors, match[1])
+				}
+			} else if matches := sdlDependencyPattern.FindStringSubmatch(line); matches != nil {
+				recipe.Dependencies[matches[1]] = sdlDependencyVersion(matches[2])
+			}
+		}
+
+		depth += strings.Count(line, "\u007b") - strings.Count(line, "\u007d")
+		if depth < 0 {
+			depth = 0
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	recipe.Name = values["name"]
+	recipe.Version = values["version"]
+	recipe.Description = values["description"]
+	recipe.License = values["license"]
+	recipe.Homepage = values["homepage"]
+
+	applyDubMetadata(metadata, "dub.sdl", recipe)
+	return nil
+}
+
+// sdlDependencyVersion reads the version attribute of a dub.sdl
+// dependency line, falling back to "path:<dir>" like dub.json
+func sdlDependencyVersion(attrs string) string {
+	if matches := sdlVersionAttr.FindStringSubmatch(attrs); matches != nil {
+		return matches[1]
+	}
+	if matches := sdlPathAttr.FindStringSubmatch(attrs); matches != nil {
+		return "path:" + matches[1]
+	}
+	return ""
+}
+
+// applyDubMetadata maps the fields shared by both recipe formats
+func applyDubMetadata(metadata *extractor.ProjectMetadata, source string, recipe dubRecipe) {
+	metadata.Name = recipe.Name
+	metadata.Description = recipe.Description
+	metadata.License = recipe.License
+	metadata.Homepage = recipe.Homepage
+	if len(recipe.Authors) > 0 {
+		metadata.Authors = recipe.Authors
+	}
+
+	if recipe.Version != "" {
+		metadata.Version = recipe.Version
+		metadata.VersionSource = source
+	}
+
+	metadata.LanguageSpecific["package_name"] = recipe.Name
+	metadata.LanguageSpecific["metadata_source"] = source
+
+	if len(recipe.Dependencies) > 0 {
+		metadata.LanguageSpecific["dependencies"] = recipe.Dependencies
+		metadata.LanguageSpecific["dependency_count"] = len(recipe.Dependencies)
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package dlang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0644))
+	}
+}
+
+func TestNewExtractor(t *testing.T) {
+	e := NewExtractor()
+	assert.NotNil(t, e)
+	assert.Equal(t, "d", e.Name())
+	assert.Equal(t, 1, e.Priority())
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected bool
+	}{
+		{
+			name:     "dub.json present",
+			files:    map[string]string{"dub.json": `{"name": "app"}`},
+			expected: true,
+		},
+		{
+			name:     "dub.sdl present",
+			files:    map[string]string{"dub.sdl": `name "app"`},
+			expected: true,
+		},
+		{
+			name:     "no dub recipe",
+			files:    map[string]string{"README.md": "# app"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeFiles(t, tmpDir, tt.files)
+
+			e := NewExtractor()
+			assert.Equal(t, tt.expected, e.Detect(tmpDir))
+		})
+	}
+}
+
+func TestExtractDubJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"dub.json": `{
+	"name": "vibe-app",
+	"version": "1.4.0",
+	"description": "A web service",
+	"license": "BSL-1.0",
+	"authors": ["Jane Doe", "John Roe"],
+	"dependencies": {
+		"vibe-d": "~>0.9.7",
+		"mir-algorithm": {"version": ">=3.20.0"},
+		"local-lib": {"path": "../local-lib"}
+	}
+}`,
+	})
+
+	e := NewExtractor()
+	metadata, err := e.Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "vibe-app", metadata.Name)
+	assert.Equal(t, "1.4.0", metadata.Version)
+	assert.Equal(t, "dub.json", metadata.VersionSource)
+	assert.Equal(t, "A web service", metadata.Description)
+	assert.Equal(t, "BSL-1.0", metadata.License)
+	assert.Equal(t, []string{"Jane Doe", "John Roe"}, metadata.Authors)
+	assert.Equal(t, "dub", metadata.LanguageSpecific["build_tool"])
+	assert.Equal(t, map[string]string{
+		"vibe-d":        "~>0.9.7",
+		"mir-algorithm": ">=3.20.0",
+		"local-lib":     "path:../local-lib",
+	}, metadata.LanguageSpecific["dependencies"])
+	assert.Equal(t, 3, metadata.LanguageSpecific["dependency_count"])
+}
+
+func TestExtractDubSDL(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"dub.sdl": `// Root package recipe
+name "sdl-app"
+version "0.2.1"
+description "Command line tool"
+authors "Jane Doe" "John Roe"
+license "MIT"
+dependency "vibe-d" version="~>0.9.7"
+dependency "local-lib" path="../local-lib"
+
+configuration "unittest" {
+	dependency "silly" version="~>1.1.1"
+}
+
+subPackage {
+	name "helper"
+}
+`,
+	})
+
+	e := NewExtractor()
+	metadata, err := e.Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "sdl-app", metadata.Name)
+	assert.Equal(t, "0.2.1", metadata.Version)
+	assert.Equal(t, "dub.sdl", metadata.VersionSource)
+	assert.Equal(t, "Command line tool", metadata.Description)
+	assert.Equal(t, "MIT", metadata.License)
+	assert.Equal(t, []string{"Jane Doe", "John Roe"}, metadata.Authors)
+	assert.Equal(t, map[string]string{
+		"vibe-d":    "~>0.9.7",
+		"local-lib": "path:../local-lib",
+	}, metadata.LanguageSpecific["dependencies"])
+}
+
+func TestExtractPrefersDubJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFiles(t, tmpDir, map[string]string{
+		"dub.json": `{"name": "from-json"}`,
+		"dub.sdl":  `name "from-sdl"`,
+	})
+
+	e := NewExtractor()
+	metadata, err := e.Extract(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, "from-json", metadata.Name)
+	assert.Empty(t, metadata.Version)
+}
+
+func TestExtractNoRecipe(t *testing.T) {
+	e := NewExtractor()
+	_, err := e.Extract(t.TempDir())
+	assert.Error(t, err)
+}
@@ -9,8 +9,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 )
 
@@ -41,6 +44,9 @@ type DockerfileMetadata struct {
 	HealthCheck  string
 	Stages       []string
 	CopyFrom     []string
+	// StageBaseImages maps each named stage, lower-cased, to the image
+	// its FROM names
+	StageBaseImages map[string]string
 }
 
 // Extract retrieves metadata from a Docker project
@@ -59,6 +65,7 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 	if err != nil {
 		return nil, err
 	}
+	metadata.AddConsumedFile(dockerfilePath)
 
 	e.populateMetadata(dockerMeta, metadata, projectPath)
 
@@ -84,6 +91,8 @@ func (e *Extractor) parseDockerfile(path string) (*DockerfileMetadata, error) {
 		Args:         make(map[string]string),
 		Stages:       make([]string, 0),
 		CopyFrom:     make([]string, 0),
+
+		StageBaseImages: make(map[string]string),
 	}
 
 	scanner := bufio.NewScanner(file)
@@ -192,12 +201,31 @@ func (e *Extractor) parseFrom(args string, meta *DockerfileMetadata) {
 		for i, part := range parts {
 			if strings.ToUpper(part) == "AS" && i+1 < len(parts) {
 				meta.Stages = append(meta.Stages, parts[i+1])
+				meta.StageBaseImages[strings.ToLower(parts[i+1])] = baseImage
 				break
 			}
 		}
 	}
 }
 
+// finalBaseImage returns the base image of the final stage, the one the
+// build ships: the last FROM, followed through any earlier stages it
+// builds on (FROM builder). It is "" without a FROM.
+func (meta *DockerfileMetadata) finalBaseImage() string {
+	if len(meta.BaseImages) == 0 {
+		return ""
+	}
+	image := meta.BaseImages[len(meta.BaseImages)-1]
+	for range meta.BaseImages {
+		parent, ok := meta.StageBaseImages[strings.ToLower(image)]
+		if !ok {
+			break
+		}
+		image = parent
+	}
+	return image
+}
+
 // parseLabel extracts label key-value pairs
 func (e *Extractor) parseLabel(args string, meta *DockerfileMetadata) {
 	// Handle multiple formats:
@@ -350,7 +378,7 @@ func applyDockerRuntimeMetadata(dockerMeta *DockerfileMetadata, metadata *extrac
 	ls["base_images"] = dockerMeta.BaseImages
 
 	if len(dockerMeta.BaseImages) > 0 {
-		ls["primary_base_image"] = dockerMeta.BaseImages[0]
+		ls["primary_base_image"] = dockerMeta.finalBaseImage()
 		ls["base_image_count"] = len(dockerMeta.BaseImages)
 	}
 	if len(dockerMeta.Labels) > 0 {
@@ -417,12 +445,99 @@ func applyDockerOCICompliance(dockerMeta *DockerfileMetadata, metadata *extracto
 	metadata.LanguageSpecific["oci_compliant"] = ociCompliant
 }
 
+// composeFileNames lists the Compose file names in the order docker
+// compose looks for them; only the first one present is read.
+var composeFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// applyDockerOCIAnnotations normalizes the OCI image labels of the
+// Dockerfile, and of the services in a Compose file next to it, into
+// "oci_annotations". Dockerfile labels take precedence.
+func applyDockerOCIAnnotations(dockerMeta *DockerfileMetadata, metadata *extractor.ProjectMetadata, projectPath string) {
+	annotations := make(map[string]string)
+	extractor.MergeOCIAnnotations(annotations, dockerMeta.Labels)
+
+	if name, compose, ok := readComposeFile(projectPath); ok {
+		metadata.AddConsumedFile(filepath.Join(projectPath, name))
+		extractor.MergeOCIAnnotations(annotations, extractor.FindOCIAnnotationLabels(compose))
+	}
+
+	extractor.SetOCIAnnotations(metadata, annotations)
+}
+
+// readComposeFile returns the name and decoded content of the first
+// Compose file present in projectPath.
+func readComposeFile(projectPath string) (string, interface{}, bool) {
+	for _, name := range composeFileNames {
+		content, err := os.ReadFile(filepath.Join(projectPath, name))
+		if err != nil {
+			continue
+		}
+		var compose interface{}
+		if err := yaml.Unmarshal(content, &compose); err != nil {
+			return "", nil, false
+		}
+		return name, compose, true
+	}
+	return "", nil, false
+}
+
+// applyDockerImageReference splits the project's image reference into
+// image_registry, image_repository and image_tag. The image a Compose
+// service builds is preferred, then any Compose service image, then the
+// Dockerfile's primary base image.
+func applyDockerImageReference(dockerMeta *DockerfileMetadata, metadata *extractor.ProjectMetadata, projectPath string) {
+	if name, compose, ok := readComposeFile(projectPath); ok {
+		if ref := composeServiceImage(compose); ref != "" {
+			if image, ok := extractor.ParseImageReference(ref); ok {
+				extractor.SetImageReference(metadata, image, name)
+				return
+			}
+		}
+	}
+	if len(dockerMeta.BaseImages) > 0 {
+		if image, ok := extractor.ParseImageReference(dockerMeta.finalBaseImage()); ok {
+			extractor.SetImageReference(metadata, image, "Dockerfile")
+		}
+	}
+}
+
+// composeServiceImage returns the image of the first service (by name)
+// that also has a build section, else of the first service with an
+// image, else "".
+func composeServiceImage(compose interface{}) string {
+	document, _ := compose.(map[string]interface{})
+	services, _ := document["services"].(map[string]interface{})
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fallback := ""
+	for _, name := range names {
+		service, _ := services[name].(map[string]interface{})
+		image, _ := service["image"].(string)
+		if image == "" {
+			continue
+		}
+		if _, builds := service["build"]; builds {
+			return image
+		}
+		if fallback == "" {
+			fallback = image
+		}
+	}
+	return fallback
+}
+
 // populateMetadata converts DockerfileMetadata to ProjectMetadata
 func (e *Extractor) populateMetadata(dockerMeta *DockerfileMetadata, metadata *extractor.ProjectMetadata, projectPath string) {
 	metadata.Name = filepath.Base(projectPath)
 	applyDockerLabelMetadata(dockerMeta, metadata)
 	applyDockerRuntimeMetadata(dockerMeta, metadata)
 	applyDockerOCICompliance(dockerMeta, metadata)
+	applyDockerOCIAnnotations(dockerMeta, metadata, projectPath)
+	applyDockerImageReference(dockerMeta, metadata, projectPath)
 }
 
 // Detect checks if this extractor can handle the project
@@ -10,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 )
 
 func TestExtractor_Name(t *testing.T) {
@@ -174,6 +176,36 @@ CMD ["node", "dist/index.js"]`
 	assert.Len(t, baseImageList, 2)
 }
 
+func TestExtractor_Extract_MultiStageFinalBaseImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerfile string
+		expected   string
+	}{
+		{
+			name:       "final stage image",
+			dockerfile: "FROM golang:1.24 AS build\nRUN go build -o /app .\n\nFROM gcr.io/distroless/static:nonroot\nCOPY --from=build /app /app\n",
+			expected:   "gcr.io/distroless/static:nonroot",
+		},
+		{
+			name:       "final stage built on an earlier stage",
+			dockerfile: "FROM python:3.12-slim AS base\nRUN pip install app\n\nFROM node:20 AS assets\nRUN npm run build\n\nFROM Base AS runtime\nCOPY --from=assets /dist /dist\n",
+			expected:   "python:3.12-slim",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(tt.dockerfile), 0644))
+
+			metadata, err := NewExtractor().Extract(dir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, metadata.LanguageSpecific["primary_base_image"])
+		})
+	}
+}
+
 func TestExtractor_Extract_Labels(t *testing.T) {
 	dir := t.TempDir()
 	dockerfilePath := filepath.Join(dir, "Dockerfile")
@@ -204,6 +236,69 @@ LABEL "key.with.dots"="value with spaces"`
 	assert.Equal(t, "custom-value", labelsMap["custom.label"])
 }
 
+func TestExtractor_Extract_OCIAnnotationsFromDockerfile(t *testing.T) {
+	dir := t.TempDir()
+	dockerfileContent := `FROM alpine:3.18
+LABEL org.opencontainers.image.title="api" \
+      org.opencontainers.image.version="1.4.0" \
+      org.opencontainers.image.source="https://github.com/example/api" \
+      org.opencontainers.image.revision="0123abc" \
+      org.opencontainers.image.vendor="Example"`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(dockerfileContent), 0644))
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"title":    "api",
+		"version":  "1.4.0",
+		"source":   "https://github.com/example/api",
+		"revision": "0123abc",
+	}, metadata.LanguageSpecific["oci_annotations"])
+}
+
+func TestExtractor_Extract_OCIAnnotationsFromCompose(t *testing.T) {
+	dir := t.TempDir()
+	dockerfileContent := `FROM alpine:3.18
+LABEL org.opencontainers.image.version="1.4.0"`
+	composeContent := `services:
+  api:
+    build:
+      context: .
+      labels:
+        - "org.opencontainers.image.title=api"
+        - "org.opencontainers.image.version=0.0.0-compose"
+  worker:
+    labels:
+      org.opencontainers.image.source: https://github.com/example/api
+      org.opencontainers.image.revision: "0123abc"
+      com.example.team: platform
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(dockerfileContent), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte(composeContent), 0644))
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	// The Dockerfile version wins over the compose label
+	assert.Equal(t, map[string]string{
+		"title":    "api",
+		"version":  "1.4.0",
+		"source":   "https://github.com/example/api",
+		"revision": "0123abc",
+	}, metadata.LanguageSpecific["oci_annotations"])
+}
+
+func TestExtractor_Extract_NoOCIAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine:3.18\nLABEL version=\"1.0\"\n"), 0644))
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	assert.NotContains(t, metadata.LanguageSpecific, "oci_annotations")
+}
+
 func TestExtractor_Extract_ExposedPorts(t *testing.T) {
 	dir := t.TempDir()
 	dockerfilePath := filepath.Join(dir, "Dockerfile")
@@ -552,3 +647,78 @@ func TestParseCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected extractor.ImageReference
+		ok       bool
+	}{
+		{
+			ref:      "ghcr.io/org/app:1.2.3",
+			expected: extractor.ImageReference{Registry: "ghcr.io", Repository: "org/app", Tag: "1.2.3"},
+			ok:       true,
+		},
+		{
+			ref:      "nginx",
+			expected: extractor.ImageReference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"},
+			ok:       true,
+		},
+		{
+			ref:      "docker.io/library/nginx",
+			expected: extractor.ImageReference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"},
+			ok:       true,
+		},
+		{
+			ref: "alpine@sha256:c5b1261d6d3e43071626931fc004f70149baeba2c8ec672bd4f27761f8e1ad6b",
+			expected: extractor.ImageReference{Registry: "docker.io", Repository: "library/alpine",
+				Digest: "sha256:c5b1261d6d3e43071626931fc004f70149baeba2c8ec672bd4f27761f8e1ad6b"},
+			ok: true,
+		},
+		{
+			ref:      "localhost:5000/team/app:dev@sha256:abc",
+			expected: extractor.ImageReference{Registry: "localhost:5000", Repository: "team/app", Tag: "dev", Digest: "sha256:abc"},
+			ok:       true,
+		},
+		{ref: "scratch"},
+		{ref: "${BASE_IMAGE}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			image, ok := extractor.ParseImageReference(tt.ref)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, image)
+		})
+	}
+}
+
+func TestExtractor_Extract_ImageReference(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM golang:1.22 AS build\nFROM gcr.io/distroless/static\n"), 0644))
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+	// The final stage's image is the one the build ships
+	assert.Equal(t, "gcr.io", metadata.LanguageSpecific["image_registry"])
+	assert.Equal(t, "distroless/static", metadata.LanguageSpecific["image_repository"])
+	assert.Equal(t, "latest", metadata.LanguageSpecific["image_tag"])
+	assert.Equal(t, "Dockerfile", metadata.LanguageSpecific["image_source"])
+
+	// The image a Compose service builds takes precedence
+	composeContent := `services:
+  db:
+    image: postgres:16
+  api:
+    build: .
+    image: ghcr.io/example/api:2.0.1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte(composeContent), 0644))
+
+	metadata, err = NewExtractor().Extract(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io", metadata.LanguageSpecific["image_registry"])
+	assert.Equal(t, "example/api", metadata.LanguageSpecific["image_repository"])
+	assert.Equal(t, "2.0.1", metadata.LanguageSpecific["image_tag"])
+	assert.Equal(t, "compose.yaml", metadata.LanguageSpecific["image_source"])
+}
@@ -46,6 +46,7 @@ type PropertyGroup struct {
 	FileVersion              string `xml:"FileVersion"`
 	PackageId                string `xml:"PackageId"`
 	PackageVersion           string `xml:"PackageVersion"`
+	IsPackable               string `xml:"IsPackable"`
 	Authors                  string `xml:"Authors"`
 	Company                  string `xml:"Company"`
 	Product                  string `xml:"Product"`
@@ -79,6 +80,20 @@ type ItemGroup struct {
 type PackageReference struct {
 	Include string `xml:"Include,attr"`
 	Version string `xml:"Version,attr"`
+	// PrivateAssets may be given as an attribute or a child element;
+	// "all" marks a development-only dependency (analyzers, build tools)
+	PrivateAssets        string `xml:"PrivateAssets,attr"`
+	PrivateAssetsElement string `xml:"PrivateAssets"`
+}
+
+// isDevelopmentDependency reports whether the reference keeps all of its
+// assets private, so it never flows to consumers of the built package
+func (p PackageReference) isDevelopmentDependency() bool {
+	assets := p.PrivateAssets
+	if assets == "" {
+		assets = p.PrivateAssetsElement
+	}
+	return strings.EqualFold(strings.TrimSpace(assets), "all")
 }
 
 // ProjectReference represents a project-to-project reference
@@ -157,14 +172,17 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 					return nil, err
 				}
 			} else {
-				return nil, fmt.Errorf("no .NET project files found")
+				return nil, extractor.WithCode(extractor.ErrNoManifest, fmt.Errorf("no .NET project files found"))
 			}
 		}
 	}
 
+	e.extractGlobalJSON(projectPath, metadata)
+
 	// Detect frameworks and tools
 	e.detectFrameworks(metadata)
 	e.generateVersionMatrix(metadata)
+	e.classifyArtifact(metadata)
 
 	return metadata, nil
 }
@@ -176,7 +194,9 @@ func (e *Extractor) extractFromProjectFile(csprojPath string, metadata *extracto
 		return fmt.Errorf("failed to parse project file: %w", err)
 	}
 
-	e.extractProjectProperties(project, metadata)
+	e.extractMergedProperties(csprojPath, project, metadata)
+	e.applyAssemblyInfoVersion(filepath.Dir(csprojPath), metadata)
+	e.classifyPublishable(metadata)
 
 	e.extractPackageReferences(project, metadata)
 
@@ -202,6 +222,7 @@ func (e *Extractor) extractFromSolution(projectPath string, slnPath string, meta
 	if err != nil {
 		return fmt.Errorf("failed to parse solution file: %w", err)
 	}
+	metadata.AddConsumedFile(slnPath)
 
 	// Store solution metadata
 	metadata.Name = strings.TrimSuffix(filepath.Base(slnPath), ".sln")
@@ -221,14 +242,88 @@ func (e *Extractor) extractFromSolution(projectPath string, slnPath string, meta
 		firstProjectPath := filepath.Join(projectPath, solution.Projects[0].Path)
 		if _, err := os.Stat(firstProjectPath); err == nil {
 			if project, err := e.parseProjectFile(firstProjectPath); err == nil {
-				e.extractProjectProperties(project, metadata)
+				e.extractMergedProperties(firstProjectPath, project, metadata)
+				e.applyAssemblyInfoVersion(filepath.Dir(firstProjectPath), metadata)
 			}
 		}
 	}
 
+	e.classifySolutionPublishable(projectPath, solution, metadata)
+
 	return nil
 }
 
+// classifySolutionPublishable marks the solution publishable to NuGet
+// when any member project is, each classified on its own properties.
+// Solution folders and missing or unparsable projects are skipped.
+func (e *Extractor) classifySolutionPublishable(projectPath string, solution *Solution, metadata *extractor.ProjectMetadata) {
+	publishable := false
+	for _, proj := range solution.Projects {
+		memberPath := filepath.Join(projectPath, proj.Path)
+		if info, err := os.Stat(memberPath); err != nil || info.IsDir() {
+			continue
+		}
+		project, err := e.parseProjectFile(memberPath)
+		if err != nil {
+			continue
+		}
+		member := &extractor.ProjectMetadata{
+			LanguageSpecific: make(map[string]interface{}),
+		}
+		e.extractMergedProperties(memberPath, project, member)
+		for _, file := range member.ConsumedFiles {
+			metadata.AddConsumedFile(file)
+		}
+		e.classifyPublishable(member)
+		if *member.Publishable {
+			publishable = true
+			break
+		}
+	}
+	metadata.SetPublishable(extractor.PublishTargetNuGet, publishable)
+}
+
+// directoryBuildPropsFile is the file MSBuild imports ahead of every
+// project below it
+const directoryBuildPropsFile = "Directory.Build.props"
+
+// extractMergedProperties applies the project's properties on top of
+// those of the Directory.Build.props MSBuild would import for it, so
+// centralized values such as Version and Authors act as defaults that
+// the project file overrides.
+func (e *Extractor) extractMergedProperties(projectFile string, project *Project, metadata *extractor.ProjectMetadata) {
+	if propsPath := findDirectoryBuildProps(filepath.Dir(projectFile)); propsPath != "" {
+		if props, err := e.parseProjectFile(propsPath); err == nil {
+			metadata.AddConsumedFile(propsPath)
+			e.extractProjectProperties(props, metadata)
+			metadata.LanguageSpecific["dotnet_has_directory_build_props"] = true
+		}
+	}
+	metadata.AddConsumedFile(projectFile)
+	e.extractProjectProperties(project, metadata)
+}
+
+// findDirectoryBuildProps returns the nearest Directory.Build.props in dir
+// or its ancestors, as MSBuild resolves it. The walk stops at the
+// repository root (a directory holding .git) so files outside the
+// checkout are never read.
+func findDirectoryBuildProps(dir string) string {
+	for {
+		candidate := filepath.Join(dir, directoryBuildPropsFile)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
 // extractFromPropsFile extracts metadata from a .props file
 func (e *Extractor) extractFromPropsFile(propsPath string, metadata *extractor.ProjectMetadata) error {
 	// Parse as project file (same XML structure)
@@ -236,6 +331,7 @@ func (e *Extractor) extractFromPropsFile(propsPath string, metadata *extractor.P
 	if err != nil {
 		return fmt.Errorf("failed to parse props file: %w", err)
 	}
+	metadata.AddConsumedFile(propsPath)
 
 	metadata.Name = strings.TrimSuffix(filepath.Base(propsPath), ".props")
 	metadata.LanguageSpecific["dotnet_props_file"] = filepath.Base(propsPath)
@@ -266,7 +362,7 @@ func (e *Extractor) parseProjectFile(path string) (*Project, error) {
 
 	var project Project
 	if err := xml.Unmarshal(data, &project); err != nil {
-		return nil, err
+		return nil, extractor.NewManifestError(path, data, err)
 	}
 
 	return &project, nil
@@ -283,7 +379,7 @@ func (e *Extractor) parseSolutionFile(path string) (*Solution, error) {
 		Projects: make([]SolutionProject, 0),
 	}
 
-	lines := strings.Split(string(data), "\n")
+	lines := strings.Split(extractor.NormalizeNewlines(string(data)), "\n")
 
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
@@ -328,7 +424,7 @@ func (e *Extractor) parseSolutionProject(line string) SolutionProject {
 
 	if len(matches) >= 3 {
 		project.Name = matches[0][1]
-		project.Path = matches[1][1]
+		project.Path = extractor.NormalizeManifestPath(matches[1][1])
 		project.GUID = matches[2][1]
 	}
 
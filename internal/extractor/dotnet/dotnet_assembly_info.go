@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package dotnet
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// assemblyInfoFile is where legacy (non SDK-style) projects declare
+// their assembly attributes, version included
+const assemblyInfoFile = "AssemblyInfo.cs"
+
+// assemblyInfoPaths are the AssemblyInfo.cs locations checked, relative
+// to the project file's directory, in order
+var assemblyInfoPaths = []string{
+	filepath.Join("Properties", assemblyInfoFile),
+	assemblyInfoFile,
+}
+
+// assemblyVersionAttributePattern matches an assembly-level version
+// attribute, e.g. [assembly: AssemblyVersion("1.2.3.0")], capturing the
+// attribute name and its value
+var assemblyVersionAttributePattern = regexp.MustCompile(`\[\s*assembly\s*:\s*(AssemblyInformationalVersion|AssemblyVersion)(?:Attribute)?\s*\(\s*"([^"]*)"\s*\)\s*\]`)
+
+// applyAssemblyInfoVersion falls back to the project's AssemblyInfo.cs
+// when the project file declares no Version, preferring
+// AssemblyInformationalVersion over AssemblyVersion. Wildcard versions
+// such as "1.0.*" are resolved at build time and are ignored.
+func (e *Extractor) applyAssemblyInfoVersion(projectDir string, metadata *extractor.ProjectMetadata) {
+	if metadata.Version != "" {
+		return
+	}
+	for _, rel := range assemblyInfoPaths {
+		path := filepath.Join(projectDir, rel)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		metadata.AddConsumedFile(path)
+		attributes := assemblyVersionAttributes(string(content))
+		for _, name := range []string{"AssemblyInformationalVersion", "AssemblyVersion"} {
+			if version := attributes[name]; version != "" && !strings.Contains(version, "*") {
+				metadata.Version = version
+				metadata.VersionSource = assemblyInfoFile
+				break
+			}
+		}
+		if version := attributes["AssemblyVersion"]; version != "" {
+			if _, ok := metadata.LanguageSpecific["dotnet_assembly_version"]; !ok {
+				metadata.LanguageSpecific["dotnet_assembly_version"] = version
+			}
+		}
+		return
+	}
+}
+
+// assemblyVersionAttributes returns the version attributes declared in
+// AssemblyInfo.cs content, skipping commented-out lines.
+func assemblyVersionAttributes(content string) map[string]string {
+	attributes := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		if m := assemblyVersionAttributePattern.FindStringSubmatch(line); m != nil {
+			if _, seen := attributes[m[1]]; !seen {
+				attributes[m[1]] = strings.TrimSpace(m[2])
+			}
+		}
+	}
+	return attributes
+}
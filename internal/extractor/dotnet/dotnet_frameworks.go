@@ -107,6 +107,15 @@ func (e *Extractor) generateVersionMatrix(metadata *extractor.ProjectMetadata) {
 		versions = []string{"8.0", "7.0", "6.0"}
 	}
 
+	// The SDK pinned by global.json leads the matrix
+	if pinned := pinnedSDKVersion(metadata); pinned != "" {
+		ordered := []string{pinned}
+		for _, version := range versions {
+			ordered = appendUnique(ordered, version)
+		}
+		versions = ordered
+	}
+
 	metadata.LanguageSpecific["dotnet_version_matrix"] = versions
 
 	matrixJSON := fmt.Sprintf(`{"dotnet-version":["%s"]}`, strings.Join(versions, `","`))
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package dotnet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// globalJSONFile pins the .NET SDK (and MSBuild project SDKs) for every
+// project below it
+const globalJSONFile = "global.json"
+
+// sdkMajorMinorPattern extracts major.minor from an SDK version such as
+// "8.0.100"
+var sdkMajorMinorPattern = regexp.MustCompile(`^(\d+)\.(\d+)`)
+
+// GlobalJSON represents the parts of global.json used for metadata
+type GlobalJSON struct {
+	SDK *struct {
+		Version         string `json:"version"`
+		RollForward     string `json:"rollForward"`
+		AllowPrerelease *bool  `json:"allowPrerelease"`
+	} `json:"sdk"`
+	MSBuildSDKs map[string]string `json:"msbuild-sdks"`
+}
+
+// extractGlobalJSON records the SDK pin and MSBuild project SDK versions
+// of a global.json in the project root. Without the file nothing is
+// recorded; a malformed one only warns, leaving the project metadata
+// from the project files intact.
+func (e *Extractor) extractGlobalJSON(projectPath string, metadata *extractor.ProjectMetadata) {
+	path := filepath.Join(projectPath, globalJSONFile)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	metadata.AddConsumedFile(path)
+
+	var global GlobalJSON
+	if err := json.Unmarshal(content, &global); err != nil {
+		extractor.Warnf("", "[WARNING] Ignoring malformed %s: %v\n", path, err)
+		return
+	}
+
+	metadata.LanguageSpecific["dotnet_global_json"] = true
+	if global.SDK != nil {
+		if global.SDK.Version != "" {
+			metadata.LanguageSpecific["dotnet_sdk_pinned_version"] = global.SDK.Version
+		}
+		if global.SDK.RollForward != "" {
+			metadata.LanguageSpecific["dotnet_sdk_roll_forward"] = global.SDK.RollForward
+		}
+		if global.SDK.AllowPrerelease != nil {
+			metadata.LanguageSpecific["dotnet_sdk_allow_prerelease"] = *global.SDK.AllowPrerelease
+		}
+	}
+	if len(global.MSBuildSDKs) > 0 {
+		metadata.LanguageSpecific["dotnet_msbuild_sdks"] = global.MSBuildSDKs
+	}
+}
+
+// pinnedSDKVersion returns the major.minor of the global.json SDK pin,
+// or "" when the SDK is not pinned
+func pinnedSDKVersion(metadata *extractor.ProjectMetadata) string {
+	pinned, _ := metadata.LanguageSpecific["dotnet_sdk_pinned_version"].(string)
+	matches := sdkMajorMinorPattern.FindStringSubmatch(pinned)
+	if matches == nil {
+		return ""
+	}
+	return matches[1] + "." + matches[2]
+}
@@ -65,6 +65,22 @@ func (e *Extractor) applyAssemblyAndPackageInfo(pg PropertyGroup, metadata *extr
 	if pg.PackageVersion != "" {
 		metadata.LanguageSpecific["dotnet_package_version"] = pg.PackageVersion
 	}
+	if pg.IsPackable != "" {
+		metadata.LanguageSpecific["dotnet_is_packable"] = strings.EqualFold(strings.TrimSpace(pg.IsPackable), "true")
+	}
+}
+
+// classifyPublishable marks the project publishable to NuGet when it
+// declares a PackageId, or sets IsPackable to true, and does not set
+// IsPackable to false (as test and tool projects commonly do).
+func (e *Extractor) classifyPublishable(metadata *extractor.ProjectMetadata) {
+	_, hasPackageID := metadata.LanguageSpecific["dotnet_package_id"]
+	packable, declared := metadata.LanguageSpecific["dotnet_is_packable"].(bool)
+	if declared {
+		metadata.SetPublishable(extractor.PublishTargetNuGet, packable)
+		return
+	}
+	metadata.SetPublishable(extractor.PublishTargetNuGet, hasPackageID)
 }
 
 // applyAuthorAndDescription records authorship, description, and licensing.
@@ -74,6 +90,7 @@ func (e *Extractor) applyAuthorAndDescription(pg PropertyGroup, metadata *extrac
 		authors := strings.Split(pg.Authors, ";")
 		if len(authors) > 0 {
 			metadata.Authors = authors
+			metadata.AuthorsStructured = extractor.ParseAuthors(authors)
 		}
 	}
 	if pg.Company != "" {
@@ -114,6 +131,34 @@ func (e *Extractor) applyURLsAndTags(pg PropertyGroup, metadata *extractor.Proje
 	}
 }
 
+// applicationSdks are the project SDKs whose default OutputType is Exe
+var applicationSdks = map[string]bool{
+	"Microsoft.NET.Sdk.Web":    true,
+	"Microsoft.NET.Sdk.Blazor": true,
+	"Microsoft.NET.Sdk.Worker": true,
+}
+
+// classifyArtifact derives the artifact kind from OutputType: Exe and
+// WinExe build applications, Library and Module build libraries. Without
+// an OutputType the SDK default applies: Exe for the web and worker SDKs,
+// Library for other SDK-style projects.
+func (e *Extractor) classifyArtifact(metadata *extractor.ProjectMetadata) {
+	outputType, _ := metadata.LanguageSpecific["dotnet_output_type"].(string)
+	switch strings.ToLower(outputType) {
+	case "exe", "winexe", "appcontainerexe":
+		metadata.ArtifactKind = extractor.ArtifactKindApplication
+	case "library", "module":
+		metadata.ArtifactKind = extractor.ArtifactKindLibrary
+	case "":
+		sdk, _ := metadata.LanguageSpecific["dotnet_sdk"].(string)
+		if applicationSdks[sdk] {
+			metadata.ArtifactKind = extractor.ArtifactKindApplication
+		} else if sdk != "" {
+			metadata.ArtifactKind = extractor.ArtifactKindLibrary
+		}
+	}
+}
+
 // applyBuildOptions records compilation, runtime, and publishing settings.
 func (e *Extractor) applyBuildOptions(pg PropertyGroup, metadata *extractor.ProjectMetadata) {
 	if pg.OutputType != "" {
@@ -146,25 +191,43 @@ func (e *Extractor) applyBuildOptions(pg PropertyGroup, metadata *extractor.Proj
 	}
 }
 
-// extractPackageReferences extracts NuGet package references
+// extractPackageReferences extracts NuGet package references in
+// declaration order. A package referenced more than once keeps its first
+// position and takes the last declared version. References with
+// PrivateAssets="all" count as dev dependencies, the rest as runtime.
 func (e *Extractor) extractPackageReferences(project *Project, metadata *extractor.ProjectMetadata) {
 	packages := make([]map[string]string, 0)
-	packageMap := make(map[string]string) // For deduplication
+	packageIndex := make(map[string]int) // For deduplication
+	devPackages := make([]bool, 0)
 
 	for _, ig := range project.ItemGroups {
 		for _, pkg := range ig.PackageReferences {
-			if pkg.Include != "" {
-				packageMap[pkg.Include] = pkg.Version
+			if pkg.Include == "" {
+				continue
+			}
+			if i, seen := packageIndex[pkg.Include]; seen {
+				packages[i]["version"] = pkg.Version
+				devPackages[i] = pkg.isDevelopmentDependency()
+				continue
 			}
+			packageIndex[pkg.Include] = len(packages)
+			packages = append(packages, map[string]string{
+				"name":    pkg.Include,
+				"version": pkg.Version,
+			})
+			devPackages = append(devPackages, pkg.isDevelopmentDependency())
 		}
 	}
 
-	for name, version := range packageMap {
-		packages = append(packages, map[string]string{
-			"name":    name,
-			"version": version,
-		})
+	var counts extractor.DependencyCounts
+	for _, dev := range devPackages {
+		if dev {
+			counts.Dev++
+		} else {
+			counts.Runtime++
+		}
 	}
+	extractor.SetDependencyCounts(metadata, counts)
 
 	if len(packages) > 0 {
 		metadata.LanguageSpecific["dotnet_package_references"] = packages
@@ -172,23 +235,21 @@ func (e *Extractor) extractPackageReferences(project *Project, metadata *extract
 	}
 }
 
-// extractProjectReferences extracts project-to-project references
+// extractProjectReferences extracts project-to-project references in
+// declaration order
 func (e *Extractor) extractProjectReferences(project *Project, metadata *extractor.ProjectMetadata) {
 	projects := make([]string, 0)
-	projectMap := make(map[string]bool) // For deduplication
+	projectSeen := make(map[string]bool) // For deduplication
 
 	for _, ig := range project.ItemGroups {
 		for _, proj := range ig.ProjectReferences {
-			if proj.Include != "" {
-				projectMap[proj.Include] = true
+			if proj.Include != "" && !projectSeen[proj.Include] {
+				projectSeen[proj.Include] = true
+				projects = append(projects, proj.Include)
 			}
 		}
 	}
 
-	for path := range projectMap {
-		projects = append(projects, path)
-	}
-
 	if len(projects) > 0 {
 		metadata.LanguageSpecific["dotnet_project_references"] = projects
 		metadata.LanguageSpecific["dotnet_project_reference_count"] = len(projects)
@@ -4,9 +4,15 @@
 package dotnet
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 )
 
 func TestDetect(t *testing.T) {
@@ -399,6 +405,107 @@ func TestExtractProjectWithReferences(t *testing.T) {
 	}
 }
 
+// TestReferenceOrderIsStable runs extraction repeatedly and checks that
+// package and project references keep their declaration order, with
+// duplicates collapsed onto the first occurrence
+func TestReferenceOrderIsStable(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	csprojContent := `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+
+  <ItemGroup>
+    <PackageReference Include="Serilog" Version="3.1.1" />
+    <PackageReference Include="Newtonsoft.Json" Version="13.0.1" />
+    <PackageReference Include="Dapper" Version="2.1.28" />
+    <ProjectReference Include="..\Zeta\Zeta.csproj" />
+    <ProjectReference Include="..\Alpha\Alpha.csproj" />
+  </ItemGroup>
+
+  <ItemGroup>
+    <PackageReference Include="Newtonsoft.Json" Version="13.0.3" />
+    <ProjectReference Include="..\Zeta\Zeta.csproj" />
+  </ItemGroup>
+</Project>`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "App.csproj"), []byte(csprojContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	e := NewExtractor()
+	var firstPackages, firstProjects interface{}
+	for run := 0; run < 10; run++ {
+		metadata, err := e.Extract(tmpDir)
+		if err != nil {
+			t.Fatalf("Extract() failed: %v", err)
+		}
+		packages := metadata.LanguageSpecific["dotnet_package_references"]
+		projects := metadata.LanguageSpecific["dotnet_project_references"]
+		if run == 0 {
+			firstPackages, firstProjects = packages, projects
+			continue
+		}
+		if !reflect.DeepEqual(packages, firstPackages) || !reflect.DeepEqual(projects, firstProjects) {
+			t.Fatalf("run %d produced different reference ordering", run)
+		}
+	}
+
+	wantPackages := []map[string]string{
+		{"name": "Serilog", "version": "3.1.1"},
+		{"name": "Newtonsoft.Json", "version": "13.0.3"},
+		{"name": "Dapper", "version": "2.1.28"},
+	}
+	if !reflect.DeepEqual(firstPackages, wantPackages) {
+		t.Errorf("dotnet_package_references = %v, want %v", firstPackages, wantPackages)
+	}
+
+	wantProjects := []string{`..\Zeta\Zeta.csproj`, `..\Alpha\Alpha.csproj`}
+	if !reflect.DeepEqual(firstProjects, wantProjects) {
+		t.Errorf("dotnet_project_references = %v, want %v", firstProjects, wantProjects)
+	}
+}
+
+func TestDependencyCounts(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	csprojContent := `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+  </PropertyGroup>
+
+  <ItemGroup>
+    <PackageReference Include="Serilog" Version="3.1.1" />
+    <PackageReference Include="Newtonsoft.Json" Version="13.0.3" />
+    <PackageReference Include="StyleCop.Analyzers" Version="1.1.118" PrivateAssets="all" />
+    <PackageReference Include="Microsoft.SourceLink.GitHub" Version="8.0.0">
+      <PrivateAssets>All</PrivateAssets>
+    </PackageReference>
+  </ItemGroup>
+</Project>`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "App.csproj"), []byte(csprojContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	e := NewExtractor()
+	metadata, err := e.Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	for key, want := range map[string]int{
+		"runtime_dependency_count": 2,
+		"dev_dependency_count":     2,
+		"total_dependency_count":   4,
+	} {
+		if got, ok := metadata.LanguageSpecific[key].(int); !ok || got != want {
+			t.Errorf("%s = %v, want %d", key, metadata.LanguageSpecific[key], want)
+		}
+	}
+}
+
 func TestExtractSolutionFile(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -455,6 +562,121 @@ EndGlobal`
 	}
 }
 
+func TestExtractSolutionFileCRLF(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	slnContent := "\r\nMicrosoft Visual Studio Solution File, Format Version 12.00\r\n" +
+		`Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "WebApp", "src\WebApp\WebApp.csproj", "{12345678-1234-1234-1234-123456789012}"` + "\r\n" +
+		"EndProject\r\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "MySolution.sln"), []byte(slnContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	projectDir := filepath.Join(tmpDir, "src", "WebApp")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	csprojContent := "<Project Sdk=\"Microsoft.NET.Sdk\">\r\n" +
+		"  <PropertyGroup>\r\n" +
+		"    <TargetFramework>net8.0</TargetFramework>\r\n" +
+		"    <Version>3.2.0</Version>\r\n" +
+		"  </PropertyGroup>\r\n" +
+		"</Project>\r\n"
+	if err := os.WriteFile(filepath.Join(projectDir, "WebApp.csproj"), []byte(csprojContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	if got := metadata.LanguageSpecific["dotnet_solution_version"]; got != "12.00" {
+		t.Errorf("dotnet_solution_version = %q, want %q", got, "12.00")
+	}
+	// The backslash project path resolves, so the project is read
+	if metadata.Version != "3.2.0" {
+		t.Errorf("Version = %q, want 3.2.0 from the solution's first project", metadata.Version)
+	}
+}
+
+func TestExtractDirectoryBuildProps(t *testing.T) {
+	repoDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repoDir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	propsContent := `<Project>
+  <PropertyGroup>
+    <Version>4.1.0</Version>
+    <Authors>Platform Team</Authors>
+    <Description>Shared description</Description>
+    <PackageLicenseExpression>Apache-2.0</PackageLicenseExpression>
+  </PropertyGroup>
+</Project>`
+	if err := os.WriteFile(filepath.Join(repoDir, "Directory.Build.props"), []byte(propsContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	projectDir := filepath.Join(repoDir, "src", "Service")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	csprojContent := `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <Description>Service description</Description>
+  </PropertyGroup>
+</Project>`
+	if err := os.WriteFile(filepath.Join(projectDir, "Service.csproj"), []byte(csprojContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	metadata, err := NewExtractor().Extract(projectDir)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	if metadata.Version != "4.1.0" {
+		t.Errorf("Version = %q, want 4.1.0 from Directory.Build.props", metadata.Version)
+	}
+	if !reflect.DeepEqual(metadata.Authors, []string{"Platform Team"}) {
+		t.Errorf("Authors = %v, want [Platform Team] from Directory.Build.props", metadata.Authors)
+	}
+	if metadata.Description != "Service description" {
+		t.Errorf("Description = %q, want the project file's override", metadata.Description)
+	}
+	if metadata.License != "Apache-2.0" {
+		t.Errorf("License = %q, want Apache-2.0", metadata.License)
+	}
+	if got := metadata.LanguageSpecific["dotnet_has_directory_build_props"]; got != true {
+		t.Errorf("dotnet_has_directory_build_props = %v, want true", got)
+	}
+}
+
+func TestExtractWithoutDirectoryBuildProps(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(tmpDir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	csprojContent := `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <Version>1.0.0</Version>
+  </PropertyGroup>
+</Project>`
+	if err := os.WriteFile(filepath.Join(tmpDir, "App.csproj"), []byte(csprojContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if _, ok := metadata.LanguageSpecific["dotnet_has_directory_build_props"]; ok {
+		t.Error("dotnet_has_directory_build_props set without a Directory.Build.props")
+	}
+}
+
 func TestExtractBlazorProject(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -809,6 +1031,110 @@ func TestExtractWithRuntimeIdentifiers(t *testing.T) {
 	}
 }
 
+func TestExtractErrorCodes(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := NewExtractor()
+
+	_, err := e.Extract(tmpDir)
+	if !errors.Is(err, extractor.ErrNoManifest) {
+		t.Errorf("Extract() without project files error = %v, want ErrNoManifest", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "App.csproj"), []byte("<Project Sdk=\"Microsoft.NET.Sdk\">\n  <PropertyGroup>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = e.Extract(tmpDir)
+	if !errors.Is(err, extractor.ErrParseFailure) {
+		t.Errorf("Extract() with malformed .csproj error = %v, want ErrParseFailure", err)
+	}
+}
+
+func TestExtractGlobalJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	csprojContent := `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFrameworks>net6.0;net8.0</TargetFrameworks>
+    <AssemblyName>Pinned</AssemblyName>
+  </PropertyGroup>
+</Project>`
+	globalJSON := `{
+  "sdk": {
+    "version": "8.0.100",
+    "rollForward": "latestFeature",
+    "allowPrerelease": false
+  },
+  "msbuild-sdks": {
+    "Microsoft.Build.Traversal": "4.1.0"
+  }
+}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "Pinned.csproj"), []byte(csprojContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewExtractor()
+	metadata, err := e.Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if got := metadata.LanguageSpecific["dotnet_version_matrix"]; !reflect.DeepEqual(got, []string{"6.0", "8.0"}) {
+		t.Errorf("dotnet_version_matrix without global.json = %v, want [6.0 8.0]", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "global.json"), []byte(globalJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	metadata, err = e.Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	ls := metadata.LanguageSpecific
+	if got := ls["dotnet_sdk_pinned_version"]; got != "8.0.100" {
+		t.Errorf("dotnet_sdk_pinned_version = %v, want 8.0.100", got)
+	}
+	if got := ls["dotnet_sdk_roll_forward"]; got != "latestFeature" {
+		t.Errorf("dotnet_sdk_roll_forward = %v, want latestFeature", got)
+	}
+	if got := ls["dotnet_sdk_allow_prerelease"]; got != false {
+		t.Errorf("dotnet_sdk_allow_prerelease = %v, want false", got)
+	}
+	if got := ls["dotnet_msbuild_sdks"]; !reflect.DeepEqual(got, map[string]string{"Microsoft.Build.Traversal": "4.1.0"}) {
+		t.Errorf("dotnet_msbuild_sdks = %v", got)
+	}
+	if got := ls["dotnet_version_matrix"]; !reflect.DeepEqual(got, []string{"8.0", "6.0"}) {
+		t.Errorf("dotnet_version_matrix = %v, want the pinned 8.0 first", got)
+	}
+	if got := ls["matrix_json"]; got != `{"dotnet-version":["8.0","6.0"]}` {
+		t.Errorf("matrix_json = %v", got)
+	}
+}
+
+func TestExtractInvalidGlobalJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "App.csproj"), []byte(`<Project Sdk="Microsoft.NET.Sdk"></Project>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "global.json"), []byte(`{"sdk": {"version": }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var warnings bytes.Buffer
+	previous := extractor.SetLogOutput(&warnings)
+	defer extractor.SetLogOutput(previous)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() with malformed global.json error = %v, want the project extracted", err)
+	}
+	if _, ok := metadata.LanguageSpecific["dotnet_global_json"]; ok {
+		t.Error("dotnet_global_json set from a malformed global.json")
+	}
+	if !strings.Contains(warnings.String(), "malformed") {
+		t.Errorf("no warning for the malformed global.json, got %q", warnings.String())
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	if len(s) == 0 || len(substr) == 0 {
@@ -821,3 +1147,226 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestArtifactKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		csproj string
+		want   string
+	}{
+		{"exe", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><OutputType>Exe</OutputType></PropertyGroup></Project>`, "application"},
+		{"library", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><OutputType>Library</OutputType></PropertyGroup></Project>`, "library"},
+		{"sdk default", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`, "library"},
+		{"web sdk default", `<Project Sdk="Microsoft.NET.Sdk.Web"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`, "application"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "App.csproj"), []byte(tt.csproj), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if metadata.ArtifactKind != tt.want {
+				t.Errorf("ArtifactKind = %q, want %q", metadata.ArtifactKind, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublishable(t *testing.T) {
+	tests := []struct {
+		name   string
+		csproj string
+		want   bool
+	}{
+		{"package id", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><PackageId>Acme.Core</PackageId></PropertyGroup></Project>`, true},
+		{"is packable", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><IsPackable>true</IsPackable></PropertyGroup></Project>`, true},
+		{"not packable", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><PackageId>Acme.Tests</PackageId><IsPackable>false</IsPackable></PropertyGroup></Project>`, false},
+		{"no package id", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "App.csproj"), []byte(tt.csproj), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if metadata.Publishable == nil {
+				t.Fatal("Publishable not set")
+			}
+			if *metadata.Publishable != tt.want {
+				t.Errorf("Publishable = %v, want %v", *metadata.Publishable, tt.want)
+			}
+			if metadata.PublishTarget != "nuget" {
+				t.Errorf("PublishTarget = %q, want nuget", metadata.PublishTarget)
+			}
+		})
+	}
+}
+
+func TestSolutionPublishable(t *testing.T) {
+	slnContent := "Microsoft Visual Studio Solution File, Format Version 12.00\n" +
+		`Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Tests", "tests\Tests\Tests.csproj", "{12345678-1234-1234-1234-123456789012}"` + "\n" +
+		"EndProject\n" +
+		`Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Core", "src\Core\Core.csproj", "{87654321-4321-4321-4321-210987654321}"` + "\n" +
+		"EndProject\n"
+
+	tests := []struct {
+		name string
+		core string
+		want bool
+	}{
+		{"packable member after the first", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><PackageId>Acme.Core</PackageId></PropertyGroup></Project>`, true},
+		{"no packable member", `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><TargetFramework>net8.0</TargetFramework></PropertyGroup></Project>`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			files := map[string]string{
+				"MySolution.sln":           slnContent,
+				"tests/Tests/Tests.csproj": `<Project Sdk="Microsoft.NET.Sdk"><PropertyGroup><IsPackable>false</IsPackable></PropertyGroup></Project>`,
+				"src/Core/Core.csproj":     tt.core,
+			}
+			for name, content := range files {
+				path := filepath.Join(tmpDir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if metadata.Publishable == nil {
+				t.Fatal("Publishable not set")
+			}
+			if *metadata.Publishable != tt.want {
+				t.Errorf("Publishable = %v, want %v", *metadata.Publishable, tt.want)
+			}
+			if metadata.PublishTarget != "nuget" {
+				t.Errorf("PublishTarget = %q, want nuget", metadata.PublishTarget)
+			}
+		})
+	}
+}
+
+func TestExtractLegacyProjectAssemblyInfo(t *testing.T) {
+	csprojContent := `<?xml version="1.0" encoding="utf-8"?>
+<Project ToolsVersion="15.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <OutputType>Library</OutputType>
+    <AssemblyName>Legacy.Core</AssemblyName>
+    <TargetFrameworkVersion>v4.7.2</TargetFrameworkVersion>
+  </PropertyGroup>
+</Project>`
+
+	tests := []struct {
+		name         string
+		path         string
+		assemblyInfo string
+		wantVersion  string
+	}{
+		{
+			name: "informational version preferred",
+			path: filepath.Join("Properties", "AssemblyInfo.cs"),
+			assemblyInfo: `using System.Reflection;
+
+[assembly: AssemblyTitle("Legacy.Core")]
+[assembly: AssemblyVersion("1.2.3.0")]
+[assembly: AssemblyFileVersion("1.2.3.0")]
+[assembly: AssemblyInformationalVersion("1.2.3-beta.1")]
+`,
+			wantVersion: "1.2.3-beta.1",
+		},
+		{
+			name: "assembly version",
+			path: filepath.Join("Properties", "AssemblyInfo.cs"),
+			assemblyInfo: `using System.Reflection;
+
+// [assembly: AssemblyInformationalVersion("0.0.1")]
+[assembly: AssemblyVersionAttribute("2.0.0.0")]
+`,
+			wantVersion: "2.0.0.0",
+		},
+		{
+			name:         "sibling file",
+			path:         "AssemblyInfo.cs",
+			assemblyInfo: `[assembly: AssemblyVersion("3.1.0.0")]`,
+			wantVersion:  "3.1.0.0",
+		},
+		{
+			name:         "wildcard ignored",
+			path:         filepath.Join("Properties", "AssemblyInfo.cs"),
+			assemblyInfo: `[assembly: AssemblyVersion("1.0.*")]`,
+			wantVersion:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "Legacy.Core.csproj"), []byte(csprojContent), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+			path := filepath.Join(tmpDir, tt.path)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(tt.assemblyInfo), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			if metadata.Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", metadata.Version, tt.wantVersion)
+			}
+			wantSource := ""
+			if tt.wantVersion != "" {
+				wantSource = "AssemblyInfo.cs"
+			}
+			if metadata.VersionSource != wantSource {
+				t.Errorf("VersionSource = %q, want %q", metadata.VersionSource, wantSource)
+			}
+		})
+	}
+}
+
+func TestExtractProjectVersionOverridesAssemblyInfo(t *testing.T) {
+	tmpDir := t.TempDir()
+	csprojContent := `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <Version>5.0.0</Version>
+  </PropertyGroup>
+</Project>`
+	if err := os.WriteFile(filepath.Join(tmpDir, "App.csproj"), []byte(csprojContent), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "AssemblyInfo.cs"), []byte(`[assembly: AssemblyVersion("1.0.0.0")]`), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+	if metadata.Version != "5.0.0" || metadata.VersionSource != "" {
+		t.Errorf("Version = %q (source %q), want 5.0.0 from the project file", metadata.Version, metadata.VersionSource)
+	}
+}
@@ -109,6 +109,7 @@ func (e *Extractor) extractFromMixExs(path string, metadata *extractor.ProjectMe
 		return err
 	}
 	defer file.Close()
+	metadata.AddConsumedFile(path)
 
 	scanner := bufio.NewScanner(file)
 	state := &mixExsState{}
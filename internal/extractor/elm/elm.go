@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package elm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// Extractor extracts metadata from Elm projects
+type Extractor struct {
+	extractor.BaseExtractor
+}
+
+// NewExtractor creates a new Elm extractor
+func NewExtractor() *Extractor {
+	return &Extractor{
+		BaseExtractor: extractor.NewBaseExtractor("elm", 1),
+	}
+}
+
+func init() {
+	extractor.RegisterExtractor(NewExtractor())
+}
+
+// ElmJSON represents the fields of elm.json shared by the application
+// and package schemas. Dependencies is kept raw because applications
+// split it into "direct" and "indirect" maps while packages map each
+// package straight to a version constraint.
+type ElmJSON struct {
+	Type              string          `json:"type"`
+	Name              string          `json:"name"`
+	Summary           string          `json:"summary"`
+	License           string          `json:"license"`
+	Version           string          `json:"version"`
+	ElmVersion        string          `json:"elm-version"`
+	SourceDirectories []string        `json:"source-directories"`
+	ExposedModules    json.RawMessage `json:"exposed-modules"`
+	Dependencies      json.RawMessage `json:"dependencies"`
+	TestDependencies  json.RawMessage `json:"test-dependencies"`
+}
+
+// elmApplicationDependencies is the application form of a dependency
+// table: exact versions split by whether the project imports them
+type elmApplicationDependencies struct {
+	Direct   map[string]string `json:"direct"`
+	Indirect map[string]string `json:"indirect"`
+}
+
+// Detect checks if this is an Elm project
+func (e *Extractor) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, "elm.json"))
+	return err == nil
+}
+
+// Extract retrieves metadata from an Elm project
+func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, error) {
+	metadata := &extractor.ProjectMetadata{
+		LanguageSpecific: make(map[string]interface{}),
+	}
+
+	path := filepath.Join(projectPath, "elm.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("elm.json not found in %s", projectPath)
+	}
+
+	var elm ElmJSON
+	if err := json.Unmarshal(content, &elm); err != nil {
+		return nil, fmt.Errorf("failed to parse elm.json: %w", extractor.NewManifestError(path, content, err))
+	}
+	metadata.AddConsumedFile(path)
+
+	ls := metadata.LanguageSpecific
+	ls["metadata_source"] = "elm.json"
+	ls["build_tool"] = "elm"
+	if elm.ElmVersion != "" {
+		ls["elm_version"] = elm.ElmVersion
+	}
+
+	switch elm.Type {
+	case "package":
+		applyElmPackage(elm, metadata)
+	case "application":
+		applyElmApplication(elm, projectPath, metadata)
+	default:
+		return nil, fmt.Errorf("elm.json has unsupported type %q (expected application or package)", elm.Type)
+	}
+
+	return metadata, nil
+}
+
+// applyElmApplication maps the application schema. Applications carry no
+// name or version, so the directory name stands in for the project name;
+// dependencies are pinned and split into direct and indirect.
+func applyElmApplication(elm ElmJSON, projectPath string, metadata *extractor.ProjectMetadata) {
+	ls := metadata.LanguageSpecific
+	metadata.Name = filepath.Base(projectPath)
+	ls["package_type"] = "application"
+
+	if len(elm.SourceDirectories) > 0 {
+		ls["source_directories"] = elm.SourceDirectories
+	}
+
+	var deps elmApplicationDependencies
+	if json.Unmarshal(elm.Dependencies, &deps) == nil {
+		if len(deps.Direct) > 0 {
+			ls["dependencies"] = deps.Direct
+			ls["dependency_count"] = len(deps.Direct)
+		}
+		if len(deps.Indirect) > 0 {
+			ls["indirect_dependencies"] = deps.Indirect
+			ls["indirect_dependency_count"] = len(deps.Indirect)
+		}
+	}
+
+	var testDeps elmApplicationDependencies
+	if json.Unmarshal(elm.TestDependencies, &testDeps) == nil && len(testDeps.Direct) > 0 {
+		ls["test_dependencies"] = testDeps.Direct
+	}
+}
+
+// applyElmPackage maps the package schema: a published "author/name"
+// with a version and version-range constraints, all of them direct.
+func applyElmPackage(elm ElmJSON, metadata *extractor.ProjectMetadata) {
+	ls := metadata.LanguageSpecific
+	metadata.Name = elm.Name
+	metadata.Description = elm.Summary
+	metadata.License = elm.License
+	if elm.Version != "" {
+		metadata.Version = elm.Version
+		metadata.VersionSource = "elm.json"
+	}
+	ls["package_type"] = "package"
+	ls["package_name"] = elm.Name
+
+	if modules := elmExposedModules(elm.ExposedModules); len(modules) > 0 {
+		ls["exposed_modules"] = modules
+	}
+
+	var deps map[string]string
+	if json.Unmarshal(elm.Dependencies, &deps) == nil && len(deps) > 0 {
+		ls["dependencies"] = deps
+		ls["dependency_count"] = len(deps)
+	}
+
+	var testDeps map[string]string
+	if json.Unmarshal(elm.TestDependencies, &testDeps) == nil && len(testDeps) > 0 {
+		ls["test_dependencies"] = testDeps
+	}
+}
+
+// elmExposedModules flattens "exposed-modules", which is either a list or
+// a map of documentation section to list. Sectioned modules are returned
+// in section-name order.
+func elmExposedModules(raw json.RawMessage) []string {
+	var modules []string
+	if json.Unmarshal(raw, &modules) == nil {
+		return modules
+	}
+
+	var sections map[string][]string
+	if json.Unmarshal(raw, &sections) != nil {
+		return nil
+	}
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		modules = append(modules, sections[name]...)
+	}
+	return modules
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package elm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeElmJSON(t *testing.T, content string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "elm-app")
+	require.NoError(t, os.Mkdir(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "elm.json"), []byte(content), 0644))
+	return dir
+}
+
+func TestNewExtractor(t *testing.T) {
+	e := NewExtractor()
+	assert.Equal(t, "elm", e.Name())
+	assert.Equal(t, 1, e.Priority())
+}
+
+func TestDetect(t *testing.T) {
+	e := NewExtractor()
+	assert.True(t, e.Detect(writeElmJSON(t, `{"type": "application"}`)))
+	assert.False(t, e.Detect(t.TempDir()))
+}
+
+func TestExtractApplication(t *testing.T) {
+	dir := writeElmJSON(t, `{
+    "type": "application",
+    "source-directories": ["src"],
+    "elm-version": "0.19.1",
+    "dependencies": {
+        "direct": {
+            "elm/browser": "1.0.2",
+            "elm/core": "1.0.5",
+            "elm/html": "1.0.0"
+        },
+        "indirect": {
+            "elm/json": "1.1.3",
+            "elm/virtual-dom": "1.0.3"
+        }
+    },
+    "test-dependencies": {
+        "direct": {"elm-explorations/test": "2.2.0"},
+        "indirect": {"elm/random": "1.0.0"}
+    }
+}`)
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "elm-app", metadata.Name)
+	assert.Empty(t, metadata.Version)
+	ls := metadata.LanguageSpecific
+	assert.Equal(t, "application", ls["package_type"])
+	assert.Equal(t, "0.19.1", ls["elm_version"])
+	assert.Equal(t, []string{"src"}, ls["source_directories"])
+	assert.Equal(t, map[string]string{
+		"elm/browser": "1.0.2",
+		"elm/core":    "1.0.5",
+		"elm/html":    "1.0.0",
+	}, ls["dependencies"])
+	assert.Equal(t, 3, ls["dependency_count"])
+	assert.Equal(t, 2, ls["indirect_dependency_count"])
+	assert.Equal(t, map[string]string{"elm-explorations/test": "2.2.0"}, ls["test_dependencies"])
+	assert.Equal(t, "elm", ls["build_tool"])
+}
+
+func TestExtractPackage(t *testing.T) {
+	dir := writeElmJSON(t, `{
+    "type": "package",
+    "name": "example/elm-widgets",
+    "summary": "Reusable widgets",
+    "license": "BSD-3-Clause",
+    "version": "2.1.0",
+    "exposed-modules": {
+        "Widgets": ["Widget", "Widget.Button"],
+        "Helpers": ["Widget.Internal"]
+    },
+    "elm-version": "0.19.0 <= v < 0.20.0",
+    "dependencies": {
+        "elm/core": "1.0.0 <= v < 2.0.0",
+        "elm/html": "1.0.0 <= v < 2.0.0"
+    },
+    "test-dependencies": {
+        "elm-explorations/test": "2.0.0 <= v < 3.0.0"
+    }
+}`)
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example/elm-widgets", metadata.Name)
+	assert.Equal(t, "2.1.0", metadata.Version)
+	assert.Equal(t, "elm.json", metadata.VersionSource)
+	assert.Equal(t, "Reusable widgets", metadata.Description)
+	assert.Equal(t, "BSD-3-Clause", metadata.License)
+	ls := metadata.LanguageSpecific
+	assert.Equal(t, "package", ls["package_type"])
+	assert.Equal(t, "0.19.0 <= v < 0.20.0", ls["elm_version"])
+	assert.Equal(t, []string{"Widget.Internal", "Widget", "Widget.Button"}, ls["exposed_modules"])
+	assert.Equal(t, map[string]string{
+		"elm/core": "1.0.0 <= v < 2.0.0",
+		"elm/html": "1.0.0 <= v < 2.0.0",
+	}, ls["dependencies"])
+	assert.Equal(t, 2, ls["dependency_count"])
+	assert.Equal(t, map[string]string{"elm-explorations/test": "2.0.0 <= v < 3.0.0"}, ls["test_dependencies"])
+}
+
+func TestExtractInvalid(t *testing.T) {
+	_, err := NewExtractor().Extract(writeElmJSON(t, `{"type": "application",`))
+	assert.ErrorContains(t, err, "failed to parse elm.json")
+
+	_, err = NewExtractor().Extract(writeElmJSON(t, `{"type": "library"}`))
+	assert.ErrorContains(t, err, "unsupported type")
+}
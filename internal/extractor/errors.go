@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import "errors"
+
+// Extraction failure classes. Extractors tag their errors with one of
+// these (directly, via WithCode, or through ManifestError for
+// ErrParseFailure) so callers can match them with errors.Is and
+// automation can branch on ErrorCode.
+var (
+	// ErrNoManifest means the project holds none of the files the
+	// extractor reads.
+	ErrNoManifest = errors.New("no manifest found")
+	// ErrParseFailure means a manifest exists but could not be decoded.
+	ErrParseFailure = errors.New("manifest parse failure")
+	// ErrUnsupported means the project type or manifest layout is not
+	// one the extractor understands.
+	ErrUnsupported = errors.New("unsupported project")
+)
+
+// Error codes reported by ErrorCode
+const (
+	ErrorCodeNoManifest       = "no_manifest"
+	ErrorCodeParseFailure     = "parse_failure"
+	ErrorCodeUnsupported      = "unsupported"
+	ErrorCodeExtractionFailed = "extraction_failed"
+)
+
+// codedError tags an error with an extraction failure class while
+// keeping its message.
+type codedError struct {
+	code error
+	err  error
+}
+
+// WithCode tags err with code (one of the Err* values) so errors.Is
+// matches it, leaving err's message and wrapped errors intact.
+func WithCode(code, err error) error {
+	return &codedError{code: code, err: err}
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap exposes both the failure class and the original error
+func (e *codedError) Unwrap() []error {
+	return []error{e.code, e.err}
+}
+
+// ErrorCode returns the stable code for an extraction error: empty for
+// nil, the code of its failure class when tagged, otherwise
+// "extraction_failed".
+func ErrorCode(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNoManifest):
+		return ErrorCodeNoManifest
+	case errors.Is(err, ErrParseFailure):
+		return ErrorCodeParseFailure
+	case errors.Is(err, ErrUnsupported):
+		return ErrorCodeUnsupported
+	}
+	return ErrorCodeExtractionFailed
+}
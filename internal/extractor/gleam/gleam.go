@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package gleam
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// Extractor extracts metadata from Gleam projects
+type Extractor struct {
+	extractor.BaseExtractor
+}
+
+// NewExtractor creates a new Gleam extractor
+func NewExtractor() *Extractor {
+	return &Extractor{
+		BaseExtractor: extractor.NewBaseExtractor("gleam", 1),
+	}
+}
+
+func init() {
+	extractor.RegisterExtractor(NewExtractor())
+}
+
+// GleamToml represents the fields of a gleam.toml file used for metadata.
+// Dependency values are a version requirement string for Hex packages or
+// a table ({ path = "..." }, { git = "..." }) for local and git sources.
+type GleamToml struct {
+	Name            string                 `toml:"name"`
+	Version         string                 `toml:"version"`
+	Description     string                 `toml:"description"`
+	Licences        []string               `toml:"licences"`
+	Target          string                 `toml:"target"`
+	Gleam           string                 `toml:"gleam"`
+	Dependencies    map[string]interface{} `toml:"dependencies"`
+	DevDependencies map[string]interface{} `toml:"dev-dependencies"`
+}
+
+// Detect checks if this is a Gleam project
+func (e *Extractor) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, "gleam.toml"))
+	return err == nil
+}
+
+// Extract retrieves metadata from a Gleam project
+func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, error) {
+	metadata := &extractor.ProjectMetadata{
+		LanguageSpecific: make(map[string]interface{}),
+	}
+
+	path := filepath.Join(projectPath, "gleam.toml")
+	if _, err := os.Stat(path); err != nil {
+		return nil, extractor.WithCode(extractor.ErrNoManifest, fmt.Errorf("gleam.toml not found in %s", projectPath))
+	}
+
+	var project GleamToml
+	if _, err := toml.DecodeFile(path, &project); err != nil {
+		return nil, extractor.NewManifestError(path, nil, err)
+	}
+	metadata.AddConsumedFile(path)
+
+	metadata.Name = project.Name
+	metadata.Description = project.Description
+	if project.Version != "" {
+		metadata.Version = project.Version
+		metadata.VersionSource = "gleam.toml"
+	}
+	// Hex lets a package offer several licences; any one may be chosen
+	if len(project.Licences) > 0 {
+		metadata.License = strings.Join(project.Licences, " OR ")
+	}
+
+	ls := metadata.LanguageSpecific
+	ls["package_name"] = project.Name
+	ls["metadata_source"] = "gleam.toml"
+	ls["build_tool"] = "gleam"
+	if len(project.Licences) > 0 {
+		ls["licences"] = project.Licences
+	}
+	// Gleam compiles to Erlang unless the target says otherwise
+	target := project.Target
+	if target == "" {
+		target = "erlang"
+	}
+	ls["target"] = target
+	if project.Gleam != "" {
+		ls["gleam_version"] = project.Gleam
+	}
+
+	if len(project.Dependencies) > 0 {
+		ls["dependencies"] = dependencyRequirements(project.Dependencies)
+		ls["dependency_count"] = len(project.Dependencies)
+	}
+	if len(project.DevDependencies) > 0 {
+		ls["dev_dependencies"] = dependencyRequirements(project.DevDependencies)
+	}
+	extractor.SetDependencyCounts(metadata, extractor.DependencyCounts{
+		Runtime: len(project.Dependencies),
+		Dev:     len(project.DevDependencies),
+	})
+
+	return metadata, nil
+}
+
+// dependencyRequirements maps each dependency to its version requirement.
+// Path and git dependencies have no requirement and are recorded by
+// their source ("path:../lib", "git:https://...").
+func dependencyRequirements(deps map[string]interface{}) map[string]string {
+	requirements := make(map[string]string, len(deps))
+	for name, value := range deps {
+		switch v := value.(type) {
+		case string:
+			requirements[name] = v
+		case map[string]interface{}:
+			if version, ok := v["version"].(string); ok {
+				requirements[name] = version
+			} else if path, ok := v["path"].(string); ok {
+				requirements[name] = "path:" + path
+			} else if git, ok := v["git"].(string); ok {
+				requirements[name] = "git:" + git
+			} else {
+				requirements[name] = ""
+			}
+		}
+	}
+	return requirements
+}
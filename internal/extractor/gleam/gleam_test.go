@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package gleam
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleGleamToml = `name = "wisp_app"
+version = "1.2.0"
+description = "A web application written in Gleam"
+licences = ["Apache-2.0", "MIT"]
+gleam = ">= 1.0.0"
+
+[dependencies]
+gleam_stdlib = ">= 0.34.0 and < 2.0.0"
+wisp = "~> 1.0"
+shared = { path = "../shared" }
+
+[dev-dependencies]
+gleeunit = ">= 1.0.0 and < 2.0.0"
+`
+
+func writeGleamToml(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gleam.toml"), []byte(content), 0644))
+	return dir
+}
+
+func TestNewExtractor(t *testing.T) {
+	e := NewExtractor()
+	assert.Equal(t, "gleam", e.Name())
+	assert.Equal(t, 1, e.Priority())
+}
+
+func TestDetect(t *testing.T) {
+	e := NewExtractor()
+	assert.True(t, e.Detect(writeGleamToml(t, sampleGleamToml)))
+	assert.False(t, e.Detect(t.TempDir()))
+}
+
+func TestExtract(t *testing.T) {
+	metadata, err := NewExtractor().Extract(writeGleamToml(t, sampleGleamToml))
+	require.NoError(t, err)
+
+	assert.Equal(t, "wisp_app", metadata.Name)
+	assert.Equal(t, "1.2.0", metadata.Version)
+	assert.Equal(t, "gleam.toml", metadata.VersionSource)
+	assert.Equal(t, "A web application written in Gleam", metadata.Description)
+	assert.Equal(t, "Apache-2.0 OR MIT", metadata.License)
+
+	ls := metadata.LanguageSpecific
+	assert.Equal(t, "gleam", ls["build_tool"])
+	assert.Equal(t, "erlang", ls["target"])
+	assert.Equal(t, ">= 1.0.0", ls["gleam_version"])
+	assert.Equal(t, []string{"Apache-2.0", "MIT"}, ls["licences"])
+	assert.Equal(t, map[string]string{
+		"gleam_stdlib": ">= 0.34.0 and < 2.0.0",
+		"wisp":         "~> 1.0",
+		"shared":       "path:../shared",
+	}, ls["dependencies"])
+	assert.Equal(t, map[string]string{"gleeunit": ">= 1.0.0 and < 2.0.0"}, ls["dev_dependencies"])
+	assert.Equal(t, 3, ls["dependency_count"])
+	assert.Equal(t, 3, ls["runtime_dependency_count"])
+	assert.Equal(t, 1, ls["dev_dependency_count"])
+	assert.Equal(t, 4, ls["total_dependency_count"])
+}
+
+func TestExtractMinimal(t *testing.T) {
+	metadata, err := NewExtractor().Extract(writeGleamToml(t, "name = \"tiny\"\ntarget = \"javascript\"\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "tiny", metadata.Name)
+	assert.Empty(t, metadata.Version)
+	assert.Empty(t, metadata.License)
+	assert.Equal(t, "javascript", metadata.LanguageSpecific["target"])
+	assert.NotContains(t, metadata.LanguageSpecific, "dependencies")
+	assert.Equal(t, 0, metadata.LanguageSpecific["total_dependency_count"])
+}
+
+func TestExtractErrors(t *testing.T) {
+	_, err := NewExtractor().Extract(t.TempDir())
+	assert.True(t, errors.Is(err, extractor.ErrNoManifest))
+
+	_, err = NewExtractor().Extract(writeGleamToml(t, "name = \n"))
+	assert.True(t, errors.Is(err, extractor.ErrParseFailure))
+}
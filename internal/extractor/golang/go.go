@@ -6,6 +6,8 @@ package golang
 import (
 	"bufio"
 	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -13,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/lfreleng-actions/build-metadata-action/internal/toolversions"
 )
 
 // Extractor extracts metadata from Go projects
@@ -76,7 +79,9 @@ func (e *Extractor) extractFromGoMod(path string, metadata *extractor.ProjectMet
 	if err != nil {
 		return fmt.Errorf("failed to parse go.mod: %w", err)
 	}
+	metadata.AddConsumedFile(path)
 
+	applyGoToolVersions(filepath.Dir(path), goMod, metadata)
 	applyGoModuleMetadata(goMod, metadata)
 	applyGoDependencies(goMod, metadata)
 
@@ -87,10 +92,62 @@ func (e *Extractor) extractFromGoMod(path string, metadata *extractor.ProjectMet
 
 	applyGoVersionMatrix(goMod, metadata)
 	applyGoProjectVersion(path, metadata)
+	applyGoArtifactKind(filepath.Dir(path), metadata)
 
 	return nil
 }
 
+// applyGoArtifactKind classifies the module by the package in its root:
+// package main builds an application, any other package a library. Main
+// packages under cmd/ add an application to a library root, and classify
+// a module with no root package on their own.
+func applyGoArtifactKind(modulePath string, metadata *extractor.ProjectMetadata) {
+	rootPackage := goPackageName(modulePath)
+	library := rootPackage != "" && rootPackage != "main"
+	application := rootPackage == "main"
+
+	if entries, err := os.ReadDir(filepath.Join(modulePath, "cmd")); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && goPackageName(filepath.Join(modulePath, "cmd", entry.Name())) == "main" {
+				application = true
+				break
+			}
+		}
+	}
+	metadata.ArtifactKind = extractor.ClassifyArtifact(library, application)
+}
+
+// goPackageName returns the package declared by the first non-test Go
+// file in dir, or "" when it has none.
+func goPackageName(dir string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return parsed.Name.Name
+		}
+	}
+	return ""
+}
+
+// applyGoToolVersions records where go_version comes from. A go.mod
+// without a go directive falls back to the golang entry of an asdf
+// .tool-versions file, which then also drives the version matrix.
+func applyGoToolVersions(dir string, goMod *GoMod, metadata *extractor.ProjectMetadata) {
+	if goMod.GoVersion != "" {
+		metadata.LanguageSpecific["go_version_source"] = "go.mod"
+		return
+	}
+	if version, ok := toolversions.Lookup(dir, "golang", "go"); ok {
+		goMod.GoVersion = version
+		metadata.LanguageSpecific["go_version_source"] = toolversions.FileName
+		metadata.AddConsumedFile(filepath.Join(dir, toolversions.FileName))
+	}
+}
+
 func applyGoModuleMetadata(goMod *GoMod, metadata *extractor.ProjectMetadata) {
 	metadata.Name = goMod.Module
 	metadata.VersionSource = "go.mod"
@@ -179,7 +236,7 @@ func applyGoVersionMatrix(goMod *GoMod, metadata *extractor.ProjectMetadata) {
 // rejects bare major-version markers (e.g. "v2") that are module path
 // components rather than real releases, leaving those to git tag fallback.
 func applyGoProjectVersion(path string, metadata *extractor.ProjectMetadata) {
-	version := extractVersionFromProject(filepath.Dir(path))
+	version, source := extractVersionFromProject(filepath.Dir(path))
 	if version == "" {
 		return
 	}
@@ -189,6 +246,7 @@ func applyGoProjectVersion(path string, metadata *extractor.ProjectMetadata) {
 		return
 	}
 
+	metadata.AddConsumedFile(source)
 	metadata.Version = version
 	metadata.VersionSource = "version file or git tag"
 }
@@ -442,14 +500,15 @@ func detectGoFrameworks(deps []Dependency) []string {
 // generateGoVersionMatrix lives in versions.go; it derives the matrix
 // from the EOL-aware supported Go version set.
 
-// extractVersionFromProject tries to extract version from common patterns
-func extractVersionFromProject(projectPath string) string {
+// extractVersionFromProject tries to extract version from common patterns,
+// returning it with the file it was read from
+func extractVersionFromProject(projectPath string) (string, string) {
 	// Try VERSION file
 	versionPath := filepath.Join(projectPath, "VERSION")
 	if content, err := os.ReadFile(versionPath); err == nil {
 		version := strings.TrimSpace(string(content))
 		if version != "" {
-			return version
+			return version, versionPath
 		}
 	}
 
@@ -486,12 +545,12 @@ func extractVersionFromProject(projectPath string) string {
 			file.Close()
 
 			if found {
-				return version
+				return version, match
 			}
 		}
 	}
 
-	return ""
+	return "", ""
 }
 
 // Detect checks if this extractor can handle the project
@@ -742,3 +742,101 @@ go 1.21
 		})
 	}
 }
+
+// TestArtifactKind verifies the classification from the root package
+// and main packages under cmd/
+func TestArtifactKind(t *testing.T) {
+	goMod := "module github.com/example/project\n\ngo 1.21\n"
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name:     "library package",
+			files:    map[string]string{"go.mod": goMod, "project.go": "package project\n", "main_test.go": "package main\n"},
+			expected: "library",
+		},
+		{
+			name:     "main package",
+			files:    map[string]string{"go.mod": goMod, "main.go": "// Command project\npackage main\n"},
+			expected: "application",
+		},
+		{
+			name:     "library with a command",
+			files:    map[string]string{"go.mod": goMod, "project.go": "package project\n", "cmd/project/main.go": "package main\n"},
+			expected: "both",
+		},
+		{
+			name:     "no sources",
+			files:    map[string]string{"go.mod": goMod},
+			expected: "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for path, content := range tt.files {
+				fullPath := filepath.Join(tmpDir, path)
+				if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+					t.Fatalf("Failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if metadata.ArtifactKind != tt.expected {
+				t.Errorf("ArtifactKind = %q, expected %q", metadata.ArtifactKind, tt.expected)
+			}
+		})
+	}
+}
+
+// TestGoVersionFromToolVersions verifies a go.mod without a go directive
+// falls back to the golang entry of .tool-versions
+func TestGoVersionFromToolVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		goMod      string
+		wantSource string
+		wantGo     string
+	}{
+		{"directive wins", "module example.com/app\n\ngo 1.22\n", "go.mod", "1.22"},
+		{"fallback", "module example.com/app\n", ".tool-versions", "1.23.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			files := map[string]string{
+				"go.mod":         tt.goMod,
+				".tool-versions": "golang 1.23.4 1.22.10\nnodejs 20.11.0\n",
+			}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if got := metadata.LanguageSpecific["go_version"]; got != tt.wantGo {
+				t.Errorf("go_version = %v, expected %s", got, tt.wantGo)
+			}
+			if got := metadata.LanguageSpecific["go_version_source"]; got != tt.wantSource {
+				t.Errorf("go_version_source = %v, expected %s", got, tt.wantSource)
+			}
+			if _, ok := metadata.LanguageSpecific["go_version_matrix"]; !ok {
+				t.Error("go_version_matrix not set")
+			}
+		})
+	}
+}
@@ -5,9 +5,9 @@ package golang
 
 import (
 	"fmt"
-	"os"
 	"sort"
 
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 	"github.com/lfreleng-actions/build-metadata-action/internal/goversions"
 )
 
@@ -48,12 +48,12 @@ func ResolveSupportedVersions() []string {
 	client := goversions.NewEOLClient(goversions.DefaultTimeout, goversions.DefaultMaxRetries)
 	supported, err := client.GetSupportedVersions()
 	if err != nil {
-		fmt.Fprintf(os.Stderr,
+		extractor.Warnf(extractor.WarnEOLFallback,
 			"[WARNING] Failed to fetch live Go EOL data (%v); using static supported set\n", err)
 		return goversions.GetFallbackVersions()
 	}
 	if len(supported) == 0 {
-		fmt.Fprintf(os.Stderr,
+		extractor.Warnf(extractor.WarnEOLFallback,
 			"[WARNING] Live Go EOL data yielded no supported versions at or above %s; using static supported set\n",
 			goversions.Baseline())
 		return goversions.GetFallbackVersions()
@@ -5,6 +5,7 @@ package haskell
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -77,13 +78,15 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 	stackPath := filepath.Join(projectPath, "stack.yaml")
 	if _, err := os.Stat(stackPath); err == nil {
 		e.extractFromStack(stackPath, metadata)
-		if metadata.LanguageSpecific["build_tool"] == "" {
-			metadata.LanguageSpecific["build_tool"] = "Stack"
-		} else {
+		if _, hasCabal := metadata.LanguageSpecific["build_tool"]; hasCabal {
 			metadata.LanguageSpecific["build_tool"] = "Stack + Cabal"
+		} else {
+			metadata.LanguageSpecific["build_tool"] = "Stack"
 		}
 	}
 
+	applyGHCVersionMatrix(metadata)
+
 	// Check for package.yaml (hpack)
 	packageYamlPath := filepath.Join(projectPath, "package.yaml")
 	if _, err := os.Stat(packageYamlPath); err == nil {
@@ -93,8 +96,35 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 	return metadata, nil
 }
 
-// cabalBuildDependsRegex matches the opening line of a build-depends stanza.
-var cabalBuildDependsRegex = regexp.MustCompile(`(?i)^build-depends:\s*(.+)$`)
+var (
+	// cabalBuildDependsRegex matches the opening line of a build-depends stanza.
+	cabalBuildDependsRegex = regexp.MustCompile(`(?i)^build-depends:\s*(.*)$`)
+	// cabalFieldRegex matches any "field:" line, which ends a multi-line
+	// build-depends stanza even when it is indented inside a component
+	cabalFieldRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*:`)
+	// stackResolverRegex matches the stack.yaml snapshot; newer Stack
+	// releases spell the key "snapshot"
+	stackResolverRegex = regexp.MustCompile(`^(?:resolver|snapshot):\s*(.+)$`)
+	// testedWithGHCRegex matches each GHC version named in tested-with
+	testedWithGHCRegex = regexp.MustCompile(`(?i)GHC\s*==\s*([0-9]+\.[0-9]+(?:\.[0-9]+)?)`)
+)
+
+// ltsToGHC maps Stackage LTS major series to the GHC release they ship.
+var ltsToGHC = map[string]string{
+	"lts-24": "9.10.2",
+	"lts-23": "9.8.4",
+	"lts-22": "9.6.4",
+	"lts-21": "9.4.8",
+	"lts-20": "9.2.8",
+	"lts-19": "9.0.2",
+	"lts-18": "8.10.7",
+	"lts-17": "8.10.4",
+	"lts-16": "8.8.4",
+}
+
+// ghcSeries lists the GHC major.minor series the version matrix is
+// drawn from, oldest first.
+var ghcSeries = []string{"8.8", "8.10", "9.0", "9.2", "9.4", "9.6", "9.8", "9.10", "9.12"}
 
 // cabalMatcher pairs a single-value field regex with the assignment it drives.
 type cabalMatcher struct {
@@ -144,14 +174,14 @@ func appendBuildDepends(line, trimmed string, inBuildDepends bool, dependencies
 		*dependencies = append(*dependencies, parseDependencies(strings.TrimSpace(matches[1]))...)
 		return true
 	}
-	if inBuildDepends && strings.HasPrefix(line, " ") {
+	if !inBuildDepends || trimmed == "" {
+		return inBuildDepends
+	}
+	if strings.HasPrefix(line, " ") && !cabalFieldRegex.MatchString(trimmed) {
 		*dependencies = append(*dependencies, parseDependencies(trimmed)...)
 		return true
 	}
-	if inBuildDepends {
-		return false
-	}
-	return inBuildDepends
+	return false
 }
 
 // extractFromCabal parses a .cabal file
@@ -161,6 +191,7 @@ func (e *Extractor) extractFromCabal(path string, metadata *extractor.ProjectMet
 		return err
 	}
 	defer file.Close()
+	metadata.AddConsumedFile(path)
 
 	var authors []string
 	var dependencies []string
@@ -177,9 +208,13 @@ func (e *Extractor) extractFromCabal(path string, metadata *extractor.ProjectMet
 			continue
 		}
 
-		for _, matcher := range matchers {
-			if matches := matcher.re.FindStringSubmatch(trimmed); matches != nil {
-				matcher.assign(strings.TrimSpace(matches[1]))
+		// Top-level fields describe the package; indented ones belong to
+		// a library/executable component and must not override them
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			for _, matcher := range matchers {
+				if matches := matcher.re.FindStringSubmatch(trimmed); matches != nil {
+					matcher.assign(strings.TrimSpace(matches[1]))
+				}
 			}
 		}
 
@@ -194,7 +229,8 @@ func (e *Extractor) extractFromCabal(path string, metadata *extractor.ProjectMet
 		metadata.Authors = authors
 	}
 
-	if len(dependencies) > 0 {
+	// Components commonly repeat the same packages, so report each once
+	if dependencies = uniqueStrings(dependencies); len(dependencies) > 0 {
 		metadata.LanguageSpecific["dependencies"] = dependencies
 		metadata.LanguageSpecific["dependency_count"] = len(dependencies)
 	}
@@ -202,6 +238,19 @@ func (e *Extractor) extractFromCabal(path string, metadata *extractor.ProjectMet
 	return nil
 }
 
+// uniqueStrings drops repeated values, keeping first-seen order.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
 // extractFromStack parses stack.yaml
 func (e *Extractor) extractFromStack(path string, metadata *extractor.ProjectMetadata) error {
 	file, err := os.Open(path)
@@ -209,21 +258,22 @@ func (e *Extractor) extractFromStack(path string, metadata *extractor.ProjectMet
 		return err
 	}
 	defer file.Close()
+	metadata.AddConsumedFile(path)
 
 	scanner := bufio.NewScanner(file)
 
-	resolverRegex := regexp.MustCompile(`^resolver:\s*(.+)$`)
-
 	for scanner.Scan() {
 		line := scanner.Text()
-		trimmed := strings.TrimSpace(line)
 
-		if strings.HasPrefix(trimmed, "#") {
+		// Only the top-level key names the snapshot; nested ones (e.g.
+		// under a custom snapshot file) are ignored
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(strings.TrimSpace(line), "#") {
 			continue
 		}
 
-		if matches := resolverRegex.FindStringSubmatch(trimmed); matches != nil {
-			resolver := strings.TrimSpace(matches[1])
+		if matches := stackResolverRegex.FindStringSubmatch(line); matches != nil {
+			resolver := strings.Trim(stripYAMLComment(matches[1]), `"'`)
+			metadata.LanguageSpecific["resolver"] = resolver
 			metadata.LanguageSpecific["stack_resolver"] = resolver
 
 			// Extract GHC version from resolver (e.g., lts-21.22 -> GHC 9.4.8)
@@ -236,6 +286,14 @@ func (e *Extractor) extractFromStack(path string, metadata *extractor.ProjectMet
 	return scanner.Err()
 }
 
+// stripYAMLComment removes a trailing "# comment" from a scalar value.
+func stripYAMLComment(value string) string {
+	if index := strings.Index(value, " #"); index >= 0 {
+		value = value[:index]
+	}
+	return strings.TrimSpace(value)
+}
+
 // extractFromPackageYaml parses package.yaml (hpack format)
 func (e *Extractor) extractFromPackageYaml(path string, metadata *extractor.ProjectMetadata) error {
 	file, err := os.Open(path)
@@ -243,6 +301,7 @@ func (e *Extractor) extractFromPackageYaml(path string, metadata *extractor.Proj
 		return err
 	}
 	defer file.Close()
+	metadata.AddConsumedFile(path)
 
 	scanner := bufio.NewScanner(file)
 
@@ -305,21 +364,17 @@ func parseDependencies(line string) []string {
 	return deps
 }
 
-// extractGHCVersionFromResolver attempts to extract GHC version from Stack resolver
+// extractGHCVersionFromResolver maps a Stack resolver to the GHC version
+// it provides: LTS snapshots through the ltsToGHC table, and compiler
+// resolvers such as "ghc-9.6.4" directly.
 func extractGHCVersionFromResolver(resolver string) string {
-	// Map common LTS versions to GHC versions
-	ltsToGHC := map[string]string{
-		"lts-22": "9.6.4",
-		"lts-21": "9.4.8",
-		"lts-20": "9.2.8",
-		"lts-19": "9.0.2",
-		"lts-18": "8.10.7",
-	}
-
-	for lts, ghc := range ltsToGHC {
-		if strings.HasPrefix(resolver, lts) {
-			return ghc
-		}
+	if strings.HasPrefix(resolver, "lts-") {
+		series, _, _ := strings.Cut(resolver, ".")
+		return ltsToGHC[series]
+	}
+
+	if version, ok := strings.CutPrefix(resolver, "ghc-"); ok {
+		return version
 	}
 
 	// Try to extract from nightly
@@ -330,7 +385,57 @@ func extractGHCVersionFromResolver(resolver string) string {
 	return ""
 }
 
-// generateGHCVersionMatrix generates a matrix of GHC versions
+// applyGHCVersionMatrix records the GHC versions to test against, with
+// the matrix as JSON ready for a workflow matrix strategy. The cabal
+// tested-with field is authoritative; otherwise the matrix starts at the
+// resolver's GHC series.
+func applyGHCVersionMatrix(metadata *extractor.ProjectMetadata) {
+	var matrix []string
+	if testedWith, ok := metadata.LanguageSpecific["tested_with"].(string); ok {
+		for _, matches := range testedWithGHCRegex.FindAllStringSubmatch(testedWith, -1) {
+			matrix = append(matrix, matches[1])
+		}
+		matrix = uniqueStrings(matrix)
+	}
+	if len(matrix) == 0 {
+		ghcVersion, _ := metadata.LanguageSpecific["ghc_version"].(string)
+		matrix = generateGHCVersionMatrix(ghcVersion)
+	}
+	if len(matrix) == 0 {
+		return
+	}
+
+	metadata.LanguageSpecific["ghc_version_matrix"] = matrix
+	metadata.LanguageSpecific["matrix_json"] = fmt.Sprintf(`{"ghc-version": [%s]}`,
+		strings.Join(quoteStrings(matrix), ", "))
+}
+
+// generateGHCVersionMatrix returns the GHC series of ghcVersion and the
+// one after it, so CI also covers the next compiler upgrade.
 func generateGHCVersionMatrix(ghcVersion string) []string {
-	return []string{"9.4", "9.6", "9.8"}
+	parts := strings.Split(ghcVersion, ".")
+	if len(parts) < 2 {
+		return nil
+	}
+	series := parts[0] + "." + parts[1]
+
+	for i, known := range ghcSeries {
+		if known != series {
+			continue
+		}
+		if i+1 < len(ghcSeries) {
+			return []string{series, ghcSeries[i+1]}
+		}
+		return []string{series}
+	}
+	return []string{series}
+}
+
+// quoteStrings adds quotes around each string
+func quoteStrings(strs []string) []string {
+	quoted := make([]string, len(strs))
+	for i, s := range strs {
+		quoted[i] = fmt.Sprintf(`"%s"`, s)
+	}
+	return quoted
 }
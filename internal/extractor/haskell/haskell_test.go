@@ -279,6 +279,21 @@ func TestExtractGHCVersionFromResolver(t *testing.T) {
 			resolver: "lts-20.26",
 			expected: "9.2.8",
 		},
+		{
+			name:     "lts-23",
+			resolver: "lts-23.5",
+			expected: "9.8.4",
+		},
+		{
+			name:     "lts-2 does not match lts-22",
+			resolver: "lts-2.22",
+			expected: "",
+		},
+		{
+			name:     "compiler resolver",
+			resolver: "ghc-9.8.2",
+			expected: "9.8.2",
+		},
 		{
 			name:     "nightly",
 			resolver: "nightly-2024-01-01",
@@ -298,3 +313,79 @@ func TestExtractGHCVersionFromResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractStackResolver(t *testing.T) {
+	stackContent := `# Stack configuration
+resolver: lts-22.0 # pinned for CI
+packages:
+- .
+extra-deps:
+- acme-missiles-0.3
+`
+
+	tmpDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tmpDir, "stack.yaml"), []byte(stackContent), 0644)
+	require.NoError(t, err)
+
+	e := NewExtractor()
+	metadata, err := e.Extract(tmpDir)
+	require.NoError(t, err)
+	require.NotNil(t, metadata)
+
+	assert.Equal(t, "Stack", metadata.LanguageSpecific["build_tool"])
+	assert.Equal(t, "lts-22.0", metadata.LanguageSpecific["resolver"])
+	assert.Equal(t, "lts-22.0", metadata.LanguageSpecific["stack_resolver"])
+	assert.Equal(t, "9.6.4", metadata.LanguageSpecific["ghc_version"])
+	assert.Equal(t, []string{"9.6", "9.8"}, metadata.LanguageSpecific["ghc_version_matrix"])
+	assert.Equal(t, `{"ghc-version": ["9.6", "9.8"]}`, metadata.LanguageSpecific["matrix_json"])
+}
+
+func TestExtractStackSnapshotKey(t *testing.T) {
+	tmpDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tmpDir, "stack.yaml"), []byte("snapshot: lts-23.5\n"), 0644)
+	require.NoError(t, err)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "lts-23.5", metadata.LanguageSpecific["resolver"])
+	assert.Equal(t, "9.8.4", metadata.LanguageSpecific["ghc_version"])
+}
+
+func TestExtractCabalWithComponentDependencies(t *testing.T) {
+	cabalContent := `cabal-version:  2.4
+name:           web-service
+version:        0.4.1
+license:        MIT
+tested-with:    GHC == 9.4.8, GHC == 9.6.4
+
+library
+  exposed-modules:  Service
+  build-depends:
+      base >= 4.7 && < 5
+    , text >= 1.2
+    , aeson
+  hs-source-dirs:   src
+  default-language: Haskell2010
+
+executable web-service
+  main-is:          Main.hs
+  build-depends:    base, web-service, text
+  hs-source-dirs:   app
+`
+
+	tmpDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tmpDir, "web-service.cabal"), []byte(cabalContent), 0644)
+	require.NoError(t, err)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "web-service", metadata.Name)
+	assert.Equal(t, "0.4.1", metadata.Version)
+	assert.Equal(t, "MIT", metadata.License)
+	assert.Equal(t, []string{"text", "aeson", "web-service"}, metadata.LanguageSpecific["dependencies"])
+	assert.Equal(t, 3, metadata.LanguageSpecific["dependency_count"])
+	assert.Equal(t, []string{"9.4.8", "9.6.4"}, metadata.LanguageSpecific["ghc_version_matrix"])
+	assert.Equal(t, `{"ghc-version": ["9.4.8", "9.6.4"]}`, metadata.LanguageSpecific["matrix_json"])
+}
@@ -91,8 +91,9 @@ func (e *Extractor) extractFromChartYAML(path string, metadata *extractor.Projec
 
 	var chart ChartYAML
 	if err := yaml.Unmarshal(content, &chart); err != nil {
-		return fmt.Errorf("failed to parse Chart.yaml: %w", err)
+		return fmt.Errorf("failed to parse Chart.yaml: %w", extractor.NewManifestError(path, content, err))
 	}
+	metadata.AddConsumedFile(path)
 
 	metadata.Name = chart.Name
 	metadata.Version = chart.Version
@@ -107,10 +108,94 @@ func (e *Extractor) extractFromChartYAML(path string, metadata *extractor.Projec
 	}
 
 	applyChartLanguageSpecific(chart, metadata)
+	applyHelmOCIAnnotations(filepath.Dir(path), chart, metadata)
+	applyHelmImageReference(filepath.Dir(path), chart, metadata)
 
 	return nil
 }
 
+// applyHelmOCIAnnotations normalizes the OCI image annotations declared in
+// Chart.yaml "annotations" and anywhere in values.yaml (e.g.
+// podAnnotations) into "oci_annotations". Chart.yaml takes precedence.
+func applyHelmOCIAnnotations(chartDir string, chart ChartYAML, metadata *extractor.ProjectMetadata) {
+	annotations := make(map[string]string)
+	extractor.MergeOCIAnnotations(annotations, chart.Annotations)
+
+	if values, ok := readValues(chartDir, metadata); ok {
+		extractor.MergeOCIAnnotations(annotations, extractor.FindOCIAnnotationLabels(values))
+	}
+
+	extractor.SetOCIAnnotations(metadata, annotations)
+}
+
+// readValues returns the decoded values.yaml of the chart, recording it
+// as consumed by metadata.
+func readValues(chartDir string, metadata *extractor.ProjectMetadata) (interface{}, bool) {
+	path := filepath.Join(chartDir, "values.yaml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var values interface{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, false
+	}
+	metadata.AddConsumedFile(path)
+	return values, true
+}
+
+// applyHelmImageReference splits the top-level values.yaml "image" into
+// image_registry, image_repository and image_tag. Both the string form
+// and the usual registry/repository/tag/digest map are read; an empty
+// tag falls back to appVersion, as the default chart templates do.
+func applyHelmImageReference(chartDir string, chart ChartYAML, metadata *extractor.ProjectMetadata) {
+	values, ok := readValues(chartDir, metadata)
+	if !ok {
+		return
+	}
+	document, _ := values.(map[string]interface{})
+
+	var ref string
+	switch image := document["image"].(type) {
+	case string:
+		ref = image
+	case map[string]interface{}:
+		ref = stringValue(image["repository"])
+		if ref == "" {
+			return
+		}
+		if registry := stringValue(image["registry"]); registry != "" {
+			ref = registry + "/" + ref
+		}
+		tag := stringValue(image["tag"])
+		if tag == "" {
+			tag = chart.AppVersion
+		}
+		if tag != "" {
+			ref += ":" + tag
+		}
+		if digest := stringValue(image["digest"]); digest != "" {
+			ref += "@" + digest
+		}
+	}
+
+	if image, ok := extractor.ParseImageReference(ref); ok {
+		extractor.SetImageReference(metadata, image, "values.yaml")
+	}
+}
+
+// stringValue formats a scalar YAML value (tags are often unquoted
+// numbers), returning "" for nil and non-scalars.
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int, float64, bool:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
 // chartAuthors formats maintainers as "Name <email>" (or just "Name").
 func chartAuthors(chart ChartYAML) []string {
 	authors := make([]string, 0, len(chart.Maintainers))
@@ -350,6 +350,36 @@ annotations:
 	assert.Equal(t, "Apache-2.0", annotationsMap["licenses"])
 }
 
+func TestExtractor_Extract_OCIAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	chartContent := `apiVersion: v2
+name: annotated-chart
+version: 1.0.0
+annotations:
+  org.opencontainers.image.title: annotated-chart
+  org.opencontainers.image.source: https://github.com/example/chart`
+	valuesContent := `image:
+  repository: ghcr.io/example/app
+podAnnotations:
+  org.opencontainers.image.version: "3.1.0"
+  org.opencontainers.image.revision: 0123abc
+  org.opencontainers.image.source: https://github.com/example/values
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(chartContent), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "values.yaml"), []byte(valuesContent), 0644))
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	// Chart.yaml annotations take precedence over values.yaml
+	assert.Equal(t, map[string]string{
+		"title":    "annotated-chart",
+		"source":   "https://github.com/example/chart",
+		"version":  "3.1.0",
+		"revision": "0123abc",
+	}, metadata.LanguageSpecific["oci_annotations"])
+}
+
 func TestExtractor_Extract_DeprecatedChart(t *testing.T) {
 	dir := t.TempDir()
 	chartPath := filepath.Join(dir, "Chart.yaml")
@@ -368,3 +398,29 @@ deprecated: true`
 
 	assert.Equal(t, true, metadata.LanguageSpecific["deprecated"])
 }
+
+func TestExtractor_Extract_ImageReference(t *testing.T) {
+	dir := t.TempDir()
+	chartContent := `apiVersion: v2
+name: app
+version: 0.3.0
+appVersion: "1.8.2"`
+	valuesContent := `image:
+  registry: quay.io
+  repository: example/app
+  tag: ""
+  digest: sha256:0123abc
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(chartContent), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "values.yaml"), []byte(valuesContent), 0644))
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	// An empty tag falls back to appVersion
+	assert.Equal(t, "quay.io", metadata.LanguageSpecific["image_registry"])
+	assert.Equal(t, "example/app", metadata.LanguageSpecific["image_repository"])
+	assert.Equal(t, "1.8.2", metadata.LanguageSpecific["image_tag"])
+	assert.Equal(t, "sha256:0123abc", metadata.LanguageSpecific["image_digest"])
+	assert.Equal(t, "values.yaml", metadata.LanguageSpecific["image_source"])
+}
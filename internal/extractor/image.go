@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import "strings"
+
+// DefaultImageRegistry is the registry of an image reference that names
+// none, as the Docker CLI resolves it
+const DefaultImageRegistry = "docker.io"
+
+// ImageReference is a container image reference split into its parts,
+// e.g. "ghcr.io/org/app:1.2.3" or "nginx@sha256:..."
+type ImageReference struct {
+	Registry   string
+	Repository string
+	// Tag is "latest" when the reference has neither tag nor digest
+	Tag    string
+	Digest string
+}
+
+// ParseImageReference splits ref the way the Docker CLI resolves it: the
+// first path component is the registry only when it looks like a host
+// (contains "." or ":", or is "localhost"), otherwise the registry is
+// docker.io, where single-component names live under "library/". It
+// returns false for an empty reference, "scratch", or a reference still
+// holding an unexpanded ${VARIABLE}.
+func ParseImageReference(ref string) (ImageReference, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || ref == "scratch" || strings.Contains(ref, "$") {
+		return ImageReference{}, false
+	}
+
+	var image ImageReference
+	name, digest, hasDigest := strings.Cut(ref, "@")
+	if hasDigest {
+		image.Digest = digest
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, image.Tag = name[:i], name[i+1:]
+	}
+	if image.Tag == "" && !hasDigest {
+		image.Tag = "latest"
+	}
+
+	image.Registry = DefaultImageRegistry
+	if host, rest, found := strings.Cut(name, "/"); found &&
+		(strings.ContainsAny(host, ".:") || host == "localhost") {
+		image.Registry, name = host, rest
+	}
+	if image.Registry == DefaultImageRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	image.Repository = name
+	if image.Repository == "" || image.Repository == "library/" {
+		return ImageReference{}, false
+	}
+	return image, true
+}
+
+// SetImageReference records image_registry, image_repository and
+// image_tag (plus image_digest when pinned) in LanguageSpecific, with
+// image_source naming where the reference was found.
+func SetImageReference(metadata *ProjectMetadata, image ImageReference, source string) {
+	if metadata.LanguageSpecific == nil {
+		metadata.LanguageSpecific = make(map[string]interface{})
+	}
+	ls := metadata.LanguageSpecific
+	ls["image_registry"] = image.Registry
+	ls["image_repository"] = image.Repository
+	ls["image_tag"] = image.Tag
+	if image.Digest != "" {
+		ls["image_digest"] = image.Digest
+	}
+	ls["image_source"] = source
+}
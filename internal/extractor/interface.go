@@ -18,6 +18,20 @@ type ProjectMetadata struct {
 	Authors       []string
 	Homepage      string
 	Repository    string
+	// ArtifactKind is one of the ArtifactKind* values; extractors that
+	// cannot tell leave it empty
+	ArtifactKind string
+	// AuthorsStructured holds the authors split into name and email,
+	// from the same source data as Authors
+	AuthorsStructured []Author
+	// ConsumedFiles lists the files the extraction read, recorded with
+	// AddConsumedFile; extractors that do not track them leave it empty
+	ConsumedFiles []string
+	// Publishable reports whether the manifest allows publishing to
+	// PublishTarget, one of the PublishTarget* values; both are set with
+	// SetPublishable, and extractors that cannot tell leave them unset
+	Publishable   *bool
+	PublishTarget string
 
 	// Language-specific metadata stored as key-value pairs
 	// Keys should be namespaced by language (e.g., "python_requires_python")
@@ -142,7 +156,7 @@ func mapProjectTypeToExtractor(projectType string) string {
 		return "elixir"
 	}
 
-	if projectType == "scala-sbt" {
+	if projectType == "scala-sbt" || projectType == "scala-cli" {
 		return "scala"
 	}
 
@@ -4,7 +4,6 @@
 package java
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -44,6 +43,8 @@ type GradleProject struct {
 	// sourceCompatibility, or gradle.properties).
 	JavaVersion       string
 	JavaVersionSource string
+	// JavaVendor is the toolchain vendor, when the build file declares one
+	JavaVendor string
 
 	// Multi-project
 	IsMultiProject bool
@@ -83,6 +84,17 @@ func (e *GradleExtractor) Extract(projectPath string) (*extractor.ProjectMetadat
 	if err != nil {
 		return nil, err
 	}
+	metadata.AddConsumedFile(buildFile)
+	settingsFile := "settings.gradle"
+	if isKotlin {
+		settingsFile += ".kts"
+	}
+	for _, name := range []string{settingsFile, "gradle.properties"} {
+		path := filepath.Join(projectPath, name)
+		if _, err := os.Stat(path); err == nil {
+			metadata.AddConsumedFile(path)
+		}
+	}
 
 	e.parseSettings(projectPath, gradleProject, isKotlin)
 	e.parseProperties(projectPath, gradleProject)
@@ -91,6 +103,8 @@ func (e *GradleExtractor) Extract(projectPath string) (*extractor.ProjectMetadat
 	applyGradleDependencies(gradleProject, metadata)
 	e.applyGradlePlugins(gradleProject, metadata)
 	applyGradleStructure(gradleProject, metadata)
+	applyJavaVersionFile(projectPath, metadata)
+	applyGradleWrapper(projectPath, metadata)
 	applyGradleVersioningType(metadata)
 
 	return metadata, nil
@@ -117,9 +131,12 @@ func (e *GradleExtractor) applyGradleCore(project *GradleProject, metadata *extr
 }
 
 // applyGradleDependencies records the dependency list plus a per-configuration
-// tally (implementation, api, testImplementation, and so on).
+// tally (implementation, api, testImplementation, and so on) and the
+// standardized dependency counts.
 func applyGradleDependencies(project *GradleProject, metadata *extractor.ProjectMetadata) {
+	var counts extractor.DependencyCounts
 	if len(project.Dependencies) == 0 {
+		extractor.SetDependencyCounts(metadata, counts)
 		return
 	}
 	deps := make([]map[string]string, 0, len(project.Dependencies))
@@ -133,10 +150,37 @@ func applyGradleDependencies(project *GradleProject, metadata *extractor.Project
 		}
 		deps = append(deps, depMap)
 		configCounts[dep.Configuration]++
+
+		switch gradleConfigurationKind(dep.Configuration) {
+		case "runtime":
+			counts.Runtime++
+		case "dev":
+			counts.Dev++
+		default:
+			counts.Other++
+		}
 	}
 	metadata.LanguageSpecific["dependencies"] = deps
 	metadata.LanguageSpecific["dependency_count"] = len(deps)
 	metadata.LanguageSpecific["dependency_configurations"] = configCounts
+	extractor.SetDependencyCounts(metadata, counts)
+}
+
+// gradleConfigurationKind classifies a dependency configuration for the
+// standardized counts: configurations on the runtime classpath are
+// "runtime", test and androidTest configurations are "dev", and
+// compile-only, annotation-processor, and other tooling configurations
+// are "other".
+func gradleConfigurationKind(configuration string) string {
+	lower := strings.ToLower(configuration)
+	if strings.HasPrefix(lower, "test") || strings.HasPrefix(lower, "androidtest") {
+		return "dev"
+	}
+	switch lower {
+	case "implementation", "api", "compile", "runtime", "runtimeonly":
+		return "runtime"
+	}
+	return "other"
 }
 
 // applyGradlePlugins records plugin identifiers and any frameworks inferred
@@ -186,6 +230,9 @@ func applyGradleStructure(project *GradleProject, metadata *extractor.ProjectMet
 		metadata.LanguageSpecific["version"] = sourceCompat
 		metadata.LanguageSpecific["version_source"] = "gradle.properties/sourceCompatibility"
 	}
+	if project.JavaVendor != "" {
+		metadata.LanguageSpecific["vendor"] = project.JavaVendor
+	}
 }
 
 // applyGradleVersioningType marks versions dynamic when they are SNAPSHOTs or
@@ -245,6 +292,7 @@ func (e *GradleExtractor) parseGradleBuild(buildFile string, isKotlin bool) (*Gr
 	project.Dependencies = e.extractDependencies(text, isKotlin)
 
 	project.JavaVersion, project.JavaVersionSource = extractGradleJavaVersion(text)
+	project.JavaVendor = extractGradleJavaVendor(text)
 
 	return project, nil
 }
@@ -253,6 +301,11 @@ func (e *GradleExtractor) parseGradleBuild(buildFile string, isKotlin bool) (*Gr
 // languageVersion = JavaLanguageVersion.of(21) or JavaLanguageVersion.of(17).
 var javaLanguageVersionPattern = regexp.MustCompile(`JavaLanguageVersion\.of\((\d+)\)`)
 
+// javaVendorPattern matches a toolchain vendor, either a JvmVendorSpec
+// constant (vendor = JvmVendorSpec.ADOPTIUM, vendor.set(...)) or
+// JvmVendorSpec.matching("name").
+var javaVendorPattern = regexp.MustCompile(`JvmVendorSpec\.(?:matching\(\s*['"]([^'"]+)['"]\s*\)|([A-Z][A-Z0-9_]*))`)
+
 // javaCompatibilityEnumPattern matches source/targetCompatibility set from
 // the JavaVersion enum, e.g. sourceCompatibility = JavaVersion.VERSION_21 or
 // VERSION_1_8. The first capture group records which keyword matched.
@@ -297,6 +350,19 @@ func extractGradleJavaVersion(content string) (string, string) {
 	return "", ""
 }
 
+// extractGradleJavaVendor returns the toolchain vendor of a Gradle build
+// file, lower-cased (e.g. "adoptium"), or "" when none is declared.
+func extractGradleJavaVendor(content string) string {
+	m := javaVendorPattern.FindStringSubmatch(content)
+	if m == nil {
+		return ""
+	}
+	if m[1] != "" {
+		return strings.ToLower(m[1])
+	}
+	return strings.ToLower(m[2])
+}
+
 // extractGradleProperty extracts a property value from Gradle build file
 func (e *GradleExtractor) extractGradleProperty(content, property string, isKotlin bool) string {
 	if isKotlin {
@@ -495,15 +561,13 @@ func (e *GradleExtractor) extractSubprojects(content string, isKotlin bool) []st
 func (e *GradleExtractor) parseProperties(projectPath string, project *GradleProject) {
 	propsFile := filepath.Join(projectPath, "gradle.properties")
 
-	file, err := os.Open(propsFile)
+	data, err := os.ReadFile(propsFile)
 	if err != nil {
 		return // Properties file is optional
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+	for _, line := range strings.Split(extractor.NormalizeNewlines(string(data)), "\n") {
+		line = strings.TrimSpace(line)
 
 		// Skip empty lines and comments
 		if line == "" || strings.HasPrefix(line, "#") {
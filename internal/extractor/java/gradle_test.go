@@ -213,6 +213,17 @@ dependencies {
 	if configCounts["testImplementation"] != 1 {
 		t.Errorf("testImplementation count = %v, want 1", configCounts["testImplementation"])
 	}
+
+	// compileOnly counts towards the total but is neither runtime nor dev
+	for key, want := range map[string]int{
+		"runtime_dependency_count": 2,
+		"dev_dependency_count":     1,
+		"total_dependency_count":   4,
+	} {
+		if got, ok := metadata.LanguageSpecific[key].(int); !ok || got != want {
+			t.Errorf("%s = %v, want %d", key, metadata.LanguageSpecific[key], want)
+		}
+	}
 }
 
 // TestGradleExtractDependenciesKotlin tests Gradle dependency extraction (Kotlin)
@@ -544,6 +555,37 @@ app.name=MyApp
 	}
 }
 
+// TestGradleExtractPropertiesCRLF tests gradle.properties with Windows
+// line endings
+func TestGradleExtractPropertiesCRLF(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "build.gradle"), []byte("plugins {\r\n    id 'java'\r\n}\r\n"), 0644); err != nil {
+		t.Fatalf("Failed to write build.gradle: %v", err)
+	}
+	gradleProperties := "# Project properties\r\nversion=2.0.0\r\ngroup=org.example\r\njava.version=17\r\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "gradle.properties"), []byte(gradleProperties), 0644); err != nil {
+		t.Fatalf("Failed to write gradle.properties: %v", err)
+	}
+
+	metadata, err := NewGradleExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	props, ok := metadata.LanguageSpecific["properties"].(map[string]string)
+	if !ok {
+		t.Fatalf("properties not found or wrong type")
+	}
+	for key, want := range map[string]string{"version": "2.0.0", "group": "org.example", "java.version": "17"} {
+		if props[key] != want {
+			t.Errorf("%s property = %q, want %q", key, props[key], want)
+		}
+	}
+	if metadata.Version != "2.0.0" {
+		t.Errorf("Version = %q, want 2.0.0", metadata.Version)
+	}
+}
+
 // TestGradleExtractDynamicVersion tests dynamic version detection
 func TestGradleExtractDynamicVersion(t *testing.T) {
 	tests := []struct {
@@ -935,3 +977,169 @@ targetCompatibility = JavaVersion.VERSION_17
 		})
 	}
 }
+
+func TestGradleExtractJavaVendor(t *testing.T) {
+	tests := []struct {
+		name           string
+		files          map[string]string
+		expectedJava   string
+		expectedSource string
+		expectedVendor string
+	}{
+		{
+			name: "toolchain with vendor",
+			files: map[string]string{"build.gradle.kts": `
+java {
+    toolchain {
+        languageVersion = JavaLanguageVersion.of(17)
+        vendor = JvmVendorSpec.ADOPTIUM
+    }
+}
+`},
+			expectedJava:   "17",
+			expectedSource: "toolchain",
+			expectedVendor: "adoptium",
+		},
+		{
+			name: "toolchain with matching vendor",
+			files: map[string]string{"build.gradle": `
+java {
+    toolchain {
+        languageVersion = JavaLanguageVersion.of(21)
+        vendor = JvmVendorSpec.matching("Eclipse")
+    }
+}
+`},
+			expectedJava:   "21",
+			expectedSource: "toolchain",
+			expectedVendor: "eclipse",
+		},
+		{
+			name: "toolchain without vendor",
+			files: map[string]string{"build.gradle": `
+java {
+    toolchain {
+        languageVersion = JavaLanguageVersion.of(17)
+    }
+}
+`},
+			expectedJava:   "17",
+			expectedSource: "toolchain",
+		},
+		{
+			name: ".java-version fallback with vendor",
+			files: map[string]string{
+				"build.gradle":  "plugins { id 'java' }\n",
+				".java-version": "temurin-21.0.2\n",
+			},
+			expectedJava:   "21.0.2",
+			expectedSource: ".java-version",
+			expectedVendor: "temurin",
+		},
+		{
+			name: ".java-version does not override the toolchain",
+			files: map[string]string{
+				"build.gradle":  "java { toolchain { languageVersion = JavaLanguageVersion.of(17) } }\n",
+				".java-version": "11\n",
+			},
+			expectedJava:   "17",
+			expectedSource: "toolchain",
+		},
+		{
+			name: ".tool-versions fallback",
+			files: map[string]string{
+				"build.gradle":   "plugins { id 'java' }\n",
+				".tool-versions": "nodejs 20.11.0\njava openjdk-21 temurin-17.0.9+9\n",
+			},
+			expectedJava:   "21",
+			expectedSource: ".tool-versions",
+			expectedVendor: "openjdk",
+		},
+		{
+			name: ".java-version wins over .tool-versions",
+			files: map[string]string{
+				"build.gradle":   "plugins { id 'java' }\n",
+				".java-version":  "17\n",
+				".tool-versions": "java temurin-21.0.2\n",
+			},
+			expectedJava:   "17",
+			expectedSource: ".java-version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			metadata, err := NewGradleExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+
+			if javaVersion, _ := metadata.LanguageSpecific["version"].(string); javaVersion != tt.expectedJava {
+				t.Errorf("java_version = %q, want %q", javaVersion, tt.expectedJava)
+			}
+			if source, _ := metadata.LanguageSpecific["version_source"].(string); source != tt.expectedSource {
+				t.Errorf("java_version_source = %q, want %q", source, tt.expectedSource)
+			}
+			vendor, declared := metadata.LanguageSpecific["vendor"]
+			if tt.expectedVendor == "" && declared {
+				t.Errorf("java_vendor = %v, want it unset without a declared vendor", vendor)
+			} else if tt.expectedVendor != "" && vendor != tt.expectedVendor {
+				t.Errorf("java_vendor = %v, want %q", vendor, tt.expectedVendor)
+			}
+		})
+	}
+}
+
+func TestGradleExtractWrapperVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties string
+		expected   string
+	}{
+		{
+			name:       "bin distribution",
+			properties: "distributionBase=GRADLE_USER_HOME\ndistributionUrl=https\\://services.gradle.org/distributions/gradle-8.5-bin.zip\n",
+			expected:   "8.5",
+		},
+		{
+			name:       "all distribution with a milestone",
+			properties: "distributionUrl=https\\://services.gradle.org/distributions/gradle-9.0-milestone-1-all.zip\n",
+			expected:   "9.0-milestone-1",
+		},
+		{
+			name:       "unrecognised distribution",
+			properties: "distributionUrl=https\\://mirror.example.com/gradle.zip\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			wrapperDir := filepath.Join(tmpDir, "gradle", "wrapper")
+			if err := os.MkdirAll(wrapperDir, 0755); err != nil {
+				t.Fatalf("Failed to create %s: %v", wrapperDir, err)
+			}
+			if err := os.WriteFile(filepath.Join(tmpDir, "build.gradle"), []byte("version = '1.0.0'\n"), 0644); err != nil {
+				t.Fatalf("Failed to write build.gradle: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(wrapperDir, "gradle-wrapper.properties"), []byte(tt.properties), 0644); err != nil {
+				t.Fatalf("Failed to write gradle-wrapper.properties: %v", err)
+			}
+
+			metadata, err := NewGradleExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if version, _ := metadata.LanguageSpecific["gradle_wrapper_version"].(string); version != tt.expected {
+				t.Errorf("gradle_wrapper_version = %q, want %q", version, tt.expected)
+			}
+		})
+	}
+}
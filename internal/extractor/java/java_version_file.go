@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/lfreleng-actions/build-metadata-action/internal/toolversions"
+)
+
+// javaVersionFile is the jenv/asdf style file pinning the project's JDK
+const javaVersionFile = ".java-version"
+
+// javaVersionFilePattern splits a .java-version value into an optional
+// vendor prefix and the version, e.g. "temurin-21.0.2", "corretto-17" or
+// plain "17".
+var javaVersionFilePattern = regexp.MustCompile(`^(?:([A-Za-z][A-Za-z0-9_.]*?)-)?(\d+(?:\.\d+)*)`)
+
+// readJavaVersionFile returns the vendor (empty unless the value names
+// one) and version pinned by .java-version in projectPath, falling back
+// to the java entry of an asdf .tool-versions file, plus the file it
+// came from.
+func readJavaVersionFile(projectPath string) (vendor, version, source string, ok bool) {
+	value, source := "", javaVersionFile
+	if content, err := os.ReadFile(filepath.Join(projectPath, javaVersionFile)); err == nil {
+		value = strings.TrimSpace(string(content))
+	} else if pinned, found := toolversions.Lookup(projectPath, "java"); found {
+		value, source = pinned, toolversions.FileName
+	} else {
+		return "", "", "", false
+	}
+	m := javaVersionFilePattern.FindStringSubmatch(value)
+	if m == nil {
+		return "", "", "", false
+	}
+	return strings.ToLower(m[1]), m[2], source, true
+}
+
+// applyJavaVersionFile falls back to .java-version or .tool-versions
+// when the build files declare no Java level, and takes the vendor it
+// names when the build files declare none.
+func applyJavaVersionFile(projectPath string, metadata *extractor.ProjectMetadata) {
+	vendor, version, source, ok := readJavaVersionFile(projectPath)
+	if !ok {
+		return
+	}
+	metadata.AddConsumedFile(filepath.Join(projectPath, source))
+	if _, declared := metadata.LanguageSpecific["version"]; !declared {
+		setJavaVersion(metadata, version, source)
+	}
+	if _, declared := metadata.LanguageSpecific["vendor"]; !declared && vendor != "" {
+		metadata.LanguageSpecific["vendor"] = vendor
+	}
+}
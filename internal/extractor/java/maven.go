@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
@@ -254,23 +256,73 @@ func (e *MavenExtractor) extractFromPOM(pomPath, projectPath string, metadata *e
 
 	var pom POM
 	if err := xml.Unmarshal(content, &pom); err != nil {
-		return fmt.Errorf("failed to parse pom.xml: %w", err)
+		return fmt.Errorf("failed to parse pom.xml: %w", extractor.NewManifestError(pomPath, content, err))
+	}
+	metadata.AddConsumedFile(pomPath)
+	for _, parentPath := range localParentPOMs(projectPath, &pom) {
+		metadata.AddConsumedFile(parentPath)
 	}
 
-	resolvedPOM := e.resolveProperties(&pom)
+	resolvedPOM := e.resolveProperties(projectPath, &pom)
+	flattenedVersion, usedFlattened := applyFlattenedPOM(projectPath, resolvedPOM)
+	if usedFlattened {
+		metadata.AddConsumedFile(filepath.Join(projectPath, flattenedPOMName))
+	}
+	applyManagedVersions(resolvedPOM)
 
 	applyPOMCoreMetadata(resolvedPOM, metadata)
 	applyPOMIdentifiers(resolvedPOM, metadata)
+	applyPOMPublishable(metadata)
+	if inherited, _ := metadata.LanguageSpecific["version_from_parent"].(bool); inherited {
+		metadata.VersionSource = parentVersionSource(projectPath, &pom)
+	}
+	if flattenedVersion {
+		metadata.VersionSource = flattenedPOMName
+	}
+	if usedFlattened {
+		metadata.LanguageSpecific["maven_used_flattened_pom"] = true
+	}
 	applyPOMProperties(resolvedPOM, metadata)
+	applyPOMPropertyResolution(&pom, resolvedPOM, metadata)
 	applyPOMDependencies(resolvedPOM, metadata)
+	applyPOMDependencyManagement(resolvedPOM, metadata)
 	applyPOMBuildPlugins(resolvedPOM, metadata)
 	applyPOMStructure(resolvedPOM, metadata)
 	e.applyPOMJavaVersion(projectPath, resolvedPOM, metadata)
+	applyJavaVersionFile(projectPath, metadata)
+	applyMavenWrapper(projectPath, metadata)
 	applyPOMVersioningType(metadata)
 
 	return nil
 }
 
+// flattenedPOMName is the file the flatten-maven-plugin writes next to
+// pom.xml with CI-friendly placeholders such as ${revision} expanded.
+const flattenedPOMName = ".flattened-pom.xml"
+
+// applyFlattenedPOM overlays the version and groupId from a
+// .flattened-pom.xml onto the resolved POM when one is present, since the
+// flatten-maven-plugin has already expanded the placeholders the raw
+// pom.xml carries. Values that are empty or still hold a placeholder are
+// ignored so the pom.xml resolution stands. It reports whether the version
+// was taken from the flattened POM, and whether any value was.
+func applyFlattenedPOM(projectPath string, pom *POM) (usedVersion, used bool) {
+	flattened, ok := readPOM(filepath.Join(projectPath, flattenedPOMName))
+	if !ok {
+		return false, false
+	}
+
+	if flattened.Version != "" && !isUnresolvedPlaceholder(flattened.Version) {
+		pom.Version = flattened.Version
+		usedVersion = true
+	}
+	if flattened.GroupID != "" && !isUnresolvedPlaceholder(flattened.GroupID) {
+		pom.GroupID = flattened.GroupID
+		used = true
+	}
+	return usedVersion, used || usedVersion
+}
+
 // applyPOMCoreMetadata maps top-level project fields, the first declared
 // license, developer authors, and the SCM URL onto the shared metadata.
 func applyPOMCoreMetadata(pom *POM, metadata *extractor.ProjectMetadata) {
@@ -288,18 +340,20 @@ func applyPOMCoreMetadata(pom *POM, metadata *extractor.ProjectMetadata) {
 	}
 
 	authors := make([]string, 0)
+	structured := make([]extractor.Author, 0)
 	if pom.Developers != nil {
 		for _, dev := range pom.Developers.Developer {
+			developer := extractor.Author{Name: dev.Name, Email: dev.Email}
+			if developer != (extractor.Author{}) {
+				structured = append(structured, developer)
+			}
 			if dev.Name != "" {
-				if dev.Email != "" {
-					authors = append(authors, fmt.Sprintf("%s <%s>", dev.Name, dev.Email))
-				} else {
-					authors = append(authors, dev.Name)
-				}
+				authors = append(authors, developer.String())
 			}
 		}
 	}
 	metadata.Authors = authors
+	metadata.AuthorsStructured = structured
 
 	if pom.SCM != nil && pom.SCM.URL != "" {
 		metadata.Repository = pom.SCM.URL
@@ -336,6 +390,15 @@ func applyPOMIdentifiers(pom *POM, metadata *extractor.ProjectMetadata) {
 	}
 }
 
+// applyPOMPublishable marks the module publishable to Maven Central when
+// it has a groupId, its own or inherited, and builds an artifact: a pom
+// packaging aggregator or parent publishes no artifact of its own.
+func applyPOMPublishable(metadata *extractor.ProjectMetadata) {
+	groupID, _ := metadata.LanguageSpecific["group_id"].(string)
+	packaging, _ := metadata.LanguageSpecific["packaging"].(string)
+	metadata.SetPublishable(extractor.PublishTargetMavenCentral, groupID != "" && packaging != "pom")
+}
+
 // applyPOMProperties records declared properties and derives the
 // revision-based dynamic versioning hint from the well-known key. The
 // Java language level is resolved separately by applyPOMJavaVersion so it
@@ -356,10 +419,14 @@ func applyPOMProperties(pom *POM, metadata *extractor.ProjectMetadata) {
 	}
 }
 
-// applyPOMDependencies records the dependency list and a per-scope tally,
-// treating an unspecified scope as Maven's implicit "compile" scope.
+// applyPOMDependencies records the dependency list, a per-scope tally, and
+// the standardized dependency counts, treating an unspecified scope as
+// Maven's implicit "compile" scope. compile and runtime scopes count as
+// runtime, test as dev, and provided/system/import only towards the total.
 func applyPOMDependencies(pom *POM, metadata *extractor.ProjectMetadata) {
+	var counts extractor.DependencyCounts
 	if pom.Dependencies == nil || len(pom.Dependencies.Dependency) == 0 {
+		extractor.SetDependencyCounts(metadata, counts)
 		return
 	}
 	deps := make([]map[string]string, 0, len(pom.Dependencies.Dependency))
@@ -380,10 +447,78 @@ func applyPOMDependencies(pom *POM, metadata *extractor.ProjectMetadata) {
 			scope = "compile"
 		}
 		scopeCounts[scope]++
+
+		switch scope {
+		case "compile", "runtime":
+			counts.Runtime++
+		case "test":
+			counts.Dev++
+		default:
+			counts.Other++
+		}
 	}
 	metadata.LanguageSpecific["dependencies"] = deps
 	metadata.LanguageSpecific["dependency_count"] = len(deps)
 	metadata.LanguageSpecific["dependency_scopes"] = scopeCounts
+	extractor.SetDependencyCounts(metadata, counts)
+}
+
+// managementKey identifies a dependency the way Maven matches it against
+// <dependencyManagement>: groupId, artifactId, type (default jar) and
+// classifier.
+func managementKey(dep Dependency) string {
+	depType := dep.Type
+	if depType == "" {
+		depType = "jar"
+	}
+	return strings.Join([]string{dep.GroupID, dep.ArtifactID, depType, dep.Classifier}, ":")
+}
+
+// isImportedBOM reports whether a managed dependency imports a BOM
+func isImportedBOM(dep Dependency) bool {
+	return dep.Scope == "import" && dep.Type == "pom"
+}
+
+// applyManagedVersions fills in the version of each dependency that omits
+// it from the POM's own <dependencyManagement>. Versions managed by
+// imported BOMs or remote parents are not resolved and stay empty.
+func applyManagedVersions(pom *POM) {
+	if pom.Dependencies == nil || pom.DependencyMgmt == nil || pom.DependencyMgmt.Dependencies == nil {
+		return
+	}
+	managed := make(map[string]string)
+	for _, dep := range pom.DependencyMgmt.Dependencies.Dependency {
+		if dep.Version != "" && !isImportedBOM(dep) {
+			managed[managementKey(dep)] = dep.Version
+		}
+	}
+	for i, dep := range pom.Dependencies.Dependency {
+		if dep.Version == "" {
+			pom.Dependencies.Dependency[i].Version = managed[managementKey(dep)]
+		}
+	}
+}
+
+// applyPOMDependencyManagement records the number of managed dependencies
+// and the BOMs imported into <dependencyManagement>
+// (maven_imported_boms, as groupId:artifactId:version).
+func applyPOMDependencyManagement(pom *POM, metadata *extractor.ProjectMetadata) {
+	if pom.DependencyMgmt == nil || pom.DependencyMgmt.Dependencies == nil {
+		return
+	}
+	var boms []string
+	managedCount := 0
+	for _, dep := range pom.DependencyMgmt.Dependencies.Dependency {
+		if isImportedBOM(dep) {
+			boms = append(boms, fmt.Sprintf("%s:%s:%s", dep.GroupID, dep.ArtifactID, dep.Version))
+			continue
+		}
+		managedCount++
+	}
+	metadata.LanguageSpecific["maven_managed_dependency_count"] = managedCount
+	if len(boms) > 0 {
+		metadata.LanguageSpecific["maven_imported_boms"] = boms
+	}
 }
 
 // applyPOMBuildPlugins records build plugin coordinates and any frameworks
@@ -489,6 +624,7 @@ func (e *MavenExtractor) applyPOMJavaVersion(projectPath string, pom *POM, metad
 	}
 	if version, source := javaVersionFromModules(projectPath, pom); version != "" {
 		setJavaVersion(metadata, version, source)
+		metadata.AddConsumedFile(filepath.Join(projectPath, strings.TrimPrefix(source, "module:"), "pom.xml"))
 		return
 	}
 }
@@ -624,8 +760,8 @@ func javaVersionFromModules(projectPath string, pom *POM) (string, string) {
 func effectiveProperties(projectPath string, pom *POM, depth int) map[string]string {
 	merged := make(map[string]string)
 	if pom.Parent != nil && depth < maxParentDepth {
-		if parentDir, parentPOM, ok := loadParentPOM(projectPath, pom); ok {
-			for key, value := range effectiveProperties(parentDir, parentPOM, depth+1) {
+		if parentPath, parentPOM, ok := loadParentPOM(projectPath, pom); ok {
+			for key, value := range effectiveProperties(filepath.Dir(parentPath), parentPOM, depth+1) {
 				merged[key] = value
 			}
 		}
@@ -637,8 +773,9 @@ func effectiveProperties(projectPath string, pom *POM, depth int) map[string]str
 }
 
 // loadParentPOM resolves and parses a POM's parent from its relativePath
-// (defaulting to "../pom.xml"), returning the parent's directory (for
-// further relativePath resolution) and the parsed POM. It returns ok=false
+// (defaulting to "../pom.xml"), returning the parent POM file (whose
+// directory anchors further relativePath resolution) and the parsed POM.
+// It returns ok=false
 // when no local parent file exists, which is the normal case for a bare
 // module checkout or a repository-root aggregator.
 func loadParentPOM(projectPath string, pom *POM) (string, *POM, bool) {
@@ -684,7 +821,38 @@ func loadParentPOM(projectPath string, pom *POM) (string, *POM, bool) {
 	if !ok {
 		return "", nil, false
 	}
-	return filepath.Dir(candidate), parentPOM, true
+	return candidate, parentPOM, true
+}
+
+// localParentPOMs returns the on-disk parent POM files of pom, nearest
+// first, following the chain as effectiveProperties does.
+func localParentPOMs(projectPath string, pom *POM) []string {
+	var parents []string
+	for depth := 0; depth < maxParentDepth; depth++ {
+		parentPath, parentPOM, ok := loadParentPOM(projectPath, pom)
+		if !ok {
+			break
+		}
+		parents = append(parents, parentPath)
+		projectPath, pom = filepath.Dir(parentPath), parentPOM
+	}
+	return parents
+}
+
+// parentVersionSource names the file a parent-inherited version came
+// from: the nearest on-disk parent POM relative to projectPath, or
+// pom.xml, whose <parent> section carries it, when the parent is only
+// published to a repository.
+func parentVersionSource(projectPath string, pom *POM) string {
+	parents := localParentPOMs(projectPath, pom)
+	if len(parents) == 0 {
+		return "pom.xml"
+	}
+	rel, err := filepath.Rel(projectPath, parents[0])
+	if err != nil {
+		return "pom.xml"
+	}
+	return filepath.ToSlash(rel)
 }
 
 // workspaceRoot returns the trusted workspace boundary (GITHUB_WORKSPACE)
@@ -747,36 +915,155 @@ func readPOM(pomPath string) (*POM, bool) {
 	return &pom, true
 }
 
-// resolveProperties resolves property placeholders in POM values
-func (e *MavenExtractor) resolveProperties(pom *POM) *POM {
+// resolveProperties resolves property placeholders in POM values. The
+// property set is the POM's effective properties (its own overlaid on any
+// on-disk parents) plus Maven's built-in project.* and parent.*
+// expressions, so a child referencing ${project.version} or a property
+// declared in a local parent still resolves. The coordinates inherit from
+// the parent section when the child omits them, matching Maven.
+func (e *MavenExtractor) resolveProperties(projectPath string, pom *POM) *POM {
 	// Create a copy to avoid modifying the original
 	resolved := *pom
 
-	props := make(map[string]string)
-	if pom.Properties.Entries != nil {
-		for k, v := range pom.Properties.Entries {
-			props[k] = v
+	props := effectiveProperties(projectPath, pom, 0)
+
+	if pom.Parent != nil {
+		parentProps := map[string]string{
+			"groupId":    pom.Parent.GroupID,
+			"artifactId": pom.Parent.ArtifactID,
+			"version":    pom.Parent.Version,
+		}
+		for key, value := range parentProps {
+			if value != "" {
+				props["project.parent."+key] = value
+				props["parent."+key] = value
+			}
 		}
 	}
 
-	// Add implicit properties
-	if pom.GroupID != "" {
-		props["project.groupId"] = pom.GroupID
+	// Add implicit properties, inheriting from the parent section when
+	// the child leaves a coordinate out
+	groupID, version := pom.GroupID, pom.Version
+	if pom.Parent != nil {
+		if groupID == "" {
+			groupID = pom.Parent.GroupID
+		}
+		if version == "" {
+			version = pom.Parent.Version
+		}
+	}
+	if groupID != "" {
+		props["project.groupId"] = groupID
+		props["pom.groupId"] = groupID
 	}
 	if pom.ArtifactID != "" {
 		props["project.artifactId"] = pom.ArtifactID
 	}
-	if pom.Version != "" {
-		props["project.version"] = pom.Version
+	if version != "" {
+		props["project.version"] = version
+		props["pom.version"] = version
 	}
 
 	// Resolve version
 	resolved.Version = resolveProperty(pom.Version, props)
 	resolved.GroupID = resolveProperty(pom.GroupID, props)
 
+	if pom.Parent != nil {
+		parent := *pom.Parent
+		parent.GroupID = resolveProperty(parent.GroupID, props)
+		parent.Version = resolveProperty(parent.Version, props)
+		resolved.Parent = &parent
+	}
+
+	if pom.Dependencies != nil {
+		deps := make([]Dependency, len(pom.Dependencies.Dependency))
+		for i, dep := range pom.Dependencies.Dependency {
+			dep.GroupID = resolveProperty(dep.GroupID, props)
+			dep.Version = resolveProperty(dep.Version, props)
+			deps[i] = dep
+		}
+		resolved.Dependencies = &Dependencies{Dependency: deps}
+	}
+
+	if pom.DependencyMgmt != nil && pom.DependencyMgmt.Dependencies != nil {
+		managed := make([]Dependency, len(pom.DependencyMgmt.Dependencies.Dependency))
+		for i, dep := range pom.DependencyMgmt.Dependencies.Dependency {
+			dep.GroupID = resolveProperty(dep.GroupID, props)
+			dep.Version = resolveProperty(dep.Version, props)
+			managed[i] = dep
+		}
+		resolved.DependencyMgmt = &DependencyMgmt{Dependencies: &Dependencies{Dependency: managed}}
+	}
+
 	return &resolved
 }
 
+// applyPOMPropertyResolution records which coordinates were expanded from
+// property placeholders (resolved_properties, keyed by field) and lists
+// any placeholders that could not be resolved (unresolved_properties), so
+// a reference to a property only defined in a remote parent such as
+// ${spring-boot.version} is flagged rather than passed on silently.
+func applyPOMPropertyResolution(raw, resolved *POM, metadata *extractor.ProjectMetadata) {
+	fields := []pomField{
+		{"version", raw.Version, resolved.Version},
+		{"group_id", raw.GroupID, resolved.GroupID},
+	}
+	if raw.Parent != nil {
+		fields = append(fields,
+			pomField{"parent_version", raw.Parent.Version, resolved.Parent.Version},
+			pomField{"parent_group_id", raw.Parent.GroupID, resolved.Parent.GroupID})
+	}
+	if raw.Dependencies != nil {
+		for i, dep := range raw.Dependencies.Dependency {
+			// Key on the resolved coordinates so a groupId written as
+			// ${project.groupId} or padded with whitespace yields the
+			// same key as its literal form
+			resolvedDep := resolved.Dependencies.Dependency[i]
+			name := fmt.Sprintf("dependency:%s:%s",
+				strings.TrimSpace(resolvedDep.GroupID), strings.TrimSpace(resolvedDep.ArtifactID))
+			fields = append(fields, pomField{name, dep.Version, resolvedDep.Version})
+		}
+	}
+
+	resolvedValues := make(map[string]string)
+	unresolvedSet := make(map[string]bool)
+	for _, field := range fields {
+		if !strings.Contains(field.raw, mavenPropertyOpen) {
+			continue
+		}
+		if isUnresolvedPlaceholder(field.resolved) {
+			for _, placeholder := range mavenPlaceholderPattern.FindAllString(field.resolved, -1) {
+				unresolvedSet[placeholder] = true
+			}
+			continue
+		}
+		resolvedValues[field.name] = field.resolved
+	}
+
+	if len(resolvedValues) > 0 {
+		metadata.LanguageSpecific["resolved_properties"] = resolvedValues
+	}
+	if len(unresolvedSet) > 0 {
+		unresolved := make([]string, 0, len(unresolvedSet))
+		for placeholder := range unresolvedSet {
+			unresolved = append(unresolved, placeholder)
+		}
+		sort.Strings(unresolved)
+		metadata.LanguageSpecific["unresolved_properties"] = unresolved
+	}
+}
+
+// pomField pairs a POM value as written with its property-resolved form.
+type pomField struct {
+	name     string
+	raw      string
+	resolved string
+}
+
+// mavenPlaceholderPattern matches a single property reference. The braces
+// are hex escapes for the same reason as mavenPropertyOpen.
+var mavenPlaceholderPattern = regexp.MustCompile(`\$\x{7b}[^\x{7d}]*\x{7d}`)
+
 // maxPropertyResolutionPasses bounds nested placeholder expansion so a
 // cyclic reference (${a} -> ${b} -> ${a}) cannot loop forever. Real POMs
 // nest at most a couple of levels, so the limit is never reached in practice.
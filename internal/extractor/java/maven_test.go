@@ -167,6 +167,234 @@ func TestMavenExtractWithParent(t *testing.T) {
 	}
 }
 
+// TestMavenExtractResolvesParentAndBuiltinProperties tests that
+// placeholders referencing the parent section, the POM's own properties,
+// and Maven's built-in project.* expressions resolve, while a property
+// only defined in a remote parent is flagged as unresolved
+func TestMavenExtractResolvesParentAndBuiltinProperties(t *testing.T) {
+	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+
+    <parent>
+        <groupId>org.example.platform</groupId>
+        <artifactId>platform-parent</artifactId>
+        <version>2.1.0</version>
+    </parent>
+
+    <artifactId>billing-service</artifactId>
+    <version>${project.parent.version}</version>
+
+    <properties>
+        <commons.version>1.4.0</commons.version>
+    </properties>
+
+    <dependencies>
+        <dependency>
+            <groupId>${project.groupId}</groupId>
+            <artifactId>platform-core</artifactId>
+            <version>${project.version}</version>
+        </dependency>
+        <dependency>
+            <groupId> org.example </groupId>
+            <artifactId>commons</artifactId>
+            <version>${commons.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-starter-web</artifactId>
+            <version>${spring-boot.version}</version>
+        </dependency>
+    </dependencies>
+</project>`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "pom.xml"), []byte(pomXML), 0644); err != nil {
+		t.Fatalf("Failed to write pom.xml: %v", err)
+	}
+
+	metadata, err := NewMavenExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if metadata.Version != "2.1.0" {
+		t.Errorf("Version = %q, want 2.1.0 (from ${project.parent.version})", metadata.Version)
+	}
+	if groupID := metadata.LanguageSpecific["group_id"]; groupID != "org.example.platform" {
+		t.Errorf("group_id = %v, want org.example.platform (inherited)", groupID)
+	}
+
+	deps, ok := metadata.LanguageSpecific["dependencies"].([]map[string]string)
+	if !ok || len(deps) != 3 {
+		t.Fatalf("dependencies = %v, want 3 entries", metadata.LanguageSpecific["dependencies"])
+	}
+	if deps[0]["group_id"] != "org.example.platform" || deps[0]["version"] != "2.1.0" {
+		t.Errorf("built-in expressions not resolved: %v", deps[0])
+	}
+	if deps[1]["version"] != "1.4.0" {
+		t.Errorf("child property not resolved: %v", deps[1])
+	}
+
+	resolved, ok := metadata.LanguageSpecific["resolved_properties"].(map[string]string)
+	if !ok {
+		t.Fatalf("resolved_properties missing")
+	}
+	if resolved["version"] != "2.1.0" {
+		t.Errorf("resolved_properties[version] = %q, want 2.1.0", resolved["version"])
+	}
+	if resolved["dependency:org.example:commons"] != "1.4.0" {
+		t.Errorf("resolved_properties missing commons dependency: %v", resolved)
+	}
+	if resolved["dependency:org.example.platform:platform-core"] != "2.1.0" {
+		t.Errorf("resolved_properties not keyed on the resolved groupId: %v", resolved)
+	}
+
+	unresolved, ok := metadata.LanguageSpecific["unresolved_properties"].([]string)
+	if !ok || len(unresolved) != 1 || unresolved[0] != "${spring-boot.version}" {
+		t.Errorf("unresolved_properties = %v, want [${spring-boot.version}]", unresolved)
+	}
+}
+
+// TestMavenExtractPrefersFlattenedPOM tests that the concrete version in
+// .flattened-pom.xml wins over the ${revision} placeholder in pom.xml
+func TestMavenExtractPrefersFlattenedPOM(t *testing.T) {
+	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>${groupPrefix}.tools</groupId>
+    <artifactId>ci-friendly</artifactId>
+    <version>${revision}${changelist}</version>
+</project>`
+
+	flattenedXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>org.example.tools</groupId>
+    <artifactId>ci-friendly</artifactId>
+    <version>3.2.1</version>
+</project>`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "pom.xml"), []byte(pomXML), 0644); err != nil {
+		t.Fatalf("Failed to write pom.xml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".flattened-pom.xml"), []byte(flattenedXML), 0644); err != nil {
+		t.Fatalf("Failed to write .flattened-pom.xml: %v", err)
+	}
+
+	metadata, err := NewMavenExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if metadata.Version != "3.2.1" {
+		t.Errorf("Version = %q, want 3.2.1 from .flattened-pom.xml", metadata.Version)
+	}
+	if metadata.VersionSource != ".flattened-pom.xml" {
+		t.Errorf("VersionSource = %q, want .flattened-pom.xml", metadata.VersionSource)
+	}
+	if groupID := metadata.LanguageSpecific["group_id"]; groupID != "org.example.tools" {
+		t.Errorf("group_id = %v, want org.example.tools", groupID)
+	}
+	if used, ok := metadata.LanguageSpecific["maven_used_flattened_pom"].(bool); !ok || !used {
+		t.Errorf("maven_used_flattened_pom = %v, want true", metadata.LanguageSpecific["maven_used_flattened_pom"])
+	}
+	if _, ok := metadata.LanguageSpecific["unresolved_properties"]; ok {
+		t.Errorf("unresolved_properties should be empty once the flattened POM resolves them")
+	}
+}
+
+// TestMavenExtractWithoutFlattenedPOM tests that pom.xml remains the
+// source when no flattened POM exists
+func TestMavenExtractWithoutFlattenedPOM(t *testing.T) {
+	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>org.example</groupId>
+    <artifactId>plain</artifactId>
+    <version>1.0.0</version>
+</project>`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "pom.xml"), []byte(pomXML), 0644); err != nil {
+		t.Fatalf("Failed to write pom.xml: %v", err)
+	}
+
+	metadata, err := NewMavenExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if metadata.VersionSource != "pom.xml" {
+		t.Errorf("VersionSource = %q, want pom.xml", metadata.VersionSource)
+	}
+	if _, ok := metadata.LanguageSpecific["maven_used_flattened_pom"]; ok {
+		t.Error("maven_used_flattened_pom should be unset without a flattened POM")
+	}
+}
+
+// TestMavenExtractVersionSourceFromParent tests that an inherited version
+// reports the parent POM it came from
+func TestMavenExtractVersionSourceFromParent(t *testing.T) {
+	parentXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>org.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>2.4.0</version>
+    <packaging>pom</packaging>
+</project>`
+	childXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <parent>
+        <groupId>org.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>2.4.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+</project>`
+
+	tests := []struct {
+		name       string
+		withParent bool
+		want       string
+	}{
+		{"local parent", true, "../pom.xml"},
+		{"remote parent", false, "pom.xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			childDir := filepath.Join(tmpDir, "child")
+			if err := os.MkdirAll(childDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if tt.withParent {
+				if err := os.WriteFile(filepath.Join(tmpDir, "pom.xml"), []byte(parentXML), 0644); err != nil {
+					t.Fatalf("Failed to write parent pom.xml: %v", err)
+				}
+			}
+			if err := os.WriteFile(filepath.Join(childDir, "pom.xml"), []byte(childXML), 0644); err != nil {
+				t.Fatalf("Failed to write pom.xml: %v", err)
+			}
+
+			metadata, err := NewMavenExtractor().Extract(childDir)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if metadata.Version != "2.4.0" {
+				t.Errorf("Version = %q, want 2.4.0 from the parent", metadata.Version)
+			}
+			if metadata.VersionSource != tt.want {
+				t.Errorf("VersionSource = %q, want %q", metadata.VersionSource, tt.want)
+			}
+		})
+	}
+}
+
 // TestMavenExtractDependencies tests Maven dependency extraction
 func TestMavenExtractDependencies(t *testing.T) {
 	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
@@ -240,6 +468,91 @@ func TestMavenExtractDependencies(t *testing.T) {
 	if scopes["provided"] != 1 {
 		t.Errorf("provided scope count = %v, want 1", scopes["provided"])
 	}
+
+	// provided counts towards the total but is neither runtime nor dev
+	for key, want := range map[string]int{
+		"runtime_dependency_count": 1,
+		"dev_dependency_count":     1,
+		"total_dependency_count":   3,
+	} {
+		if got, ok := metadata.LanguageSpecific[key].(int); !ok || got != want {
+			t.Errorf("%s = %v, want %d", key, metadata.LanguageSpecific[key], want)
+		}
+	}
+}
+
+// TestMavenExtractDependencyManagement tests managed versions and BOM imports
+func TestMavenExtractDependencyManagement(t *testing.T) {
+	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+
+    <groupId>com.example</groupId>
+    <artifactId>managed-app</artifactId>
+    <version>1.0.0</version>
+
+    <properties>
+        <jackson.version>2.16.1</jackson.version>
+    </properties>
+
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.springframework.boot</groupId>
+                <artifactId>spring-boot-dependencies</artifactId>
+                <version>3.2.0</version>
+                <type>pom</type>
+                <scope>import</scope>
+            </dependency>
+            <dependency>
+                <groupId>com.fasterxml.jackson.core</groupId>
+                <artifactId>jackson-databind</artifactId>
+                <version>${jackson.version}</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+
+    <dependencies>
+        <dependency>
+            <groupId>com.fasterxml.jackson.core</groupId>
+            <artifactId>jackson-databind</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>org.springframework.boot</groupId>
+            <artifactId>spring-boot-starter-web</artifactId>
+        </dependency>
+    </dependencies>
+</project>`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "pom.xml"), []byte(pomXML), 0644); err != nil {
+		t.Fatalf("Failed to write pom.xml: %v", err)
+	}
+
+	metadata, err := NewMavenExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	deps, ok := metadata.LanguageSpecific["dependencies"].([]map[string]string)
+	if !ok || len(deps) != 2 {
+		t.Fatalf("dependencies = %v, want 2 entries", metadata.LanguageSpecific["dependencies"])
+	}
+	if deps[0]["version"] != "2.16.1" {
+		t.Errorf("jackson-databind version = %q, want the managed 2.16.1", deps[0]["version"])
+	}
+	// Managed by the imported BOM, which is not fetched
+	if deps[1]["version"] != "" {
+		t.Errorf("spring-boot-starter-web version = %q, want empty", deps[1]["version"])
+	}
+
+	boms, ok := metadata.LanguageSpecific["maven_imported_boms"].([]string)
+	if !ok || len(boms) != 1 || boms[0] != "org.springframework.boot:spring-boot-dependencies:3.2.0" {
+		t.Errorf("maven_imported_boms = %v", metadata.LanguageSpecific["maven_imported_boms"])
+	}
+	if got := metadata.LanguageSpecific["maven_managed_dependency_count"]; got != 1 {
+		t.Errorf("maven_managed_dependency_count = %v, want 1", got)
+	}
 }
 
 // TestMavenExtractProperties tests Maven properties extraction
@@ -689,6 +1002,45 @@ func TestMavenExtractDefaultPackaging(t *testing.T) {
 	}
 }
 
+// TestMavenExtractPublishable tests publishability from groupId and packaging
+func TestMavenExtractPublishable(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		expect bool
+	}{
+		{"jar", `<groupId>com.example</groupId><artifactId>lib</artifactId><version>1.0.0</version>`, true},
+		{"pom packaging", `<groupId>com.example</groupId><artifactId>parent</artifactId><version>1.0.0</version><packaging>pom</packaging>`, false},
+		{"inherited groupId", `<parent><groupId>com.example</groupId><artifactId>parent</artifactId><version>1.0.0</version></parent><artifactId>lib</artifactId>`, true},
+		{"no groupId", `<artifactId>lib</artifactId><version>1.0.0</version>`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0"><modelVersion>4.0.0</modelVersion>` + tt.body + `</project>`
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "pom.xml"), []byte(pomXML), 0644); err != nil {
+				t.Fatalf("Failed to write pom.xml: %v", err)
+			}
+
+			metadata, err := NewMavenExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if metadata.Publishable == nil {
+				t.Fatal("Publishable not set")
+			}
+			if *metadata.Publishable != tt.expect {
+				t.Errorf("Publishable = %v, want %v", *metadata.Publishable, tt.expect)
+			}
+			if metadata.PublishTarget != "maven-central" {
+				t.Errorf("PublishTarget = %q, want maven-central", metadata.PublishTarget)
+			}
+		})
+	}
+}
+
 // TestMavenFrameworkDetection tests framework detection from dependencies
 func TestMavenFrameworkDetection(t *testing.T) {
 	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
@@ -1462,3 +1814,73 @@ func TestMavenExtractJavaVersionSkipsUnresolvedPlaceholder(t *testing.T) {
 		t.Errorf("java_version_source = %q, want maven.compiler.target", source)
 	}
 }
+
+// TestMavenExtractJavaVersionFile verifies that .java-version supplies the
+// Java level and vendor when the POM declares no level.
+func TestMavenExtractJavaVersionFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>pinned</artifactId>
+    <version>1.0.0</version>
+</project>`
+	if err := os.WriteFile(filepath.Join(tmpDir, "pom.xml"), []byte(pomXML), 0644); err != nil {
+		t.Fatalf("Failed to write pom.xml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, ".java-version"), []byte("corretto-17\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .java-version: %v", err)
+	}
+
+	metadata, err := NewMavenExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if version, _ := metadata.LanguageSpecific["version"].(string); version != "17" {
+		t.Errorf("java_version = %q, want 17 from .java-version", version)
+	}
+	if source, _ := metadata.LanguageSpecific["version_source"].(string); source != ".java-version" {
+		t.Errorf("java_version_source = %q, want .java-version", source)
+	}
+	if vendor, _ := metadata.LanguageSpecific["vendor"].(string); vendor != "corretto" {
+		t.Errorf("java_vendor = %q, want corretto", vendor)
+	}
+}
+
+func TestMavenExtractWrapperVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	pomXML := `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>wrapped</artifactId>
+    <version>1.0.0</version>
+</project>`
+	properties := `# Licensed to the Apache Software Foundation (ASF)
+wrapperVersion=3.3.2
+distributionType=only-script
+distributionUrl=https://repo.maven.apache.org/maven2/org/apache/maven/apache-maven/3.9.6/apache-maven-3.9.6-bin.zip
+`
+	wrapperDir := filepath.Join(tmpDir, ".mvn", "wrapper")
+	if err := os.MkdirAll(wrapperDir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", wrapperDir, err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "pom.xml"), []byte(pomXML), 0644); err != nil {
+		t.Fatalf("Failed to write pom.xml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(wrapperDir, "maven-wrapper.properties"), []byte(properties), 0644); err != nil {
+		t.Fatalf("Failed to write maven-wrapper.properties: %v", err)
+	}
+
+	metadata, err := NewMavenExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if version, _ := metadata.LanguageSpecific["maven_wrapper_version"].(string); version != "3.9.6" {
+		t.Errorf("maven_wrapper_version = %q, want 3.9.6 from distributionUrl", version)
+	}
+	if version, _ := metadata.LanguageSpecific["maven_wrapper_script_version"].(string); version != "3.3.2" {
+		t.Errorf("maven_wrapper_script_version = %q, want 3.3.2 from wrapperVersion", version)
+	}
+}
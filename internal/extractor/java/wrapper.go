@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+var (
+	// mavenWrapperProperties is where the Maven Wrapper pins its
+	// distribution
+	mavenWrapperProperties = filepath.Join(".mvn", "wrapper", "maven-wrapper.properties")
+	// gradleWrapperProperties is where the Gradle Wrapper pins its
+	// distribution
+	gradleWrapperProperties = filepath.Join("gradle", "wrapper", "gradle-wrapper.properties")
+)
+
+var (
+	// mavenDistributionPattern captures the Maven version of a
+	// distributionUrl, e.g. ".../apache-maven-3.9.6-bin.zip"
+	mavenDistributionPattern = regexp.MustCompile(`apache-maven-(\d[^/]*?)-bin\.(?:zip|tar\.gz)$`)
+	// gradleDistributionPattern captures the Gradle version of a
+	// distributionUrl, e.g. ".../gradle-8.5-bin.zip"
+	gradleDistributionPattern = regexp.MustCompile(`gradle-(\d[^/]*?)-(?:bin|all)\.zip$`)
+)
+
+// applyMavenWrapper records maven_wrapper_version, the Maven version the
+// wrapper downloads, and maven_wrapper_script_version, the version of
+// the wrapper itself (wrapperVersion, written by Maven Wrapper 3.3+).
+func applyMavenWrapper(projectPath string, metadata *extractor.ProjectMetadata) {
+	props, ok := readPropertiesFile(filepath.Join(projectPath, mavenWrapperProperties))
+	if !ok {
+		return
+	}
+	if m := mavenDistributionPattern.FindStringSubmatch(props["distributionUrl"]); m != nil {
+		metadata.LanguageSpecific["maven_wrapper_version"] = m[1]
+	}
+	if version := props["wrapperVersion"]; version != "" {
+		metadata.LanguageSpecific["maven_wrapper_script_version"] = version
+	}
+}
+
+// applyGradleWrapper records gradle_wrapper_version, the Gradle version
+// the wrapper downloads.
+func applyGradleWrapper(projectPath string, metadata *extractor.ProjectMetadata) {
+	props, ok := readPropertiesFile(filepath.Join(projectPath, gradleWrapperProperties))
+	if !ok {
+		return
+	}
+	if m := gradleDistributionPattern.FindStringSubmatch(props["distributionUrl"]); m != nil {
+		metadata.LanguageSpecific["gradle_wrapper_version"] = m[1]
+	}
+}
+
+// propertiesUnescaper undoes the escaping Java properties files apply to
+// ':' and '=' (e.g. "https\://services.gradle.org/...")
+var propertiesUnescaper = strings.NewReplacer(`\:`, ":", `\=`, "=", `\\`, `\`)
+
+// readPropertiesFile parses the key=value (or key:value) lines of a Java
+// properties file, skipping comments.
+func readPropertiesFile(path string) (map[string]string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	props := make(map[string]string)
+	for _, line := range strings.Split(extractor.NormalizeNewlines(string(data)), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		i := strings.IndexAny(line, "=:")
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:i])
+		props[key] = propertiesUnescaper.Replace(strings.TrimSpace(line[i+1:]))
+	}
+	return props, true
+}
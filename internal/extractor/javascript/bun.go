@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package javascript
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// bunLockFiles are Bun's lock files: the text bun.lock that Bun 1.2
+// writes by default, then the older binary bun.lockb
+var bunLockFiles = []string{"bun.lock", "bun.lockb"}
+
+// bunConfigFile is Bun's configuration in the project root
+const bunConfigFile = "bunfig.toml"
+
+// BunConfig holds the [install] table of bunfig.toml. A registry is
+// either a URL string or a table with a url (plus credentials, which
+// are never read).
+type BunConfig struct {
+	Install struct {
+		Registry         interface{}            `toml:"registry"`
+		Scopes           map[string]interface{} `toml:"scopes"`
+		Production       *bool                  `toml:"production"`
+		FrozenLockfile   *bool                  `toml:"frozenLockfile"`
+		Exact            *bool                  `toml:"exact"`
+		Optional         *bool                  `toml:"optional"`
+		Dev              *bool                  `toml:"dev"`
+		Peer             *bool                  `toml:"peer"`
+		SaveTextLockfile *bool                  `toml:"saveTextLockfile"`
+		Linker           string                 `toml:"linker"`
+	} `toml:"install"`
+}
+
+// applyBunConfig records whether bunfig.toml exists as
+// bun_config_present, for Bun projects or whenever the file is there,
+// and from its [install] table the default registry as bun_registry, the
+// per-scope registries as bun_scoped_registries and the install
+// settings it sets as bun_install_settings. Credentials are dropped from
+// registry URLs.
+func applyBunConfig(projectPath string, metadata *extractor.ProjectMetadata) {
+	path := filepath.Join(projectPath, bunConfigFile)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if metadata.LanguageSpecific["package_manager"] == "bun" {
+			metadata.LanguageSpecific["bun_config_present"] = false
+		}
+		return
+	}
+	metadata.LanguageSpecific["bun_config_present"] = true
+
+	var config BunConfig
+	if _, err := toml.Decode(string(content), &config); err != nil {
+		extractor.Debugf("javascript: failed to parse %s: %v", path, err)
+		return
+	}
+	metadata.AddConsumedFile(path)
+
+	install := config.Install
+	if registry := bunRegistryURL(install.Registry); registry != "" {
+		metadata.LanguageSpecific["bun_registry"] = registry
+	}
+	scoped := make(map[string]string)
+	for scope, registry := range install.Scopes {
+		if registryURL := bunRegistryURL(registry); registryURL != "" {
+			scoped[scope] = registryURL
+		}
+	}
+	if len(scoped) > 0 {
+		metadata.LanguageSpecific["bun_scoped_registries"] = scoped
+	}
+
+	settings := make(map[string]interface{})
+	for name, value := range map[string]*bool{
+		"production":       install.Production,
+		"frozenLockfile":   install.FrozenLockfile,
+		"exact":            install.Exact,
+		"optional":         install.Optional,
+		"dev":              install.Dev,
+		"peer":             install.Peer,
+		"saveTextLockfile": install.SaveTextLockfile,
+	} {
+		if value != nil {
+			settings[name] = *value
+		}
+	}
+	if install.Linker != "" {
+		settings["linker"] = install.Linker
+	}
+	if len(settings) > 0 {
+		metadata.LanguageSpecific["bun_install_settings"] = settings
+	}
+}
+
+// bunRegistryURL returns the URL of a bunfig.toml registry value, a
+// string or a table with a url, with any user info removed; "" when the
+// value names no URL.
+func bunRegistryURL(value interface{}) string {
+	var raw string
+	switch registry := value.(type) {
+	case string:
+		raw = registry
+	case map[string]interface{}:
+		raw, _ = registry["url"].(string)
+	}
+	if raw == "" {
+		return ""
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if parsed.User == nil {
+		return raw
+	}
+	parsed.User = nil
+	return parsed.String()
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package javascript
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// Values of app_type
+const (
+	appTypeElectron = "electron"
+	appTypeTauri    = "tauri"
+	appTypeWeb      = "web"
+	appTypeNone     = "none"
+)
+
+// tauriConfigPath is the Tauri configuration inside the project, relative
+// to the package.json directory
+var tauriConfigPath = filepath.Join("src-tauri", "tauri.conf.json")
+
+// TauriConfig holds the version fields of tauri.conf.json: Tauri 1
+// nests the version under "package", Tauri 2 declares it at the top
+// level. Either may name a JSON file (usually "../package.json") whose
+// version is used instead.
+type TauriConfig struct {
+	Version string `json:"version"`
+	Package struct {
+		Version string `json:"version"`
+	} `json:"package"`
+}
+
+// applyDesktopApp classifies the package as an Electron app, a Tauri
+// app, a web app (a frontend framework and no desktop shell) or none,
+// and reports the desktop app version from the config the packager
+// reads. Tauri wins over Electron since its config lives alongside.
+func applyDesktopApp(projectPath string, pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
+	if version, ok := readTauriVersion(projectPath); ok {
+		metadata.LanguageSpecific["app_type"] = appTypeTauri
+		metadata.LanguageSpecific["desktop_app_config"] = filepath.ToSlash(tauriConfigPath)
+		if version != "" {
+			metadata.LanguageSpecific["desktop_app_version"] = version
+		}
+		return
+	}
+
+	if isElectronApp(pkg) {
+		metadata.LanguageSpecific["app_type"] = appTypeElectron
+		metadata.LanguageSpecific["desktop_app_config"] = "package.json"
+		if version := electronAppVersion(pkg); version != "" {
+			metadata.LanguageSpecific["desktop_app_version"] = version
+		}
+		return
+	}
+
+	if len(detectFrameworks(pkg.Dependencies, pkg.DevDependencies)) > 0 {
+		metadata.LanguageSpecific["app_type"] = appTypeWeb
+	} else {
+		metadata.LanguageSpecific["app_type"] = appTypeNone
+	}
+}
+
+// isElectronApp reports an electron dependency or an electron-builder
+// "build" configuration object in package.json.
+func isElectronApp(pkg *PackageJSON) bool {
+	if _, ok := pkg.DevDependencies["electron"]; ok {
+		return true
+	}
+	if _, ok := pkg.Dependencies["electron"]; ok {
+		return true
+	}
+	_, ok := pkg.Build.(map[string]interface{})
+	return ok
+}
+
+// electronAppVersion returns the version electron-builder stamps on the
+// app: build.buildVersion or build.extraMetadata.version when set,
+// otherwise the package version.
+func electronAppVersion(pkg *PackageJSON) string {
+	if build, ok := pkg.Build.(map[string]interface{}); ok {
+		if version, ok := build["buildVersion"].(string); ok && version != "" {
+			return version
+		}
+		if extra, ok := build["extraMetadata"].(map[string]interface{}); ok {
+			if version, ok := extra["version"].(string); ok && version != "" {
+				return version
+			}
+		}
+	}
+	return pkg.Version
+}
+
+// readTauriVersion reads src-tauri/tauri.conf.json, reporting whether
+// the project is a Tauri app and the app version it declares. An
+// unparsable config still marks the project as Tauri, without a version.
+func readTauriVersion(projectPath string) (string, bool) {
+	path := filepath.Join(projectPath, tauriConfigPath)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	var conf TauriConfig
+	if err := json.Unmarshal(content, &conf); err != nil {
+		return "", true
+	}
+	version := conf.Package.Version
+	if version == "" {
+		version = conf.Version
+	}
+	if strings.HasSuffix(version, ".json") {
+		version = readPackageVersion(filepath.Join(filepath.Dir(path), version))
+	}
+	return version, true
+}
+
+// readPackageVersion returns the version declared by the package.json
+// style file at path, or "" when it cannot be read.
+func readPackageVersion(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return ""
+	}
+	return pkg.Version
+}
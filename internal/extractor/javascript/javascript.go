@@ -8,10 +8,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 	"github.com/lfreleng-actions/build-metadata-action/internal/jsonutil"
+	"github.com/lfreleng-actions/build-metadata-action/internal/toolversions"
+	"gopkg.in/yaml.v3"
 )
 
 // Extractor extracts metadata from JavaScript/Node.js projects
@@ -59,6 +64,9 @@ type PackageJSON struct {
 	Volta          map[string]interface{} `json:"volta"`
 
 	Config map[string]interface{} `json:"config"`
+
+	// Build is the electron-builder configuration, when present
+	Build interface{} `json:"build"`
 }
 
 // Author represents a package author
@@ -102,21 +110,55 @@ func (e *Extractor) extractFromPackageJSON(path, projectPath string, metadata *e
 
 	var pkg PackageJSON
 	if err := json.Unmarshal(content, &pkg); err != nil {
-		return fmt.Errorf("failed to parse package.json: %w", err)
+		return fmt.Errorf("failed to parse package.json: %w", extractor.NewManifestError(path, content, err))
 	}
+	metadata.AddConsumedFile(path)
 
 	applyPackageCore(&pkg, metadata)
+	applyNodeVersion(projectPath, &pkg, metadata)
 	applyPackageManager(projectPath, &pkg, metadata)
-	applyPackageWorkspaces(&pkg, metadata)
+	applyBunConfig(projectPath, metadata)
+	applyPackageWorkspaces(projectPath, &pkg, metadata)
 	applyPackageDependencies(&pkg, metadata)
 	applyPackageScripts(&pkg, metadata)
 	applyPackageTooling(&pkg, metadata)
 	applyPackageVersioningType(&pkg, metadata)
 	applyPackageTypeScript(projectPath, &pkg, metadata)
+	applyDesktopApp(projectPath, &pkg, metadata)
+	applyArtifactKind(&pkg, metadata)
+	applyPublishable(&pkg, metadata)
 
 	return nil
 }
 
+// applyPublishable marks the package publishable to npm unless it is
+// private; npm also refuses a package without a name or version.
+func applyPublishable(pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
+	metadata.SetPublishable(extractor.PublishTargetNpm, !pkg.Private && pkg.Name != "" && pkg.Version != "")
+}
+
+// applyArtifactKind classifies the package: a private package cannot be
+// published, so it is an application (a private workspace root is left
+// unclassified); a "bin" makes it an application, or both when it also
+// has a main or module entry; anything else is a library.
+func applyArtifactKind(pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
+	if pkg.Private {
+		if isWorkspace, _ := metadata.LanguageSpecific["is_workspace"].(bool); !isWorkspace {
+			metadata.ArtifactKind = extractor.ArtifactKindApplication
+		}
+		return
+	}
+	hasBin := false
+	switch bin := pkg.Bin.(type) {
+	case string:
+		hasBin = bin != ""
+	case map[string]interface{}:
+		hasBin = len(bin) > 0
+	}
+	hasEntry := pkg.Main != "" || pkg.Module != ""
+	metadata.ArtifactKind = extractor.ClassifyArtifact(!hasBin || hasEntry, hasBin)
+}
+
 // applyPackageCore maps identity fields, license/author/repository, module
 // type, entry points, and Node/npm engine constraints. Module type defaults
 // to commonjs, matching Node's behavior when "type" is absent.
@@ -162,6 +204,35 @@ func applyPackageCore(pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
 	}
 }
 
+// applyNodeVersion resolves the Node.js version the project targets and
+// derives a test matrix from it. engines.node is authoritative; when it
+// is absent the version pinned in .nvmrc, .node-version or .tool-versions
+// (in that order) is used instead. node_version_source records which one
+// won.
+func applyNodeVersion(projectPath string, pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
+	nodeVersion, source := pkg.Engines["node"], "engines.node"
+	if nodeVersion == "" {
+		nodeVersion, source = readNodeVersionFile(projectPath)
+		if nodeVersion != "" {
+			metadata.AddConsumedFile(filepath.Join(projectPath, source))
+		}
+	}
+	if nodeVersion == "" {
+		return
+	}
+
+	metadata.LanguageSpecific["node_version"] = nodeVersion
+	metadata.LanguageSpecific["node_version_source"] = source
+
+	matrix := generateNodeVersionMatrix(nodeVersion)
+	if len(matrix) > 0 {
+		metadata.LanguageSpecific["node_version_matrix"] = matrix
+		matrixJSON := fmt.Sprintf(`{"node-version": [%s]}`,
+			strings.Join(quoteStrings(matrix), ", "))
+		metadata.LanguageSpecific["matrix_json"] = matrixJSON
+	}
+}
+
 // applyPackageManager resolves the package manager and its lock file, recording
 // has_lock_file even when no lock file is present on disk.
 func applyPackageManager(projectPath string, pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
@@ -178,32 +249,183 @@ func applyPackageManager(projectPath string, pkg *PackageJSON, metadata *extract
 }
 
 // applyPackageWorkspaces records monorepo workspace patterns when present.
-func applyPackageWorkspaces(pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
-	if pkg.Workspaces == nil {
+// pnpm ignores the package.json "workspaces" field and reads its patterns
+// from pnpm-workspace.yaml; npm and yarn (classic and berry) use the field.
+// workspace_protocol names the semantics in effect and
+// workspace_package_count the number of packages the patterns resolve to.
+// When the root declares no version, workspace_versions maps each member
+// package's directory to its own version.
+func applyPackageWorkspaces(projectPath string, pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
+	packageManager, _ := metadata.LanguageSpecific["package_manager"].(string)
+
+	var workspaces []string
+	if packageManager == "pnpm" {
+		pnpmWorkspace := filepath.Join(projectPath, "pnpm-workspace.yaml")
+		if workspaces = readPnpmWorkspacePackages(pnpmWorkspace); len(workspaces) > 0 {
+			metadata.AddConsumedFile(pnpmWorkspace)
+		}
+	} else {
+		workspaces = extractWorkspaces(pkg.Workspaces)
+	}
+	if len(workspaces) == 0 {
 		return
 	}
-	workspaces := extractWorkspaces(pkg.Workspaces)
-	if len(workspaces) > 0 {
-		metadata.LanguageSpecific["is_workspace"] = true
-		metadata.LanguageSpecific["workspaces"] = workspaces
-		metadata.LanguageSpecific["workspace_count"] = len(workspaces)
+
+	metadata.LanguageSpecific["is_workspace"] = true
+	metadata.LanguageSpecific["workspaces"] = workspaces
+	metadata.LanguageSpecific["workspace_count"] = len(workspaces)
+	metadata.LanguageSpecific["workspace_protocol"] = workspaceProtocol(packageManager)
+	packageDirs := workspacePackageDirs(projectPath, workspaces)
+	metadata.LanguageSpecific["workspace_package_count"] = len(packageDirs)
+
+	// A versionless root leaves release orchestration to its members
+	if pkg.Version == "" {
+		if versions := workspaceVersions(projectPath, packageDirs, metadata); len(versions) > 0 {
+			metadata.LanguageSpecific["workspace_versions"] = versions
+		}
+	}
+}
+
+// workspaceProtocol maps a package manager onto the workspace semantics it
+// follows. Managers without their own format (e.g. bun) read the
+// package.json field the way npm does.
+func workspaceProtocol(packageManager string) string {
+	switch packageManager {
+	case "yarn", "yarn-berry":
+		return "yarn"
+	case "pnpm":
+		return "pnpm"
+	default:
+		return "npm"
+	}
+}
+
+// readPnpmWorkspacePackages returns the "packages" patterns declared in
+// pnpm-workspace.yaml, or nil when the file is missing or unparsable.
+func readPnpmWorkspacePackages(path string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var workspace struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(content, &workspace); err != nil {
+		return nil
+	}
+	return workspace.Packages
+}
+
+// workspacePackageDirs returns the sorted, slash-separated relative
+// directories holding a package.json that the workspace patterns match.
+// Patterns starting with "!" exclude matches; "**" is treated as a single
+// path segment, which covers the usual "packages/*" and "apps/**" layouts.
+func workspacePackageDirs(projectPath string, patterns []string) []string {
+	var includes, excludes []string
+	for _, pattern := range patterns {
+		if exclude, ok := strings.CutPrefix(pattern, "!"); ok {
+			excludes = append(excludes, normalizeWorkspacePattern(exclude))
+		} else {
+			includes = append(includes, normalizeWorkspacePattern(pattern))
+		}
+	}
+
+	packages := make(map[string]bool)
+	for _, pattern := range includes {
+		matches, err := filepath.Glob(filepath.Join(projectPath, filepath.FromSlash(pattern)))
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			rel, err := filepath.Rel(projectPath, match)
+			if err != nil || isExcludedWorkspace(filepath.ToSlash(rel), excludes) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(match, "package.json")); err == nil {
+				packages[filepath.ToSlash(rel)] = true
+			}
+		}
+	}
+
+	dirs := make([]string, 0, len(packages))
+	for dir := range packages {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// workspaceVersions maps each workspace package directory to the version
+// its package.json declares, skipping members without one or whose
+// package.json cannot be parsed, and records each member read.
+func workspaceVersions(projectPath string, dirs []string, metadata *extractor.ProjectMetadata) map[string]string {
+	versions := make(map[string]string)
+	for _, dir := range dirs {
+		path := filepath.Join(projectPath, filepath.FromSlash(dir), "package.json")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var member struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(content, &member); err != nil || member.Version == "" {
+			continue
+		}
+		metadata.AddConsumedFile(path)
+		versions[dir] = member.Version
+	}
+	return versions
+}
+
+// normalizeWorkspacePattern strips "./" and trailing slashes and collapses
+// "**" so the pattern can be used with filepath.Glob.
+func normalizeWorkspacePattern(pattern string) string {
+	pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "./")
+	pattern = strings.TrimSuffix(pattern, "/")
+	return strings.ReplaceAll(pattern, "**", "*")
+}
+
+// isExcludedWorkspace reports whether a package directory matches one of
+// the exclusion patterns, either as a whole or through any of its path
+// segments (so "*/test/*" also excludes "packages/test").
+func isExcludedWorkspace(rel string, excludes []string) bool {
+	for _, exclude := range excludes {
+		if matched, _ := filepath.Match(exclude, rel); matched {
+			return true
+		}
+		trimmed := strings.Trim(exclude, "*/")
+		if trimmed != "" && !strings.ContainsAny(trimmed, "*?[") {
+			for _, segment := range strings.Split(rel, "/") {
+				if segment == trimmed {
+					return true
+				}
+			}
+		}
 	}
+	return false
 }
 
-// applyPackageDependencies records per-kind and total dependency counts, and
-// the runtime dependency map. Counts are only emitted when at least one
-// dependency of any kind exists.
+// applyPackageDependencies records the standardized runtime, dev and total
+// dependency counts, the per-kind counts, and the runtime dependency map.
+// Counts are only emitted when package.json declares at least one
+// dependency section, even an empty one; without any they are unknown
+// rather than zero.
 func applyPackageDependencies(pkg *PackageJSON, metadata *extractor.ProjectMetadata) {
-	totalDeps := len(pkg.Dependencies) + len(pkg.DevDependencies) +
-		len(pkg.PeerDependencies) + len(pkg.OptionalDependencies)
-	if totalDeps == 0 {
+	if pkg.Dependencies == nil && pkg.DevDependencies == nil &&
+		pkg.PeerDependencies == nil && pkg.OptionalDependencies == nil {
 		return
 	}
+	// Peer and optional dependencies are installed by the consumer or may
+	// be absent, so they only contribute to the total
+	extractor.SetDependencyCounts(metadata, extractor.DependencyCounts{
+		Runtime: len(pkg.Dependencies),
+		Dev:     len(pkg.DevDependencies),
+		Other:   len(pkg.PeerDependencies) + len(pkg.OptionalDependencies),
+	})
 	metadata.LanguageSpecific["dependency_count"] = len(pkg.Dependencies)
-	metadata.LanguageSpecific["dev_dependency_count"] = len(pkg.DevDependencies)
 	metadata.LanguageSpecific["peer_dependency_count"] = len(pkg.PeerDependencies)
 	metadata.LanguageSpecific["optional_dependency_count"] = len(pkg.OptionalDependencies)
-	metadata.LanguageSpecific["total_dependency_count"] = totalDeps
 
 	if len(pkg.Dependencies) > 0 {
 		metadata.LanguageSpecific["dependencies"] = pkg.Dependencies
@@ -400,8 +622,10 @@ func detectPackageManager(projectPath, packageManagerField string) string {
 		return packageManagerField
 	}
 
-	if _, err := os.Stat(filepath.Join(projectPath, "pnpm-lock.yaml")); err == nil {
-		return "pnpm"
+	for _, pnpmFile := range []string{"pnpm-lock.yaml", "pnpm-workspace.yaml"} {
+		if _, err := os.Stat(filepath.Join(projectPath, pnpmFile)); err == nil {
+			return "pnpm"
+		}
 	}
 
 	if _, err := os.Stat(filepath.Join(projectPath, "yarn.lock")); err == nil {
@@ -417,22 +641,107 @@ func detectPackageManager(projectPath, packageManagerField string) string {
 		return "npm"
 	}
 
-	if _, err := os.Stat(filepath.Join(projectPath, "bun.lockb")); err == nil {
-		return "bun"
+	for _, bunFile := range bunLockFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, bunFile)); err == nil {
+			return "bun"
+		}
 	}
 
 	// Default to npm
 	return "npm"
 }
 
-// detectLockFile returns the lock file name and whether it exists
+// nodeVersionFiles lists the version manager files consulted when
+// package.json declares no engines.node, in order of precedence.
+var nodeVersionFiles = []string{".nvmrc", ".node-version"}
+
+// readNodeVersionFile returns the Node.js version pinned by the first
+// version manager file present, along with the file name. The first
+// non-empty, non-comment line is used and a leading "v" is stripped, so
+// both "v20" and "20.11.1" are accepted. The nodejs entry of an asdf
+// .tool-versions file is the last resort.
+func readNodeVersionFile(projectPath string) (string, string) {
+	for _, name := range nodeVersionFiles {
+		content, err := os.ReadFile(filepath.Join(projectPath, name))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			return strings.TrimPrefix(line, "v"), name
+		}
+	}
+	if version, ok := toolversions.Lookup(projectPath, "nodejs", "node"); ok {
+		return strings.TrimPrefix(version, "v"), toolversions.FileName
+	}
+	return "", ""
+}
+
+// nodeLTSVersions is the static table of Node.js LTS release lines used
+// to build the version matrix, oldest first.
+var nodeLTSVersions = []string{"18", "20", "22", "24"}
+
+// nodeMajorPattern captures the first major version number in a Node.js
+// version or range (e.g. "20" from "v20.11.1" or "18" from ">=18.0.0").
+var nodeMajorPattern = regexp.MustCompile(`(\d+)`)
+
+// generateNodeVersionMatrix builds the Node.js test matrix from the
+// resolved major version plus the adjacent newer LTS release lines (at
+// most two). Older lines are never added since they would fall below a
+// minimum constraint such as ">=20". Aliases like "lts/*" or "node"
+// carry no major version and yield no matrix.
+func generateNodeVersionMatrix(nodeVersion string) []string {
+	matches := nodeMajorPattern.FindStringSubmatch(nodeVersion)
+	if len(matches) < 2 {
+		return nil
+	}
+	major, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return nil
+	}
+
+	matrix := []string{strconv.Itoa(major)}
+	for _, lts := range nodeLTSVersions {
+		if len(matrix) == 3 {
+			break
+		}
+		if ltsMajor, _ := strconv.Atoi(lts); ltsMajor > major {
+			matrix = append(matrix, lts)
+		}
+	}
+	return matrix
+}
+
+// quoteStrings adds quotes around each string
+func quoteStrings(strs []string) []string {
+	quoted := make([]string, len(strs))
+	for i, s := range strs {
+		quoted[i] = fmt.Sprintf(`"%s"`, s)
+	}
+	return quoted
+}
+
+// detectLockFile returns the lock file name and whether it exists. Bun
+// has two lock file formats and reports whichever is present, preferring
+// the text bun.lock.
 func detectLockFile(projectPath, packageManager string) (string, bool) {
 	lockFiles := map[string]string{
 		"npm":        "package-lock.json",
 		"yarn":       "yarn.lock",
 		"yarn-berry": "yarn.lock",
 		"pnpm":       "pnpm-lock.yaml",
-		"bun":        "bun.lockb",
+		"bun":        bunLockFiles[0],
+	}
+
+	if packageManager == "bun" {
+		for _, bunFile := range bunLockFiles {
+			if _, err := os.Stat(filepath.Join(projectPath, bunFile)); err == nil {
+				return bunFile, true
+			}
+		}
 	}
 
 	lockFile, ok := lockFiles[packageManager]
@@ -448,24 +757,71 @@ func detectLockFile(projectPath, packageManager string) (string, bool) {
 	return lockFile, false
 }
 
+// packageCheck maps a dependency name to the display name reported when
+// it is present. Checks are kept in ordered slices rather than maps so
+// the detected lists come out in the same order on every run.
+type packageCheck struct {
+	pkg  string
+	name string
+}
+
+// scriptChecks lists the well-known script names in lifecycle order
+var scriptChecks = []string{
+	"build",
+	"test",
+	"start",
+	"dev",
+	"lint",
+	"format",
+	"prepare",
+	"prepublishOnly",
+}
+
+var frameworkChecks = []packageCheck{
+	{"react", "React"},
+	{"vue", "Vue.js"},
+	{"@angular/core", "Angular"},
+	{"next", "Next.js"},
+	{"nuxt", "Nuxt.js"},
+	{"svelte", "Svelte"},
+	{"solid-js", "Solid.js"},
+	{"preact", "Preact"},
+	{"gatsby", "Gatsby"},
+	{"astro", "Astro"},
+	{"@remix-run/react", "Remix"},
+	{"@builder.io/qwik", "Qwik"},
+}
+
+var buildToolChecks = []packageCheck{
+	{"webpack", "Webpack"},
+	{"vite", "Vite"},
+	{"rollup", "Rollup"},
+	{"parcel", "Parcel"},
+	{"esbuild", "esbuild"},
+	{"swc", "SWC"},
+	{"turbopack", "Turbopack"},
+	{"@babel/core", "Babel"},
+	{"typescript", "TypeScript"},
+}
+
+var testingFrameworkChecks = []packageCheck{
+	{"jest", "Jest"},
+	{"vitest", "Vitest"},
+	{"mocha", "Mocha"},
+	{"jasmine", "Jasmine"},
+	{"@playwright/test", "Playwright"},
+	{"cypress", "Cypress"},
+	{"@testing-library/react", "React Testing Library"},
+	{"ava", "AVA"},
+}
+
 // detectScriptPatterns detects common script patterns
 func detectScriptPatterns(scripts map[string]string) []string {
 	patterns := make([]string, 0)
 
-	scriptChecks := map[string]string{
-		"build":          "build",
-		"test":           "test",
-		"start":          "start",
-		"dev":            "dev",
-		"lint":           "lint",
-		"format":         "format",
-		"prepare":        "prepare",
-		"prepublishOnly": "prepublishOnly",
-	}
-
-	for pattern, scriptName := range scriptChecks {
+	for _, scriptName := range scriptChecks {
 		if _, exists := scripts[scriptName]; exists {
-			patterns = append(patterns, pattern)
+			patterns = append(patterns, scriptName)
 		}
 	}
 
@@ -474,88 +830,33 @@ func detectScriptPatterns(scripts map[string]string) []string {
 
 // detectFrameworks detects common JavaScript frameworks
 func detectFrameworks(deps, devDeps map[string]string) []string {
-	frameworks := make([]string, 0)
-
-	frameworkChecks := map[string]string{
-		"react":            "React",
-		"vue":              "Vue.js",
-		"@angular/core":    "Angular",
-		"next":             "Next.js",
-		"nuxt":             "Nuxt.js",
-		"svelte":           "Svelte",
-		"solid-js":         "Solid.js",
-		"preact":           "Preact",
-		"gatsby":           "Gatsby",
-		"astro":            "Astro",
-		"@remix-run/react": "Remix",
-		"@builder.io/qwik": "Qwik",
-	}
-
-	for pkg, name := range frameworkChecks {
-		if _, exists := deps[pkg]; exists {
-			frameworks = append(frameworks, name)
-		}
-		if _, exists := devDeps[pkg]; exists {
-			frameworks = append(frameworks, name)
-		}
-	}
-
-	return frameworks
+	return detectPackages(frameworkChecks, deps, devDeps)
 }
 
 // detectBuildTools detects build tools
 func detectBuildTools(deps, devDeps map[string]string) []string {
-	tools := make([]string, 0)
-
-	toolChecks := map[string]string{
-		"webpack":     "Webpack",
-		"vite":        "Vite",
-		"rollup":      "Rollup",
-		"parcel":      "Parcel",
-		"esbuild":     "esbuild",
-		"swc":         "SWC",
-		"turbopack":   "Turbopack",
-		"@babel/core": "Babel",
-		"typescript":  "TypeScript",
-	}
-
-	for pkg, name := range toolChecks {
-		if _, exists := deps[pkg]; exists {
-			tools = append(tools, name)
-		}
-		if _, exists := devDeps[pkg]; exists {
-			tools = append(tools, name)
-		}
-	}
-
-	return tools
+	return detectPackages(buildToolChecks, deps, devDeps)
 }
 
 // detectTestingFrameworks detects testing frameworks
 func detectTestingFrameworks(deps, devDeps map[string]string) []string {
-	frameworks := make([]string, 0)
+	return detectPackages(testingFrameworkChecks, deps, devDeps)
+}
 
-	testChecks := map[string]string{
-		"jest":                   "Jest",
-		"vitest":                 "Vitest",
-		"mocha":                  "Mocha",
-		"jasmine":                "Jasmine",
-		"@playwright/test":       "Playwright",
-		"cypress":                "Cypress",
-		"@testing-library/react": "React Testing Library",
-		"ava":                    "AVA",
-	}
+// detectPackages returns the display name of every check whose package
+// appears in either dependency set, once each, in check order
+func detectPackages(checks []packageCheck, deps, devDeps map[string]string) []string {
+	found := make([]string, 0)
 
-	for pkg, name := range testChecks {
-		if _, exists := deps[pkg]; exists {
-			frameworks = append(frameworks, name)
-		}
-		if _, exists := devDeps[pkg]; exists {
-			frameworks = append(frameworks, name)
+	for _, check := range checks {
+		if _, exists := deps[check.pkg]; exists {
+			found = append(found, check.name)
+		} else if _, exists := devDeps[check.pkg]; exists {
+			found = append(found, check.name)
 		}
 	}
 
-	return frameworks
+	return found
 }
 
 // detectTypeScript checks if the project uses TypeScript
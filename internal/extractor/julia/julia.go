@@ -4,9 +4,12 @@
 package julia
 
 import (
+	"fmt"
+	"math"
 	"os"
 	"path/filepath"
-	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -107,8 +110,9 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 func (e *Extractor) extractFromProjectToml(path string, metadata *extractor.ProjectMetadata) error {
 	var project ProjectToml
 	if _, err := toml.DecodeFile(path, &project); err != nil {
-		return err
+		return extractor.NewManifestError(path, nil, err)
 	}
+	metadata.AddConsumedFile(path)
 
 	if project.Name != "" {
 		metadata.Name = project.Name
@@ -132,6 +136,7 @@ func (e *Extractor) extractFromProjectToml(path string, metadata *extractor.Proj
 		for dep := range project.Deps {
 			dependencies = append(dependencies, dep)
 		}
+		sort.Strings(dependencies)
 		metadata.LanguageSpecific["dependencies"] = dependencies
 		metadata.LanguageSpecific["dependency_count"] = len(dependencies)
 	}
@@ -143,6 +148,8 @@ func (e *Extractor) extractFromProjectToml(path string, metadata *extractor.Proj
 		matrix := generateJuliaVersionMatrix(juliaCompat)
 		if len(matrix) > 0 {
 			metadata.LanguageSpecific["julia_version_matrix"] = matrix
+			metadata.LanguageSpecific["matrix_json"] = fmt.Sprintf(`{"julia-version": [%s]}`,
+				strings.Join(quoteStrings(matrix), ", "))
 		}
 	}
 
@@ -195,90 +202,197 @@ func (e *Extractor) detectPackageType(projectPath string, metadata *extractor.Pr
 	}
 }
 
-// generateJuliaVersionMatrix generates a matrix of Julia versions
-func generateJuliaVersionMatrix(versionSpec string) []string {
-	versionSpec = strings.ReplaceAll(versionSpec, " ", "")
-
-	if strings.HasPrefix(versionSpec, "^") {
-		// Caret notation: ^1.9 means >=1.9.0, <2.0.0
-		version := strings.TrimPrefix(versionSpec, "^")
-		return generateCaretVersions(version)
-	} else if strings.HasPrefix(versionSpec, "~") {
-		// Tilde notation: ~1.9 means >=1.9.0, <1.10.0
-		version := strings.TrimPrefix(versionSpec, "~")
-		return generateTildeVersions(version)
-	} else if strings.Contains(versionSpec, "-") {
-		// Range notation: 1.6-1.9
-		parts := strings.Split(versionSpec, "-")
-		if len(parts) == 2 {
-			return generateRangeVersions(parts[0], parts[1])
-		}
-	} else if matched, _ := regexp.MatchString(`^[0-9.]+$`, versionSpec); matched {
-		// Exact version
-		return []string{normalizeVersion(versionSpec)}
-	}
+// juliaSupportedVersions lists the Julia release series the version matrix
+// draws from, oldest first: the previous LTS (1.6), the current LTS (1.10)
+// and every release in between and after.
+var juliaSupportedVersions = []string{"1.6", "1.7", "1.8", "1.9", "1.10", "1.11", "1.12"}
+
+// juliaLTSVersion is the current long-term support series; without a
+// usable compat entry the matrix covers it and every newer release.
+const juliaLTSVersion = "1.10"
+
+// juliaVersionBound is an upper bound on a Julia version range
+type juliaVersionBound struct {
+	version   [3]int
+	inclusive bool
+	unbounded bool
+}
 
-	// Default to recent stable versions
-	return []string{"1.9", "1.10"}
+// juliaVersionRange is a version interval described by one compat entry
+type juliaVersionRange struct {
+	lower [3]int
+	upper juliaVersionBound
 }
 
-// generateCaretVersions generates versions for caret notation
-func generateCaretVersions(version string) []string {
-	parts := strings.Split(version, ".")
-	if len(parts) < 2 {
-		return []string{"1.9", "1.10"}
+// generateJuliaVersionMatrix returns the supported Julia series that
+// satisfy a Pkg compat specifier. Comma-separated entries are a union; a
+// bare or caret version ("1.9", "^1.9") accepts anything up to the next
+// breaking release, "~" pins the minor series, "=" an exact version, and
+// "a - b" and inequalities (">=", "<", "≥", "≤") give explicit bounds.
+func generateJuliaVersionMatrix(versionSpec string) []string {
+	var ranges []juliaVersionRange
+	for _, entry := range strings.Split(versionSpec, ",") {
+		if r, ok := parseJuliaCompatEntry(strings.TrimSpace(entry)); ok {
+			ranges = append(ranges, r)
+		}
 	}
 
-	major := parts[0]
-	if major == "1" {
-		// Julia 1.x series
-		return []string{"1.9", "1.10"}
+	var matrix []string
+	if len(ranges) > 0 {
+		for _, series := range juliaSupportedVersions {
+			for _, r := range ranges {
+				if r.containsSeries(series) {
+					matrix = append(matrix, series)
+					break
+				}
+			}
+		}
+	}
+	if len(matrix) > 0 {
+		return matrix
 	}
 
-	return []string{version}
+	// Default to the LTS and newer releases
+	for i, series := range juliaSupportedVersions {
+		if series == juliaLTSVersion {
+			return append([]string(nil), juliaSupportedVersions[i:]...)
+		}
+	}
+	return nil
 }
 
-// generateTildeVersions generates versions for tilde notation
-func generateTildeVersions(version string) []string {
-	parts := strings.Split(version, ".")
-	if len(parts) < 2 {
-		return []string{version}
+// parseJuliaCompatEntry parses a single compat specifier into a range
+func parseJuliaCompatEntry(entry string) (juliaVersionRange, bool) {
+	if entry == "" {
+		return juliaVersionRange{}, false
 	}
 
-	// ~1.9 means 1.9.x
-	major := parts[0]
-	minor := parts[1]
+	if lower, upper, ok := strings.Cut(entry, "-"); ok {
+		lowVersion, _, okLow := parseJuliaVersion(lower)
+		highVersion, highParts, okHigh := parseJuliaVersion(upper)
+		if !okLow || !okHigh {
+			return juliaVersionRange{}, false
+		}
+		return juliaVersionRange{lower: lowVersion, upper: wildcardUpperBound(highVersion, highParts)}, true
+	}
 
-	if major == "1" && minor == "9" {
-		return []string{"1.9"}
-	} else if major == "1" && minor == "10" {
-		return []string{"1.10"}
+	for _, op := range []string{">=", "≥", "<=", "≤", ">", "<", "=", "^", "~"} {
+		rest, ok := strings.CutPrefix(entry, op)
+		if !ok {
+			continue
+		}
+		version, parts, ok := parseJuliaVersion(rest)
+		if !ok {
+			return juliaVersionRange{}, false
+		}
+		switch op {
+		case ">=", "≥", ">":
+			return juliaVersionRange{lower: version, upper: juliaVersionBound{unbounded: true}}, true
+		case "<=", "≤":
+			return juliaVersionRange{upper: juliaVersionBound{version: version, inclusive: true}}, true
+		case "<":
+			return juliaVersionRange{upper: juliaVersionBound{version: version}}, true
+		case "=":
+			return juliaVersionRange{lower: version, upper: juliaVersionBound{version: version, inclusive: true}}, true
+		case "~":
+			upper := [3]int{version[0] + 1, 0, 0}
+			if parts > 1 {
+				upper = [3]int{version[0], version[1] + 1, 0}
+			}
+			return juliaVersionRange{lower: version, upper: juliaVersionBound{version: upper}}, true
+		}
+		return caretRange(version, parts), true
 	}
 
-	return []string{major + "." + minor}
+	version, parts, ok := parseJuliaVersion(entry)
+	if !ok {
+		return juliaVersionRange{}, false
+	}
+	return caretRange(version, parts), true
 }
 
-// generateRangeVersions generates versions for range notation
-func generateRangeVersions(start, end string) []string {
-	// Simplified: return start, middle, and end versions
-	startNorm := normalizeVersion(start)
-	endNorm := normalizeVersion(end)
+// caretRange applies caret semantics: the leftmost non-zero component
+// (or the last given one) may not change
+func caretRange(version [3]int, parts int) juliaVersionRange {
+	var upper [3]int
+	switch {
+	case version[0] > 0 || parts == 1:
+		upper = [3]int{version[0] + 1, 0, 0}
+	case version[1] > 0 || parts == 2:
+		upper = [3]int{0, version[1] + 1, 0}
+	default:
+		upper = [3]int{0, 0, version[2] + 1}
+	}
+	return juliaVersionRange{lower: version, upper: juliaVersionBound{version: upper}}
+}
 
-	versions := []string{startNorm}
+// wildcardUpperBound treats missing components of a hyphen range's upper
+// end as wildcards, so "1.6 - 1.9" includes every 1.9.x release
+func wildcardUpperBound(version [3]int, parts int) juliaVersionBound {
+	switch parts {
+	case 1:
+		return juliaVersionBound{version: [3]int{version[0] + 1, 0, 0}}
+	case 2:
+		return juliaVersionBound{version: [3]int{version[0], version[1] + 1, 0}}
+	}
+	return juliaVersionBound{version: version, inclusive: true}
+}
+
+// parseJuliaVersion parses "1", "1.9" or "1.9.4" (with an optional "v"
+// prefix), returning the components and how many were given
+func parseJuliaVersion(version string) ([3]int, int, bool) {
+	var result [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	fields := strings.Split(version, ".")
+	if version == "" || len(fields) > 3 {
+		return result, 0, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return result, 0, false
+		}
+		result[i] = n
+	}
+	return result, len(fields), true
+}
 
-	// Add middle version if range is large enough
-	if startNorm != endNorm {
-		versions = append(versions, endNorm)
+// containsSeries reports whether any release of a major.minor series
+// falls inside the range
+func (r juliaVersionRange) containsSeries(series string) bool {
+	version, _, ok := parseJuliaVersion(series)
+	if !ok {
+		return false
+	}
+	seriesEnd := [3]int{version[0], version[1], math.MaxInt}
+	if compareJuliaVersions(seriesEnd, r.lower) < 0 {
+		return false
+	}
+	if r.upper.unbounded {
+		return true
 	}
+	c := compareJuliaVersions(version, r.upper.version)
+	return c < 0 || (c == 0 && r.upper.inclusive)
+}
 
-	return versions
+// compareJuliaVersions orders two versions component by component
+func compareJuliaVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
 }
 
-// normalizeVersion normalizes a version string to major.minor format
-func normalizeVersion(version string) string {
-	parts := strings.Split(version, ".")
-	if len(parts) >= 2 {
-		return parts[0] + "." + parts[1]
+// quoteStrings adds quotes around each string
+func quoteStrings(strs []string) []string {
+	quoted := make([]string, len(strs))
+	for i, s := range strs {
+		quoted[i] = fmt.Sprintf(`"%s"`, s)
 	}
-	return version
+	return quoted
 }
@@ -133,6 +133,38 @@ CSV = "0.10"
 	assert.Equal(t, 3, metadata.LanguageSpecific["dependency_count"])
 }
 
+func TestExtractCompatMatrix(t *testing.T) {
+	projectTomlContent := `name = "CompatPackage"
+uuid = "87654321-4321-4321-4321-cba987654321"
+version = "0.3.0"
+
+[deps]
+JSON3 = "0f8b85d8-7281-11e9-16c2-39a750bddbf1"
+StaticArrays = "90137ffa-7385-5640-81b9-e52037218182"
+
+[compat]
+julia = "1.6"
+JSON3 = "1"
+`
+
+	tmpDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tmpDir, "Project.toml"), []byte(projectTomlContent), 0644)
+	require.NoError(t, err)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "CompatPackage", metadata.Name)
+	assert.Equal(t, "0.3.0", metadata.Version)
+	assert.Equal(t, "87654321-4321-4321-4321-cba987654321", metadata.LanguageSpecific["uuid"])
+	assert.Equal(t, "1.6", metadata.LanguageSpecific["julia_version"])
+	assert.Equal(t, []string{"JSON3", "StaticArrays"}, metadata.LanguageSpecific["dependencies"])
+	assert.Equal(t, 2, metadata.LanguageSpecific["dependency_count"])
+	assert.Equal(t, map[string]string{"JSON3": "1"}, metadata.LanguageSpecific["compat"])
+	assert.Equal(t, []string{"1.6", "1.7", "1.8", "1.9", "1.10", "1.11", "1.12"}, metadata.LanguageSpecific["julia_version_matrix"])
+	assert.Equal(t, `{"julia-version": ["1.6", "1.7", "1.8", "1.9", "1.10", "1.11", "1.12"]}`, metadata.LanguageSpecific["matrix_json"])
+}
+
 func TestExtractMinimal(t *testing.T) {
 	projectTomlContent := `name = "MinimalPackage"
 version = "0.1.0"
@@ -272,7 +304,12 @@ func TestGenerateJuliaVersionMatrix(t *testing.T) {
 		{
 			name:        "caret notation 1.9",
 			versionSpec: "^1.9",
-			expected:    []string{"1.9", "1.10"},
+			expected:    []string{"1.9", "1.10", "1.11", "1.12"},
+		},
+		{
+			name:        "bare version uses caret semantics",
+			versionSpec: "1.9.4",
+			expected:    []string{"1.9", "1.10", "1.11", "1.12"},
 		},
 		{
 			name:        "tilde notation 1.9",
@@ -287,54 +324,48 @@ func TestGenerateJuliaVersionMatrix(t *testing.T) {
 		{
 			name:        "range notation",
 			versionSpec: "1.6-1.9",
-			expected:    []string{"1.6", "1.9"},
+			expected:    []string{"1.6", "1.7", "1.8", "1.9"},
+		},
+		{
+			name:        "spaced range notation",
+			versionSpec: "1.6 - 1.8",
+			expected:    []string{"1.6", "1.7", "1.8"},
 		},
 		{
 			name:        "exact version",
-			versionSpec: "1.9.4",
+			versionSpec: "=1.9.4",
 			expected:    []string{"1.9"},
 		},
 		{
-			name:        "default for unknown",
-			versionSpec: "unknown",
-			expected:    []string{"1.9", "1.10"},
+			name:        "union of entries",
+			versionSpec: "~1.6, 1.10",
+			expected:    []string{"1.6", "1.10", "1.11", "1.12"},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := generateJuliaVersionMatrix(tt.versionSpec)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-func TestNormalizeVersion(t *testing.T) {
-	tests := []struct {
-		name     string
-		version  string
-		expected string
-	}{
 		{
-			name:     "three parts",
-			version:  "1.9.4",
-			expected: "1.9",
+			name:        "inequalities",
+			versionSpec: ">= 1.8, < 1.7",
+			expected:    []string{"1.6", "1.8", "1.9", "1.10", "1.11", "1.12"},
 		},
 		{
-			name:     "two parts",
-			version:  "1.9",
-			expected: "1.9",
+			name:        "exclusive upper bound",
+			versionSpec: "<1.8",
+			expected:    []string{"1.6", "1.7"},
 		},
 		{
-			name:     "one part",
-			version:  "1",
-			expected: "1",
+			name:        "default for unknown",
+			versionSpec: "unknown",
+			expected:    []string{"1.10", "1.11", "1.12"},
+		},
+		{
+			name:        "default when nothing supported matches",
+			versionSpec: "~1.3",
+			expected:    []string{"1.10", "1.11", "1.12"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := normalizeVersion(tt.version)
+			result := generateJuliaVersionMatrix(tt.versionSpec)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
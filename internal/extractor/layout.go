@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// testDirCandidates are the conventional test directories, relative to
+// the project root, across ecosystems: test/ (Ruby minitest, Node),
+// tests/ (Python, Rust integration tests), spec/ (RSpec), __tests__/
+// (Jest) and src/test/ (Maven and Gradle)
+var testDirCandidates = []string{"test", "tests", "spec", "__tests__", "src/test"}
+
+// sourceDirCandidates are the conventional source directories, relative
+// to the project root
+var sourceDirCandidates = []string{"src", "lib", "app"}
+
+// SourceLayout lists the conventional test and source directories a
+// project has, as slash-separated paths relative to its root
+type SourceLayout struct {
+	TestDirs   []string
+	SourceDirs []string
+}
+
+// DetectSourceLayout looks for the conventional test and source
+// directories at the root of projectPath, in candidate order.
+func DetectSourceLayout(projectPath string) SourceLayout {
+	return SourceLayout{
+		TestDirs:   existingDirs(projectPath, testDirCandidates),
+		SourceDirs: existingDirs(projectPath, sourceDirCandidates),
+	}
+}
+
+// HasTestDir reports whether the layout includes the test directory dir.
+func (l SourceLayout) HasTestDir(dir string) bool {
+	for _, testDir := range l.TestDirs {
+		if testDir == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// existingDirs returns the candidates that are directories under root.
+func existingDirs(root string, candidates []string) []string {
+	var dirs []string
+	for _, candidate := range candidates {
+		if info, err := os.Stat(filepath.Join(root, filepath.FromSlash(candidate))); err == nil && info.IsDir() {
+			dirs = append(dirs, candidate)
+		}
+	}
+	return dirs
+}
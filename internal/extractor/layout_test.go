@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetectSourceLayout(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"tests", "src/test", "src/main", "docs"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A file named like a candidate directory does not count
+	if err := os.WriteFile(filepath.Join(dir, "lib"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	layout := DetectSourceLayout(dir)
+	if want := []string{"tests", "src/test"}; !reflect.DeepEqual(layout.TestDirs, want) {
+		t.Errorf("TestDirs = %v, want %v", layout.TestDirs, want)
+	}
+	if want := []string{"src"}; !reflect.DeepEqual(layout.SourceDirs, want) {
+		t.Errorf("SourceDirs = %v, want %v", layout.SourceDirs, want)
+	}
+	if !layout.HasTestDir("tests") || layout.HasTestDir("spec") {
+		t.Errorf("HasTestDir() disagrees with TestDirs %v", layout.TestDirs)
+	}
+
+	if layout := DetectSourceLayout(t.TempDir()); layout.TestDirs != nil || layout.SourceDirs != nil {
+		t.Errorf("DetectSourceLayout() of an empty project = %+v, want nothing", layout)
+	}
+}
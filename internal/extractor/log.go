@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// LogLevel controls which diagnostic lines extractors write through
+// Debugf. Workflow warnings and errors (::warning::, ::error::) are not
+// routed through the logger and always surface.
+type LogLevel int
+
+const (
+	// LogQuiet suppresses all informational and debug lines
+	LogQuiet LogLevel = iota
+	// LogNormal is the default: debug lines stay hidden
+	LogNormal
+	// LogVerbose adds the debug lines extractors emit
+	LogVerbose
+)
+
+var (
+	logMu    sync.Mutex
+	logLevel = logLevelFromEnv()
+	// logOutput overrides the destination; nil writes to the current
+	// os.Stderr
+	logOutput io.Writer
+	// suppressedWarnings holds the warning categories not to write
+	suppressedWarnings map[string]bool
+)
+
+// Warning categories, the keywords the suppress_warnings input accepts.
+// Each names a family of warnings that are often benign for a given
+// repository; errors are never categorized.
+const (
+	// WarnNoExtractor: the detected project type has no extractor
+	WarnNoExtractor = "no-extractor"
+	// WarnVersionEmpty: no project version could be determined
+	WarnVersionEmpty = "version-empty"
+	// WarnEmptyRequiresPython: a Python project declares no
+	// requires-python, so a fallback matrix is used
+	WarnEmptyRequiresPython = "empty-requires-python"
+	// WarnEOLFallback: live end-of-life data was unavailable and the
+	// static supported versions were used
+	WarnEOLFallback = "eol-fallback"
+	// WarnShallowClone: the checkout is a shallow git clone
+	WarnShallowClone = "shallow-clone"
+)
+
+// WarningCategories lists every warning category, in order
+var WarningCategories = []string{
+	WarnNoExtractor,
+	WarnVersionEmpty,
+	WarnEmptyRequiresPython,
+	WarnEOLFallback,
+	WarnShallowClone,
+}
+
+// logLevelFromEnv derives the default level from the INPUT_QUIET and
+// INPUT_VERBOSE action inputs, so extractors honor them even when the
+// caller never sets a level.
+func logLevelFromEnv() LogLevel {
+	switch {
+	case os.Getenv("INPUT_QUIET") == "true":
+		return LogQuiet
+	case os.Getenv("INPUT_VERBOSE") == "true":
+		return LogVerbose
+	}
+	return LogNormal
+}
+
+// SetLogLevel sets the level for subsequent extractor log lines.
+func SetLogLevel(level LogLevel) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	logLevel = level
+}
+
+// SetLogOutput redirects extractor log lines, stderr by default (nil),
+// and returns the previous writer so tests can restore it.
+func SetLogOutput(w io.Writer) io.Writer {
+	logMu.Lock()
+	defer logMu.Unlock()
+	previous := logOutput
+	logOutput = w
+	return previous
+}
+
+// Debugf writes a debug line when the level is LogVerbose. The format
+// carries its own prefix and trailing newline, matching the
+// "[DEBUG] ..." lines extractors have always written to stderr.
+func Debugf(format string, args ...interface{}) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	if logLevel < LogVerbose {
+		return
+	}
+	w := logOutput
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, format, args...)
+}
+
+// SetSuppressedWarnings replaces the warning categories Warnf and
+// DebugWarnf drop.
+func SetSuppressedWarnings(categories []string) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	suppressedWarnings = make(map[string]bool, len(categories))
+	for _, category := range categories {
+		suppressedWarnings[category] = true
+	}
+}
+
+// WarningSuppressed reports whether warnings in category are dropped.
+func WarningSuppressed(category string) bool {
+	logMu.Lock()
+	defer logMu.Unlock()
+	return suppressedWarnings[category]
+}
+
+// Warnf writes a warning line in category, at every log level, unless
+// the category is suppressed. Like Debugf the format carries its own
+// prefix ("::warning::" or "[WARNING]") and trailing newline.
+func Warnf(category, format string, args ...interface{}) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	if suppressedWarnings[category] {
+		return
+	}
+	w := logOutput
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, format, args...)
+}
+
+// DebugWarnf is Debugf for a verbose-only warning in category; it is
+// dropped when the category is suppressed.
+func DebugWarnf(category, format string, args ...interface{}) {
+	if WarningSuppressed(category) {
+		return
+	}
+	Debugf(format, args...)
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWarnfSuppression(t *testing.T) {
+	var buf bytes.Buffer
+	previous := SetLogOutput(&buf)
+	defer SetLogOutput(previous)
+	defer SetSuppressedWarnings(nil)
+
+	SetSuppressedWarnings(nil)
+	Warnf(WarnEOLFallback, "[WARNING] live EOL data unavailable\n")
+	if buf.String() != "[WARNING] live EOL data unavailable\n" {
+		t.Errorf("unsuppressed warning wrote %q", buf.String())
+	}
+
+	buf.Reset()
+	SetSuppressedWarnings([]string{WarnEOLFallback})
+	if !WarningSuppressed(WarnEOLFallback) {
+		t.Error("WarningSuppressed() = false for a listed category")
+	}
+	Warnf(WarnEOLFallback, "[WARNING] live EOL data unavailable\n")
+	if buf.Len() != 0 {
+		t.Errorf("suppressed warning wrote %q", buf.String())
+	}
+
+	// Other categories still show
+	Warnf(WarnShallowClone, "::warning::shallow clone\n")
+	if buf.String() != "::warning::shallow clone\n" {
+		t.Errorf("warning in an unlisted category wrote %q", buf.String())
+	}
+}
+
+func TestDebugWarnfSuppression(t *testing.T) {
+	var buf bytes.Buffer
+	previous := SetLogOutput(&buf)
+	defer SetLogOutput(previous)
+	defer SetLogLevel(logLevelFromEnv())
+	defer SetSuppressedWarnings(nil)
+
+	SetLogLevel(LogVerbose)
+	SetSuppressedWarnings([]string{WarnEmptyRequiresPython})
+	DebugWarnf(WarnEmptyRequiresPython, "[WARNING] requires-python is empty\n")
+	if buf.Len() != 0 {
+		t.Errorf("suppressed debug warning wrote %q", buf.String())
+	}
+
+	SetSuppressedWarnings(nil)
+	DebugWarnf(WarnEmptyRequiresPython, "[WARNING] requires-python is empty\n")
+	if buf.String() != "[WARNING] requires-python is empty\n" {
+		t.Errorf("unsuppressed debug warning wrote %q", buf.String())
+	}
+
+	// Debug warnings stay behind the verbose level
+	buf.Reset()
+	SetLogLevel(LogNormal)
+	DebugWarnf(WarnEmptyRequiresPython, "[WARNING] requires-python is empty\n")
+	if buf.Len() != 0 {
+		t.Errorf("debug warning at the normal level wrote %q", buf.String())
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+// yamlErrorLineRe matches the line number yaml.v3 embeds in its messages
+var yamlErrorLineRe = regexp.MustCompile(`\bline (\d+)\b`)
+
+// ManifestError reports a manifest that exists but is malformed, as
+// opposed to a project the extractor cannot handle. Extractors wrap
+// TOML/JSON/XML/YAML decode failures in it so callers can tell corrupt
+// manifests apart from other extraction failures (see the
+// strict_manifest input).
+type ManifestError struct {
+	// File is the manifest path
+	File string
+	// Line is the 1-based line of the fault, or 0 when unknown
+	Line int
+	// Err is the underlying decode error
+	Err error
+}
+
+// NewManifestError wraps a decode error for file, deriving the line from
+// the decoder's error where it carries one. content is the decoded text
+// and is only used to turn a JSON byte offset into a line.
+func NewManifestError(file string, content []byte, err error) *ManifestError {
+	return &ManifestError{File: file, Line: decodeErrorLine(content, err), Err: err}
+}
+
+// Error renders "file:line: message", omitting the line when unknown
+func (e *ManifestError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.File, e.Err)
+}
+
+// Unwrap returns the underlying decode error
+func (e *ManifestError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every ManifestError match ErrParseFailure
+func (e *ManifestError) Is(target error) bool {
+	return target == ErrParseFailure
+}
+
+// decodeErrorLine extracts the line number from the error types of the
+// TOML, JSON, XML and YAML decoders used by the extractors.
+func decodeErrorLine(content []byte, err error) int {
+	var tomlErr toml.ParseError
+	if errors.As(err, &tomlErr) {
+		return tomlErr.Position.Line
+	}
+	var xmlErr *xml.SyntaxError
+	if errors.As(err, &xmlErr) {
+		return xmlErr.Line
+	}
+	var jsonSyntaxErr *json.SyntaxError
+	if errors.As(err, &jsonSyntaxErr) {
+		return offsetLine(content, jsonSyntaxErr.Offset)
+	}
+	var jsonTypeErr *json.UnmarshalTypeError
+	if errors.As(err, &jsonTypeErr) {
+		return offsetLine(content, jsonTypeErr.Offset)
+	}
+	if err != nil {
+		if m := yamlErrorLineRe.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			return line
+		}
+	}
+	return 0
+}
+
+// offsetLine converts a byte offset into content to a 1-based line
+func offsetLine(content []byte, offset int64) int {
+	if offset <= 0 || len(content) == 0 {
+		return 0
+	}
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OCIAnnotationPrefix is the key prefix of the pre-defined OCI image
+// annotations (https://github.com/opencontainers/image-spec/blob/main/annotations.md)
+const OCIAnnotationPrefix = "org.opencontainers.image."
+
+// ociAnnotationFields are the annotations normalized into the
+// "oci_annotations" map, keyed by their name without OCIAnnotationPrefix
+var ociAnnotationFields = map[string]bool{
+	"version":  true,
+	"source":   true,
+	"revision": true,
+	"title":    true,
+}
+
+// MergeOCIAnnotations copies the recognised org.opencontainers.image.*
+// entries of labels into annotations, keyed by field name ("version",
+// "source", "revision", "title"). Fields already set in annotations are
+// kept, so callers merge sources in order of precedence. Empty values are
+// ignored.
+func MergeOCIAnnotations(annotations, labels map[string]string) {
+	for key, value := range labels {
+		field, ok := strings.CutPrefix(key, OCIAnnotationPrefix)
+		if !ok || !ociAnnotationFields[field] || value == "" {
+			continue
+		}
+		if _, exists := annotations[field]; !exists {
+			annotations[field] = value
+		}
+	}
+}
+
+// FindOCIAnnotationLabels walks a decoded YAML or JSON document and
+// returns every org.opencontainers.image.* entry it contains, wherever it
+// is nested: map keys (Helm "podAnnotations", compose "labels" maps) as
+// well as "key=value" list items (compose "labels" lists). The walk is
+// depth-first, visiting map keys in sorted order and list items in
+// document order, and when a key occurs more than once the first one
+// visited wins, so the result is the same on every run.
+func FindOCIAnnotationLabels(document interface{}) map[string]string {
+	labels := make(map[string]string)
+	collectOCIAnnotationLabels(document, labels)
+	return labels
+}
+
+func collectOCIAnnotationLabels(value interface{}, labels map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			item := v[key]
+			if strings.HasPrefix(key, OCIAnnotationPrefix) {
+				if _, exists := labels[key]; !exists && item != nil {
+					labels[key] = fmt.Sprint(item)
+				}
+				continue
+			}
+			collectOCIAnnotationLabels(item, labels)
+		}
+	case []interface{}:
+		for _, item := range v {
+			if entry, ok := item.(string); ok {
+				key, labelValue, found := strings.Cut(entry, "=")
+				if found && strings.HasPrefix(key, OCIAnnotationPrefix) {
+					if _, exists := labels[key]; !exists {
+						labels[key] = labelValue
+					}
+				}
+				continue
+			}
+			collectOCIAnnotationLabels(item, labels)
+		}
+	}
+}
+
+// SetOCIAnnotations records annotations as LanguageSpecific
+// "oci_annotations" when any field was found.
+func SetOCIAnnotations(metadata *ProjectMetadata, annotations map[string]string) {
+	if len(annotations) == 0 {
+		return
+	}
+	if metadata.LanguageSpecific == nil {
+		metadata.LanguageSpecific = make(map[string]interface{})
+	}
+	metadata.LanguageSpecific["oci_annotations"] = annotations
+}
@@ -118,8 +118,9 @@ func (e *Extractor) extractFromComposerJSON(path string, metadata *extractor.Pro
 
 	var composer ComposerJSON
 	if err := json.Unmarshal(content, &composer); err != nil {
-		return fmt.Errorf("failed to parse composer.json: %w", err)
+		return fmt.Errorf("failed to parse composer.json: %w", extractor.NewManifestError(path, content, err))
 	}
+	metadata.AddConsumedFile(path)
 
 	applyComposerCore(&composer, metadata)
 	applyComposerPackageInfo(&composer, metadata)
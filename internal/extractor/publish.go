@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+// Values of ProjectMetadata.PublishTarget, naming the registry a
+// publishable project is released to.
+const (
+	PublishTargetCratesIO     = "crates.io"
+	PublishTargetNpm          = "npm"
+	PublishTargetPyPI         = "pypi"
+	PublishTargetNuGet        = "nuget"
+	PublishTargetMavenCentral = "maven-central"
+)
+
+// SetPublishable records whether the project can be published to target.
+// The target is kept for an unpublishable project too, so consumers can
+// tell which registry the verdict refers to.
+func (m *ProjectMetadata) SetPublishable(target string, publishable bool) {
+	m.Publishable = &publishable
+	m.PublishTarget = target
+}
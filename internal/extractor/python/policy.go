@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 	"github.com/lfreleng-actions/build-metadata-action/internal/pyversions"
 )
 
@@ -108,7 +109,7 @@ func ResolvePolicy(offline bool, timeout time.Duration, maxRetries int) *Policy
 	client := pyversions.NewEOLClient(timeout, maxRetries)
 	data, err := client.FetchEOLData()
 	if err != nil {
-		fmt.Fprintf(os.Stderr,
+		extractor.DebugWarnf(extractor.WarnEOLFallback,
 			"[WARNING] Failed to fetch live Python EOL data (%v); using static supported set\n", err)
 		p.SupportedSet = append([]string(nil), supportedPythonVersions...)
 		p.LiveFallbackUsed = true
@@ -153,7 +154,7 @@ func ResolvePolicy(offline bool, timeout time.Duration, maxRetries int) *Policy
 		cycles = append(cycles, cycle)
 	}
 	if len(cycles) == 0 {
-		fmt.Fprintf(os.Stderr,
+		extractor.DebugWarnf(extractor.WarnEOLFallback,
 			"[WARNING] Live EOL data yielded no Python versions in the %s..%s range; using static supported set\n",
 			floor, ceiling)
 		p.SupportedSet = append([]string(nil), supportedPythonVersions...)
@@ -190,16 +191,12 @@ func detectEOLInMatrix(matrix []string, p *Policy) []string {
 	return hits
 }
 
-// debugf writes a debug log line to stderr only when INPUT_VERBOSE is
-// set to "true" (matching the convention used by other extractors and
-// documented by the action's `verbose` input). Without this gate, the
+// debugf writes a debug log line through the extractor logger, which
+// prints it only when verbose is enabled. Without this gate, the
 // extractor would spam logs even on quiet runs and bury more important
 // `::warning::` / `::error::` lines.
 func debugf(format string, args ...interface{}) {
-	if os.Getenv("INPUT_VERBOSE") != "true" {
-		return
-	}
-	fmt.Fprintf(os.Stderr, format, args...)
+	extractor.Debugf(format, args...)
 }
 
 // writeOutOfRangeStepSummary appends a notice to the GitHub Actions
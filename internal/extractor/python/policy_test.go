@@ -4,9 +4,12 @@
 package python
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -292,3 +295,43 @@ func TestPolicyEnsuresActiveIsNeverNil(t *testing.T) {
 	withPolicy(t, nil) // SetActivePolicy(nil) installs the default
 	assert.NotNil(t, ActivePolicy())
 }
+
+// TestDebugLoggingFollowsLogLevel confirms the extractor's [DEBUG] and
+// [WARNING] diagnostics reach the log only at the verbose level: quiet
+// and default runs stay silent.
+func TestDebugLoggingFollowsLogLevel(t *testing.T) {
+	withPolicy(t, &Policy{
+		Offline:      true,
+		SupportedSet: []string{"3.12", "3.13"},
+		EOLVersions:  map[string]bool{},
+	})
+	var buf bytes.Buffer
+	prev := extractor.SetLogOutput(&buf)
+	t.Cleanup(func() {
+		extractor.SetLogOutput(prev)
+		extractor.SetLogLevel(extractor.LogNormal)
+	})
+
+	// No version signal, so the extractor logs the fallback matrix
+	tmpDir := createTempProject(t, map[string]string{"setup.cfg": "[metadata]\nname = quiet-pkg\n"})
+	t.Cleanup(func() { _ = os.RemoveAll(tmpDir) })
+
+	for _, tt := range []struct {
+		level  extractor.LogLevel
+		logged bool
+	}{
+		{extractor.LogQuiet, false},
+		{extractor.LogNormal, false},
+		{extractor.LogVerbose, true},
+	} {
+		buf.Reset()
+		extractor.SetLogLevel(tt.level)
+		_, err := NewExtractor().Extract(tmpDir)
+		require.NoError(t, err)
+		if tt.logged {
+			assert.Contains(t, buf.String(), "[WARNING] setup.cfg does not declare requires-python")
+		} else {
+			assert.Empty(t, buf.String(), "level %d must not log debug lines", tt.level)
+		}
+	}
+}
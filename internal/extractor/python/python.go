@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/lfreleng-actions/build-metadata-action/internal/toolversions"
 )
 
 // Extractor extracts metadata from Python projects
@@ -84,6 +86,13 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 			return nil, err
 		}
 		if handled {
+			metadata.AddConsumedFile(files.pyprojectPath)
+			applyPythonDependencyCounts(metadata)
+			applyPythonTooling(projectPath, metadata)
+			applyRequirementsFiles(projectPath, metadata)
+			applyToolVersionsPython(projectPath, metadata)
+			backend, _ := metadata.LanguageSpecific["build_backend"].(string)
+			applyPythonPublishable(metadata, backend != "" || files.setupPyExists)
 			return metadata, nil
 		}
 		// pyproject.toml exists but has no [project] section; fall
@@ -96,6 +105,7 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 			return nil, fmt.Errorf("found setup.cfg but failed to parse it: %w\n\nFiles found: %s\nFiles not found: %s",
 				err, strings.Join(files.filesFound, ", "), strings.Join(files.filesNotFound, ", "))
 		}
+		metadata.AddConsumedFile(files.setupCfgPath)
 		// Canonical PBR layout pairs declarative setup.cfg with a tiny
 		// setup.py shim such as `setup(setup_requires=['pbr'], pbr=True)`.
 		// Cross-reference setup.py when the cfg-derived versioning_type is
@@ -112,6 +122,11 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 			loadRequirementsTxt(projectPath, metadata)
 		}
 		applyFallbackPythonMatrix(metadata, "setup.cfg")
+		applyPythonDependencyCounts(metadata)
+		applyPythonTooling(projectPath, metadata)
+		applyRequirementsFiles(projectPath, metadata)
+		applyToolVersionsPython(projectPath, metadata)
+		applyPythonPublishable(metadata, true)
 		return metadata, nil
 	}
 
@@ -121,15 +136,27 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 			return nil, fmt.Errorf("found setup.py but failed to parse it: %w\n\nFiles found: %s\nFiles not found: %s",
 				err, strings.Join(files.filesFound, ", "), strings.Join(files.filesNotFound, ", "))
 		}
+		metadata.AddConsumedFile(files.setupPyPath)
 		if _, hasDeps := metadata.LanguageSpecific["dependencies"]; !hasDeps {
 			loadRequirementsTxt(projectPath, metadata)
 		}
 		applyFallbackPythonMatrix(metadata, "setup.py")
+		applyPythonDependencyCounts(metadata)
+		applyPythonTooling(projectPath, metadata)
+		applyRequirementsFiles(projectPath, metadata)
+		applyToolVersionsPython(projectPath, metadata)
+		applyPythonPublishable(metadata, true)
 		return metadata, nil
 	}
 
-	return nil, fmt.Errorf("no Python project files found in %s\n\nSearched for: pyproject.toml, setup.cfg, setup.py\nFiles found: %s\nFiles not found: %s",
-		projectPath, strings.Join(files.filesFound, ", "), strings.Join(files.filesNotFound, ", "))
+	// A pyproject.toml without [project] or a recognised tool table is
+	// a layout this extractor does not understand, not a missing manifest
+	code := extractor.ErrNoManifest
+	if files.pyprojectExists {
+		code = extractor.ErrUnsupported
+	}
+	return nil, extractor.WithCode(code, fmt.Errorf("no Python project files found in %s\n\nSearched for: pyproject.toml, setup.cfg, setup.py\nFiles found: %s\nFiles not found: %s",
+		projectPath, strings.Join(files.filesFound, ", "), strings.Join(files.filesNotFound, ", ")))
 }
 
 // extractViaPyProject runs the pyproject.toml extraction path. It returns
@@ -212,6 +239,7 @@ func loadRequirementsTxt(projectPath string, metadata *extractor.ProjectMetadata
 	if err != nil {
 		return
 	}
+	metadata.AddConsumedFile(path)
 	var deps []string
 	for _, line := range strings.Split(string(content), "\n") {
 		line = strings.TrimSpace(line)
@@ -254,3 +282,103 @@ func indexInlineComment(line string) int {
 func init() {
 	extractor.RegisterExtractor(NewExtractor())
 }
+
+// pythonDevExtras names the optional-dependency extras conventionally
+// reserved for development and test tooling
+var pythonDevExtras = map[string]bool{
+	"dev":         true,
+	"develop":     true,
+	"development": true,
+	"test":        true,
+	"tests":       true,
+	"testing":     true,
+}
+
+// applyPythonDependencyCounts sets the standardized dependency counts
+// from what the active metadata source declared: install requirements
+// are runtime, PEP 735 dependency groups and dev/test extras are dev,
+// and any other extras only count towards the total.
+func applyPythonDependencyCounts(metadata *extractor.ProjectMetadata) {
+	var counts extractor.DependencyCounts
+	if deps, ok := metadata.LanguageSpecific["dependencies"].([]string); ok {
+		counts.Runtime = len(deps)
+	}
+	if extras, ok := metadata.LanguageSpecific["optional_dependencies"].(map[string][]string); ok {
+		for name, deps := range extras {
+			if pythonDevExtras[strings.ToLower(name)] {
+				counts.Dev += len(deps)
+			} else {
+				counts.Other += len(deps)
+			}
+		}
+	}
+	if groups, ok := metadata.LanguageSpecific["dependency_groups"].(map[string][]string); ok {
+		for _, deps := range groups {
+			counts.Dev += len(deps)
+		}
+	}
+	extractor.SetDependencyCounts(metadata, counts)
+}
+
+// applyPythonEntryPoints records the declared console script names
+// (sorted) and the remaining entry point groups, each a name -> object
+// reference map, together with their counts. A project declaring console
+// or GUI scripts is classified as an application, any other as a library
+// (plugin entry points such as pytest11 do not make it runnable).
+func applyPythonEntryPoints(metadata *extractor.ProjectMetadata, scripts map[string]string, groups map[string]map[string]string) {
+	if len(scripts) > 0 {
+		names := make([]string, 0, len(scripts))
+		for name := range scripts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		metadata.LanguageSpecific["console_scripts"] = names
+		metadata.LanguageSpecific["console_script_count"] = len(names)
+	}
+	if len(groups) > 0 {
+		metadata.LanguageSpecific["entry_points"] = groups
+		metadata.LanguageSpecific["entry_point_group_count"] = len(groups)
+	}
+	application := len(scripts) > 0 || len(groups["gui_scripts"]) > 0 || len(groups["console_scripts"]) > 0
+	metadata.ArtifactKind = extractor.ClassifyArtifact(!application, application)
+}
+
+// applyToolVersionsPython records the interpreter the python entry of
+// an asdf .tool-versions file pins as python_version. When the matrix is
+// the static fallback, for want of requires-python or classifiers, the
+// pinned minor version becomes build_version if the matrix holds it.
+func applyToolVersionsPython(projectPath string, metadata *extractor.ProjectMetadata) {
+	version, ok := toolversions.Lookup(projectPath, "python")
+	if !ok {
+		return
+	}
+	metadata.LanguageSpecific["python_version"] = version
+	metadata.LanguageSpecific["python_version_source"] = toolversions.FileName
+	metadata.AddConsumedFile(filepath.Join(projectPath, toolversions.FileName))
+
+	if fallback, _ := metadata.LanguageSpecific["requires_python_fallback"].(bool); !fallback {
+		return
+	}
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return
+	}
+	minor := parts[0] + "." + parts[1]
+	matrix, _ := metadata.LanguageSpecific["version_matrix"].([]string)
+	for _, candidate := range matrix {
+		if candidate == minor {
+			metadata.LanguageSpecific["build_version"] = minor
+			return
+		}
+	}
+}
+
+// applyPythonPublishable marks the project publishable to PyPI when it
+// names itself, has a version (static or dynamic) and builds with a
+// declared backend; setup.py and setup.cfg projects build with
+// setuptools, so hasBuildBackend is true for them.
+func applyPythonPublishable(metadata *extractor.ProjectMetadata, hasBuildBackend bool) {
+	dynamic := metadata.LanguageSpecific["versioning_type"] == "dynamic"
+	hasVersion := metadata.Version != "" || dynamic
+	metadata.SetPublishable(extractor.PublishTargetPyPI, metadata.Name != "" && hasVersion && hasBuildBackend)
+}
@@ -395,6 +395,25 @@ func TestLegacy_ParseSetupCfgContinuationLines(t *testing.T) {
 		cfg["options"]["install_requires"].Lines)
 }
 
+// TestLegacy_ParseSetupCfgCRLF verifies Windows line endings leave no
+// "\r" on scalar or continuation values.
+func TestLegacy_ParseSetupCfgCRLF(t *testing.T) {
+	input := "[metadata]\r\n" +
+		"name = crlf-pkg\r\n" +
+		"version = 3.2.0\r\n" +
+		"classifiers =\r\n" +
+		"    First\r\n" +
+		"    Second\r\n"
+
+	cfg := parseSetupCfg(input)
+
+	require.Contains(t, cfg, "metadata")
+	assert.Equal(t, "crlf-pkg", cfg["metadata"]["name"].Raw)
+	assert.Equal(t, "3.2.0", cfg["metadata"]["version"].Raw)
+	assert.Equal(t, []string{"First", "Second"}, cfg["metadata"]["classifiers"].Lines)
+	assert.NotContains(t, cfg["metadata"]["classifiers"].Raw, "\r")
+}
+
 // TestLegacy_DerivePythonVersionsFromClassifiers exercises the helper
 // directly with edge cases: duplicates, the bare-major 3, non-Python
 // classifiers, and out-of-order entries.
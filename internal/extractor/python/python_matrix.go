@@ -153,7 +153,7 @@ func applyFallbackPythonMatrix(metadata *extractor.ProjectMetadata, source strin
 		metadata.LanguageSpecific["requires_python_source"] = "static-fallback"
 	}
 
-	fmt.Fprintf(os.Stderr,
+	extractor.DebugWarnf(extractor.WarnEmptyRequiresPython,
 		"[WARNING] %s does not declare requires-python or Python classifiers; using fallback Python matrix %v (build_version=%s, latest supported)\n",
 		source, fallback, fallback[len(fallback)-1])
 }
@@ -4,8 +4,10 @@
 package python
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -26,6 +28,7 @@ type PyProjectTOML struct {
 		Classifiers    []string                     `toml:"classifiers"`
 		RequiresPython string                       `toml:"requires-python"`
 		Dependencies   []string                     `toml:"dependencies"`
+		OptionalDeps   map[string][]string          `toml:"optional-dependencies"`
 		URLs           map[string]string            `toml:"urls"`
 		Scripts        map[string]string            `toml:"scripts"`
 		EntryPoints    map[string]map[string]string `toml:"entry-points"`
@@ -38,6 +41,10 @@ type PyProjectTOML struct {
 		BuildBackend string   `toml:"build-backend"`
 	} `toml:"build-system"`
 
+	// DependencyGroups holds PEP 735 [dependency-groups]; entries are
+	// requirement strings or {include-group = "..."} tables
+	DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+
 	Tool map[string]interface{} `toml:"tool"`
 }
 
@@ -59,6 +66,8 @@ func (e *Extractor) extractFromPyProject(path string, metadata *extractor.Projec
 	applyPyProjectCoreMetadata(metadata, pyproject)
 	applyPyProjectLanguageSpecific(metadata, pyproject)
 	poetryPythonConstraint := applyPyProjectToolConfig(metadata, pyproject)
+	metadata.LanguageSpecific["build_backend_tool"] = deriveBuildBackendTool(pyproject)
+	applyPackageManager(filepath.Dir(path), pyproject, metadata)
 
 	if err := generatePyProjectMatrix(metadata, pyproject, poetryPythonConstraint); err != nil {
 		return err
@@ -86,14 +95,28 @@ func decodePyProject(path string) (PyProjectTOML, []byte, error) {
 		return pyproject, nil, fmt.Errorf("failed to read pyproject.toml: %w", readErr)
 	}
 
+	// Unresolved merge-conflict markers are the most common way a
+	// pyproject.toml becomes invalid; name the marker line directly
+	// rather than relying on the TOML decoder's message.
+	if line := mergeConflictMarkerLine(string(fileContent)); line > 0 {
+		return pyproject, fileContent, &extractor.ManifestError{
+			File: path,
+			Line: line,
+			Err:  errors.New("unresolved git merge conflict marker (<<<<<<<)"),
+		}
+	}
+
 	// Detect an unquoted version value (invalid TOML syntax) in the
 	// [project] table, for example `version = 1.0.0` written by a buggy
 	// patching tool. The descriptive error is returned to the caller,
 	// which surfaces it to the user, rather than printed here.
-	if raw := projectTableUnquotedVersion(string(fileContent)); raw != "" {
-		return pyproject, fileContent, fmt.Errorf(
-			"pyproject.toml [project].version has invalid TOML syntax: "+
-				"unquoted value %q (should be version = %q)", raw, raw)
+	if raw, line := projectTableUnquotedVersion(string(fileContent)); raw != "" {
+		return pyproject, fileContent, &extractor.ManifestError{
+			File: path,
+			Line: line,
+			Err: fmt.Errorf("pyproject.toml [project].version has invalid TOML syntax: "+
+				"unquoted value %q (should be version = %q)", raw, raw),
+		}
 	}
 
 	if _, err := toml.DecodeFile(path, &pyproject); err != nil {
@@ -108,23 +131,24 @@ func decodePyProject(path string) (PyProjectTOML, []byte, error) {
 				preview = preview[:500] + "..."
 			}
 			debugf("[ERROR] File preview:\n%s\n", preview)
-			return pyproject, fileContent, fmt.Errorf("TOML parsing failed - file contains invalid TOML syntax: %w\n\nCommon causes:\n- Git merge conflict markers (<<<<<<<, =======, >>>>>>>)\n- Unclosed strings or brackets\n- Invalid escape sequences\n- Incorrect indentation or structure", err)
+			return pyproject, fileContent, fmt.Errorf("TOML parsing failed - file contains invalid TOML syntax: %w\n\nCommon causes:\n- Git merge conflict markers (<<<<<<<, =======, >>>>>>>)\n- Unclosed strings or brackets\n- Invalid escape sequences\n- Incorrect indentation or structure",
+				extractor.NewManifestError(path, fileContent, err))
 		}
-		return pyproject, fileContent, fmt.Errorf("TOML parsing failed: %w", err)
+		return pyproject, fileContent, fmt.Errorf("TOML parsing failed: %w", extractor.NewManifestError(path, fileContent, err))
 	}
 
 	return pyproject, fileContent, nil
 }
 
-// projectTableUnquotedVersion returns the raw value when the [project]
-// table declares `version` with an unquoted (invalid TOML) value such
-// as `version = 1.0.0`. It returns "" when the version is absent or
-// quoted. The scan is limited to the [project] table so unrelated
-// tables (for example [tool.*]) cannot trigger a false positive.
-func projectTableUnquotedVersion(content string) string {
+// projectTableUnquotedVersion returns the raw value and its 1-based line
+// when the [project] table declares `version` with an unquoted (invalid
+// TOML) value such as `version = 1.0.0`. It returns "" when the version
+// is absent or quoted. The scan is limited to the [project] table so
+// unrelated tables (for example [tool.*]) cannot trigger a false positive.
+func projectTableUnquotedVersion(content string) (string, int) {
 	unquoted := regexp.MustCompile(`^\s*version\s*=\s*([^"'\s][^\s]*)\s*$`)
 	inProject := false
-	for _, line := range strings.Split(content, "\n") {
+	for i, line := range strings.Split(content, "\n") {
 		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "[") {
 			inProject = trimmed == "[project]"
 			continue
@@ -133,25 +157,36 @@ func projectTableUnquotedVersion(content string) string {
 			continue
 		}
 		if m := unquoted.FindStringSubmatch(line); len(m) > 1 {
-			return m[1]
+			return m[1], i + 1
+		}
+	}
+	return "", 0
+}
+
+// mergeConflictMarkerLine returns the 1-based line of the first
+// "<<<<<<<" conflict marker at the start of a line, or 0 when none.
+func mergeConflictMarkerLine(content string) int {
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "<<<<<<<") {
+			return i + 1
 		}
 	}
-	return ""
+	return 0
 }
 
 // warnMissingPyProjectFields emits verbose diagnostics for empty core
 // fields. requires-python gets extra attention: when it is missing from
 // the struct but present in the raw text we log a manual extraction so
 // operators can see the field exists but failed to bind. Output is gated
-// behind INPUT_VERBOSE via debugf so normal runs stay quiet.
+// behind verbose via debugf so normal runs stay quiet.
 func warnMissingPyProjectFields(pyproject PyProjectTOML, fileContent []byte) {
 	if pyproject.Project.Name == "" {
 		debugf("[WARNING] pyproject.toml parsed successfully but [project].name is empty\n")
 	}
 	if pyproject.Project.Version == "" {
-		debugf("[WARNING] pyproject.toml parsed successfully but [project].version is empty\n")
+		extractor.DebugWarnf(extractor.WarnVersionEmpty, "[WARNING] pyproject.toml parsed successfully but [project].version is empty\n")
 	}
-	if pyproject.Project.RequiresPython == "" {
+	if pyproject.Project.RequiresPython == "" && !extractor.WarningSuppressed(extractor.WarnEmptyRequiresPython) {
 		debugf("[WARNING] pyproject.toml parsed successfully but [project].requires-python is empty or missing\n")
 		if strings.Contains(string(fileContent), "requires-python") {
 			debugf("[WARNING] requires-python field EXISTS in file but was not parsed into struct\n")
@@ -188,16 +223,18 @@ func applyPyProjectCoreMetadata(metadata *extractor.ProjectMetadata, pyproject P
 	metadata.VersionSource = "pyproject.toml"
 
 	authors := make([]string, 0, len(pyproject.Project.Authors))
+	structured := make([]extractor.Author, 0, len(pyproject.Project.Authors))
 	for _, author := range pyproject.Project.Authors {
+		parsed := extractor.Author{Name: author.Name, Email: author.Email}
+		if parsed != (extractor.Author{}) {
+			structured = append(structured, parsed)
+		}
 		if author.Name != "" {
-			if author.Email != "" {
-				authors = append(authors, fmt.Sprintf("%s <%s>", author.Name, author.Email))
-			} else {
-				authors = append(authors, author.Name)
-			}
+			authors = append(authors, parsed.String())
 		}
 	}
 	metadata.Authors = authors
+	metadata.AuthorsStructured = structured
 
 	for key, value := range pyproject.Project.URLs {
 		lowerKey := strings.ToLower(key)
@@ -247,6 +284,26 @@ func applyPyProjectLanguageSpecific(metadata *extractor.ProjectMetadata, pyproje
 		metadata.LanguageSpecific["dependency_count"] = len(pyproject.Project.Dependencies)
 		metadata.LanguageSpecific["dependencies_source"] = "pyproject.toml"
 	}
+	applyPythonEntryPoints(metadata, pyproject.Project.Scripts, pyproject.Project.EntryPoints)
+
+	if len(pyproject.Project.OptionalDeps) > 0 {
+		metadata.LanguageSpecific["optional_dependencies"] = pyproject.Project.OptionalDeps
+	}
+	if len(pyproject.DependencyGroups) > 0 {
+		groups := make(map[string][]string, len(pyproject.DependencyGroups))
+		for name, entries := range pyproject.DependencyGroups {
+			requirements := []string{}
+			for _, entry := range entries {
+				// Skip {include-group = "..."} tables; the included
+				// group is counted under its own name
+				if requirement, ok := entry.(string); ok {
+					requirements = append(requirements, requirement)
+				}
+			}
+			groups[name] = requirements
+		}
+		metadata.LanguageSpecific["dependency_groups"] = groups
+	}
 }
 
 // applyPyProjectToolConfig records `[tool.*]` configuration for Poetry,
@@ -261,9 +318,13 @@ func applyPyProjectToolConfig(metadata *extractor.ProjectMetadata, pyproject PyP
 	poetryPythonConstraint := ""
 	if poetry, ok := pyproject.Tool["poetry"].(map[string]interface{}); ok {
 		metadata.LanguageSpecific["poetry_config"] = true
-		if version, ok := poetry["version"].(string); ok && metadata.Version == "" {
-			metadata.Version = version
-			metadata.VersionSource = "pyproject.toml (poetry)"
+		if version, ok := poetry["version"].(string); ok {
+			if metadata.Version == "" {
+				metadata.Version = version
+				metadata.VersionSource = "pyproject.toml (poetry)"
+			} else if version != metadata.Version {
+				recordPoetryVersionConflict(metadata, version)
+			}
 		}
 		// Poetry projects without a PEP 621 `[project]` table express
 		// the Python constraint in `[tool.poetry.dependencies].python`.
@@ -297,6 +358,57 @@ func applyPyProjectToolConfig(metadata *extractor.ProjectMetadata, pyproject PyP
 	return poetryPythonConstraint
 }
 
+// buildBackendTools maps PEP 517 build-backend modules to the canonical
+// tool name reported as build_backend_tool
+var buildBackendTools = map[string]string{
+	"poetry.core.masonry.api": "poetry",
+	"poetry.masonry.api":      "poetry",
+	"hatchling.build":         "hatch",
+	"pdm.backend":             "pdm",
+	"pdm.pep517.api":          "pdm",
+	"flit_core.buildapi":      "flit",
+	"flit.buildapi":           "flit",
+	"setuptools.build_meta":   "setuptools",
+}
+
+// buildBackendToolTables lists the [tool.*] tables consulted, in order,
+// when build-system.build-backend is absent or unrecognised
+var buildBackendToolTables = []string{"poetry", "pdm", "hatch", "flit", "setuptools"}
+
+// recordPoetryVersionConflict flags a pyproject.toml whose
+// `[tool.poetry].version` differs from the PEP 621 `[project].version`
+// already applied. PEP 621 takes precedence (Poetry 2 reads `[project]`
+// too), so the version is kept and both values are recorded as
+// "pep621_version" and "poetry_version" alongside "version_conflict".
+func recordPoetryVersionConflict(metadata *extractor.ProjectMetadata, poetryVersion string) {
+	metadata.LanguageSpecific["version_conflict"] = true
+	metadata.LanguageSpecific["pep621_version"] = metadata.Version
+	metadata.LanguageSpecific["poetry_version"] = poetryVersion
+	debugf(
+		"[WARNING] pyproject.toml declares [project].version %q and [tool.poetry].version %q; using [project].version\n",
+		metadata.Version, poetryVersion)
+}
+
+// deriveBuildBackendTool reports the canonical build tool (poetry, pdm,
+// hatch, flit, setuptools, or unknown). The build-backend string wins;
+// an object reference such as `setuptools.build_meta:__legacy__` is
+// matched on its module. Without a recognised backend the first tool
+// table present decides.
+func deriveBuildBackendTool(pyproject PyProjectTOML) string {
+	backend := strings.TrimSpace(pyproject.BuildSystem.BuildBackend)
+	module, _, _ := strings.Cut(backend, ":")
+	if tool, ok := buildBackendTools[module]; ok {
+		return tool
+	}
+
+	for _, tool := range buildBackendToolTables {
+		if _, ok := pyproject.Tool[tool].(map[string]interface{}); ok {
+			return tool
+		}
+	}
+	return "unknown"
+}
+
 // generatePyProjectMatrix emits the Python version matrix from the
 // declared `requires-python`, falling back to the Poetry Python
 // constraint when the PEP 621 field is absent.
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// requirementsFileGlob matches the pip requirements files read from the
+// project root, e.g. requirements.txt and requirements-dev.txt
+const requirementsFileGlob = "requirements*.txt"
+
+// requirementsFile is what one pip requirements file declares
+type requirementsFile struct {
+	// requirements are the requirement specifiers, environment markers
+	// included, and the targets of editable installs
+	requirements []string
+	// editables are the targets of -e/--editable lines
+	editables []string
+	// includes are the files named by -r/--requirement lines
+	includes []string
+	// constraints are the files named by -c/--constraint lines
+	constraints []string
+}
+
+// applyRequirementsFiles records the pip requirements files in the
+// project root alongside whatever the manifest declares: their names as
+// requirements_files, the distinct requirements they list as
+// requirements_dependency_count, and the include, constraint and
+// editable targets they reference. Included files are not followed;
+// they are usually other requirements*.txt files read here anyway.
+func applyRequirementsFiles(projectPath string, metadata *extractor.ProjectMetadata) {
+	paths, _ := filepath.Glob(filepath.Join(projectPath, requirementsFileGlob))
+	sort.Strings(paths)
+
+	var names, editables, includes, constraints []string
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		metadata.AddConsumedFile(path)
+		names = append(names, filepath.Base(path))
+		parsed := parseRequirementsFile(string(content))
+		for _, requirement := range parsed.requirements {
+			seen[requirement] = true
+		}
+		editables = appendUnique(editables, parsed.editables...)
+		includes = appendUnique(includes, parsed.includes...)
+		constraints = appendUnique(constraints, parsed.constraints...)
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	ls := metadata.LanguageSpecific
+	ls["requirements_files"] = names
+	ls["requirements_dependency_count"] = len(seen)
+	if len(editables) > 0 {
+		ls["requirements_editable_installs"] = editables
+	}
+	if len(includes) > 0 {
+		ls["requirements_includes"] = includes
+	}
+	if len(constraints) > 0 {
+		ls["requirements_constraints"] = constraints
+	}
+}
+
+// parseRequirementsFile reads pip requirements file content. Comments,
+// blank lines and pip options (index URLs, --hash and the like) are
+// skipped, backslash continuations joined, and -r/-c lines recorded as
+// references rather than requirements. Environment markers stay part of
+// the requirement.
+func parseRequirementsFile(content string) requirementsFile {
+	var parsed requirementsFile
+	for _, line := range joinContinuations(content) {
+		if idx := indexInlineComment(line); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if option, value, ok := requirementsOption(line); ok {
+			switch option {
+			case "-r", "--requirement":
+				parsed.includes = append(parsed.includes, value)
+			case "-c", "--constraint":
+				parsed.constraints = append(parsed.constraints, value)
+			case "-e", "--editable":
+				parsed.editables = append(parsed.editables, value)
+				parsed.requirements = append(parsed.requirements, value)
+			}
+			continue
+		}
+
+		// Per-requirement options such as --hash follow the specifier
+		if idx := strings.Index(line, " --"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		parsed.requirements = append(parsed.requirements, line)
+	}
+	return parsed
+}
+
+// requirementsOption splits a pip option line such as "-r dev.txt",
+// "-rdev.txt" or "--requirement=dev.txt" into the option and its value.
+// It returns false for a requirement line.
+func requirementsOption(line string) (option, value string, ok bool) {
+	if !strings.HasPrefix(line, "-") {
+		return "", "", false
+	}
+	if strings.HasPrefix(line, "--") {
+		option = line
+		if idx := strings.IndexAny(line, " \t="); idx >= 0 {
+			option, value = line[:idx], line[idx+1:]
+		}
+	} else {
+		option, value = line[:2], line[2:]
+	}
+	return option, strings.TrimSpace(value), true
+}
+
+// joinContinuations splits content into logical lines, joining lines
+// ending in a backslash with the next one as pip does.
+func joinContinuations(content string) []string {
+	var lines []string
+	var current strings.Builder
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\"))
+			continue
+		}
+		current.WriteString(line)
+		lines = append(lines, current.String())
+		current.Reset()
+	}
+	if current.Len() > 0 {
+		lines = append(lines, current.String())
+	}
+	return lines
+}
+
+// appendUnique appends the values not yet in list.
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
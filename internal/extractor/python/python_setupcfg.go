@@ -69,6 +69,15 @@ func (e *Extractor) extractFromSetupCfg(path string, metadata *extractor.Project
 		metadata.LanguageSpecific["dependency_count"] = len(deps)
 		metadata.LanguageSpecific["dependencies_source"] = "setup.cfg"
 	}
+	applySetupCfgEntryPoints(metadata, cfg)
+
+	if section, ok := cfg["options.extras_require"]; ok && len(section) > 0 {
+		extras := make(map[string][]string, len(section))
+		for name, value := range section {
+			extras[name] = value.Lines
+		}
+		metadata.LanguageSpecific["optional_dependencies"] = extras
+	}
 
 	applySetupCfgVersioning(metadata, cfg)
 
@@ -119,11 +128,9 @@ func applySetupCfgCoreMetadata(metadata *extractor.ProjectMetadata, cfg map[stri
 
 	if author := setupCfgScalar(cfg, "metadata", "author"); author != "" {
 		email := setupCfgScalar(cfg, "metadata", "author_email")
-		if email != "" {
-			metadata.Authors = []string{fmt.Sprintf("%s <%s>", author, email)}
-		} else {
-			metadata.Authors = []string{author}
-		}
+		parsed := extractor.Author{Name: author, Email: email}
+		metadata.Authors = []string{parsed.String()}
+		metadata.AuthorsStructured = []extractor.Author{parsed}
 	}
 
 	metadata.LanguageSpecific["package_name"] = metadata.Name
@@ -209,6 +216,40 @@ func applySetupCfgVersioning(metadata *extractor.ProjectMetadata, cfg map[string
 	}
 }
 
+// applySetupCfgEntryPoints reads [options.entry_points], where each key
+// is an entry point group and each value line is `name = object.ref`.
+// The console_scripts group maps onto console_scripts like pyproject's
+// [project.scripts]; every other group is reported under entry_points.
+func applySetupCfgEntryPoints(metadata *extractor.ProjectMetadata, cfg map[string]map[string]setupCfgValue) {
+	section, ok := cfg["options.entry_points"]
+	if !ok {
+		return
+	}
+
+	scripts := make(map[string]string)
+	groups := make(map[string]map[string]string)
+	for group, value := range section {
+		entries := make(map[string]string)
+		for _, line := range value.Lines {
+			name, target, found := strings.Cut(line, "=")
+			if !found || strings.TrimSpace(name) == "" {
+				continue
+			}
+			entries[strings.TrimSpace(name)] = strings.TrimSpace(target)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		if group == "console_scripts" {
+			scripts = entries
+			continue
+		}
+		groups[group] = entries
+	}
+
+	applyPythonEntryPoints(metadata, scripts, groups)
+}
+
 // setupCfgValue represents a value parsed from setup.cfg. Python's
 // RawConfigParser folds indented continuation lines onto the preceding
 // key; multi-line values are typically intended as lists. We retain both
@@ -251,7 +292,7 @@ func (p *setupCfgParser) flush() {
 // configparser semantics (continuation folding, section headers, comment
 // handling, and `=`/`:` separated key-value pairs).
 func (p *setupCfgParser) feed(rawLine string) {
-	line := strings.TrimRight(rawLine, "\r")
+	line := rawLine
 	trimmed := strings.TrimSpace(line)
 	isIndented := len(line) > 0 && (line[0] == ' ' || line[0] == '\t')
 
@@ -340,7 +381,7 @@ func setupCfgSeparatorIndex(trimmed string) int {
 // accepted, matching Python's configparser.
 func parseSetupCfg(content string) map[string]map[string]setupCfgValue {
 	p := &setupCfgParser{result: make(map[string]map[string]setupCfgValue)}
-	for _, rawLine := range strings.Split(content, "\n") {
+	for _, rawLine := range strings.Split(extractor.NormalizeNewlines(content), "\n") {
 		p.feed(rawLine)
 	}
 	p.flush()
@@ -400,7 +441,7 @@ func parseINI(content string) map[string]map[string]string {
 	result := make(map[string]map[string]string)
 	var currentSection string
 
-	lines := strings.Split(content, "\n")
+	lines := strings.Split(extractor.NormalizeNewlines(content), "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 
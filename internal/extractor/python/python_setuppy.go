@@ -30,11 +30,9 @@ func (e *Extractor) extractFromSetupPy(path string, metadata *extractor.ProjectM
 
 	if author := extractSetupPyField(text, "author"); author != "" {
 		email := extractSetupPyField(text, "author_email")
-		if email != "" {
-			metadata.Authors = []string{fmt.Sprintf("%s <%s>", author, email)}
-		} else {
-			metadata.Authors = []string{author}
-		}
+		parsed := extractor.Author{Name: author, Email: email}
+		metadata.Authors = []string{parsed.String()}
+		metadata.AuthorsStructured = []extractor.Author{parsed}
 	}
 
 	// Python-specific
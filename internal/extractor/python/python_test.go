@@ -4,10 +4,13 @@
 package python
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -68,7 +71,8 @@ description = "An example package"
 license = "Apache-2.0"
 authors = [
     {name = "John Doe", email = "john@example.com"},
-    {name = "Jane Smith"}
+    {name = "Jane Smith"},
+    {email = "team@example.com"}
 ]
 requires-python = ">=3.8"
 dependencies = [
@@ -103,6 +107,14 @@ build-backend = "setuptools.build_meta"
 	assert.Equal(t, "https://github.com/example/package", metadata.Repository)
 	assert.Contains(t, metadata.Authors, "John Doe <john@example.com>")
 	assert.Contains(t, metadata.Authors, "Jane Smith")
+	assert.Len(t, metadata.Authors, 2)
+	require.Len(t, metadata.AuthorsStructured, 3)
+	assert.Equal(t, "John Doe", metadata.AuthorsStructured[0].Name)
+	assert.Equal(t, "john@example.com", metadata.AuthorsStructured[0].Email)
+	assert.Equal(t, "Jane Smith", metadata.AuthorsStructured[1].Name)
+	assert.Empty(t, metadata.AuthorsStructured[1].Email)
+	assert.Empty(t, metadata.AuthorsStructured[2].Name)
+	assert.Equal(t, "team@example.com", metadata.AuthorsStructured[2].Email)
 
 	// Language-specific metadata
 	assert.Equal(t, "example-package", metadata.LanguageSpecific["package_name"])
@@ -258,6 +270,240 @@ install_requires =
 	assert.Equal(t, ">=3.7", metadata.LanguageSpecific["requires_python"])
 }
 
+func TestPythonExtractor_Extract_DependencyCounts_PyProject(t *testing.T) {
+	pyprojectContent := `[project]
+name = "counted"
+version = "1.0.0"
+dependencies = ["requests>=2.28.0", "click>=8.0.0"]
+
+[project.optional-dependencies]
+dev = ["black", "ruff"]
+yaml = ["pyyaml"]
+
+[dependency-groups]
+test = ["pytest", {include-group = "lint"}]
+lint = ["mypy"]
+`
+
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": pyprojectContent,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	// Extras other than dev/test only count towards the total
+	assert.Equal(t, 2, metadata.LanguageSpecific["runtime_dependency_count"])
+	assert.Equal(t, 4, metadata.LanguageSpecific["dev_dependency_count"])
+	assert.Equal(t, 7, metadata.LanguageSpecific["total_dependency_count"])
+}
+
+func TestPythonExtractor_Extract_TestFrameworksAndLinters(t *testing.T) {
+	pyprojectContent := `[project]
+name = "tooled"
+version = "1.0.0"
+
+[tool.pytest.ini_options]
+testpaths = ["tests"]
+
+[tool.ruff]
+line-length = 100
+
+[tool.ruff.lint]
+select = ["E", "F"]
+
+[tool.mypy]
+strict = true
+`
+
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": pyprojectContent,
+		"tox.ini":        "[tox]\nenv_list = py312\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"pytest", "tox"}, metadata.LanguageSpecific["test_frameworks"])
+	assert.Equal(t, []string{"mypy", "ruff"}, metadata.LanguageSpecific["linters"])
+}
+
+func TestPythonExtractor_Extract_ToolingFromConfigFiles(t *testing.T) {
+	setupCfgContent := `[metadata]
+name = legacy-tooled
+version = 0.1.0
+
+[flake8]
+max-line-length = 88
+
+[mypy]
+ignore_missing_imports = True
+`
+
+	tmpDir := createTempProject(t, map[string]string{
+		"setup.cfg":         setupCfgContent,
+		"tests/conftest.py": "",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"pytest"}, metadata.LanguageSpecific["test_frameworks"])
+	assert.Equal(t, []string{"flake8", "mypy"}, metadata.LanguageSpecific["linters"])
+}
+
+func TestPythonExtractor_Extract_NoTooling(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"bare\"\nversion = \"1.0.0\"\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.NotContains(t, metadata.LanguageSpecific, "test_frameworks")
+	assert.NotContains(t, metadata.LanguageSpecific, "linters")
+}
+
+func TestPythonExtractor_Extract_DependencyCounts_SetupCfg(t *testing.T) {
+	setupCfgContent := `[metadata]
+name = counted
+version = 1.0.0
+
+[options]
+install_requires =
+    numpy>=1.20
+
+[options.extras_require]
+test =
+    pytest
+    pytest-cov
+`
+
+	tmpDir := createTempProject(t, map[string]string{
+		"setup.cfg": setupCfgContent,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, metadata.LanguageSpecific["runtime_dependency_count"])
+	assert.Equal(t, 2, metadata.LanguageSpecific["dev_dependency_count"])
+	assert.Equal(t, 3, metadata.LanguageSpecific["total_dependency_count"])
+}
+
+func TestPythonExtractor_Extract_DependencyCounts_SetupPy(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"setup.py": `from setuptools import setup
+
+setup(
+    name="counted",
+    version="1.0.0",
+)
+`,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, metadata.LanguageSpecific["runtime_dependency_count"])
+	assert.Equal(t, 0, metadata.LanguageSpecific["dev_dependency_count"])
+	assert.Equal(t, 0, metadata.LanguageSpecific["total_dependency_count"])
+}
+
+func TestPythonExtractor_Extract_EntryPoints_PyProject(t *testing.T) {
+	pyprojectContent := `[project]
+name = "cli-tool"
+version = "1.0.0"
+
+[project.scripts]
+mytool = "cli_tool.main:run"
+mytool-admin = "cli_tool.admin:run"
+
+[project.entry-points."pytest11"]
+cli_tool = "cli_tool.pytest_plugin"
+`
+
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": pyprojectContent,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"mytool", "mytool-admin"}, metadata.LanguageSpecific["console_scripts"])
+	assert.Equal(t, 2, metadata.LanguageSpecific["console_script_count"])
+	assert.Equal(t, map[string]map[string]string{
+		"pytest11": {"cli_tool": "cli_tool.pytest_plugin"},
+	}, metadata.LanguageSpecific["entry_points"])
+	assert.Equal(t, 1, metadata.LanguageSpecific["entry_point_group_count"])
+}
+
+func TestPythonExtractor_Extract_EntryPoints_SetupCfg(t *testing.T) {
+	setupCfgContent := `[metadata]
+name = cli-tool
+version = 1.0.0
+
+[options.entry_points]
+console_scripts =
+    mytool = cli_tool.main:run
+    mytool-admin = cli_tool.admin:run
+pytest11 =
+    cli_tool = cli_tool.pytest_plugin
+`
+
+	tmpDir := createTempProject(t, map[string]string{
+		"setup.cfg": setupCfgContent,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"mytool", "mytool-admin"}, metadata.LanguageSpecific["console_scripts"])
+	assert.Equal(t, 2, metadata.LanguageSpecific["console_script_count"])
+	assert.Equal(t, map[string]map[string]string{
+		"pytest11": {"cli_tool": "cli_tool.pytest_plugin"},
+	}, metadata.LanguageSpecific["entry_points"])
+	assert.Equal(t, 1, metadata.LanguageSpecific["entry_point_group_count"])
+}
+
+func TestPythonExtractor_Extract_BuildBackendTool(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"poetry backend", "[build-system]\nbuild-backend = \"poetry.core.masonry.api\"\n", "poetry"},
+		{"hatch backend", "[build-system]\nbuild-backend = \"hatchling.build\"\n", "hatch"},
+		{"pdm backend", "[build-system]\nbuild-backend = \"pdm.backend\"\n", "pdm"},
+		{"flit backend", "[build-system]\nbuild-backend = \"flit_core.buildapi\"\n", "flit"},
+		{"setuptools backend", "[build-system]\nbuild-backend = \"setuptools.build_meta\"\n", "setuptools"},
+		{"setuptools legacy backend", "[build-system]\nbuild-backend = \"setuptools.build_meta:__legacy__\"\n", "setuptools"},
+		{"poetry tool table", "[tool.poetry]\nname = \"x\"\n", "poetry"},
+		{"unrecognised backend", "[build-system]\nbuild-backend = \"maturin\"\n", "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTempProject(t, map[string]string{
+				"pyproject.toml": "[project]\nname = \"backend-test\"\nversion = \"1.0.0\"\n\n" + tt.content,
+			})
+			defer os.RemoveAll(tmpDir)
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, metadata.LanguageSpecific["build_backend_tool"])
+		})
+	}
+}
+
 func TestPythonExtractor_Extract_DynamicVersion(t *testing.T) {
 	pyprojectContent := `[project]
 name = "dynamic-package"
@@ -316,6 +562,80 @@ build-backend = "poetry.core.masonry.api"
 	assert.True(t, hasPoetry)
 }
 
+func TestPythonExtractor_Extract_PoetryVersionConflict(t *testing.T) {
+	pyprojectContent := `[project]
+name = "conflicted"
+version = "2.0.0"
+requires-python = ">=3.10"
+
+[tool.poetry]
+version = "1.9.0"
+`
+
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": pyprojectContent,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0.0", metadata.Version, "[project].version takes precedence")
+	assert.Equal(t, "pyproject.toml", metadata.VersionSource)
+	assert.Equal(t, true, metadata.LanguageSpecific["version_conflict"])
+	assert.Equal(t, "2.0.0", metadata.LanguageSpecific["pep621_version"])
+	assert.Equal(t, "1.9.0", metadata.LanguageSpecific["poetry_version"])
+}
+
+func TestPythonExtractor_Extract_PoetryVersionAgrees(t *testing.T) {
+	pyprojectContent := `[project]
+name = "agreed"
+version = "2.0.0"
+
+[tool.poetry]
+version = "2.0.0"
+`
+
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": pyprojectContent,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0.0", metadata.Version)
+	assert.NotContains(t, metadata.LanguageSpecific, "version_conflict")
+}
+
+func TestPythonExtractor_Extract_PyProjectQuietStderr(t *testing.T) {
+	withPolicy(t, &Policy{
+		Offline:      true,
+		SupportedSet: []string{"3.11", "3.12", "3.13"},
+		EOLVersions:  map[string]bool{},
+	})
+	extractor.SetLogLevel(extractor.LogNormal)
+	t.Cleanup(func() { extractor.SetLogLevel(extractor.LogNormal) })
+
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"clean\"\nversion = \"1.0.0\"\nrequires-python = \">=3.11\"\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stderr := os.Stderr
+	os.Stderr = w
+	_, extractErr := NewExtractor().Extract(tmpDir)
+	os.Stderr = stderr
+	require.NoError(t, w.Close())
+	logged, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	require.NoError(t, extractErr)
+	assert.Empty(t, string(logged), "a valid pyproject.toml must not write to stderr without verbose")
+}
+
 func TestGeneratePythonVersionMatrix(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -487,6 +807,15 @@ key4 = value4
 	assert.Equal(t, "value4", result["section3"]["key4"])
 }
 
+func TestParseINICRLF(t *testing.T) {
+	content := "[metadata]\r\nname = crlf-pkg\r\nversion = 3.2.0\r\n"
+
+	result := parseINI(content)
+
+	assert.Equal(t, "crlf-pkg", result["metadata"]["name"])
+	assert.Equal(t, "3.2.0", result["metadata"]["version"])
+}
+
 func TestPythonExtractor_ProjectMatchPackage(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -603,6 +932,85 @@ setup(
 	assert.Equal(t, []string{"3.10", "3.11", "3.12", "3.13", "3.14"}, versionMatrix)
 }
 
+func TestPythonExtractor_ManifestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		line     int
+		contains string
+	}{
+		{
+			name:     "merge conflict marker",
+			content:  "[project]\nname = \"demo\"\n<<<<<<< HEAD\nversion = \"1.0.0\"\n=======\nversion = \"2.0.0\"\n>>>>>>> main\n",
+			line:     3,
+			contains: "merge conflict marker",
+		},
+		{
+			name:     "unquoted version",
+			content:  "[project]\nname = \"demo\"\nversion = 1.0.0\n",
+			line:     3,
+			contains: "unquoted value",
+		},
+		{
+			name:     "invalid TOML",
+			content:  "[project]\nname = \"demo\"\ndescription = \"unterminated\n",
+			line:     3,
+			contains: "TOML parsing failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTempProject(t, map[string]string{"pyproject.toml": tt.content})
+			defer os.RemoveAll(tmpDir)
+
+			_, err := NewExtractor().Extract(tmpDir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.contains)
+
+			var manifestErr *extractor.ManifestError
+			require.ErrorAs(t, err, &manifestErr)
+			assert.Equal(t, filepath.Join(tmpDir, "pyproject.toml"), manifestErr.File)
+			assert.Equal(t, tt.line, manifestErr.Line)
+		})
+	}
+}
+
+func TestPythonExtractor_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		code  error
+	}{
+		{
+			name:  "no manifest",
+			files: map[string]string{"README.md": "# demo\n"},
+			code:  extractor.ErrNoManifest,
+		},
+		{
+			name:  "invalid TOML",
+			files: map[string]string{"pyproject.toml": "[project]\nname = \"demo\"\ndescription = \"unterminated\n"},
+			code:  extractor.ErrParseFailure,
+		},
+		{
+			name:  "pyproject.toml without project metadata",
+			files: map[string]string{"pyproject.toml": "[tool.black]\nline-length = 88\n"},
+			code:  extractor.ErrUnsupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTempProject(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			_, err := NewExtractor().Extract(tmpDir)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.code)
+		})
+	}
+}
+
 // Helper function to create temporary test projects
 func createTempProject(t *testing.T, files map[string]string) string {
 	tmpDir, err := os.MkdirTemp("", "python-test-*")
@@ -610,9 +1018,302 @@ func createTempProject(t *testing.T, files map[string]string) string {
 
 	for filename, content := range files {
 		filePath := filepath.Join(tmpDir, filename)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
 		err := os.WriteFile(filePath, []byte(content), 0644)
 		require.NoError(t, err)
 	}
 
 	return tmpDir
 }
+
+func TestPythonExtractor_ArtifactKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name: "pure package",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"lib\"\nversion = \"1.0.0\"\n",
+			},
+			expected: "library",
+		},
+		{
+			name: "console scripts",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"tool\"\nversion = \"1.0.0\"\n\n[project.scripts]\ntool = \"tool.cli:main\"\n",
+			},
+			expected: "application",
+		},
+		{
+			name: "plugin entry points only",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"plugin\"\nversion = \"1.0.0\"\n\n[project.entry-points.pytest11]\nplugin = \"plugin.hooks\"\n",
+			},
+			expected: "library",
+		},
+		{
+			name: "setup.cfg console_scripts",
+			files: map[string]string{
+				"setup.cfg": "[metadata]\nname = tool\nversion = 1.0.0\n\n[options.entry_points]\nconsole_scripts =\n    tool = tool.cli:main\n",
+			},
+			expected: "application",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTempProject(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, metadata.ArtifactKind)
+		})
+	}
+}
+
+func TestPythonExtractor_Publishable(t *testing.T) {
+	backend := "\n[build-system]\nrequires = [\"hatchling\"]\nbuild-backend = \"hatchling.build\"\n"
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected bool
+	}{
+		{
+			name: "name, version and backend",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"lib\"\nversion = \"1.0.0\"\n" + backend,
+			},
+			expected: true,
+		},
+		{
+			name: "dynamic version",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"lib\"\ndynamic = [\"version\"]\n" + backend,
+			},
+			expected: true,
+		},
+		{
+			name: "no build backend",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"lib\"\nversion = \"1.0.0\"\n",
+			},
+			expected: false,
+		},
+		{
+			name: "no version",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"lib\"\n" + backend,
+			},
+			expected: false,
+		},
+		{
+			name: "setup.cfg",
+			files: map[string]string{
+				"setup.cfg": "[metadata]\nname = lib\nversion = 1.0.0\n",
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := createTempProject(t, tt.files)
+			defer os.RemoveAll(tmpDir)
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			require.NoError(t, err)
+			require.NotNil(t, metadata.Publishable)
+			assert.Equal(t, tt.expected, *metadata.Publishable)
+			assert.Equal(t, "pypi", metadata.PublishTarget)
+		})
+	}
+}
+
+func TestPythonExtractor_RequirementsFiles(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\nversion = \"1.0.0\"\n",
+		"requirements.txt": `# Runtime dependencies
+-c constraints.txt
+--index-url https://pypi.example.com/simple
+requests>=2.31  # HTTP client
+importlib-metadata>=6.0; python_version < "3.10"
+urllib3==2.0.7 \
+    --hash=sha256:abc123
+-e ./vendor/internal-lib
+`,
+		"requirements-dev.txt": `-r requirements.txt
+pytest>=7.0
+requests>=2.31
+--editable=git+https://github.com/example/tool.git#egg=tool
+`,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	ls := metadata.LanguageSpecific
+	assert.Equal(t, []string{"requirements-dev.txt", "requirements.txt"}, ls["requirements_files"])
+	// requests appears in both files and is counted once
+	assert.Equal(t, 6, ls["requirements_dependency_count"])
+	assert.Equal(t, []string{"requirements.txt"}, ls["requirements_includes"])
+	assert.Equal(t, []string{"constraints.txt"}, ls["requirements_constraints"])
+	assert.Equal(t, []string{"git+https://github.com/example/tool.git#egg=tool", "./vendor/internal-lib"}, ls["requirements_editable_installs"])
+	// The declared (empty) dependency list is left alone
+	assert.Nil(t, ls["dependencies_source"])
+	assert.Equal(t, []string{
+		filepath.Join(tmpDir, "pyproject.toml"),
+		filepath.Join(tmpDir, "requirements-dev.txt"),
+		filepath.Join(tmpDir, "requirements.txt"),
+	}, metadata.ConsumedFiles)
+}
+
+func TestParseRequirementsFile(t *testing.T) {
+	parsed := parseRequirementsFile("flask==3.0.0  # web\n\n# comment\n-rbase.txt\n" +
+		"pywin32>=306; sys_platform == \"win32\"\n--constraint constraints.txt\n-e .\n")
+	assert.Equal(t, []string{"flask==3.0.0", "pywin32>=306; sys_platform == \"win32\"", "."}, parsed.requirements)
+	assert.Equal(t, []string{"base.txt"}, parsed.includes)
+	assert.Equal(t, []string{"constraints.txt"}, parsed.constraints)
+	assert.Equal(t, []string{"."}, parsed.editables)
+}
+
+func TestPythonExtractor_NoRequirementsFiles(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\nversion = \"1.0.0\"\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+	assert.NotContains(t, metadata.LanguageSpecific, "requirements_files")
+	assert.NotContains(t, metadata.LanguageSpecific, "requirements_dependency_count")
+}
+
+func TestPythonExtractor_UvProject(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": `[project]
+name = "app"
+version = "0.2.0"
+requires-python = ">=3.11"
+dependencies = ["httpx>=0.27"]
+
+[tool.uv]
+dev-dependencies = ["pytest>=8"]
+`,
+		"uv.lock": `version = 1
+requires-python = ">=3.11"
+
+[[package]]
+name = "app"
+version = "0.2.0"
+source = { editable = "." }
+dependencies = [{ name = "httpx" }]
+
+[[package]]
+name = "httpx"
+version = "0.27.2"
+source = { registry = "https://pypi.org/simple" }
+
+[[package]]
+name = "idna"
+version = "3.10"
+source = { registry = "https://pypi.org/simple" }
+`,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	ls := metadata.LanguageSpecific
+	assert.Equal(t, "uv", ls["package_manager"])
+	assert.Equal(t, true, ls["uv_config"])
+	assert.Equal(t, true, ls["uv_lock_present"])
+	// The editable project itself is not a resolved dependency
+	assert.Equal(t, map[string]string{"httpx": "0.27.2", "idna": "3.10"}, ls["resolved_dependencies"])
+	assert.Contains(t, metadata.ConsumedFiles, filepath.Join(tmpDir, "uv.lock"))
+}
+
+func TestPythonExtractor_UvWithoutLock(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\nversion = \"1.0.0\"\n\n[tool.uv]\npackage = true\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, "uv", metadata.LanguageSpecific["package_manager"])
+	assert.Equal(t, false, metadata.LanguageSpecific["uv_lock_present"])
+	assert.NotContains(t, metadata.LanguageSpecific, "resolved_dependencies")
+}
+
+func TestPythonExtractor_UvLockUnparsable(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\nversion = \"1.0.0\"\n",
+		"uv.lock":        "version = 1\n[[package]\nname = \"httpx\"\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	var warnings bytes.Buffer
+	previous := extractor.SetLogOutput(&warnings)
+	defer extractor.SetLogOutput(previous)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, "uv", metadata.LanguageSpecific["package_manager"])
+	assert.Equal(t, true, metadata.LanguageSpecific["uv_lock_present"])
+	assert.NotContains(t, metadata.LanguageSpecific, "resolved_dependencies")
+	assert.Contains(t, warnings.String(), "uv.lock")
+}
+
+func TestPythonExtractor_PackageManagerFromLockFile(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\nversion = \"1.0.0\"\n",
+		"poetry.lock":    "# This file is automatically @generated by Poetry\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, "poetry", metadata.LanguageSpecific["package_manager"])
+	assert.NotContains(t, metadata.LanguageSpecific, "uv_lock_present")
+}
+
+func TestPythonExtractor_ToolVersions(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\nversion = \"1.0.0\"\n",
+		".tool-versions": "nodejs 20.11.0\npython 3.12.4 3.11.9\nruby 3.3.0\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	ls := metadata.LanguageSpecific
+	assert.Equal(t, "3.12.4", ls["python_version"])
+	assert.Equal(t, ".tool-versions", ls["python_version_source"])
+	// Without requires-python the pin picks the build version from the
+	// fallback matrix
+	assert.Equal(t, true, ls["requires_python_fallback"])
+	assert.Contains(t, ls["version_matrix"], "3.12")
+	assert.Equal(t, "3.12", ls["build_version"])
+}
+
+func TestPythonExtractor_ToolVersionsKeepsRequiresPython(t *testing.T) {
+	tmpDir := createTempProject(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\nversion = \"1.0.0\"\nrequires-python = \">=3.11\"\n",
+		".tool-versions": "python 3.11.9\n",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	require.NoError(t, err)
+
+	ls := metadata.LanguageSpecific
+	assert.Equal(t, "3.11.9", ls["python_version"])
+	assert.NotEqual(t, "3.11", ls["build_version"],
+		"a declared requires-python keeps its own build version")
+}
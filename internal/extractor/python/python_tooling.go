@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package python
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// pythonToolSignal describes where a test framework or linter declares
+// its configuration: a [tool.<name>] table in pyproject.toml, a
+// dedicated file next to it, or a section of a shared INI file
+// (setup.cfg / tox.ini).
+type pythonToolSignal struct {
+	name        string
+	toolTables  []string
+	files       []string
+	iniSections []string
+}
+
+// pythonTestFrameworks lists the detected test runners. tox and nox
+// orchestrate test environments rather than run tests, but CI
+// auto-configuration treats them the same way.
+var pythonTestFrameworks = []pythonToolSignal{
+	{name: "pytest", toolTables: []string{"pytest"}, files: []string{"pytest.ini", "conftest.py", "tests/conftest.py"}, iniSections: []string{"tool:pytest", "pytest"}},
+	{name: "tox", toolTables: []string{"tox"}, files: []string{"tox.ini"}},
+	{name: "nox", files: []string{"noxfile.py"}},
+}
+
+// pythonLinters lists the detected linters, formatters and type checkers.
+var pythonLinters = []pythonToolSignal{
+	{name: "ruff", toolTables: []string{"ruff"}, files: []string{"ruff.toml", ".ruff.toml"}},
+	{name: "black", toolTables: []string{"black"}},
+	{name: "mypy", toolTables: []string{"mypy"}, files: []string{"mypy.ini", ".mypy.ini"}, iniSections: []string{"mypy"}},
+	{name: "flake8", files: []string{".flake8"}, iniSections: []string{"flake8"}},
+	{name: "pylint", toolTables: []string{"pylint"}, files: []string{".pylintrc", "pylintrc"}},
+	{name: "isort", toolTables: []string{"isort"}, files: []string{".isort.cfg"}, iniSections: []string{"isort"}},
+}
+
+// applyPythonTooling records the test frameworks and linters a project
+// configures as sorted "test_frameworks" and "linters" slices. Detection
+// looks only at configuration, never at installed packages, so it works
+// the same for every metadata source.
+func applyPythonTooling(projectPath string, metadata *extractor.ProjectMetadata) {
+	tools := pyprojectToolTables(filepath.Join(projectPath, "pyproject.toml"))
+	sections := iniSectionNames(filepath.Join(projectPath, "setup.cfg"))
+	for name := range iniSectionNames(filepath.Join(projectPath, "tox.ini")) {
+		sections[name] = true
+	}
+
+	if found := detectPythonTools(projectPath, pythonTestFrameworks, tools, sections); len(found) > 0 {
+		metadata.LanguageSpecific["test_frameworks"] = found
+	}
+	if found := detectPythonTools(projectPath, pythonLinters, tools, sections); len(found) > 0 {
+		metadata.LanguageSpecific["linters"] = found
+	}
+}
+
+// detectPythonTools returns the sorted names of the signals present.
+func detectPythonTools(projectPath string, signals []pythonToolSignal, tools map[string]interface{}, sections map[string]bool) []string {
+	var found []string
+	for _, signal := range signals {
+		if hasPythonToolSignal(projectPath, signal, tools, sections) {
+			found = append(found, signal.name)
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+func hasPythonToolSignal(projectPath string, signal pythonToolSignal, tools map[string]interface{}, sections map[string]bool) bool {
+	for _, table := range signal.toolTables {
+		if _, ok := tools[table]; ok {
+			return true
+		}
+	}
+	for _, section := range signal.iniSections {
+		if sections[section] {
+			return true
+		}
+	}
+	for _, name := range signal.files {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// pyprojectToolTables decodes only the [tool] table of pyproject.toml,
+// returning nil when the file is missing or unparsable.
+func pyprojectToolTables(path string) map[string]interface{} {
+	var pyproject struct {
+		Tool map[string]interface{} `toml:"tool"`
+	}
+	if _, err := toml.DecodeFile(path, &pyproject); err != nil {
+		return nil
+	}
+	return pyproject.Tool
+}
+
+// iniSectionNames returns the lower-cased "[section]" headers of an INI
+// file; a missing file yields an empty set.
+func iniSectionNames(path string) map[string]bool {
+	sections := make(map[string]bool)
+	file, err := os.Open(path)
+	if err != nil {
+		return sections
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			sections[strings.ToLower(strings.TrimSpace(line[1:len(line)-1]))] = true
+		}
+	}
+	return sections
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package python
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// uvLockFile is the lockfile uv writes next to pyproject.toml
+const uvLockFile = "uv.lock"
+
+// packageManagerLockFiles maps the lockfiles of the Python package
+// managers to the manager, in the order they are looked for
+var packageManagerLockFiles = []struct {
+	file    string
+	manager string
+}{
+	{uvLockFile, "uv"},
+	{"poetry.lock", "poetry"},
+	{"pdm.lock", "pdm"},
+}
+
+// packageManagerToolTables lists the [tool.*] tables naming a package
+// manager, consulted in order when no lockfile is present
+var packageManagerToolTables = []string{"uv", "poetry", "pdm", "hatch"}
+
+// UvLock holds the [[package]] entries of uv.lock
+type UvLock struct {
+	Version int `toml:"version"`
+	Package []struct {
+		Name    string                 `toml:"name"`
+		Version string                 `toml:"version"`
+		Source  map[string]interface{} `toml:"source"`
+	} `toml:"package"`
+}
+
+// applyPackageManager records the package manager the project uses as
+// package_manager: the one whose lockfile sits next to pyproject.toml,
+// else the first with a [tool.*] table. Nothing is recorded for a
+// project showing neither. For uv, uv_config reports a [tool.uv] table
+// and uv_lock_present the lockfile, whose resolved packages become
+// resolved_dependencies; a lockfile that fails to parse still counts as
+// present, with a warning.
+func applyPackageManager(projectDir string, pyproject PyProjectTOML, metadata *extractor.ProjectMetadata) {
+	manager := ""
+	for _, candidate := range packageManagerLockFiles {
+		if _, err := os.Stat(filepath.Join(projectDir, candidate.file)); err == nil {
+			manager = candidate.manager
+			break
+		}
+	}
+	if manager == "" {
+		for _, tool := range packageManagerToolTables {
+			if _, ok := pyproject.Tool[tool].(map[string]interface{}); ok {
+				manager = tool
+				break
+			}
+		}
+	}
+	if manager == "" {
+		return
+	}
+	metadata.LanguageSpecific["package_manager"] = manager
+
+	if _, ok := pyproject.Tool["uv"].(map[string]interface{}); ok {
+		metadata.LanguageSpecific["uv_config"] = true
+	}
+	if manager != "uv" {
+		return
+	}
+
+	lockPath := filepath.Join(projectDir, uvLockFile)
+	_, statErr := os.Stat(lockPath)
+	metadata.LanguageSpecific["uv_lock_present"] = statErr == nil
+	if statErr != nil {
+		return
+	}
+	metadata.AddConsumedFile(lockPath)
+	resolved, err := parseUvLock(lockPath)
+	if err != nil {
+		extractor.Warnf("", "[WARNING] Ignoring unparsable %s: %v\n", lockPath, err)
+		return
+	}
+	if len(resolved) > 0 {
+		metadata.LanguageSpecific["resolved_dependencies"] = resolved
+	}
+}
+
+// parseUvLock reads uv.lock, returning the resolved package versions by
+// name. Editable, virtual and directory sources are the project and its
+// workspace members rather than dependencies, and are left out.
+func parseUvLock(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lock UvLock
+	if _, err := toml.Decode(string(content), &lock); err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]string)
+	for _, pkg := range lock.Package {
+		if pkg.Name == "" || pkg.Version == "" || isLocalUvSource(pkg.Source) {
+			continue
+		}
+		resolved[pkg.Name] = pkg.Version
+	}
+	return resolved, nil
+}
+
+// isLocalUvSource reports whether a uv.lock source points into the
+// project tree.
+func isLocalUvSource(source map[string]interface{}) bool {
+	for _, kind := range []string{"editable", "virtual", "directory"} {
+		if _, ok := source[kind]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/lfreleng-actions/build-metadata-action/internal/toolversions"
 )
 
 // Extractor extracts metadata from Ruby projects
@@ -118,12 +119,25 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 		}
 	}
 
+	lockPath := filepath.Join(projectPath, "Gemfile.lock")
+	if _, err := os.Stat(lockPath); err == nil {
+		if err := e.extractFromGemfileLock(lockPath, metadata); err != nil {
+			// Non-fatal error, continue
+		}
+	}
+
 	rubyVersionPath := filepath.Join(projectPath, ".ruby-version")
 	if _, err := os.Stat(rubyVersionPath); err == nil {
-		if version, err := e.extractRubyVersion(rubyVersionPath); err == nil {
+		if engine, version, err := e.extractRubyVersion(rubyVersionPath); err == nil {
 			metadata.LanguageSpecific["ruby_version"] = version
+			metadata.LanguageSpecific["ruby_version_source"] = ".ruby-version"
+			metadata.AddConsumedFile(rubyVersionPath)
+			if engine != "" {
+				metadata.LanguageSpecific["ruby_engine"] = engine
+			}
 		}
 	}
+	applyToolVersionsRuby(projectPath, metadata)
 
 	// Detect frameworks
 	frameworks := e.detectFrameworks(projectPath)
@@ -163,6 +177,7 @@ func (e *Extractor) extractFromGemspec(gemspecPath string, metadata *extractor.P
 	if err != nil {
 		return err
 	}
+	metadata.AddConsumedFile(gemspecPath)
 
 	applyGemspecMetadata(spec, metadata)
 	return nil
@@ -263,6 +278,20 @@ func parseGemspec(r io.Reader) (GemspecMetadata, error) {
 	return spec, nil
 }
 
+// gemspecAuthors pairs spec.authors with spec.email by position, as
+// RubyGems lists them; authors beyond the emails get none.
+func gemspecAuthors(spec GemspecMetadata) []extractor.Author {
+	authors := make([]extractor.Author, 0, len(spec.Authors))
+	for i, name := range spec.Authors {
+		author := extractor.ParseAuthor(name)
+		if author.Email == "" && i < len(spec.Email) {
+			author.Email = spec.Email[i]
+		}
+		authors = append(authors, author)
+	}
+	return authors
+}
+
 func applyGemspecMetadata(spec GemspecMetadata, metadata *extractor.ProjectMetadata) {
 	metadata.Name = spec.Name
 	metadata.Version = spec.Version
@@ -270,6 +299,7 @@ func applyGemspecMetadata(spec GemspecMetadata, metadata *extractor.ProjectMetad
 	metadata.Homepage = spec.Homepage
 	metadata.License = spec.License
 	metadata.Authors = spec.Authors
+	metadata.AuthorsStructured = gemspecAuthors(spec)
 
 	if spec.Summary != "" {
 		metadata.LanguageSpecific["ruby_summary"] = spec.Summary
@@ -289,6 +319,10 @@ func applyGemspecMetadata(spec GemspecMetadata, metadata *extractor.ProjectMetad
 	if len(spec.DevelopmentDependencies) > 0 {
 		metadata.LanguageSpecific["ruby_development_dependencies"] = spec.DevelopmentDependencies
 	}
+	extractor.SetDependencyCounts(metadata, extractor.DependencyCounts{
+		Runtime: len(spec.RuntimeDependencies),
+		Dev:     len(spec.DevelopmentDependencies),
+	})
 }
 
 // extractFromGemfile parses a Gemfile
@@ -298,6 +332,7 @@ func (e *Extractor) extractFromGemfile(gemfilePath string, metadata *extractor.P
 		return err
 	}
 	defer file.Close()
+	metadata.AddConsumedFile(gemfilePath)
 
 	scanner := bufio.NewScanner(file)
 	var (
@@ -313,6 +348,18 @@ func (e *Extractor) extractFromGemfile(gemfilePath string, metadata *extractor.P
 	sourceRe := regexp.MustCompile(`source\s+["']([^"']+)["']`)
 	gemRe := regexp.MustCompile(`gem\s+["']([^"']+)["'](?:\s*,\s*["']([^"']+)["'])?`)
 	platformRe := regexp.MustCompile(`platform\s+:([a-z_]+)`)
+	groupRe := regexp.MustCompile(`^group\s+(.+?)\s+do\b`)
+	blockRe := regexp.MustCompile(`\bdo\s*(\|[^|]*\|)?$`)
+	inlineGroupRe := regexp.MustCompile(`\bgroups?:\s*(\[[^\]]*\]|:[a-z_]+)`)
+	keywordRe := regexp.MustCompile(`^(if|unless|case|begin|while|until|def|class|module)\b`)
+	endRe := regexp.MustCompile(`^end\b`)
+	oneLineEndRe := regexp.MustCompile(`\bend$`)
+
+	// blocks tracks the open blocks so each end closes the one it
+	// belongs to: do...end blocks, marked dev for groups that only cover
+	// development/test, and keyword constructs such as if...end, which
+	// are never dev but must not let their end close an enclosing group
+	var blocks []gemfileBlock
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -330,11 +377,31 @@ func (e *Extractor) extractFromGemfile(gemfilePath string, metadata *extractor.P
 			source = matches[1]
 		}
 
+		if endRe.MatchString(line) {
+			if len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+			continue
+		}
+
+		if matches := groupRe.FindStringSubmatch(line); len(matches) > 1 {
+			blocks = append(blocks, gemfileBlock{dev: isDevelopmentGroup(matches[1])})
+			continue
+		}
+
+		if blockRe.MatchString(line) || (keywordRe.MatchString(line) && !oneLineEndRe.MatchString(line)) {
+			blocks = append(blocks, gemfileBlock{})
+		}
+
 		if matches := gemRe.FindStringSubmatch(line); len(matches) > 1 {
 			dep := Dependency{
 				Name: matches[1],
 				Type: "runtime",
 			}
+			inlineGroup := inlineGroupRe.FindStringSubmatch(line)
+			if inDevelopmentBlock(blocks) || (len(inlineGroup) > 1 && isDevelopmentGroup(inlineGroup[1])) {
+				dep.Type = "development"
+			}
 			if len(matches) > 2 && matches[2] != "" {
 				dep.Requirement = matches[2]
 			}
@@ -362,6 +429,7 @@ func (e *Extractor) extractFromGemfile(gemfilePath string, metadata *extractor.P
 		// Don't override if already set from .ruby-version
 		if _, exists := metadata.LanguageSpecific["ruby_version"]; !exists {
 			metadata.LanguageSpecific["ruby_version"] = rubyVersion
+			metadata.LanguageSpecific["ruby_version_source"] = "Gemfile"
 		}
 	}
 	if source != "" {
@@ -370,6 +438,19 @@ func (e *Extractor) extractFromGemfile(gemfilePath string, metadata *extractor.P
 	if len(dependencies) > 0 {
 		metadata.LanguageSpecific["ruby_gemfile_dependencies"] = dependencies
 	}
+	// A gemspec describes the published gem, so its counts take precedence
+	// over the Gemfile's, which also covers the local toolchain
+	if _, exists := metadata.LanguageSpecific["runtime_dependency_count"]; !exists {
+		var counts extractor.DependencyCounts
+		for _, dep := range dependencies {
+			if dep.Type == "development" {
+				counts.Dev++
+			} else {
+				counts.Runtime++
+			}
+		}
+		extractor.SetDependencyCounts(metadata, counts)
+	}
 	if hasBundler {
 		metadata.LanguageSpecific["ruby_has_bundler"] = true
 	}
@@ -380,14 +461,125 @@ func (e *Extractor) extractFromGemfile(gemfilePath string, metadata *extractor.P
 	return nil
 }
 
-// extractRubyVersion reads the Ruby version from .ruby-version file
-func (e *Extractor) extractRubyVersion(versionPath string) (string, error) {
+// gemfileLockSpecRe matches a resolved gem in a Gemfile.lock specs list:
+// four spaces of indentation, then "name (version)". The deeper-indented
+// lines below each spec are its own requirements and do not match.
+var gemfileLockSpecRe = regexp.MustCompile(`^ {4}([^\s(]+) \(([^)]+)\)$`)
+
+// extractFromGemfileLock parses a Gemfile.lock, recording the gems
+// resolved from the GEM section as "resolved_dependencies" (name to
+// version), the BUNDLED WITH version as "ruby_bundler_version" and the
+// RUBY VERSION block as "ruby_locked_version". Gems from PATH and GIT
+// sources are not resolved from a registry and are left out.
+func (e *Extractor) extractFromGemfileLock(lockPath string, metadata *extractor.ProjectMetadata) error {
+	file, err := os.Open(lockPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	metadata.AddConsumedFile(lockPath)
+
+	var (
+		section       string
+		inSpecs       bool
+		resolved      = make(map[string]string)
+		bundler       string
+		lockedVersion string
+	)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" {
+			continue
+		}
+
+		// Section headers start at column zero
+		if !strings.HasPrefix(line, " ") {
+			section = line
+			inSpecs = false
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch section {
+		case "GEM":
+			if trimmed == "specs:" {
+				inSpecs = true
+				continue
+			}
+			if inSpecs {
+				if matches := gemfileLockSpecRe.FindStringSubmatch(line); len(matches) > 2 {
+					resolved[matches[1]] = matches[2]
+				}
+			}
+		case "BUNDLED WITH":
+			bundler = trimmed
+		case "RUBY VERSION":
+			if version, ok := strings.CutPrefix(trimmed, "ruby "); ok {
+				lockedVersion = strings.TrimSpace(version)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(resolved) > 0 {
+		metadata.LanguageSpecific["resolved_dependencies"] = resolved
+	}
+	if bundler != "" {
+		metadata.LanguageSpecific["ruby_bundler_version"] = bundler
+	}
+	if lockedVersion != "" {
+		metadata.LanguageSpecific["ruby_locked_version"] = lockedVersion
+	}
+
+	return nil
+}
+
+// applyToolVersionsRuby falls back to the ruby entry of an asdf
+// .tool-versions file when neither .ruby-version nor the Gemfile names
+// the Ruby version. Engine-prefixed entries ("jruby-9.4.0.0") set
+// ruby_engine as they do in .ruby-version.
+func applyToolVersionsRuby(projectPath string, metadata *extractor.ProjectMetadata) {
+	if _, exists := metadata.LanguageSpecific["ruby_version"]; exists {
+		return
+	}
+	version, ok := toolversions.Lookup(projectPath, "ruby")
+	if !ok {
+		return
+	}
+	if matches := rubyEngineVersionRe.FindStringSubmatch(version); matches != nil {
+		metadata.LanguageSpecific["ruby_engine"] = matches[1]
+		version = matches[2]
+	}
+	metadata.LanguageSpecific["ruby_version"] = version
+	metadata.LanguageSpecific["ruby_version_source"] = toolversions.FileName
+	metadata.AddConsumedFile(filepath.Join(projectPath, toolversions.FileName))
+}
+
+// rubyEngineVersionRe matches an engine-prefixed .ruby-version entry
+// such as "ruby-3.2.0" or "jruby-9.4.0.0".
+var rubyEngineVersionRe = regexp.MustCompile(`^([A-Za-z][A-Za-z+]*)-(\d.*)$`)
+
+// extractRubyVersion reads the Ruby version from .ruby-version file,
+// returning the engine as well when the entry names one ("ruby-3.2.0",
+// "jruby-9.4.0.0"). A trailing "@gemset" (rvm) is dropped.
+func (e *Extractor) extractRubyVersion(versionPath string) (engine, version string, err error) {
 	data, err := os.ReadFile(versionPath)
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+	// Only the first line names the version; tools ignore the rest
+	content := strings.TrimSpace(extractor.NormalizeNewlines(string(data)))
+	version, _, _ = strings.Cut(content, "\n")
+	version, _, _ = strings.Cut(strings.TrimSpace(version), "@")
+	if matches := rubyEngineVersionRe.FindStringSubmatch(version); matches != nil {
+		return matches[1], matches[2], nil
 	}
-	version := strings.TrimSpace(string(data))
-	return version, nil
+	return "", version, nil
 }
 
 // detectFrameworks detects Ruby frameworks in use
@@ -424,11 +616,12 @@ func (e *Extractor) detectFrameworks(projectPath string) []string {
 		frameworks = append(frameworks, "grape")
 	}
 
-	if e.hasPath(projectPath, "spec") {
+	layout := extractor.DetectSourceLayout(projectPath)
+	if layout.HasTestDir("spec") {
 		frameworks = append(frameworks, "rspec")
 	}
 
-	if e.hasPath(projectPath, "test") {
+	if layout.HasTestDir("test") {
 		frameworks = append(frameworks, "minitest")
 	}
 
@@ -494,6 +687,40 @@ func (e *Extractor) hasGemfileDependency(projectPath, gemName string) bool {
 	return gemPattern.Match(content)
 }
 
+// gemfileGroupNameRe extracts each symbol from a Gemfile group list
+var gemfileGroupNameRe = regexp.MustCompile(`:([a-z_]+)`)
+
+// isDevelopmentGroup reports whether a Gemfile group list (e.g.
+// ":development, :test" or "[:test]") names only development or test groups
+func isDevelopmentGroup(groups string) bool {
+	names := gemfileGroupNameRe.FindAllStringSubmatch(groups, -1)
+	if len(names) == 0 {
+		return false
+	}
+	for _, name := range names {
+		if name[1] != "development" && name[1] != "test" {
+			return false
+		}
+	}
+	return true
+}
+
+// gemfileBlock is an open Gemfile block; dev marks a group block that
+// only covers development/test
+type gemfileBlock struct {
+	dev bool
+}
+
+// inDevelopmentBlock reports whether any open block is a development group
+func inDevelopmentBlock(blocks []gemfileBlock) bool {
+	for _, block := range blocks {
+		if block.dev {
+			return true
+		}
+	}
+	return false
+}
+
 // contains checks if a string slice contains a value
 func contains(slice []string, value string) bool {
 	for _, v := range slice {
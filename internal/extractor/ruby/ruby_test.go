@@ -179,6 +179,16 @@ end`,
 				if len(devDeps) != 2 {
 					t.Errorf("Expected 2 development dependencies, got %d", len(devDeps))
 				}
+
+				for key, want := range map[string]int{
+					"runtime_dependency_count": 2,
+					"dev_dependency_count":     2,
+					"total_dependency_count":   4,
+				} {
+					if got, ok := m.LanguageSpecific[key].(int); !ok || got != want {
+						t.Errorf("%s = %v, want %d", key, m.LanguageSpecific[key], want)
+					}
+				}
 			},
 		},
 		{
@@ -350,6 +360,38 @@ gem 'puma'`,
 				}
 			},
 		},
+		{
+			name: "Gemfile with development groups",
+			gemfile: `source 'https://rubygems.org'
+
+gem 'rails', '~> 7.0'
+gem 'rubocop', group: :development
+
+group :development, :test do
+  if ENV['CI']
+    gem 'simplecov'
+  end
+  gem 'rspec-rails'
+  platforms :mri do
+    gem 'byebug'
+  end
+end
+
+group :production do
+  gem 'pg'
+end`,
+			validate: func(t *testing.T, m *extractor.ProjectMetadata) {
+				for key, want := range map[string]int{
+					"runtime_dependency_count": 2,
+					"dev_dependency_count":     4,
+					"total_dependency_count":   6,
+				} {
+					if got, ok := m.LanguageSpecific[key].(int); !ok || got != want {
+						t.Errorf("%s = %v, want %d", key, m.LanguageSpecific[key], want)
+					}
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -374,11 +416,107 @@ gem 'puma'`,
 	}
 }
 
+func TestExtractFromGemfileLock(t *testing.T) {
+	lockfile := `PATH
+  remote: .
+  specs:
+    my_gem (0.1.0)
+      rack (~> 3.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    mustermann (3.0.0)
+      ruby2_keywords (~> 0.0.1)
+    nokogiri (1.15.4-x86_64-linux)
+      racc (~> 1.4)
+    rack (3.0.8)
+    racc (1.7.1)
+    ruby2_keywords (0.0.5)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  my_gem!
+  nokogiri (~> 1.15)
+  rack
+
+RUBY VERSION
+   ruby 3.2.2p53
+
+BUNDLED WITH
+   2.4.19
+`
+
+	tmpDir := t.TempDir()
+	lockPath := filepath.Join(tmpDir, "Gemfile.lock")
+	if err := os.WriteFile(lockPath, []byte(lockfile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewExtractor()
+	metadata := &extractor.ProjectMetadata{
+		LanguageSpecific: make(map[string]interface{}),
+	}
+	if err := e.extractFromGemfileLock(lockPath, metadata); err != nil {
+		t.Fatalf("extractFromGemfileLock() error = %v", err)
+	}
+
+	resolved, ok := metadata.LanguageSpecific["resolved_dependencies"].(map[string]string)
+	if !ok {
+		t.Fatalf("resolved_dependencies = %#v, want map[string]string", metadata.LanguageSpecific["resolved_dependencies"])
+	}
+	want := map[string]string{
+		"mustermann":     "3.0.0",
+		"nokogiri":       "1.15.4-x86_64-linux",
+		"rack":           "3.0.8",
+		"racc":           "1.7.1",
+		"ruby2_keywords": "0.0.5",
+	}
+	if len(resolved) != len(want) {
+		t.Errorf("resolved_dependencies = %v, want %v", resolved, want)
+	}
+	for name, version := range want {
+		if resolved[name] != version {
+			t.Errorf("resolved_dependencies[%q] = %q, want %q", name, resolved[name], version)
+		}
+	}
+	if _, ok := resolved["my_gem"]; ok {
+		t.Error("resolved_dependencies includes the PATH gem my_gem")
+	}
+
+	if got := metadata.LanguageSpecific["ruby_bundler_version"]; got != "2.4.19" {
+		t.Errorf("ruby_bundler_version = %v, want 2.4.19", got)
+	}
+	if got := metadata.LanguageSpecific["ruby_locked_version"]; got != "3.2.2p53" {
+		t.Errorf("ruby_locked_version = %v, want 3.2.2p53", got)
+	}
+}
+
+func TestExtractWithoutGemfileLock(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "Gemfile"), []byte("source 'https://rubygems.org'\ngem 'rack'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	for _, key := range []string{"resolved_dependencies", "ruby_bundler_version", "ruby_locked_version"} {
+		if _, ok := metadata.LanguageSpecific[key]; ok {
+			t.Errorf("%s set without a Gemfile.lock", key)
+		}
+	}
+}
+
 func TestExtractRubyVersion(t *testing.T) {
 	tests := []struct {
-		name    string
-		content string
-		want    string
+		name       string
+		content    string
+		want       string
+		wantEngine string
 	}{
 		{
 			name:    "simple version",
@@ -395,6 +533,39 @@ func TestExtractRubyVersion(t *testing.T) {
 			content: "  2.7.8  \n",
 			want:    "2.7.8",
 		},
+		{
+			name:    "version with CRLF",
+			content: "3.2.0\r\n",
+			want:    "3.2.0",
+		},
+		{
+			name:    "version with trailing lines",
+			content: "3.3.0\r\n# managed by rbenv\r\n",
+			want:    "3.3.0",
+		},
+		{
+			name:       "ruby engine prefix",
+			content:    "ruby-3.2.0\n",
+			want:       "3.2.0",
+			wantEngine: "ruby",
+		},
+		{
+			name:    "rvm gemset suffix",
+			content: "3.2.0@rails\n",
+			want:    "3.2.0",
+		},
+		{
+			name:       "jruby",
+			content:    "jruby-9.4.0.0",
+			want:       "9.4.0.0",
+			wantEngine: "jruby",
+		},
+		{
+			name:       "engine prefix with gemset",
+			content:    "ruby-3.3.0-preview1@app",
+			want:       "3.3.0-preview1",
+			wantEngine: "ruby",
+		},
 	}
 
 	for _, tt := range tests {
@@ -406,17 +577,83 @@ func TestExtractRubyVersion(t *testing.T) {
 			}
 
 			e := NewExtractor()
-			version, err := e.extractRubyVersion(versionPath)
+			engine, version, err := e.extractRubyVersion(versionPath)
 			if err != nil {
 				t.Fatalf("extractRubyVersion() error = %v", err)
 			}
 			if version != tt.want {
-				t.Errorf("extractRubyVersion() = %q, want %q", version, tt.want)
+				t.Errorf("extractRubyVersion() version = %q, want %q", version, tt.want)
+			}
+			if engine != tt.wantEngine {
+				t.Errorf("extractRubyVersion() engine = %q, want %q", engine, tt.wantEngine)
 			}
 		})
 	}
 }
 
+func TestExtractRubyEngine(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"Gemfile":       "source 'https://rubygems.org'\n\ngem 'rack'\n",
+		".ruby-version": "jruby-9.4.0.0\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if version := metadata.LanguageSpecific["ruby_version"]; version != "9.4.0.0" {
+		t.Errorf("ruby_version = %v, want %q", version, "9.4.0.0")
+	}
+	if engine := metadata.LanguageSpecific["ruby_engine"]; engine != "jruby" {
+		t.Errorf("ruby_engine = %v, want %q", engine, "jruby")
+	}
+}
+
+func TestExtractRubyVersionFromToolVersions(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"Gemfile":        "source 'https://rubygems.org'\n\ngem 'rack'\n",
+		".tool-versions": "python 3.12.1\nnodejs 20.11.0\nruby 3.3.0 3.2.2\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if version := metadata.LanguageSpecific["ruby_version"]; version != "3.3.0" {
+		t.Errorf("ruby_version = %v, want %q", version, "3.3.0")
+	}
+	if source := metadata.LanguageSpecific["ruby_version_source"]; source != ".tool-versions" {
+		t.Errorf("ruby_version_source = %v, want %q", source, ".tool-versions")
+	}
+
+	// .ruby-version takes precedence
+	if err := os.WriteFile(filepath.Join(tmpDir, ".ruby-version"), []byte("3.2.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	metadata, err = NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if version := metadata.LanguageSpecific["ruby_version"]; version != "3.2.0" {
+		t.Errorf("ruby_version = %v, want %q", version, "3.2.0")
+	}
+	if source := metadata.LanguageSpecific["ruby_version_source"]; source != ".ruby-version" {
+		t.Errorf("ruby_version_source = %v, want %q", source, ".ruby-version")
+	}
+}
+
 func TestDetectFrameworks(t *testing.T) {
 	tests := []struct {
 		name     string
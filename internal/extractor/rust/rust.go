@@ -71,16 +71,17 @@ type Workspace struct {
 
 // WorkspacePackage represents workspace-level package metadata
 type WorkspacePackage struct {
-	Version     string   `toml:"version"`
-	Authors     []string `toml:"authors"`
-	Edition     string   `toml:"edition"`
-	RustVersion string   `toml:"rust-version"`
-	Description string   `toml:"description"`
-	Homepage    string   `toml:"homepage"`
-	Repository  string   `toml:"repository"`
-	License     string   `toml:"license"`
-	Keywords    []string `toml:"keywords"`
-	Categories  []string `toml:"categories"`
+	Version     string      `toml:"version"`
+	Authors     []string    `toml:"authors"`
+	Edition     string      `toml:"edition"`
+	RustVersion string      `toml:"rust-version"`
+	Description string      `toml:"description"`
+	Homepage    string      `toml:"homepage"`
+	Repository  string      `toml:"repository"`
+	License     string      `toml:"license"`
+	Keywords    []string    `toml:"keywords"`
+	Categories  []string    `toml:"categories"`
+	Publish     interface{} `toml:"publish"`
 }
 
 // Bin represents a [[bin]] section
@@ -120,7 +121,7 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 		return metadata, nil
 	}
 
-	return nil, fmt.Errorf("no Cargo.toml file found in %s", projectPath)
+	return nil, extractor.WithCode(extractor.ErrNoManifest, fmt.Errorf("no Cargo.toml file found in %s", projectPath))
 }
 
 // Detect checks if this extractor can handle the project
@@ -5,65 +5,106 @@ package rust
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/lfreleng-actions/build-metadata-action/internal/toolversions"
 )
 
 // extractFromCargoToml extracts metadata from Cargo.toml file
 func (e *Extractor) extractFromCargoToml(path string, metadata *extractor.ProjectMetadata) error {
 	var cargo CargoToml
 
-	if _, err := toml.DecodeFile(path, &cargo); err != nil {
-		return fmt.Errorf("failed to parse Cargo.toml: %w", err)
+	md, err := toml.DecodeFile(path, &cargo)
+	if err != nil {
+		return fmt.Errorf("failed to parse Cargo.toml: %w", extractor.NewManifestError(path, nil, err))
+	}
+	metadata.AddConsumedFile(path)
+
+	if !md.IsDefined("workspace") {
+		inheritWorkspacePackage(filepath.Dir(path), &cargo, metadata)
 	}
 
 	applyCoreMetadata(&cargo, metadata)
 	edition, rustVersion := applyPackageDetails(&cargo, metadata)
+	rustVersion = applyRustToolVersions(filepath.Dir(path), rustVersion, metadata)
 	applyDependencyMetadata(&cargo, metadata)
 	applyProjectStructure(&cargo, metadata)
+	applyArtifactKind(&cargo, md.IsDefined("lib"), filepath.Dir(path), metadata)
+	applyPublishable(&cargo, metadata)
+	applyPackageMetadata(&cargo, metadata)
 	applyFrameworksAndMatrix(&cargo, metadata, edition, rustVersion)
 
 	return nil
 }
 
+// inheritWorkspacePackage supplies the [workspace.package] values of the
+// enclosing workspace to a member manifest, which names none itself, so
+// fields declared as `{ workspace = true }` (rust-version included, and
+// with it the version matrix) resolve as Cargo resolves them. The root is
+// the nearest parent Cargo.toml with a [workspace] table; the search
+// stops at the repository root.
+func inheritWorkspacePackage(memberDir string, cargo *CargoToml, metadata *extractor.ProjectMetadata) {
+	dir := memberDir
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return
+		}
+		dir = parent
+
+		rootPath := filepath.Join(dir, "Cargo.toml")
+		if _, err := os.Stat(rootPath); err != nil {
+			continue
+		}
+		var root CargoToml
+		md, err := toml.DecodeFile(rootPath, &root)
+		if err != nil || !md.IsDefined("workspace") {
+			continue
+		}
+		cargo.Workspace.Package = root.Workspace.Package
+		metadata.AddConsumedFile(rootPath)
+		if rel, err := filepath.Rel(memberDir, dir); err == nil {
+			metadata.LanguageSpecific["workspace_root"] = filepath.ToSlash(rel)
+		}
+		extractor.Debugf("[DEBUG] Rust: inheriting [workspace.package] from %s\n", rootPath)
+		return
+	}
+}
+
 // applyCoreMetadata maps the top-level package fields, resolving workspace
 // inheritance and rejecting non-semantic version markers so that a git-tag
 // fallback can be applied later.
 func applyCoreMetadata(cargo *CargoToml, metadata *extractor.ProjectMetadata) {
 	metadata.Name = cargo.Package.Name
 
-	if os.Getenv("INPUT_VERBOSE") == "true" {
-		log.Printf("[DEBUG] Rust: Package.Version type=%T, value=%#v", cargo.Package.Version, cargo.Package.Version)
-		log.Printf("[DEBUG] Rust: Workspace.Package.Version=%s", cargo.Workspace.Package.Version)
-	}
+	extractor.Debugf("[DEBUG] Rust: Package.Version type=%T, value=%#v\n", cargo.Package.Version, cargo.Package.Version)
+	extractor.Debugf("[DEBUG] Rust: Workspace.Package.Version=%s\n", cargo.Workspace.Package.Version)
 
 	version := getStringValue(cargo.Package.Version, cargo.Workspace.Package.Version)
 
-	if os.Getenv("INPUT_VERBOSE") == "true" {
-		log.Printf("[DEBUG] Rust: getStringValue returned: '%s'", version)
-	}
+	extractor.Debugf("[DEBUG] Rust: getStringValue returned: '%s'\n", version)
 
 	if version != "" && version != "true" && version != "false" {
 		metadata.Version = version
-		if os.Getenv("INPUT_VERBOSE") == "true" {
-			log.Printf("[DEBUG] Rust: Setting metadata.Version to: '%s'", version)
-		}
+		extractor.Debugf("[DEBUG] Rust: Setting metadata.Version to: '%s'\n", version)
 	} else {
 		metadata.Version = ""
-		if os.Getenv("INPUT_VERBOSE") == "true" {
-			log.Printf("[DEBUG] Rust: Version invalid ('%s'), clearing for git tag fallback", version)
-		}
+		extractor.Debugf("[DEBUG] Rust: Version invalid ('%s'), clearing for git tag fallback\n", version)
 	}
 	metadata.Description = getStringValue(cargo.Package.Description, cargo.Workspace.Package.Description)
 	metadata.License = getStringValue(cargo.Package.License, cargo.Workspace.Package.License)
 	metadata.Homepage = getStringValue(cargo.Package.Homepage, cargo.Workspace.Package.Homepage)
 	metadata.Repository = getStringValue(cargo.Package.Repository, cargo.Workspace.Package.Repository)
 	metadata.Authors = getStringSliceValue(cargo.Package.Authors, cargo.Workspace.Package.Authors)
+	metadata.AuthorsStructured = extractor.ParseAuthors(metadata.Authors)
 	metadata.VersionSource = "Cargo.toml"
 
 	metadata.LanguageSpecific["package_name"] = cargo.Package.Name
@@ -115,7 +156,7 @@ func applyPackageDetails(cargo *CargoToml, metadata *extractor.ProjectMetadata)
 }
 
 // applyDependencyMetadata records normal, dev and build dependencies together
-// with optional-dependency names and the aggregate count.
+// with optional-dependency names and the standardized dependency counts.
 func applyDependencyMetadata(cargo *CargoToml, metadata *extractor.ProjectMetadata) {
 	if len(cargo.Dependencies) > 0 {
 		deps := parseDependencies(cargo.Dependencies)
@@ -145,9 +186,26 @@ func applyDependencyMetadata(cargo *CargoToml, metadata *extractor.ProjectMetada
 		metadata.LanguageSpecific["build_dependency_count"] = len(buildDeps)
 	}
 
-	totalDeps := len(cargo.Dependencies) + len(cargo.DevDependencies) + len(cargo.BuildDependencies)
-	if totalDeps > 0 {
-		metadata.LanguageSpecific["total_dependency_count"] = totalDeps
+	// Build dependencies only run build scripts, so they count towards the
+	// total but are neither runtime nor dev
+	extractor.SetDependencyCounts(metadata, extractor.DependencyCounts{
+		Runtime: len(cargo.Dependencies),
+		Dev:     len(cargo.DevDependencies),
+		Other:   len(cargo.BuildDependencies),
+	})
+}
+
+// applyPackageMetadata surfaces the free-form [package.metadata] table,
+// where tools and teams keep their own configuration (docs.rs, MSRV
+// policies, packaging hints), as a nested map so consumers can read
+// custom keys. has_docs_rs_config reports a [package.metadata."docs.rs"]
+// table specifically.
+func applyPackageMetadata(cargo *CargoToml, metadata *extractor.ProjectMetadata) {
+	_, hasDocsRS := cargo.Package.Metadata["docs.rs"]
+	metadata.LanguageSpecific["has_docs_rs_config"] = hasDocsRS
+
+	if len(cargo.Package.Metadata) > 0 {
+		metadata.LanguageSpecific["cargo_package_metadata"] = cargo.Package.Metadata
 	}
 }
 
@@ -198,6 +256,84 @@ func applyProjectStructure(cargo *CargoToml, metadata *extractor.ProjectMetadata
 	}
 }
 
+// applyArtifactKind classifies the package by its targets, declared
+// ([lib], [[bin]]) or auto-discovered by Cargo (src/lib.rs, src/main.rs,
+// src/bin/). A virtual workspace manifest has no package and stays
+// unclassified.
+func applyArtifactKind(cargo *CargoToml, libDeclared bool, projectPath string, metadata *extractor.ProjectMetadata) {
+	if cargo.Package.Name == "" {
+		return
+	}
+	exists := func(rel string) bool {
+		_, err := os.Stat(filepath.Join(projectPath, rel))
+		return err == nil
+	}
+	library := libDeclared || exists(filepath.Join("src", "lib.rs"))
+	application := len(cargo.Bin) > 0 || exists(filepath.Join("src", "main.rs")) || exists(filepath.Join("src", "bin"))
+	metadata.ArtifactKind = extractor.ClassifyArtifact(library, application)
+}
+
+// cratesIORegistry is the name Cargo gives crates.io in a publish list
+const cratesIORegistry = "crates-io"
+
+// applyPublishable derives publishability from package.publish: false
+// or an empty registry list forbids publishing, a list naming other
+// registries restricts it to the first of them, and anything else allows
+// crates.io. `publish.workspace = true` takes the [workspace.package]
+// value. A virtual workspace manifest has no package to publish.
+func applyPublishable(cargo *CargoToml, metadata *extractor.ProjectMetadata) {
+	if cargo.Package.Name == "" {
+		return
+	}
+	publish := cargo.Package.Publish
+	if table, ok := publish.(map[string]interface{}); ok && table["workspace"] == true {
+		publish = cargo.Workspace.Package.Publish
+	}
+	switch value := publish.(type) {
+	case bool:
+		metadata.SetPublishable(extractor.PublishTargetCratesIO, value)
+	case []interface{}:
+		if len(value) == 0 {
+			metadata.SetPublishable(extractor.PublishTargetCratesIO, false)
+			return
+		}
+		target := ""
+		for _, registry := range value {
+			name, _ := registry.(string)
+			if name == cratesIORegistry {
+				target = extractor.PublishTargetCratesIO
+				break
+			}
+			if target == "" {
+				target = name
+			}
+		}
+		metadata.SetPublishable(target, true)
+	default:
+		metadata.SetPublishable(extractor.PublishTargetCratesIO, true)
+	}
+}
+
+// applyRustToolVersions records where rust_version comes from. Without a
+// declared rust-version the rust entry of an asdf .tool-versions file
+// becomes rust_version and drives the version matrix; it is a toolchain
+// pin rather than an MSRV, so msrv stays unset. Channel pins such as
+// stable or nightly name no version and are skipped.
+func applyRustToolVersions(dir, rustVersion string, metadata *extractor.ProjectMetadata) string {
+	if rustVersion != "" {
+		metadata.LanguageSpecific["rust_version_source"] = "Cargo.toml"
+		return rustVersion
+	}
+	version, ok := toolversions.Lookup(dir, "rust")
+	if !ok || version[0] < '0' || version[0] > '9' {
+		return ""
+	}
+	metadata.LanguageSpecific["rust_version"] = version
+	metadata.LanguageSpecific["rust_version_source"] = toolversions.FileName
+	metadata.AddConsumedFile(filepath.Join(dir, toolversions.FileName))
+	return version
+}
+
 // applyFrameworksAndMatrix records detected frameworks and derives the Rust
 // version matrix from the MSRV, falling back to the edition when unset.
 func applyFrameworksAndMatrix(cargo *CargoToml, metadata *extractor.ProjectMetadata, edition, rustVersion string) {
@@ -4,10 +4,14 @@
 package rust
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
 )
 
 // TestDetect verifies the extractor can detect Rust projects
@@ -124,6 +128,11 @@ repository = "https://github.com/example/my-crate"
 		t.Errorf("Repository = %v, expected https://github.com/example/my-crate", metadata.Repository)
 	}
 
+	if len(metadata.AuthorsStructured) != 1 || metadata.AuthorsStructured[0].Name != "John Doe" ||
+		metadata.AuthorsStructured[0].Email != "john@example.com" {
+		t.Errorf("AuthorsStructured = %+v, expected John Doe / john@example.com", metadata.AuthorsStructured)
+	}
+
 	// Verify language-specific metadata
 	if metadata.LanguageSpecific["edition"] != "2021" {
 		t.Errorf("edition = %v, expected 2021", metadata.LanguageSpecific["edition"])
@@ -188,6 +197,12 @@ cc = "1.0"
 		t.Errorf("build_dependency_count = %v, expected 1", buildDepCount)
 	}
 
+	// Check runtime dependencies (build dependencies are excluded)
+	runtimeDepCount, ok := metadata.LanguageSpecific["runtime_dependency_count"].(int)
+	if !ok || runtimeDepCount != 3 {
+		t.Errorf("runtime_dependency_count = %v, expected 3", runtimeDepCount)
+	}
+
 	// Check total dependencies
 	totalDepCount, ok := metadata.LanguageSpecific["total_dependency_count"].(int)
 	if !ok || totalDepCount != 5 {
@@ -494,6 +509,137 @@ path = "src/bin/app2.rs"
 	}
 }
 
+// TestPackageMetadata tests that [package.metadata] is exposed as a
+// nested map and that a docs.rs table is flagged
+func TestPackageMetadata(t *testing.T) {
+	cargoToml := `[package]
+name = "meta-crate"
+version = "0.3.0"
+
+[package.metadata.foo]
+channel = "nightly"
+targets = ["x86_64-unknown-linux-gnu"]
+
+[package.metadata."docs.rs"]
+all-features = true
+`
+
+	tmpDir, err := os.MkdirTemp("", "rust-extractor-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cargoPath := filepath.Join(tmpDir, "Cargo.toml")
+	if err := os.WriteFile(cargoPath, []byte(cargoToml), 0644); err != nil {
+		t.Fatalf("Failed to write Cargo.toml: %v", err)
+	}
+
+	extractor := NewExtractor()
+	metadata, err := extractor.Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	packageMetadata, ok := metadata.LanguageSpecific["cargo_package_metadata"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("cargo_package_metadata is not a map: %#v", metadata.LanguageSpecific["cargo_package_metadata"])
+	}
+
+	foo, ok := packageMetadata["foo"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("package.metadata.foo is not a map: %#v", packageMetadata["foo"])
+	}
+	if foo["channel"] != "nightly" {
+		t.Errorf("package.metadata.foo.channel = %v, expected nightly", foo["channel"])
+	}
+
+	if hasDocsRS, _ := metadata.LanguageSpecific["has_docs_rs_config"].(bool); !hasDocsRS {
+		t.Errorf("has_docs_rs_config = %v, expected true", metadata.LanguageSpecific["has_docs_rs_config"])
+	}
+}
+
+// TestPackageMetadataAbsent tests a crate without [package.metadata]
+func TestPackageMetadataAbsent(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "rust-extractor-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cargoPath := filepath.Join(tmpDir, "Cargo.toml")
+	if err := os.WriteFile(cargoPath, []byte("[package]\nname = \"plain\"\nversion = \"1.0.0\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write Cargo.toml: %v", err)
+	}
+
+	extractor := NewExtractor()
+	metadata, err := extractor.Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if _, ok := metadata.LanguageSpecific["cargo_package_metadata"]; ok {
+		t.Error("cargo_package_metadata should be absent")
+	}
+	if hasDocsRS, _ := metadata.LanguageSpecific["has_docs_rs_config"].(bool); hasDocsRS {
+		t.Error("has_docs_rs_config = true, expected false")
+	}
+}
+
+// TestMapDerivedOrderIsStable runs extraction repeatedly and checks that
+// slices built from Cargo.toml tables come out identical and sorted
+func TestMapDerivedOrderIsStable(t *testing.T) {
+	cargoToml := `[package]
+name = "stable-order"
+version = "0.1.0"
+
+[dependencies]
+tokio = "1"
+serde = "1"
+clap = "4"
+anyhow = "1"
+
+[features]
+zeta = []
+alpha = []
+mid = ["alpha"]
+`
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "Cargo.toml"), []byte(cargoToml), 0644); err != nil {
+		t.Fatalf("Failed to write Cargo.toml: %v", err)
+	}
+
+	keys := []string{"feature_names", "dependencies", "frameworks"}
+
+	extractor := NewExtractor()
+	var first map[string]interface{}
+	for run := 0; run < 10; run++ {
+		metadata, err := extractor.Extract(tmpDir)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		got := make(map[string]interface{})
+		for _, key := range keys {
+			got[key] = metadata.LanguageSpecific[key]
+		}
+		if first == nil {
+			first = got
+			continue
+		}
+		if !reflect.DeepEqual(got, first) {
+			t.Fatalf("run %d produced %v, first run produced %v", run, got, first)
+		}
+	}
+
+	if want := []string{"alpha", "mid", "zeta"}; !reflect.DeepEqual(first["feature_names"], want) {
+		t.Errorf("feature_names = %v, expected %v", first["feature_names"], want)
+	}
+	if want := []string{"anyhow@1", "clap@4", "serde@1", "tokio@1"}; !reflect.DeepEqual(first["dependencies"], want) {
+		t.Errorf("dependencies = %v, expected %v", first["dependencies"], want)
+	}
+}
+
 // TestNoCargoToml tests behavior when no Cargo.toml exists
 func TestNoCargoToml(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "rust-extractor-test-*")
@@ -651,6 +797,37 @@ func TestRustVersionCacheExpiration(t *testing.T) {
 	}
 }
 
+// TestStaticMatricesBypassesFetch verifies SetStaticMatrices makes the
+// matrix come from the static table even when fetched versions exist
+func TestStaticMatricesBypassesFetch(t *testing.T) {
+	// A fresh cache stands in for a successful rust-lang.org fetch
+	rustVersionCache.Lock()
+	rustVersionCache.versions = []string{"1.82", "1.83", "1.84", "1.85", "stable"}
+	rustVersionCache.fetchedAt = time.Now()
+	rustVersionCache.cacheTTL = 1 * time.Hour
+	rustVersionCache.Unlock()
+	defer func() {
+		rustVersionCache.Lock()
+		rustVersionCache.versions = nil
+		rustVersionCache.fetchedAt = time.Time{}
+		rustVersionCache.Unlock()
+	}()
+
+	dynamic := generateRustVersionMatrix("1.82")
+	if !reflect.DeepEqual(dynamic, []string{"1.82", "1.83", "1.84", "1.85", "stable"}) {
+		t.Fatalf("dynamic matrix = %v, want the cached versions", dynamic)
+	}
+
+	SetStaticMatrices(true)
+	defer SetStaticMatrices(false)
+
+	static := generateRustVersionMatrix("1.82")
+	expected := []string{"1.82", "1.83", "1.84", "stable"}
+	if !reflect.DeepEqual(static, expected) {
+		t.Errorf("static matrix = %v, want %v", static, expected)
+	}
+}
+
 // TestRustVersionCacheConcurrency verifies cache is thread-safe
 func TestRustVersionCacheConcurrency(t *testing.T) {
 	// Populate cache
@@ -782,3 +959,278 @@ serde = "1.0"
 		t.Errorf("Expected 2 workspace members, got %v", metadata.LanguageSpecific["workspace_members"])
 	}
 }
+
+// TestWorkspaceMemberInheritsRustVersion checks that a member crate
+// declaring rust-version.workspace = true picks the MSRV up from the
+// workspace root in a parent directory and starts its matrix there,
+// rather than falling back to an edition-based matrix.
+func TestWorkspaceMemberInheritsRustVersion(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rootToml := `[workspace]
+members = ["crates/core"]
+resolver = "2"
+
+[workspace.package]
+version = "0.4.0"
+edition = "2021"
+rust-version = "1.74"
+license = "Apache-2.0"
+`
+	memberToml := `[package]
+name = "core"
+version.workspace = true
+edition.workspace = true
+rust-version.workspace = true
+license = { workspace = true }
+`
+	memberDir := filepath.Join(root, "crates", "core")
+	if err := os.MkdirAll(memberDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "Cargo.toml"), []byte(rootToml), 0644); err != nil {
+		t.Fatalf("Failed to write Cargo.toml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(memberDir, "Cargo.toml"), []byte(memberToml), 0644); err != nil {
+		t.Fatalf("Failed to write Cargo.toml: %v", err)
+	}
+
+	metadata, err := NewExtractor().Extract(memberDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if msrv := metadata.LanguageSpecific["msrv"]; msrv != "1.74" {
+		t.Errorf("msrv = %v, want 1.74 from the workspace root", msrv)
+	}
+	matrix, ok := metadata.LanguageSpecific["rust_version_matrix"].([]string)
+	if !ok || len(matrix) == 0 || matrix[0] != "1.74" {
+		t.Errorf("rust_version_matrix = %v, want it to start at the 1.74 MSRV", metadata.LanguageSpecific["rust_version_matrix"])
+	}
+	if metadata.Version != "0.4.0" || metadata.License != "Apache-2.0" {
+		t.Errorf("Version, License = %q, %q, want the workspace values", metadata.Version, metadata.License)
+	}
+	if got := metadata.LanguageSpecific["workspace_root"]; got != "../.." {
+		t.Errorf("workspace_root = %v, want ../..", got)
+	}
+	if _, ok := metadata.LanguageSpecific["is_workspace"]; ok {
+		t.Error("is_workspace set for a member crate")
+	}
+}
+
+// TestVirtualWorkspaceRustVersion checks that a virtual workspace
+// manifest derives its matrix from [workspace.package].rust-version.
+func TestVirtualWorkspaceRustVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	cargoToml := `[workspace]
+members = ["a", "b"]
+
+[workspace.package]
+edition = "2021"
+rust-version = "1.70"
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "Cargo.toml"), []byte(cargoToml), 0644); err != nil {
+		t.Fatalf("Failed to write Cargo.toml: %v", err)
+	}
+
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	matrix, ok := metadata.LanguageSpecific["rust_version_matrix"].([]string)
+	if !ok || len(matrix) == 0 || matrix[0] != "1.70" {
+		t.Errorf("rust_version_matrix = %v, want it to start at the 1.70 MSRV", metadata.LanguageSpecific["rust_version_matrix"])
+	}
+}
+
+// TestExtractErrorCodes checks that a missing Cargo.toml and a malformed
+// one report different failure classes.
+func TestExtractErrorCodes(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := NewExtractor()
+
+	_, err := e.Extract(tmpDir)
+	if !errors.Is(err, extractor.ErrNoManifest) {
+		t.Errorf("Extract() without Cargo.toml error = %v, want ErrNoManifest", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "Cargo.toml"), []byte("[package\nname = \"broken\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = e.Extract(tmpDir)
+	if !errors.Is(err, extractor.ErrParseFailure) {
+		t.Errorf("Extract() with malformed Cargo.toml error = %v, want ErrParseFailure", err)
+	}
+	if code := extractor.ErrorCode(err); code != extractor.ErrorCodeParseFailure {
+		t.Errorf("ErrorCode() = %q, want %q", code, extractor.ErrorCodeParseFailure)
+	}
+}
+
+// TestArtifactKind verifies the library/application classification from
+// declared and auto-discovered Cargo targets
+func TestArtifactKind(t *testing.T) {
+	manifest := "[package]\nname = \"crate\"\nversion = \"0.1.0\"\n"
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name:     "src/lib.rs",
+			files:    map[string]string{"Cargo.toml": manifest, "src/lib.rs": ""},
+			expected: "library",
+		},
+		{
+			name:     "src/main.rs",
+			files:    map[string]string{"Cargo.toml": manifest, "src/main.rs": ""},
+			expected: "application",
+		},
+		{
+			name:     "[lib] and [[bin]]",
+			files:    map[string]string{"Cargo.toml": manifest + "\n[lib]\npath = \"lib.rs\"\n\n[[bin]]\nname = \"tool\"\npath = \"tool.rs\"\n"},
+			expected: "both",
+		},
+		{
+			name:     "virtual workspace",
+			files:    map[string]string{"Cargo.toml": "[workspace]\nmembers = [\"crates/*\"]\n"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for path, content := range tt.files {
+				fullPath := filepath.Join(tmpDir, path)
+				if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+					t.Fatalf("Failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if metadata.ArtifactKind != tt.expected {
+				t.Errorf("ArtifactKind = %q, expected %q", metadata.ArtifactKind, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPublishable(t *testing.T) {
+	manifest := "[package]\nname = \"crate\"\nversion = \"0.1.0\"\n"
+	tests := []struct {
+		name        string
+		manifest    string
+		publishable bool
+		target      string
+	}{
+		{name: "publish unset", manifest: manifest, publishable: true, target: "crates.io"},
+		{name: "publish = false", manifest: manifest + "publish = false\n", publishable: false, target: "crates.io"},
+		{name: "empty registry list", manifest: manifest + "publish = []\n", publishable: false, target: "crates.io"},
+		{name: "alternative registry", manifest: manifest + "publish = [\"internal\"]\n", publishable: true, target: "internal"},
+		{name: "crates-io listed", manifest: manifest + "publish = [\"internal\", \"crates-io\"]\n", publishable: true, target: "crates.io"},
+		{
+			name:        "inherited from workspace",
+			manifest:    manifest + "publish.workspace = true\n\n[workspace]\n\n[workspace.package]\npublish = false\n",
+			publishable: false,
+			target:      "crates.io",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tmpDir, "Cargo.toml"), []byte(tt.manifest), 0644); err != nil {
+				t.Fatalf("Failed to write Cargo.toml: %v", err)
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if metadata.Publishable == nil {
+				t.Fatal("Publishable not set")
+			}
+			if *metadata.Publishable != tt.publishable {
+				t.Errorf("Publishable = %v, expected %v", *metadata.Publishable, tt.publishable)
+			}
+			if metadata.PublishTarget != tt.target {
+				t.Errorf("PublishTarget = %q, expected %q", metadata.PublishTarget, tt.target)
+			}
+		})
+	}
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "Cargo.toml"), []byte("[workspace]\nmembers = [\"crates/*\"]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write Cargo.toml: %v", err)
+	}
+	metadata, err := NewExtractor().Extract(tmpDir)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if metadata.Publishable != nil {
+		t.Errorf("Publishable = %v for a virtual workspace, expected unset", *metadata.Publishable)
+	}
+}
+
+func TestRustVersionFromToolVersions(t *testing.T) {
+	SetStaticMatrices(true)
+	defer SetStaticMatrices(false)
+
+	manifest := "[package]\nname = \"crate\"\nversion = \"0.1.0\"\n"
+	tests := []struct {
+		name         string
+		manifest     string
+		toolVersions string
+		rustVersion  string
+		source       string
+		msrv         bool
+	}{
+		{name: "declared rust-version wins", manifest: manifest + "rust-version = \"1.80\"\n", toolVersions: "rust 1.83.0\n", rustVersion: "1.80", source: "Cargo.toml", msrv: true},
+		{name: "fallback", manifest: manifest, toolVersions: "nodejs 20.11.0\nrust 1.83.0 1.82.0\n", rustVersion: "1.83.0", source: ".tool-versions"},
+		{name: "channel pin", manifest: manifest, toolVersions: "rust stable\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			files := map[string]string{"Cargo.toml": tt.manifest, ".tool-versions": tt.toolVersions}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			metadata, err := NewExtractor().Extract(tmpDir)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			ls := metadata.LanguageSpecific
+			if tt.rustVersion == "" {
+				if _, ok := ls["rust_version"]; ok {
+					t.Errorf("rust_version = %v, expected unset", ls["rust_version"])
+				}
+				return
+			}
+			if ls["rust_version"] != tt.rustVersion {
+				t.Errorf("rust_version = %v, expected %s", ls["rust_version"], tt.rustVersion)
+			}
+			if ls["rust_version_source"] != tt.source {
+				t.Errorf("rust_version_source = %v, expected %s", ls["rust_version_source"], tt.source)
+			}
+			if _, ok := ls["msrv"]; ok != tt.msrv {
+				t.Errorf("msrv set = %v, expected %v", ok, tt.msrv)
+			}
+			if _, ok := ls["rust_version_matrix"]; !ok {
+				t.Error("rust_version_matrix not set")
+			}
+		})
+	}
+}
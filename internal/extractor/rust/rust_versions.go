@@ -24,6 +24,18 @@ var rustVersionCache struct {
 	cacheTTL  time.Duration
 }
 
+// staticMatrices, when set, makes generateRustVersionMatrix use only the
+// static fallback table and never call fetchRustVersions, so the matrix
+// cannot change when a new Rust release ships. The CLI enables it for
+// the static_matrices input via SetStaticMatrices.
+var staticMatrices bool
+
+// SetStaticMatrices selects whether version matrices are generated from
+// the static fallback table only.
+func SetStaticMatrices(enabled bool) {
+	staticMatrices = enabled
+}
+
 func init() {
 	// Rust releases every 6 weeks, so a 72-hour TTL is conservative.
 	rustVersionCache.cacheTTL = 72 * time.Hour
@@ -184,14 +196,19 @@ func cloneVersions(versions []string) []string {
 //
 // The fallback ensures that temporary network issues or API maintenance don't
 // cause build failures, while the dynamic approach keeps testing current.
+// With staticMatrices set the fetch is skipped and the fallback always
+// applies.
 func generateRustVersionMatrix(msrv string) []string {
-	dynamicVersions, err := fetchRustVersions()
-	if err == nil && len(dynamicVersions) > 0 {
-		return filterVersionsFromMSRV(msrv, dynamicVersions)
+	if !staticMatrices {
+		dynamicVersions, err := fetchRustVersions()
+		if err == nil && len(dynamicVersions) > 0 {
+			return filterVersionsFromMSRV(msrv, dynamicVersions)
+		}
 	}
 
-	// Static fallback used only when the dynamic fetch fails (network issues,
-	// API down, timeout); kept current as of November 2025.
+	// Static fallback used when the dynamic fetch fails (network issues,
+	// API down, timeout) or static matrices are requested; kept current
+	// as of November 2025.
 	versionMap := map[string][]string{
 		"1.84": {"1.84", "stable"},
 		"1.83": {"1.83", "1.84", "stable"},
@@ -209,9 +226,21 @@ func generateRustVersionMatrix(msrv string) []string {
 		return versions
 	}
 
-	for version, testVersions := range versionMap {
+	// Check prefixes longest-first in a fixed order so the chosen matrix
+	// never depends on map iteration order
+	prefixes := make([]string, 0, len(versionMap))
+	for version := range versionMap {
+		prefixes = append(prefixes, version)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i]) != len(prefixes[j]) {
+			return len(prefixes[i]) > len(prefixes[j])
+		}
+		return prefixes[i] > prefixes[j]
+	})
+	for _, version := range prefixes {
 		if strings.HasPrefix(msrv, version) {
-			return testVersions
+			return versionMap[version]
 		}
 	}
 
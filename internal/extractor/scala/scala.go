@@ -46,6 +46,10 @@ func (e *Extractor) Detect(projectPath string) bool {
 		return true
 	}
 
+	if _, err := os.Stat(filepath.Join(projectPath, scalaVersionFile)); err == nil {
+		return true
+	}
+
 	// Check for pom.xml with Scala (Maven)
 	pomPath := filepath.Join(projectPath, "pom.xml")
 	if content, err := os.ReadFile(pomPath); err == nil {
@@ -79,30 +83,117 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 		LanguageSpecific: make(map[string]interface{}),
 	}
 
-	// Try build.sbt first (most common)
+	buildTool := "unknown"
+
+	// Try build.sbt first (most common), then build.sc (Mill)
 	buildSbtPath := filepath.Join(projectPath, "build.sbt")
-	if _, err := os.Stat(buildSbtPath); err == nil {
-		if err := e.extractFromBuildSbt(buildSbtPath, metadata); err == nil {
-			metadata.LanguageSpecific["build_tool"] = "SBT"
-			e.extractSbtVersion(projectPath, metadata)
-			return metadata, nil
-		}
+	buildScPath := filepath.Join(projectPath, "build.sc")
+	if _, err := os.Stat(buildSbtPath); err == nil && e.extractFromBuildSbt(buildSbtPath, metadata) == nil {
+		buildTool = "SBT"
+		metadata.AddConsumedFile(buildSbtPath)
+		e.extractSbtVersion(projectPath, metadata)
+	} else if _, err := os.Stat(buildScPath); err == nil && e.extractFromMill(buildScPath, metadata) == nil {
+		buildTool = "Mill"
+		metadata.AddConsumedFile(buildScPath)
 	}
 
-	// Try build.sc (Mill)
-	buildScPath := filepath.Join(projectPath, "build.sc")
-	if _, err := os.Stat(buildScPath); err == nil {
-		if err := e.extractFromMill(buildScPath, metadata); err == nil {
-			metadata.LanguageSpecific["build_tool"] = "Mill"
-			return metadata, nil
-		}
+	if _, ok := metadata.LanguageSpecific["scala_version"]; ok {
+		metadata.LanguageSpecific["scala_version_source"] = buildFileNames[buildTool]
+	}
+
+	// scala-cli directives and .scala-version only supply the Scala
+	// version when the build file sets none
+	if e.extractScalaCLI(projectPath, metadata) && buildTool == "unknown" {
+		buildTool = "scala-cli"
+	}
+	if _, ok := metadata.LanguageSpecific["scala_version"]; !ok {
+		e.extractScalaVersionFile(projectPath, metadata)
 	}
 
-	// Fallback
-	metadata.LanguageSpecific["build_tool"] = "unknown"
+	metadata.LanguageSpecific["build_tool"] = buildTool
 	return metadata, nil
 }
 
+// buildFileNames maps a build tool to the file its Scala version is read from
+var buildFileNames = map[string]string{
+	"SBT":  "build.sbt",
+	"Mill": "build.sc",
+}
+
+// scalaVersionFile pins the Scala version for version managers and CI
+const scalaVersionFile = ".scala-version"
+
+// scalaCLIUsingPattern matches a scala-cli using directive, capturing the
+// directive key and its first value: //> using scala 3.3.1
+var scalaCLIUsingPattern = regexp.MustCompile(`^//>\s*using\s+([\w.-]+)(?:\s+"?([^"\s]+)"?)?`)
+
+// extractScalaCLI scans project.scala and the *.scala files in the
+// project root and src/ for scala-cli using directives, which must
+// precede any code. It sets scala_cli and, unless the build file set
+// one, the Scala version of a `using scala` directive, and reports
+// whether any directive was found.
+func (e *Extractor) extractScalaCLI(projectPath string, metadata *extractor.ProjectMetadata) bool {
+	var files []string
+	for _, pattern := range []string{"*.scala", filepath.Join("src", "*.scala")} {
+		matches, _ := filepath.Glob(filepath.Join(projectPath, pattern))
+		files = append(files, matches...)
+	}
+
+	found := false
+	var scalaVersion, source string
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			matches := scalaCLIUsingPattern.FindStringSubmatch(line)
+			if matches == nil {
+				// Comments may sit among the directives; code ends them
+				if strings.HasPrefix(line, "//") || strings.HasPrefix(line, "/*") || strings.HasPrefix(line, "*") {
+					continue
+				}
+				break
+			}
+			found = true
+			if matches[1] == "scala" && matches[2] != "" && scalaVersion == "" {
+				scalaVersion = matches[2]
+				source = path
+			}
+		}
+		file.Close()
+	}
+
+	if !found {
+		return false
+	}
+	metadata.LanguageSpecific["scala_cli"] = true
+	if _, ok := metadata.LanguageSpecific["scala_version"]; !ok && scalaVersion != "" {
+		applyScalaVersion(scalaVersion, metadata)
+		metadata.LanguageSpecific["scala_version_source"] = filepath.Base(source)
+		metadata.AddConsumedFile(source)
+	}
+	return true
+}
+
+// extractScalaVersionFile reads the Scala version from .scala-version
+func (e *Extractor) extractScalaVersionFile(projectPath string, metadata *extractor.ProjectMetadata) {
+	content, err := os.ReadFile(filepath.Join(projectPath, scalaVersionFile))
+	if err != nil {
+		return
+	}
+	if version := strings.TrimSpace(string(content)); version != "" {
+		applyScalaVersion(version, metadata)
+		metadata.LanguageSpecific["scala_version_source"] = scalaVersionFile
+		metadata.AddConsumedFile(filepath.Join(projectPath, scalaVersionFile))
+	}
+}
+
 // sbtMatcher pairs a single-value build.sbt regex with its assignment.
 type sbtMatcher struct {
 	re     *regexp.Regexp
@@ -159,17 +250,29 @@ func trackSbtDependencyBlock(line string, standaloneRe *regexp.Regexp, inBlock b
 	return true, parenDepth
 }
 
-// applySbtScalaVersion records the detected Scala version and its matrix.
-func applySbtScalaVersion(scalaVersion string, metadata *extractor.ProjectMetadata) {
+// applyScalaVersion records the detected Scala version, its matrix, and
+// the matrix as JSON ready for a workflow matrix strategy.
+func applyScalaVersion(scalaVersion string, metadata *extractor.ProjectMetadata) {
 	if scalaVersion == "" {
 		return
 	}
 	metadata.LanguageSpecific["scala_version"] = scalaVersion
 	if matrix := generateScalaVersionMatrix(scalaVersion); len(matrix) > 0 {
 		metadata.LanguageSpecific["scala_version_matrix"] = matrix
+		metadata.LanguageSpecific["matrix_json"] = fmt.Sprintf(`{"scala-version": [%s]}`,
+			strings.Join(quoteStrings(matrix), ", "))
 	}
 }
 
+// quoteStrings adds quotes around each string
+func quoteStrings(strs []string) []string {
+	quoted := make([]string, len(strs))
+	for i, s := range strs {
+		quoted[i] = fmt.Sprintf(`"%s"`, s)
+	}
+	return quoted
+}
+
 // extractFromBuildSbt parses build.sbt
 func (e *Extractor) extractFromBuildSbt(path string, metadata *extractor.ProjectMetadata) error {
 	file, err := os.Open(path)
@@ -216,7 +319,7 @@ func (e *Extractor) extractFromBuildSbt(path string, metadata *extractor.Project
 		return err
 	}
 
-	applySbtScalaVersion(scalaVersion, metadata)
+	applyScalaVersion(scalaVersion, metadata)
 
 	if len(dependencies) > 0 {
 		metadata.LanguageSpecific["dependencies"] = dependencies
@@ -237,6 +340,7 @@ func (e *Extractor) extractSbtVersion(projectPath string, metadata *extractor.Pr
 	sbtVersionRegex := regexp.MustCompile(`sbt\.version\s*=\s*([0-9.]+)`)
 	if matches := sbtVersionRegex.FindStringSubmatch(string(content)); matches != nil {
 		metadata.LanguageSpecific["sbt_version"] = matches[1]
+		metadata.AddConsumedFile(buildPropsPath)
 	}
 }
 
@@ -285,9 +389,7 @@ func (e *Extractor) extractFromMill(path string, metadata *extractor.ProjectMeta
 		return err
 	}
 
-	if scalaVersion != "" {
-		metadata.LanguageSpecific["scala_version"] = scalaVersion
-	}
+	applyScalaVersion(scalaVersion, metadata)
 
 	if len(dependencies) > 0 {
 		metadata.LanguageSpecific["dependencies"] = dependencies
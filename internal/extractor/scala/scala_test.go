@@ -53,6 +53,13 @@ func TestDetect(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: ".scala-version",
+			files: map[string]string{
+				".scala-version": "3.3.1\n",
+			},
+			expected: true,
+		},
 		{
 			name:     "no Scala indicators",
 			files:    map[string]string{},
@@ -270,3 +277,93 @@ func TestGenerateScalaVersionMatrix(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractFromBuildSbtAppendedDependencies(t *testing.T) {
+	buildSbtContent := `ThisBuild / scalaVersion := "2.13.12"
+
+name := "appended-deps"
+version := "0.3.0"
+
+libraryDependencies += "org.typelevel" %% "cats-effect" % "3.5.2"
+libraryDependencies += "co.fs2" %% "fs2-core" % "3.9.3"
+`
+	buildPropsContent := "sbt.version = 1.9.7\n"
+
+	tmpDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(tmpDir, "build.sbt"), []byte(buildSbtContent), 0644)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "project"), 0755))
+	err = os.WriteFile(filepath.Join(tmpDir, "project", "build.properties"), []byte(buildPropsContent), 0644)
+	require.NoError(t, err)
+
+	e := NewExtractor()
+	metadata, err := e.Extract(tmpDir)
+	require.NoError(t, err)
+	require.NotNil(t, metadata)
+
+	assert.Equal(t, "appended-deps", metadata.Name)
+	assert.Equal(t, "0.3.0", metadata.Version)
+	assert.Equal(t, "2.13.12", metadata.LanguageSpecific["scala_version"])
+	assert.Equal(t, "1.9.7", metadata.LanguageSpecific["sbt_version"])
+
+	deps := metadata.LanguageSpecific["dependencies"].([]string)
+	assert.Equal(t, []string{
+		"org.typelevel:cats-effect:3.5.2",
+		"co.fs2:fs2-core:3.9.3",
+	}, deps)
+	assert.Equal(t, 2, metadata.LanguageSpecific["dependency_count"])
+
+	assert.Equal(t, []string{"2.13"}, metadata.LanguageSpecific["scala_version_matrix"])
+	assert.Equal(t, `{"scala-version": ["2.13"]}`, metadata.LanguageSpecific["matrix_json"])
+}
+
+func writeScalaFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+	return tmpDir
+}
+
+func TestExtractScalaVersionFile(t *testing.T) {
+	metadata, err := NewExtractor().Extract(writeScalaFiles(t, map[string]string{
+		".scala-version": "2.13.12\n",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.13.12", metadata.LanguageSpecific["scala_version"])
+	assert.Equal(t, ".scala-version", metadata.LanguageSpecific["scala_version_source"])
+	assert.Equal(t, []string{"2.13"}, metadata.LanguageSpecific["scala_version_matrix"])
+	assert.NotContains(t, metadata.LanguageSpecific, "scala_cli")
+}
+
+func TestExtractScalaCLIDirective(t *testing.T) {
+	metadata, err := NewExtractor().Extract(writeScalaFiles(t, map[string]string{
+		"project.scala":  "// project settings\n//> using scala 3.3.1\n//> using dep \"com.lihaoyi::os-lib:0.9.3\"\n",
+		"Main.scala":     "@main def hello() = println(\"//> using scala 2.12.18\")\n",
+		".scala-version": "2.13.12\n",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, true, metadata.LanguageSpecific["scala_cli"])
+	assert.Equal(t, "scala-cli", metadata.LanguageSpecific["build_tool"])
+	assert.Equal(t, "3.3.1", metadata.LanguageSpecific["scala_version"])
+	assert.Equal(t, "project.scala", metadata.LanguageSpecific["scala_version_source"])
+}
+
+func TestExtractBuildSbtScalaVersionPreferred(t *testing.T) {
+	metadata, err := NewExtractor().Extract(writeScalaFiles(t, map[string]string{
+		"build.sbt":      `scalaVersion := "2.13.12"`,
+		"Tool.scala":     "//> using scala \"3.3.1\"\nobject Tool\n",
+		".scala-version": "3.4.0\n",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "SBT", metadata.LanguageSpecific["build_tool"])
+	assert.Equal(t, "2.13.12", metadata.LanguageSpecific["scala_version"])
+	assert.Equal(t, "build.sbt", metadata.LanguageSpecific["scala_version_source"])
+	assert.Equal(t, true, metadata.LanguageSpecific["scala_cli"])
+}
@@ -83,6 +83,7 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 	if err != nil {
 		return nil, err
 	}
+	metadata.AddConsumedFile(packagePath)
 
 	e.populateMetadata(manifest, metadata, projectPath)
 
@@ -132,20 +133,18 @@ func (e *Extractor) extractPackageName(text string) string {
 	return ""
 }
 
+// swiftToolsVersionPattern matches the Package.swift header comment
+// selecting the manifest format: "// swift-tools-version:5.9", with or
+// without a space after the colon, or the space-separated form older
+// manifests used ("// swift-tools-version 5.5"). SwiftPM matches the
+// label case-insensitively.
+var swiftToolsVersionPattern = regexp.MustCompile(`(?i)//\s*swift-tools-version\s*(?::\s*|\s+)(\d+\.\d+(?:\.\d+)?)`)
+
 // extractSwiftToolsVersion extracts the Swift tools version from the header comment
 func (e *Extractor) extractSwiftToolsVersion(text string) string {
-	// Pattern: // swift-tools-version:5.7
-	re := regexp.MustCompile(`//\s*swift-tools-version:\s*(\d+\.\d+(?:\.\d+)?)`)
-	if matches := re.FindStringSubmatch(text); len(matches) > 1 {
+	if matches := swiftToolsVersionPattern.FindStringSubmatch(text); len(matches) > 1 {
 		return matches[1]
 	}
-
-	// Also try older format
-	re = regexp.MustCompile(`//\s*swift-tools-version\s+(\d+\.\d+(?:\.\d+)?)`)
-	if matches := re.FindStringSubmatch(text); len(matches) > 1 {
-		return matches[1]
-	}
-
 	return ""
 }
 
@@ -407,6 +407,52 @@ let package = Package(
 	assert.Equal(t, "HybridPackage", metadata.Name)
 }
 
+func TestExtractSwiftToolsVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "no space after colon", header: "// swift-tools-version:5.9", want: "5.9"},
+		{name: "space after colon", header: "// swift-tools-version: 5.9", want: "5.9"},
+		{name: "patch version", header: "// swift-tools-version:5.10.1", want: "5.10.1"},
+		{name: "space separated", header: "// swift-tools-version 5.5", want: "5.5"},
+		{name: "no space after slashes", header: "//swift-tools-version:6.0", want: "6.0"},
+		{name: "mixed case label", header: "// Swift-Tools-Version: 5.8", want: "5.8"},
+		{name: "no header", header: "// A package", want: ""},
+	}
+
+	e := NewExtractor()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := tt.header + "\nimport PackageDescription\n"
+			assert.Equal(t, tt.want, e.extractSwiftToolsVersion(text))
+		})
+	}
+}
+
+func TestExtractor_Extract_ToolsVersionMatrix(t *testing.T) {
+	for _, header := range []string{"// swift-tools-version:5.10", "// swift-tools-version: 5.10"} {
+		t.Run(header, func(t *testing.T) {
+			dir := t.TempDir()
+			packageContent := header + `
+import PackageDescription
+
+let package = Package(
+    name: "MatrixPackage"
+)`
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "Package.swift"), []byte(packageContent), 0644))
+
+			metadata, err := NewExtractor().Extract(dir)
+			require.NoError(t, err)
+
+			assert.Equal(t, "5.10", metadata.LanguageSpecific["swift_tools_version"])
+			assert.Equal(t, []string{"5.10", "5.11", "6.0", "6.1"}, metadata.LanguageSpecific["swift_version_matrix"])
+			assert.Equal(t, `{"swift-version": ["5.10", "5.11", "6.0", "6.1"]}`, metadata.LanguageSpecific["matrix_json"])
+		})
+	}
+}
+
 func TestGenerateSwiftVersionMatrix(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/lfreleng-actions/build-metadata-action/internal/toolversions"
+)
+
+// Values of the iac_engine field
+const (
+	engineTerraform = "terraform"
+	engineOpenTofu  = "opentofu"
+	engineBoth      = "both"
+)
+
+// lockFileName is the dependency lock file both engines write; the
+// registry host of each locked provider tells them apart
+const lockFileName = ".terraform.lock.hcl"
+
+// lockProviderRe matches a provider block of the dependency lock file,
+// capturing the registry host of its address
+var lockProviderRe = regexp.MustCompile(`(?m)^\s*provider\s+"([^"/]+)/`)
+
+// Registry hosts recorded in the dependency lock file
+const (
+	terraformRegistry = "registry.terraform.io"
+	openTofuRegistry  = "registry.opentofu.org"
+)
+
+// engineSignals records what points a configuration at each engine
+type engineSignals struct {
+	terraform bool
+	openTofu  bool
+}
+
+// detectEngineSignals looks for engine-specific files in projectPath:
+// .tofu configuration files, version-manager pins (.opentofu-version,
+// .terraform-version, .tool-versions) and the providers' registry in
+// .terraform.lock.hcl. An HCP Terraform cloud block, recorded while
+// parsing, also counts for Terraform. The files read are recorded as
+// consumed by metadata.
+func detectEngineSignals(projectPath string, config *TerraformConfig, metadata *extractor.ProjectMetadata) engineSignals {
+	signals := engineSignals{terraform: config.UsesCloud, openTofu: config.IsOpenTofu}
+
+	if files, _ := filepath.Glob(filepath.Join(projectPath, "*.tofu")); len(files) > 0 {
+		signals.openTofu = true
+	}
+	for _, name := range []string{".opentofu", ".opentofu-version"} {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			signals.openTofu = true
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(projectPath, ".terraform-version")); err == nil {
+		metadata.AddConsumedFile(filepath.Join(projectPath, ".terraform-version"))
+		if strings.Contains(string(content), "tofu") {
+			signals.openTofu = true
+		} else {
+			signals.terraform = true
+		}
+	}
+	terraformTool, openTofuTool := toolVersionsEngines(projectPath)
+	signals.terraform = signals.terraform || terraformTool
+	signals.openTofu = signals.openTofu || openTofuTool
+	if terraformTool || openTofuTool {
+		metadata.AddConsumedFile(filepath.Join(projectPath, toolversions.FileName))
+	}
+
+	if content, err := os.ReadFile(filepath.Join(projectPath, lockFileName)); err == nil {
+		metadata.AddConsumedFile(filepath.Join(projectPath, lockFileName))
+		for _, match := range lockProviderRe.FindAllStringSubmatch(string(content), -1) {
+			switch match[1] {
+			case terraformRegistry:
+				signals.terraform = true
+			case openTofuRegistry:
+				signals.openTofu = true
+			}
+		}
+	}
+	return signals
+}
+
+// toolVersionsEngines reports whether an asdf/mise .tool-versions file
+// in projectPath pins terraform and opentofu.
+func toolVersionsEngines(projectPath string) (terraform, openTofu bool) {
+	tools, err := toolversions.Read(projectPath)
+	if err != nil {
+		return false, false
+	}
+	_, terraform = tools["terraform"]
+	_, openTofu = tools["opentofu"]
+	return terraform, openTofu
+}
+
+// engine names the engine the signals point at: both when each has its
+// own, Terraform when none point at OpenTofu.
+func (s engineSignals) engine() string {
+	switch {
+	case s.openTofu && s.terraform:
+		return engineBoth
+	case s.openTofu:
+		return engineOpenTofu
+	default:
+		return engineTerraform
+	}
+}
@@ -8,11 +8,14 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // Extractor extracts metadata from Terraform projects
@@ -36,6 +39,8 @@ type TerraformConfig struct {
 	Modules           []ModuleCall
 	Resources         []Resource
 	IsOpenTofu        bool // Detected if using OpenTofu
+	UsesCloud         bool // Declares an HCP Terraform cloud block
+	Engine            string
 }
 
 // ProviderRequirement represents a required provider
@@ -69,8 +74,8 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 		Resources:         make([]Resource, 0),
 	}
 
-	files, err := filepath.Glob(filepath.Join(projectPath, "*.tf"))
-	if err != nil || len(files) == 0 {
+	files := configFiles(projectPath)
+	if len(files) == 0 {
 		return nil, fmt.Errorf("no Terraform files found in %s", projectPath)
 	}
 
@@ -79,21 +84,11 @@ func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, err
 			// Continue on error, we'll gather what we can
 			continue
 		}
+		metadata.AddConsumedFile(file)
 	}
 
-	openTofuFiles := []string{
-		filepath.Join(projectPath, ".opentofu"),
-		filepath.Join(projectPath, ".terraform-version"),
-	}
-	for _, ofFile := range openTofuFiles {
-		if content, err := os.ReadFile(ofFile); err == nil {
-			contentStr := string(content)
-			if strings.Contains(contentStr, "opentofu") || strings.Contains(contentStr, "tofu") {
-				config.IsOpenTofu = true
-				break
-			}
-		}
-	}
+	config.Engine = detectEngineSignals(projectPath, config, metadata).engine()
+	config.IsOpenTofu = config.Engine != engineTerraform
 
 	e.populateMetadata(config, metadata, projectPath)
 
@@ -159,32 +154,71 @@ func (e *Extractor) parseTerraformBlock(block *hcl.Block, config *TerraformConfi
 	content, _, _ := block.Body.PartialContent(schema)
 	if content != nil {
 		if attr, exists := content.Attributes["required_version"]; exists {
-			val, _ := attr.Expr.Value(nil)
-			config.RequiredVersion = strings.Trim(val.AsString(), `"`)
+			config.RequiredVersion = stringValue(attr.Expr)
 		}
 
 		for _, innerBlock := range content.Blocks {
 			if innerBlock.Type == "required_providers" {
 				attrs, _ := innerBlock.Body.JustAttributes()
 				for name, attr := range attrs {
-					val, _ := attr.Expr.Value(nil)
-					if val.Type().IsObjectType() {
-						config.RequiredProviders[name] = ProviderRequirement{}
-					} else {
-						config.RequiredProviders[name] = ProviderRequirement{
-							Version: strings.Trim(val.AsString(), `"`),
-						}
-					}
+					config.RequiredProviders[name] = providerRequirement(attr.Expr)
 				}
 			} else if innerBlock.Type == "backend" {
 				if len(innerBlock.Labels) > 0 {
 					config.Backend = innerBlock.Labels[0]
 				}
+			} else if innerBlock.Type == "cloud" {
+				config.UsesCloud = true
 			}
 		}
 	}
 }
 
+// providerRequirement reads a required_providers entry, either the
+// object form `{ source = "...", version = "..." }` or the legacy
+// version-only string form.
+func providerRequirement(expr hcl.Expression) ProviderRequirement {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return ProviderRequirement{}
+	}
+
+	if val.Type() == cty.String {
+		return ProviderRequirement{Version: val.AsString()}
+	}
+
+	var req ProviderRequirement
+	if val.Type().IsObjectType() {
+		req.Source = objectString(val, "source")
+		req.Version = objectString(val, "version")
+	}
+	return req
+}
+
+// objectString returns the string attribute name of an object value, or
+// "" when it is absent or not a string.
+func objectString(val cty.Value, name string) string {
+	if !val.Type().HasAttribute(name) {
+		return ""
+	}
+	attr := val.GetAttr(name)
+	if attr.IsNull() || attr.Type() != cty.String {
+		return ""
+	}
+	return attr.AsString()
+}
+
+// stringValue evaluates a literal string expression, returning "" for
+// anything else (e.g. variable references, which cannot be resolved
+// without a full Terraform evaluation context).
+func stringValue(expr hcl.Expression) string {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+		return ""
+	}
+	return strings.Trim(val.AsString(), `"`)
+}
+
 // parseModuleBlock extracts module call information
 func (e *Extractor) parseModuleBlock(block *hcl.Block, config *TerraformConfig) {
 	if len(block.Labels) == 0 {
@@ -233,28 +267,8 @@ func (e *Extractor) parseWithRegex(content string, config *TerraformConfig) erro
 		config.RequiredVersion = matches[1]
 	}
 
-	providerBlockRe := regexp.MustCompile(`required_providers\s*{([^}]+)}`)
-	if matches := providerBlockRe.FindStringSubmatch(content); len(matches) > 1 {
-		providerContent := matches[1]
-		providerRe := regexp.MustCompile(`(\w+)\s*=\s*{[^}]*version\s*=\s*"([^"]+)"`)
-		for _, match := range providerRe.FindAllStringSubmatch(providerContent, -1) {
-			if len(match) > 2 {
-				config.RequiredProviders[match[1]] = ProviderRequirement{
-					Version: match[2],
-				}
-			}
-		}
-		// Also handle simple string syntax
-		simpleProviderRe := regexp.MustCompile(`(\w+)\s*=\s*"([^"]+)"`)
-		for _, match := range simpleProviderRe.FindAllStringSubmatch(providerContent, -1) {
-			if len(match) > 2 {
-				if _, exists := config.RequiredProviders[match[1]]; !exists {
-					config.RequiredProviders[match[1]] = ProviderRequirement{
-						Version: match[2],
-					}
-				}
-			}
-		}
+	if providerContent, ok := blockBody(content, requiredProvidersRe); ok {
+		parseRequiredProvidersWithRegex(providerContent, config)
 	}
 
 	backendRe := regexp.MustCompile(`backend\s+"(\w+)"\s*{`)
@@ -285,6 +299,90 @@ func (e *Extractor) parseWithRegex(content string, config *TerraformConfig) erro
 	return nil
 }
 
+var (
+	requiredProvidersRe = regexp.MustCompile(`required_providers\s*\{`)
+	// providerEntryRe matches the start of a required_providers entry:
+	// either `name = {` (object form) or `name = "constraint"`
+	providerEntryRe   = regexp.MustCompile(`(?m)^\s*([A-Za-z][\w-]*)\s*=\s*(\{|"([^"]*)")`)
+	providerSourceRe  = regexp.MustCompile(`source\s*=\s*"([^"]+)"`)
+	providerVersionRe = regexp.MustCompile(`version\s*=\s*"([^"]+)"`)
+)
+
+// blockBody returns the text between the braces of the first block whose
+// header matches header (which must end with the opening brace). Braces
+// are balanced so nested objects stay inside the body; braces inside
+// quoted strings are ignored.
+func blockBody(content string, header *regexp.Regexp) (string, bool) {
+	loc := header.FindStringIndex(content)
+	if loc == nil {
+		return "", false
+	}
+	return balancedBody(content, loc[1])
+}
+
+// balancedBody scans from start, just past an opening brace, to its
+// matching closing brace and returns the text in between.
+func balancedBody(content string, start int) (string, bool) {
+	depth := 1
+	inString := false
+	for i := start; i < len(content); i++ {
+		switch c := content[i]; {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return content[start:i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseRequiredProvidersWithRegex reads required_providers entries from
+// the block body, skipping over each object entry so its source and
+// version attributes are never mistaken for providers.
+func parseRequiredProvidersWithRegex(body string, config *TerraformConfig) {
+	for offset := 0; offset < len(body); {
+		loc := providerEntryRe.FindStringSubmatchIndex(body[offset:])
+		if loc == nil {
+			return
+		}
+		name := body[offset+loc[2] : offset+loc[3]]
+		end := offset + loc[1]
+
+		if body[offset+loc[4]] == '"' {
+			config.RequiredProviders[name] = ProviderRequirement{
+				Version: body[offset+loc[6] : offset+loc[7]],
+			}
+			offset = end
+			continue
+		}
+
+		entry, ok := balancedBody(body, end)
+		if !ok {
+			return
+		}
+		var req ProviderRequirement
+		if matches := providerSourceRe.FindStringSubmatch(entry); matches != nil {
+			req.Source = matches[1]
+		}
+		if matches := providerVersionRe.FindStringSubmatch(entry); matches != nil {
+			req.Version = matches[1]
+		}
+		config.RequiredProviders[name] = req
+		offset = end + len(entry) + 1
+	}
+}
+
 // populateMetadata converts TerraformConfig to ProjectMetadata
 func (e *Extractor) populateMetadata(config *TerraformConfig, metadata *extractor.ProjectMetadata, projectPath string) {
 	// Try to extract project name from directory
@@ -294,33 +392,41 @@ func (e *Extractor) populateMetadata(config *TerraformConfig, metadata *extracto
 
 	// Terraform/OpenTofu-specific metadata
 	metadata.LanguageSpecific["terraform_version"] = config.RequiredVersion
+	if config.RequiredVersion != "" {
+		metadata.LanguageSpecific["required_version"] = config.RequiredVersion
+	}
 	metadata.LanguageSpecific["metadata_source"] = "versions.tf"
 	metadata.LanguageSpecific["is_opentofu"] = config.IsOpenTofu
-
-	if config.IsOpenTofu {
-		metadata.LanguageSpecific["engine"] = "opentofu"
-	} else {
-		metadata.LanguageSpecific["engine"] = "terraform"
+	metadata.LanguageSpecific["iac_engine"] = config.Engine
+	// engine names a single engine: a configuration that targets both
+	// falls back to Terraform, which its non-OpenTofu signals require
+	switch config.Engine {
+	case engineOpenTofu:
+		metadata.LanguageSpecific["engine"] = engineOpenTofu
+	default:
+		metadata.LanguageSpecific["engine"] = engineTerraform
 	}
 
 	if config.Backend != "" {
 		metadata.LanguageSpecific["backend"] = config.Backend
 	}
 
-	// Providers
+	// Providers, sorted by name so the output is stable across runs
 	if len(config.RequiredProviders) > 0 {
-		providers := make([]map[string]string, 0, len(config.RequiredProviders))
-		for name, req := range config.RequiredProviders {
-			provider := map[string]string{
-				"name": name,
-			}
-			if req.Version != "" {
-				provider["version"] = req.Version
-			}
-			if req.Source != "" {
-				provider["source"] = req.Source
-			}
-			providers = append(providers, provider)
+		names := make([]string, 0, len(config.RequiredProviders))
+		for name := range config.RequiredProviders {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		providers := make([]map[string]string, 0, len(names))
+		for _, name := range names {
+			req := config.RequiredProviders[name]
+			providers = append(providers, map[string]string{
+				"name":    name,
+				"source":  req.Source,
+				"version": req.Version,
+			})
 		}
 		metadata.LanguageSpecific["providers"] = providers
 		metadata.LanguageSpecific["provider_count"] = len(providers)
@@ -356,16 +462,16 @@ func (e *Extractor) populateMetadata(config *TerraformConfig, metadata *extracto
 	// Generate Terraform/OpenTofu version matrix
 	if config.RequiredVersion != "" {
 		matrix := generateTerraformVersionMatrix(config.RequiredVersion)
+		if config.Engine == engineOpenTofu {
+			matrix = openTofuReleases(matrix)
+		}
 		if len(matrix) > 0 {
 			metadata.LanguageSpecific["terraform_version_matrix"] = matrix
 
-			// Generate matrix for both Terraform and OpenTofu if applicable
-			engine := "terraform"
-			if config.IsOpenTofu {
-				engine = "opentofu"
-			}
-			matrixJSON := fmt.Sprintf(`{"%s-version": [%s]}`,
-				engine, strings.Join(quoteStrings(matrix), ", "))
+			// One axis for either engine, so workflows read the same key
+			// whichever setup action consumes it
+			matrixJSON := fmt.Sprintf(`{"terraform-version": [%s]}`,
+				strings.Join(quoteStrings(matrix), ", "))
 			metadata.LanguageSpecific["matrix_json"] = matrixJSON
 		}
 	}
@@ -373,8 +479,51 @@ func (e *Extractor) populateMetadata(config *TerraformConfig, metadata *extracto
 
 // Detect checks if this extractor can handle the project
 func (e *Extractor) Detect(projectPath string) bool {
-	files, err := filepath.Glob(filepath.Join(projectPath, "*.tf"))
-	return err == nil && len(files) > 0
+	return len(configFiles(projectPath)) > 0
+}
+
+// configFiles returns the configuration files in projectPath: *.tf, read
+// by both engines, and the OpenTofu-only *.tofu.
+func configFiles(projectPath string) []string {
+	tf, _ := filepath.Glob(filepath.Join(projectPath, "*.tf"))
+	tofu, _ := filepath.Glob(filepath.Join(projectPath, "*.tofu"))
+	return append(tf, tofu...)
+}
+
+// openTofuFirstRelease is the first OpenTofu minor release; OpenTofu
+// forked from Terraform 1.5 and has no earlier versions
+const openTofuFirstRelease = "1.6"
+
+// openTofuReleases drops the versions of matrix OpenTofu never released.
+func openTofuReleases(matrix []string) []string {
+	releases := make([]string, 0, len(matrix))
+	for _, version := range matrix {
+		if compareMinor(version, openTofuFirstRelease) >= 0 {
+			releases = append(releases, version)
+		}
+	}
+	return releases
+}
+
+// compareMinor compares two "major.minor" versions numerically.
+func compareMinor(a, b string) int {
+	ap, bp := strings.SplitN(a, ".", 2), strings.SplitN(b, ".", 2)
+	for i := 0; i < 2; i++ {
+		var x, y int
+		if i < len(ap) {
+			x, _ = strconv.Atoi(ap[i])
+		}
+		if i < len(bp) {
+			y, _ = strconv.Atoi(bp[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
 }
 
 // Helper functions
@@ -546,3 +546,188 @@ func TestExtractor_Extract_EmptyTerraformBlock(t *testing.T) {
 	// Should still succeed with resources but no terraform block
 	assert.Equal(t, 1, metadata.LanguageSpecific["resource_count"])
 }
+
+func TestExtractor_Extract_ProvidersAndBackend(t *testing.T) {
+	dir := t.TempDir()
+	tfContent := `terraform {
+  required_version = ">= 1.6.0"
+
+  required_providers {
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.6"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0, < 6.0"
+    }
+  }
+
+  backend "s3" {
+    bucket = "state"
+    key    = "prod/terraform.tfstate"
+  }
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "versions.tf"), []byte(tfContent), 0644))
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, ">= 1.6.0", metadata.LanguageSpecific["required_version"])
+	assert.Equal(t, "s3", metadata.LanguageSpecific["backend"])
+	assert.Equal(t, []map[string]string{
+		{"name": "aws", "source": "hashicorp/aws", "version": ">= 5.0, < 6.0"},
+		{"name": "random", "source": "hashicorp/random", "version": "~> 3.6"},
+	}, metadata.LanguageSpecific["providers"])
+	assert.Equal(t, 2, metadata.LanguageSpecific["provider_count"])
+}
+
+func TestExtractor_Extract_ProvidersRegexFallback(t *testing.T) {
+	dir := t.TempDir()
+	// The unterminated locals block makes the HCL parser fail, so the
+	// block scanner has to handle the nested provider objects
+	tfContent := `terraform {
+  required_version = "~> 1.7"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    google = {
+      source  = "hashicorp/google"
+      version = ">= 4.0"
+    }
+  }
+
+  backend "s3" {
+    bucket = "state"
+  }
+}
+
+locals {
+  broken =
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(tfContent), 0644))
+
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "~> 1.7", metadata.LanguageSpecific["required_version"])
+	assert.Equal(t, "s3", metadata.LanguageSpecific["backend"])
+	assert.Equal(t, []map[string]string{
+		{"name": "aws", "source": "hashicorp/aws", "version": "~> 5.0"},
+		{"name": "google", "source": "hashicorp/google", "version": ">= 4.0"},
+	}, metadata.LanguageSpecific["providers"])
+}
+
+func TestBlockBody(t *testing.T) {
+	content := `required_providers {
+  aws = { source = "hashicorp/aws" }
+  note = "}"
+}
+trailing {}`
+
+	body, ok := blockBody(content, requiredProvidersRe)
+	require.True(t, ok)
+	assert.Equal(t, "\n  aws = { source = \"hashicorp/aws\" }\n  note = \"}\"\n", body)
+
+	_, ok = blockBody("required_providers { aws = {", requiredProvidersRe)
+	assert.False(t, ok)
+}
+
+func TestExtractor_Extract_IaCEngine(t *testing.T) {
+	versions := "terraform {\n  required_version = \">= 1.5.0\"\n}\n"
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected string
+	}{
+		{
+			name:     "plain terraform",
+			files:    map[string]string{"versions.tf": versions},
+			expected: "terraform",
+		},
+		{
+			name: "terraform lock",
+			files: map[string]string{
+				"versions.tf":         versions,
+				".terraform.lock.hcl": "provider \"registry.terraform.io/hashicorp/aws\" {\n  version = \"5.31.0\"\n}\n",
+			},
+			expected: "terraform",
+		},
+		{
+			name: "tofu files",
+			files: map[string]string{
+				"versions.tf": versions,
+				"main.tofu":   "resource \"null_resource\" \"this\" {}\n",
+			},
+			expected: "opentofu",
+		},
+		{
+			name: "required_version with an OpenTofu lock",
+			files: map[string]string{
+				"versions.tf":         versions,
+				".terraform.lock.hcl": "provider \"registry.opentofu.org/hashicorp/aws\" {\n  version = \"5.31.0\"\n}\n",
+			},
+			expected: "opentofu",
+		},
+		{
+			name: "opentofu in .tool-versions",
+			files: map[string]string{
+				"versions.tf":    versions,
+				".tool-versions": "opentofu 1.8.2\n",
+			},
+			expected: "opentofu",
+		},
+		{
+			name: "both engines pinned",
+			files: map[string]string{
+				"versions.tf":    versions,
+				".tool-versions": "terraform 1.9.0\nopentofu 1.8.2\n",
+			},
+			expected: "both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+			}
+
+			metadata, err := NewExtractor().Extract(dir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, metadata.LanguageSpecific["iac_engine"])
+			assert.Equal(t, tt.expected != "terraform", metadata.LanguageSpecific["is_opentofu"])
+
+			// The single-engine field falls back to Terraform for both
+			engine := "terraform"
+			if tt.expected == "opentofu" {
+				engine = "opentofu"
+			}
+			assert.Equal(t, engine, metadata.LanguageSpecific["engine"])
+		})
+	}
+}
+
+func TestExtractor_Extract_OpenTofuMatrix(t *testing.T) {
+	versions := "terraform {\n  required_version = \">= 1.5.0\"\n}\n"
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "versions.tf"), []byte(versions), 0644))
+	metadata, err := NewExtractor().Extract(dir)
+	require.NoError(t, err)
+	assert.Equal(t, `{"terraform-version": ["1.5", "1.6", "1.7", "1.8", "1.9", "1.10"]}`, metadata.LanguageSpecific["matrix_json"])
+
+	// OpenTofu has no 1.5 release
+	dir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "versions.tofu"), []byte(versions), 0644))
+	metadata, err = NewExtractor().Extract(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "opentofu", metadata.LanguageSpecific["iac_engine"])
+	assert.Equal(t, ">= 1.5.0", metadata.LanguageSpecific["required_version"])
+	assert.Equal(t, `{"terraform-version": ["1.6", "1.7", "1.8", "1.9", "1.10"]}`, metadata.LanguageSpecific["matrix_json"])
+	assert.Equal(t, []string{"1.6", "1.7", "1.8", "1.9", "1.10"}, metadata.LanguageSpecific["terraform_version_matrix"])
+}
@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// NormalizeNewlines converts CRLF and lone CR line endings to LF, so
+// line-based manifest parsers read files checked out on Windows (or with
+// core.autocrlf) without a trailing "\r" on every value.
+func NormalizeNewlines(content string) string {
+	if !strings.Contains(content, "\r") {
+		return content
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\r", "\n")
+}
+
+// NormalizeManifestPath converts a path written in a manifest with
+// Windows separators (as .sln files always are) to the host's form.
+func NormalizeManifestPath(path string) string {
+	return filepath.FromSlash(strings.ReplaceAll(path, `\`, "/"))
+}
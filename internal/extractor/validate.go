@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/detector"
+)
+
+// Validate checks that every registered extractor is wired to the
+// detector: GetExtractor resolves its own name to it (as the
+// project_type input relies on), at least one detected project type
+// routes to it, and outputPrefix (the caller's project type to output
+// prefix mapping) gives its name the same prefix as one of those
+// detected types, so forcing the type does not rename its outputs.
+// Every problem found is reported.
+func Validate(outputPrefix func(projectType string) string) error {
+	routed := make(map[string][]string)
+	for _, projectType := range detector.ProjectTypes() {
+		name := mapProjectTypeToExtractor(projectType)
+		routed[name] = append(routed[name], projectType)
+	}
+
+	names := make([]string, 0, len(globalRegistry.extractors))
+	for name := range globalRegistry.extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if e, err := GetExtractor(name); err != nil || e.Name() != name {
+			errs = append(errs, fmt.Errorf("extractor %s: GetExtractor(%q) does not resolve to it", name, name))
+		}
+
+		projectTypes := routed[name]
+		if len(projectTypes) == 0 {
+			errs = append(errs, fmt.Errorf("extractor %s: no detected project type maps to it", name))
+			continue
+		}
+
+		prefix := outputPrefix(name)
+		matched := false
+		for _, projectType := range projectTypes {
+			if outputPrefix(projectType) == prefix {
+				matched = true
+				break
+			}
+		}
+		if prefix == "" || !matched {
+			errs = append(errs, fmt.Errorf("extractor %s: output prefix %q differs from that of its project types %v", name, prefix, projectTypes))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package vlang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lfreleng-actions/build-metadata-action/internal/extractor"
+)
+
+// Extractor extracts metadata from V projects
+type Extractor struct {
+	extractor.BaseExtractor
+}
+
+// NewExtractor creates a new V extractor
+func NewExtractor() *Extractor {
+	return &Extractor{
+		BaseExtractor: extractor.NewBaseExtractor("vlang", 1),
+	}
+}
+
+func init() {
+	extractor.RegisterExtractor(NewExtractor())
+}
+
+// VMod represents the fields of a v.mod Module literal used for metadata
+type VMod struct {
+	Name         string
+	Version      string
+	Description  string
+	License      string
+	RepoURL      string
+	Author       string
+	Dependencies []string
+}
+
+var (
+	// vmodLineCommentPattern strips `// ...` comments outside strings
+	vmodLineCommentPattern = regexp.MustCompile(`(?m)^((?:[^'"/]|'[^']*'|"[^"]*"|/[^/])*)//.*$`)
+	// vmodFieldPattern matches a string field such as `name: 'mymodule'`,
+	// with either quote style
+	vmodFieldPattern = regexp.MustCompile(`\b([a-z_]+)\s*:\s*(?:'([^']*)'|"([^"]*)")`)
+	// vmodDependenciesPattern captures the body of the dependencies list,
+	// which may span several lines
+	vmodDependenciesPattern = regexp.MustCompile(`\bdependencies\s*:\s*\[([^\]]*)\]`)
+	// vmodStringPattern extracts each quoted string in a list
+	vmodStringPattern = regexp.MustCompile(`'([^']*)'|"([^"]*)"`)
+)
+
+// Detect checks if this is a V project
+func (e *Extractor) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, "v.mod"))
+	return err == nil
+}
+
+// Extract retrieves metadata from a V project
+func (e *Extractor) Extract(projectPath string) (*extractor.ProjectMetadata, error) {
+	metadata := &extractor.ProjectMetadata{
+		LanguageSpecific: make(map[string]interface{}),
+	}
+
+	path := filepath.Join(projectPath, "v.mod")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("v.mod not found in %s", projectPath)
+	}
+
+	vmod, err := parseVMod(string(content))
+	if err != nil {
+		return nil, err
+	}
+	metadata.AddConsumedFile(path)
+
+	metadata.Name = vmod.Name
+	metadata.Description = vmod.Description
+	metadata.License = vmod.License
+	metadata.Repository = vmod.RepoURL
+	if vmod.Author != "" {
+		metadata.Authors = []string{vmod.Author}
+	}
+	if vmod.Version != "" {
+		metadata.Version = vmod.Version
+		metadata.VersionSource = "v.mod"
+	}
+
+	ls := metadata.LanguageSpecific
+	ls["package_name"] = vmod.Name
+	ls["metadata_source"] = "v.mod"
+	ls["build_tool"] = "v"
+	if len(vmod.Dependencies) > 0 {
+		ls["dependencies"] = vmod.Dependencies
+		ls["dependency_count"] = len(vmod.Dependencies)
+	}
+
+	return metadata, nil
+}
+
+// parseVMod reads the `Module { ... }` literal of a v.mod file with
+// tolerant matching: fields may appear in any order, use either quote
+// style and carry line comments. Only the first occurrence of a field
+// counts.
+func parseVMod(content string) (VMod, error) {
+	content = vmodLineCommentPattern.ReplaceAllString(extractor.NormalizeNewlines(content), "$1")
+
+	start := strings.Index(content, "Module")
+	if start < 0 {
+		return VMod{}, fmt.Errorf("v.mod has no Module declaration")
+	}
+	body := content[start:]
+	if open := strings.Index(body, "{"); open >= 0 {
+		body = body[open+1:]
+	}
+	if end := strings.LastIndex(body, "}"); end >= 0 {
+		body = body[:end]
+	}
+
+	var vmod VMod
+	if matches := vmodDependenciesPattern.FindStringSubmatch(body); matches != nil {
+		for _, match := range vmodStringPattern.FindAllStringSubmatch(matches[1], -1) {
+			if dep := match[1] + match[2]; dep != "" {
+				vmod.Dependencies = append(vmod.Dependencies, dep)
+			}
+		}
+		body = strings.Replace(body, matches[0], "", 1)
+	}
+
+	fields := make(map[string]string)
+	for _, match := range vmodFieldPattern.FindAllStringSubmatch(body, -1) {
+		if _, seen := fields[match[1]]; !seen {
+			fields[match[1]] = match[2] + match[3]
+		}
+	}
+	vmod.Name = fields["name"]
+	vmod.Version = fields["version"]
+	vmod.Description = fields["description"]
+	vmod.License = fields["license"]
+	vmod.RepoURL = fields["repo_url"]
+	vmod.Author = fields["author"]
+
+	return vmod, nil
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package vlang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleVMod = `Module {
+	name: 'vweb_app'
+	description: 'A small web service, written in V'
+	version: '0.3.1' // bumped for release
+	license: 'MIT'
+	repo_url: "https://github.com/example/vweb_app"
+	author: 'Example Dev'
+	dependencies: [
+		'markdown',
+		"vsl", // numerics
+	]
+}
+`
+
+func writeVMod(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v.mod"), []byte(content), 0644))
+	return dir
+}
+
+func TestNewExtractor(t *testing.T) {
+	e := NewExtractor()
+	assert.Equal(t, "vlang", e.Name())
+	assert.Equal(t, 1, e.Priority())
+}
+
+func TestDetect(t *testing.T) {
+	e := NewExtractor()
+	assert.True(t, e.Detect(writeVMod(t, sampleVMod)))
+	assert.False(t, e.Detect(t.TempDir()))
+}
+
+func TestExtract(t *testing.T) {
+	metadata, err := NewExtractor().Extract(writeVMod(t, sampleVMod))
+	require.NoError(t, err)
+
+	assert.Equal(t, "vweb_app", metadata.Name)
+	assert.Equal(t, "0.3.1", metadata.Version)
+	assert.Equal(t, "v.mod", metadata.VersionSource)
+	assert.Equal(t, "A small web service, written in V", metadata.Description)
+	assert.Equal(t, "MIT", metadata.License)
+	assert.Equal(t, "https://github.com/example/vweb_app", metadata.Repository)
+	assert.Equal(t, []string{"Example Dev"}, metadata.Authors)
+
+	ls := metadata.LanguageSpecific
+	assert.Equal(t, "vweb_app", ls["package_name"])
+	assert.Equal(t, "v.mod", ls["metadata_source"])
+	assert.Equal(t, "v", ls["build_tool"])
+	assert.Equal(t, []string{"markdown", "vsl"}, ls["dependencies"])
+	assert.Equal(t, 2, ls["dependency_count"])
+}
+
+func TestExtractMinimal(t *testing.T) {
+	metadata, err := NewExtractor().Extract(writeVMod(t, "Module{name:\"tiny\" dependencies:[]}\r\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "tiny", metadata.Name)
+	assert.Empty(t, metadata.Version)
+	assert.Empty(t, metadata.VersionSource)
+	assert.NotContains(t, metadata.LanguageSpecific, "dependencies")
+}
+
+func TestExtractWithoutModule(t *testing.T) {
+	_, err := NewExtractor().Extract(writeVMod(t, "// empty\n"))
+	assert.Error(t, err)
+}
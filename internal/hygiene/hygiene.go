@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+// Package hygiene records which repository hygiene files (pre-commit,
+// editorconfig, license, readme, contributing guide, code owners and
+// linter configuration) a project carries, independent of its language.
+package hygiene
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Report holds the presence of each hygiene file
+type Report struct {
+	HasPrecommit    bool `json:"has_precommit"`
+	HasEditorconfig bool `json:"has_editorconfig"`
+	HasLicenseFile  bool `json:"has_license_file"`
+	HasReadme       bool `json:"has_readme"`
+	HasContributing bool `json:"has_contributing"`
+	HasCodeowners   bool `json:"has_codeowners"`
+	HasLinterConfig bool `json:"has_linter_config"`
+	// LinterConfigs lists the linter configuration files found, sorted
+	LinterConfigs []string `json:"linter_configs,omitempty"`
+}
+
+// The file families below match a name exactly or followed by an
+// extension or suffix ("README" matches README.md and README.rst,
+// "LICENSE" matches LICENSE-APACHE), ignoring case.
+var (
+	precommitNames    = []string{".pre-commit-config"}
+	editorconfigNames = []string{".editorconfig"}
+	licenseNames      = []string{"license", "licence", "copying"}
+	readmeNames       = []string{"readme"}
+	contributingNames = []string{"contributing"}
+	codeownersNames   = []string{"codeowners"}
+	linterNames       = []string{
+		".golangci", ".eslintrc", "eslint.config", ".stylelintrc",
+		"ruff.toml", ".ruff.toml", ".flake8", ".pylintrc", "mypy.ini",
+		".rubocop.yml", "clippy.toml", ".clippy.toml", ".swiftlint.yml",
+		"analysis_options.yaml", ".clang-tidy", ".shellcheckrc",
+		".hadolint", ".yamllint", ".markdownlint", ".markdownlint-cli2",
+		".tflint.hcl", ".credo.exs", ".scalafmt.conf", ".hlint.yaml",
+	}
+)
+
+// Collect inspects the project directory. Contributing guides and code
+// owners are also found in .github/ and docs/, where GitHub looks for
+// them.
+func Collect(projectPath string) Report {
+	root := listFiles(projectPath)
+	shared := append([]string(nil), root...)
+	shared = append(shared, listFiles(filepath.Join(projectPath, ".github"))...)
+	shared = append(shared, listFiles(filepath.Join(projectPath, "docs"))...)
+
+	report := Report{
+		HasPrecommit:    len(matchFiles(root, precommitNames)) > 0,
+		HasEditorconfig: len(matchFiles(root, editorconfigNames)) > 0,
+		HasLicenseFile:  len(matchFiles(root, licenseNames)) > 0,
+		HasReadme:       len(matchFiles(root, readmeNames)) > 0,
+		HasContributing: len(matchFiles(shared, contributingNames)) > 0,
+		HasCodeowners:   len(matchFiles(shared, codeownersNames)) > 0,
+		LinterConfigs:   matchFiles(root, linterNames),
+	}
+	report.HasLinterConfig = len(report.LinterConfigs) > 0
+	return report
+}
+
+// listFiles returns the names of the regular files in dir; a missing
+// directory yields none.
+func listFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// matchFiles returns the sorted, de-duplicated files belonging to one of
+// the families.
+func matchFiles(files, families []string) []string {
+	seen := make(map[string]bool)
+	var matched []string
+	for _, file := range files {
+		if seen[file] || !inFamily(file, families) {
+			continue
+		}
+		seen[file] = true
+		matched = append(matched, file)
+	}
+	sort.Strings(matched)
+	return matched
+}
+
+func inFamily(file string, families []string) bool {
+	lower := strings.ToLower(file)
+	for _, family := range families {
+		rest, ok := strings.CutPrefix(lower, family)
+		if ok && (rest == "" || rest[0] == '.' || rest[0] == '-' || rest[0] == '_') {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package hygiene
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCollectEachFile(t *testing.T) {
+	tests := []struct {
+		file string
+		want Report
+	}{
+		{".pre-commit-config.yaml", Report{HasPrecommit: true}},
+		{".editorconfig", Report{HasEditorconfig: true}},
+		{"LICENSE", Report{HasLicenseFile: true}},
+		{"LICENSE-APACHE", Report{HasLicenseFile: true}},
+		{"COPYING.txt", Report{HasLicenseFile: true}},
+		{"README.md", Report{HasReadme: true}},
+		{"readme.rst", Report{HasReadme: true}},
+		{"CONTRIBUTING.md", Report{HasContributing: true}},
+		{".github/CONTRIBUTING.md", Report{HasContributing: true}},
+		{"CODEOWNERS", Report{HasCodeowners: true}},
+		{".github/CODEOWNERS", Report{HasCodeowners: true}},
+		{"docs/CODEOWNERS", Report{HasCodeowners: true}},
+		{".golangci.yml", Report{HasLinterConfig: true, LinterConfigs: []string{".golangci.yml"}}},
+		{"eslint.config.mjs", Report{HasLinterConfig: true, LinterConfigs: []string{"eslint.config.mjs"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.file)
+
+			if got := Collect(dir); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Collect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectEmptyProject(t *testing.T) {
+	if got := Collect(t.TempDir()); !reflect.DeepEqual(got, Report{}) {
+		t.Errorf("Collect() = %+v, want nothing present", got)
+	}
+}
+
+func TestCollectLookalikes(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, "READMEFIRST", "licenses.json", ".pre-commit-hooks.yaml", "docs/README.md")
+	if err := os.Mkdir(filepath.Join(dir, "LICENSE"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := Collect(dir); !reflect.DeepEqual(got, Report{}) {
+		t.Errorf("Collect() = %+v, want no matches for look-alike names or directories", got)
+	}
+}
+
+func TestCollectFullProject(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, ".pre-commit-config.yaml", ".editorconfig", "LICENSE", "README.md",
+		".github/CONTRIBUTING.md", ".github/CODEOWNERS", ".yamllint", ".markdownlint.yaml")
+
+	want := Report{
+		HasPrecommit:    true,
+		HasEditorconfig: true,
+		HasLicenseFile:  true,
+		HasReadme:       true,
+		HasContributing: true,
+		HasCodeowners:   true,
+		HasLinterConfig: true,
+		LinterConfigs:   []string{".markdownlint.yaml", ".yamllint"},
+	}
+	if got := Collect(dir); !reflect.DeepEqual(got, want) {
+		t.Errorf("Collect() = %+v, want %+v", got, want)
+	}
+}
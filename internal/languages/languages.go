@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+// Package languages classifies a source tree by the languages of its
+// files, independent of which manifests it carries.
+package languages
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MaxDepth is how many directory levels below the project path Scan
+// descends; deeper files are not counted
+const MaxDepth = 5
+
+// extensionLanguages maps a lower-cased file extension to the language
+// it is written in
+var extensionLanguages = map[string]string{
+	".py":     "python",
+	".pyi":    "python",
+	".js":     "javascript",
+	".jsx":    "javascript",
+	".mjs":    "javascript",
+	".cjs":    "javascript",
+	".ts":     "typescript",
+	".tsx":    "typescript",
+	".mts":    "typescript",
+	".cts":    "typescript",
+	".go":     "go",
+	".rs":     "rust",
+	".java":   "java",
+	".kt":     "kotlin",
+	".kts":    "kotlin",
+	".scala":  "scala",
+	".groovy": "groovy",
+	".cs":     "csharp",
+	".fs":     "fsharp",
+	".vb":     "vb",
+	".rb":     "ruby",
+	".php":    "php",
+	".swift":  "swift",
+	".m":      "objective-c",
+	".c":      "c",
+	".h":      "c",
+	".cc":     "cpp",
+	".cpp":    "cpp",
+	".cxx":    "cpp",
+	".hpp":    "cpp",
+	".hh":     "cpp",
+	".dart":   "dart",
+	".ex":     "elixir",
+	".exs":    "elixir",
+	".erl":    "erlang",
+	".gleam":  "gleam",
+	".hs":     "haskell",
+	".elm":    "elm",
+	".jl":     "julia",
+	".lua":    "lua",
+	".pl":     "perl",
+	".pm":     "perl",
+	".r":      "r",
+	".sh":     "shell",
+	".bash":   "shell",
+	".tf":     "terraform",
+	".tofu":   "terraform",
+	".cbl":    "cobol",
+	".cob":    "cobol",
+	".cpy":    "cobol",
+	".d":      "d",
+	".v":      "vlang", // shared with Verilog, which has no project type
+}
+
+// skippedDirs are directories holding dependencies, virtual
+// environments or build output rather than the project's own sources;
+// hidden directories are skipped as well
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"venv":         true,
+	"__pycache__":  true,
+	"target":       true,
+	"build":        true,
+	"dist":         true,
+	"bin":          true,
+	"obj":          true,
+}
+
+// Scan counts the files of each recognized language under projectPath,
+// at most MaxDepth levels down, skipping hidden, dependency and build
+// output directories. Files of unrecognized types are not counted.
+func Scan(projectPath string) map[string]int {
+	breakdown := make(map[string]int)
+	root := filepath.Clean(projectPath)
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") || skippedDirs[d.Name()] || depth(root, path) > MaxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if language, ok := extensionLanguages[strings.ToLower(filepath.Ext(d.Name()))]; ok {
+			breakdown[language]++
+		}
+		return nil
+	})
+	return breakdown
+}
+
+// depth returns how many directory levels path lies below root.
+func depth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return 0
+	}
+	return len(strings.Split(rel, string(filepath.Separator)))
+}
+
+// Primary returns the language with the most files in breakdown, ties
+// going to the alphabetically first, or "" when breakdown is empty.
+func Primary(breakdown map[string]int) string {
+	names := make([]string, 0, len(breakdown))
+	for name := range breakdown {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	primary := ""
+	for _, name := range names {
+		if breakdown[name] > breakdown[primary] {
+			primary = name
+		}
+	}
+	return primary
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package languages
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root,
+		"pkg/__init__.py", "pkg/core.py", "pkg/cli.py", "pkg/util/io.py",
+		"tests/test_core.py", "setup.py",
+		"docs/conf.js", "web/app.js",
+		"README.md", "pyproject.toml",
+		// Skipped: dependencies, hidden and too deep
+		"node_modules/lib/index.js", "node_modules/lib/util.js",
+		".venv/lib/site.py", "a/b/c/d/e/f/deep.js",
+	)
+
+	got := Scan(root)
+	want := map[string]int{"python": 6, "javascript": 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() = %v, want %v", got, want)
+	}
+	if primary := Primary(got); primary != "python" {
+		t.Errorf("Primary() = %q, want python", primary)
+	}
+}
+
+func TestScanDepthLimit(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "a/b/c/d/e/in.go", "a/b/c/d/e/f/out.go")
+	if got := Scan(root); got["go"] != 1 {
+		t.Errorf("Scan() counted %d go files, want 1 within MaxDepth", got["go"])
+	}
+}
+
+func TestScanProjectTypeLanguages(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root,
+		"src/main.v", "src/app.d", "copy/PAYREC.CPY", "prog/PAYROLL.CBL",
+		"main.tofu", "variables.tf",
+	)
+	want := map[string]int{"vlang": 1, "d": 1, "cobol": 2, "terraform": 2}
+	if got := Scan(root); !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan() = %v, want %v", got, want)
+	}
+}
+
+func TestPrimary(t *testing.T) {
+	tests := []struct {
+		name      string
+		breakdown map[string]int
+		want      string
+	}{
+		{name: "most files", breakdown: map[string]int{"go": 3, "shell": 5}, want: "shell"},
+		{name: "tie alphabetical", breakdown: map[string]int{"rust": 2, "c": 2}, want: "c"},
+		{name: "empty", breakdown: map[string]int{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Primary(tt.breakdown); got != tt.want {
+				t.Errorf("Primary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
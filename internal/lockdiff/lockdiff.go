@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+// Package lockdiff compares the dependencies a lockfile resolves with
+// those the same lockfile resolved at an earlier git revision.
+package lockdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Lockfiles are the supported lockfiles, in the order they are looked
+// for in the project directory
+var Lockfiles = []string{"package-lock.json", "Cargo.lock"}
+
+// Versions maps a resolved package name to its distinct versions, in
+// version order; a lockfile may resolve one package at several versions
+type Versions map[string][]string
+
+// Result lists the dependency changes between two revisions of a
+// lockfile
+type Result struct {
+	// Lockfile is the lockfile compared, relative to the project path
+	Lockfile string
+	// Added lists packages new since the ref, as one "name@version"
+	// per version
+	Added []string
+	// Removed lists packages gone since the ref, as one "name@version"
+	// per version
+	Removed []string
+	// Updated lists packages resolved at other versions, as
+	// "name old -> new" with several versions separated by spaces
+	Updated []string
+}
+
+// Compare diffs the first supported lockfile in projectPath against
+// the same file at ref in the enclosing git repository. A lockfile
+// absent at ref counts every current package as added.
+func Compare(projectPath, ref string) (*Result, error) {
+	name := findLockfile(projectPath)
+	if name == "" {
+		return nil, fmt.Errorf("no supported lockfile (%s) in %s", strings.Join(Lockfiles, ", "), projectPath)
+	}
+	content, err := os.ReadFile(filepath.Join(projectPath, name))
+	if err != nil {
+		return nil, err
+	}
+	current, err := Parse(name, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	previousContent, err := readAtRef(projectPath, ref, name)
+	if err != nil {
+		return nil, err
+	}
+	previous := Versions{}
+	if previousContent != nil {
+		if previous, err = Parse(name, previousContent); err != nil {
+			return nil, fmt.Errorf("failed to parse %s at %s: %w", name, ref, err)
+		}
+	}
+
+	result := Diff(previous, current)
+	result.Lockfile = name
+	return result, nil
+}
+
+// findLockfile returns the first of Lockfiles present in projectPath,
+// or "" when there is none.
+func findLockfile(projectPath string) string {
+	for _, name := range Lockfiles {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// readAtRef returns the blob of projectPath/name at ref, or nil when
+// the file did not exist at that revision. A ref starting with "-" is
+// rejected so git never reads it as an option.
+func readAtRef(projectPath, ref, name string) ([]byte, error) {
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git ref %q", ref)
+	}
+	prefixOutput, err := exec.Command("git", "-C", projectPath, "rev-parse", "--show-prefix").Output()
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository: %w", err)
+	}
+	if err := exec.Command("git", "-C", projectPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}").Run(); err != nil {
+		return nil, fmt.Errorf("unknown git ref %q", ref)
+	}
+	object := ref + ":" + strings.TrimSpace(string(prefixOutput)) + name
+	if err := exec.Command("git", "-C", projectPath, "cat-file", "-e", object).Run(); err != nil {
+		return nil, nil
+	}
+	content, err := exec.Command("git", "-C", projectPath, "show", object).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", object, err)
+	}
+	return content, nil
+}
+
+// Parse reads the resolved packages of the named lockfile.
+func Parse(name string, content []byte) (Versions, error) {
+	switch name {
+	case "package-lock.json":
+		return parsePackageLock(content)
+	case "Cargo.lock":
+		return parseCargoLock(content)
+	}
+	return nil, fmt.Errorf("unsupported lockfile %s", name)
+}
+
+// packageLock holds the parts of package-lock.json naming resolved
+// packages: the "packages" map of lockfileVersion 2 and 3, and the
+// nested "dependencies" tree of version 1
+type packageLock struct {
+	Packages map[string]struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Link    bool   `json:"link"`
+	} `json:"packages"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+// packageLockDependency is a lockfileVersion 1 dependency entry
+type packageLockDependency struct {
+	Version      string                           `json:"version"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+// parsePackageLock reads package-lock.json. Entries of the "packages"
+// map outside node_modules are the project and its workspaces, and
+// links point at them, so neither counts as a dependency.
+func parsePackageLock(content []byte) (Versions, error) {
+	var lock packageLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	versions := Versions{}
+	if len(lock.Packages) > 0 {
+		for path, pkg := range lock.Packages {
+			i := strings.LastIndex(path, "node_modules/")
+			if i < 0 || pkg.Link || pkg.Version == "" {
+				continue
+			}
+			name := pkg.Name
+			if name == "" {
+				name = path[i+len("node_modules/"):]
+			}
+			versions.add(name, pkg.Version)
+		}
+		return versions, nil
+	}
+	var walk func(map[string]packageLockDependency)
+	walk = func(deps map[string]packageLockDependency) {
+		for name, dep := range deps {
+			if dep.Version != "" {
+				versions.add(name, dep.Version)
+			}
+			walk(dep.Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return versions, nil
+}
+
+// cargoLock holds the [[package]] entries of Cargo.lock
+type cargoLock struct {
+	Package []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+		Source  string `toml:"source"`
+	} `toml:"package"`
+}
+
+// parseCargoLock reads Cargo.lock. Packages without a source are the
+// workspace's own crates, not dependencies.
+func parseCargoLock(content []byte) (Versions, error) {
+	var lock cargoLock
+	if _, err := toml.Decode(string(content), &lock); err != nil {
+		return nil, err
+	}
+	versions := Versions{}
+	for _, pkg := range lock.Package {
+		if pkg.Source == "" || pkg.Name == "" {
+			continue
+		}
+		versions.add(pkg.Name, pkg.Version)
+	}
+	return versions, nil
+}
+
+// add records version for name, keeping the versions distinct and in
+// version order.
+func (v Versions) add(name, version string) {
+	for _, existing := range v[name] {
+		if existing == version {
+			return
+		}
+	}
+	versions := append(v[name], version)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+	v[name] = versions
+}
+
+// compareVersions orders two semver-style versions: the dotted core
+// compares numerically, a prerelease sorts before its release and
+// build metadata after "+" is ignored. Versions equal by those rules
+// fall back to comparing the strings.
+func compareVersions(a, b string) int {
+	coreA, preA, _ := strings.Cut(strings.SplitN(a, "+", 2)[0], "-")
+	coreB, preB, _ := strings.Cut(strings.SplitN(b, "+", 2)[0], "-")
+	if c := compareIdentifiers(strings.Split(coreA, "."), strings.Split(coreB, ".")); c != 0 {
+		return c
+	}
+	switch {
+	case preA == "" && preB != "":
+		return 1
+	case preA != "" && preB == "":
+		return -1
+	}
+	if c := compareIdentifiers(strings.Split(preA, "."), strings.Split(preB, ".")); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// compareIdentifiers compares dot-separated version identifiers one by
+// one, numerically when both are numbers. Numeric identifiers sort
+// before alphanumeric ones and a shorter list before a longer one.
+func compareIdentifiers(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		numA, errA := strconv.Atoi(a[i])
+		numB, errB := strconv.Atoi(b[i])
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// Diff compares two resolutions, listing each kind of change sorted by
+// package name. The lists are empty, not nil, when nothing changed.
+func Diff(previous, current Versions) *Result {
+	result := &Result{Added: []string{}, Removed: []string{}, Updated: []string{}}
+	for _, name := range sortedNames(current) {
+		old, existed := previous[name]
+		switch {
+		case !existed:
+			result.Added = append(result.Added, versionEntries(name, current[name])...)
+		case strings.Join(old, " ") != strings.Join(current[name], " "):
+			result.Updated = append(result.Updated, fmt.Sprintf("%s %s -> %s", name, strings.Join(old, " "), strings.Join(current[name], " ")))
+		}
+	}
+	for _, name := range sortedNames(previous) {
+		if _, exists := current[name]; !exists {
+			result.Removed = append(result.Removed, versionEntries(name, previous[name])...)
+		}
+	}
+	return result
+}
+
+// versionEntries returns one "name@version" entry per version, so the
+// entries stay free of the commas that join list outputs.
+func versionEntries(name string, versions []string) []string {
+	entries := make([]string, 0, len(versions))
+	for _, version := range versions {
+		entries = append(entries, name+"@"+version)
+	}
+	return entries
+}
+
+// sortedNames returns the package names of v in order.
+func sortedNames(v Versions) []string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
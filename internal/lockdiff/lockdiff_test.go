@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package lockdiff
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// runGit runs a git command in dir with a fixed identity, failing the test
+// on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	base := []string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}
+	if output, err := exec.Command("git", append(base, args...)...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newLockfileRepo creates a git repository with one commit per lockfile
+// version, writing name under subdir.
+func newLockfileRepo(t *testing.T, subdir, name string, versions ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet")
+	for i, content := range versions {
+		writeFile(t, filepath.Join(dir, subdir, name), content)
+		runGit(t, dir, "add", "-A")
+		runGit(t, dir, "commit", "--quiet", "-m", "lockfile "+string(rune('1'+i)))
+	}
+	return filepath.Join(dir, subdir)
+}
+
+const packageLockV1 = `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/express": {"version": "4.18.2"},
+    "node_modules/lodash": {"version": "4.17.20"},
+    "node_modules/left-pad": {"version": "1.3.0"}
+  }
+}
+`
+
+const packageLockV2 = `{
+  "name": "app",
+  "version": "1.1.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.1.0"},
+    "node_modules/express": {"version": "4.18.2"},
+    "node_modules/lodash": {"version": "4.17.21"},
+    "node_modules/@types/node": {"version": "20.11.0"},
+    "node_modules/express/node_modules/debug": {"version": "2.6.9"},
+    "packages/lib": {"name": "lib", "version": "0.1.0"},
+    "node_modules/lib": {"resolved": "packages/lib", "link": true}
+  }
+}
+`
+
+const cargoLockV1 = `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = ["serde"]
+
+[[package]]
+name = "serde"
+version = "1.0.190"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`
+
+const cargoLockV2 = `version = 3
+
+[[package]]
+name = "app"
+version = "0.2.0"
+dependencies = ["serde", "tokio"]
+
+[[package]]
+name = "serde"
+version = "1.0.193"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "tokio"
+version = "1.35.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		lockfile string
+		old, new string
+		want     Result
+	}{
+		{
+			name:     "npm",
+			lockfile: "package-lock.json",
+			old:      packageLockV1,
+			new:      packageLockV2,
+			want: Result{
+				Lockfile: "package-lock.json",
+				Added:    []string{"@types/node@20.11.0", "debug@2.6.9"},
+				Removed:  []string{"left-pad@1.3.0"},
+				Updated:  []string{"lodash 4.17.20 -> 4.17.21"},
+			},
+		},
+		{
+			name:     "cargo",
+			lockfile: "Cargo.lock",
+			old:      cargoLockV1,
+			new:      cargoLockV2,
+			want: Result{
+				Lockfile: "Cargo.lock",
+				Added:    []string{"tokio@1.35.0"},
+				Removed:  []string{"rand@0.8.5"},
+				Updated:  []string{"serde 1.0.190 -> 1.0.193"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newLockfileRepo(t, "", tt.lockfile, tt.old, tt.new)
+			got, err := Compare(dir, "HEAD~1")
+			if err != nil {
+				t.Fatalf("Compare() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Compare() = %+v, want %+v", *got, tt.want)
+			}
+
+			// No changes against the current commit
+			got, err = Compare(dir, "HEAD")
+			if err != nil {
+				t.Fatalf("Compare(HEAD) error = %v", err)
+			}
+			if len(got.Added)+len(got.Removed)+len(got.Updated) != 0 {
+				t.Errorf("Compare(HEAD) = %+v, want no changes", *got)
+			}
+		})
+	}
+}
+
+func TestCompareSubdirectory(t *testing.T) {
+	dir := newLockfileRepo(t, "rust", "Cargo.lock", cargoLockV1, cargoLockV2)
+	got, err := Compare(dir, "HEAD~1")
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Updated, []string{"serde 1.0.190 -> 1.0.193"}) {
+		t.Errorf("Updated = %v, want [serde 1.0.190 -> 1.0.193]", got.Updated)
+	}
+}
+
+func TestCompareLockfileNewSinceRef(t *testing.T) {
+	dir := newLockfileRepo(t, "", "README.md", "app\n")
+	writeFile(t, filepath.Join(dir, "Cargo.lock"), cargoLockV1)
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "--quiet", "-m", "add lockfile")
+
+	got, err := Compare(dir, "HEAD~1")
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Added, []string{"rand@0.8.5", "serde@1.0.190"}) {
+		t.Errorf("Added = %v, want every package", got.Added)
+	}
+}
+
+func TestCompareErrors(t *testing.T) {
+	dir := newLockfileRepo(t, "", "Cargo.lock", cargoLockV1)
+	if _, err := Compare(dir, "no-such-ref"); err == nil {
+		t.Error("Compare() with an unknown ref succeeded")
+	}
+	if _, err := Compare(dir, "--output=/dev/null"); err == nil {
+		t.Error("Compare() with an option-like ref succeeded")
+	}
+	if _, err := Compare(t.TempDir(), "HEAD"); err == nil {
+		t.Error("Compare() without a lockfile succeeded")
+	}
+}
+
+func TestParsePackageLockVersion1(t *testing.T) {
+	content := `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "express": {
+      "version": "4.18.2",
+      "dependencies": {"debug": {"version": "2.6.9"}}
+    },
+    "debug": {"version": "4.3.4"}
+  }
+}`
+	got, err := Parse("package-lock.json", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := Versions{"express": {"4.18.2"}, "debug": {"2.6.9", "4.3.4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffSeveralVersions(t *testing.T) {
+	previous := Versions{"debug": {"2.6.9", "4.3.4"}, "ms": {"2.0.0", "2.1.2"}}
+	current := Versions{"debug": {"2.6.9", "4.3.5"}, "semver": {"5.7.2", "7.5.4"}}
+	want := &Result{
+		Added:   []string{"semver@5.7.2", "semver@7.5.4"},
+		Removed: []string{"ms@2.0.0", "ms@2.1.2"},
+		Updated: []string{"debug 2.6.9 4.3.4 -> 2.6.9 4.3.5"},
+	}
+	if got := Diff(previous, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", *got, *want)
+	}
+}
+
+func TestVersionsOrder(t *testing.T) {
+	got := Versions{}
+	for _, version := range []string{"10.0.0", "9.0.0", "1.0.0", "1.0.0-rc.1", "1.0.0-alpha", "1.0.0-alpha.10", "1.0.0-alpha.2", "9.0.0"} {
+		got.add("pkg", version)
+	}
+	want := []string{"1.0.0-alpha", "1.0.0-alpha.2", "1.0.0-alpha.10", "1.0.0-rc.1", "1.0.0", "9.0.0", "10.0.0"}
+	if !reflect.DeepEqual(got["pkg"], want) {
+		t.Errorf("versions = %v, want %v", got["pkg"], want)
+	}
+}
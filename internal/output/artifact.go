@@ -4,9 +4,12 @@
 package output
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -22,6 +25,11 @@ type ArtifactUploader struct {
 	OutputDir      string
 	ValidateOutput bool
 	StrictMode     bool
+	// RetentionDays is the retention the calling workflow should request
+	// when uploading the artifact; 0 defers to the repository default.
+	RetentionDays int
+	// Compress bundles the written files into a .tar.gz archive
+	Compress bool
 }
 
 // ArtifactResult contains information about the uploaded artifact
@@ -29,11 +37,16 @@ type ArtifactResult struct {
 	Path   string
 	Suffix string
 	Name   string
-	Files  []string
+	// Files lists the uncompressed files written under Path
+	Files []string
+	// ArchivePath is the .tar.gz bundle of Files, set only when
+	// compression was requested
+	ArchivePath   string
+	RetentionDays int
 }
 
 // NewArtifactUploader creates a new artifact uploader
-func NewArtifactUploader(enabled bool, namePrefix string, formats []string, outputDir string, validateOutput bool, strictMode bool) *ArtifactUploader {
+func NewArtifactUploader(enabled bool, namePrefix string, formats []string, outputDir string, validateOutput bool, strictMode bool, retentionDays int, compress bool) *ArtifactUploader {
 	if namePrefix == "" {
 		namePrefix = "build-metadata"
 	}
@@ -53,6 +66,8 @@ func NewArtifactUploader(enabled bool, namePrefix string, formats []string, outp
 		OutputDir:      outputDir,
 		ValidateOutput: validateOutput,
 		StrictMode:     strictMode,
+		RetentionDays:  retentionDays,
+		Compress:       compress,
 	}
 }
 
@@ -76,10 +91,11 @@ func (a *ArtifactUploader) Upload(metadata interface{}, jobName string) (*Artifa
 	}
 
 	result := &ArtifactResult{
-		Path:   artifactPath,
-		Suffix: suffix,
-		Name:   artifactName,
-		Files:  make([]string, 0),
+		Path:          artifactPath,
+		Suffix:        suffix,
+		Name:          artifactName,
+		Files:         make([]string, 0),
+		RetentionDays: a.RetentionDays,
 	}
 
 	// Generate and write outputs in requested formats
@@ -99,14 +115,98 @@ func (a *ArtifactUploader) Upload(metadata interface{}, jobName string) (*Artifa
 			}
 			result.Files = append(result.Files, files...)
 
+		case "html":
+			files, err := a.writeHTML(artifactPath, metadata)
+			if err != nil {
+				return nil, fmt.Errorf("failed to write HTML artifacts: %w", err)
+			}
+			result.Files = append(result.Files, files...)
+
+		case "jsonl":
+			files, err := a.writeJSONL(artifactPath, metadata)
+			if err != nil {
+				return nil, fmt.Errorf("failed to write JSONL artifacts: %w", err)
+			}
+			result.Files = append(result.Files, files...)
+
+		case "spdx":
+			files, err := a.writeSPDX(artifactPath, metadata)
+			if err != nil {
+				return nil, fmt.Errorf("failed to write SPDX artifacts: %w", err)
+			}
+			result.Files = append(result.Files, files...)
+
 		default:
 			return nil, fmt.Errorf("unsupported artifact format: %s", format)
 		}
 	}
 
+	if a.Compress {
+		archivePath := artifactPath + ".tar.gz"
+		if err := writeTarGz(archivePath, artifactPath, result.Files); err != nil {
+			return nil, fmt.Errorf("failed to compress artifacts: %w", err)
+		}
+		result.ArchivePath = archivePath
+	}
+
 	return result, nil
 }
 
+// writeTarGz bundles the named files from dir into a gzip-compressed
+// tarball at archivePath. The archive sits next to the artifact
+// directory rather than inside it so it never includes itself.
+func writeTarGz(archivePath, dir string, files []string) (err error) {
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := archive.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, name := range files {
+		if err := addTarFile(tarWriter, filepath.Join(dir, name), name); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// addTarFile appends a single regular file to the tarball under name
+func addTarFile(tarWriter *tar.Writer, path, name string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
+
 // writeJSON writes JSON artifacts (compact and pretty)
 func (a *ArtifactUploader) writeJSON(artifactPath string, metadata interface{}) ([]string, error) {
 	files := make([]string, 0, 2)
@@ -164,6 +264,41 @@ func (a *ArtifactUploader) writeYAML(artifactPath string, metadata interface{})
 	return files, nil
 }
 
+// writeHTML writes the self-contained HTML report artifact
+func (a *ArtifactUploader) writeHTML(artifactPath string, metadata interface{}) ([]string, error) {
+	htmlPath := filepath.Join(artifactPath, "metadata.html")
+	if err := os.WriteFile(htmlPath, []byte(GenerateHTML(metadata)), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write HTML: %w", err)
+	}
+	return []string{"metadata.html"}, nil
+}
+
+// writeJSONL writes the JSON Lines artifact
+func (a *ArtifactUploader) writeJSONL(artifactPath string, metadata interface{}) ([]string, error) {
+	lines, err := GenerateJSONL(metadata)
+	if err != nil {
+		return nil, err
+	}
+	jsonlPath := filepath.Join(artifactPath, "metadata.jsonl")
+	if err := os.WriteFile(jsonlPath, []byte(lines), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write JSONL: %w", err)
+	}
+	return []string{"metadata.jsonl"}, nil
+}
+
+// writeSPDX writes the SPDX tag-value SBOM artifact
+func (a *ArtifactUploader) writeSPDX(artifactPath string, metadata interface{}) ([]string, error) {
+	document, err := GenerateSPDX(metadata)
+	if err != nil {
+		return nil, err
+	}
+	spdxPath := filepath.Join(artifactPath, "metadata.spdx")
+	if err := os.WriteFile(spdxPath, []byte(document), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write SPDX: %w", err)
+	}
+	return []string{"metadata.spdx"}, nil
+}
+
 // generateSuffix generates a random 4-character alphanumeric suffix
 func generateSuffix() (string, error) {
 	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
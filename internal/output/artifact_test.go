@@ -4,7 +4,10 @@
 package output
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -74,6 +77,8 @@ func TestNewArtifactUploader(t *testing.T) {
 				tt.outputDir,
 				tt.validateOutput,
 				tt.strictMode,
+				0,
+				false,
 			)
 
 			if uploader.Enabled != tt.enabled {
@@ -105,7 +110,7 @@ func TestNewArtifactUploader(t *testing.T) {
 
 // TestUpload_Disabled tests that disabled uploader returns nil
 func TestUpload_Disabled(t *testing.T) {
-	uploader := NewArtifactUploader(false, "test", []string{"json"}, "", false, false)
+	uploader := NewArtifactUploader(false, "test", []string{"json"}, "", false, false, 0, false)
 
 	metadata := map[string]interface{}{
 		"project": "test",
@@ -127,7 +132,7 @@ func TestUpload_Disabled(t *testing.T) {
 func TestUpload_JSON(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "test-metadata", []string{"json"}, tmpDir, false, false)
+	uploader := NewArtifactUploader(true, "test-metadata", []string{"json"}, tmpDir, false, false, 0, false)
 
 	metadata := map[string]interface{}{
 		"common": map[string]interface{}{
@@ -197,7 +202,7 @@ func TestUpload_JSON(t *testing.T) {
 func TestUpload_YAML(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "test-metadata", []string{"yaml"}, tmpDir, false, false)
+	uploader := NewArtifactUploader(true, "test-metadata", []string{"yaml"}, tmpDir, false, false, 0, false)
 
 	metadata := map[string]interface{}{
 		"common": map[string]interface{}{
@@ -246,7 +251,7 @@ func TestUpload_YAML(t *testing.T) {
 func TestUpload_BothFormats(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "test", []string{"json", "yaml"}, tmpDir, false, false)
+	uploader := NewArtifactUploader(true, "test", []string{"json", "yaml"}, tmpDir, false, false, 0, false)
 
 	metadata := map[string]interface{}{
 		"project": "test",
@@ -274,11 +279,161 @@ func TestUpload_BothFormats(t *testing.T) {
 	}
 }
 
+// TestUpload_HTML tests uploading the HTML report
+func TestUpload_HTML(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	uploader := NewArtifactUploader(true, "test", []string{"html"}, tmpDir, false, false, 0, false)
+
+	metadata := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_name": "html-project",
+		},
+	}
+
+	result, err := uploader.Upload(metadata, "html-job")
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	if len(result.Files) != 1 || result.Files[0] != "metadata.html" {
+		t.Fatalf("Expected [metadata.html], got %v", result.Files)
+	}
+
+	content, err := os.ReadFile(filepath.Join(result.Path, "metadata.html"))
+	if err != nil {
+		t.Fatalf("Failed to read HTML artifact: %v", err)
+	}
+	if !strings.Contains(string(content), "html-project") {
+		t.Error("HTML artifact should contain the project name")
+	}
+}
+
+func TestUpload_SPDX(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	uploader := NewArtifactUploader(true, "test", []string{"spdx"}, tmpDir, false, false, 0, false)
+
+	metadata := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_name": "spdx-project",
+		},
+	}
+
+	result, err := uploader.Upload(metadata, "spdx-job")
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	if len(result.Files) != 1 || result.Files[0] != "metadata.spdx" {
+		t.Fatalf("Expected [metadata.spdx], got %v", result.Files)
+	}
+
+	content, err := os.ReadFile(filepath.Join(result.Path, "metadata.spdx"))
+	if err != nil {
+		t.Fatalf("Failed to read SPDX artifact: %v", err)
+	}
+	if !strings.Contains(string(content), "PackageName: spdx-project") {
+		t.Error("SPDX artifact should name the project package")
+	}
+}
+
+// TestUpload_Compressed tests that compression produces a valid .tar.gz
+// holding every written file while still reporting the uncompressed list
+func TestUpload_Compressed(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	uploader := NewArtifactUploader(true, "test", []string{"json", "yaml"}, tmpDir, false, false, 14, true)
+
+	metadata := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_name": "archived-project",
+		},
+	}
+
+	result, err := uploader.Upload(metadata, "gzip-job")
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	if result.RetentionDays != 14 {
+		t.Errorf("Expected retention 14 days, got %d", result.RetentionDays)
+	}
+	if len(result.Files) != 3 {
+		t.Errorf("Expected 3 uncompressed files, got %v", result.Files)
+	}
+	if result.ArchivePath != result.Path+".tar.gz" {
+		t.Fatalf("Expected archive at %q, got %q", result.Path+".tar.gz", result.ArchivePath)
+	}
+
+	archive, err := os.Open(result.ArchivePath)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	defer archive.Close()
+
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		t.Fatalf("Archive is not valid gzip: %v", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	entries := make(map[string]string)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Archive is not a valid tarball: %v", err)
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			t.Fatalf("Failed to read %s from archive: %v", header.Name, err)
+		}
+		entries[header.Name] = string(content)
+	}
+
+	for _, name := range result.Files {
+		content, ok := entries[name]
+		if !ok {
+			t.Errorf("Archive is missing %q", name)
+			continue
+		}
+		onDisk, err := os.ReadFile(filepath.Join(result.Path, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if content != string(onDisk) {
+			t.Errorf("Archived %q differs from the file on disk", name)
+		}
+	}
+}
+
+// TestUpload_Uncompressed tests that no archive is written by default
+func TestUpload_Uncompressed(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	uploader := NewArtifactUploader(true, "test", []string{"json"}, tmpDir, false, false, 0, false)
+
+	result, err := uploader.Upload(map[string]interface{}{"key": "value"}, "plain-job")
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+
+	if result.ArchivePath != "" {
+		t.Errorf("Expected no archive, got %q", result.ArchivePath)
+	}
+	if _, err := os.Stat(result.Path + ".tar.gz"); !os.IsNotExist(err) {
+		t.Error("Archive should not be written without compression")
+	}
+}
+
 // TestUpload_UnsupportedFormat tests handling of unsupported formats
 func TestUpload_UnsupportedFormat(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "test", []string{"xml"}, tmpDir, false, false)
+	uploader := NewArtifactUploader(true, "test", []string{"xml"}, tmpDir, false, false, 0, false)
 
 	metadata := map[string]interface{}{
 		"project": "test",
@@ -299,7 +454,7 @@ func TestUpload_UnsupportedFormat(t *testing.T) {
 func TestUpload_WithValidation(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "test", []string{"json"}, tmpDir, true, true)
+	uploader := NewArtifactUploader(true, "test", []string{"json"}, tmpDir, true, true, 0, false)
 
 	metadata := map[string]interface{}{
 		"common": map[string]interface{}{
@@ -330,7 +485,7 @@ func TestUpload_WithValidation(t *testing.T) {
 func TestUpload_ComplexMetadata(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "complex", []string{"json", "yaml"}, tmpDir, false, false)
+	uploader := NewArtifactUploader(true, "complex", []string{"json", "yaml"}, tmpDir, false, false, 0, false)
 
 	metadata := map[string]interface{}{
 		"common": map[string]interface{}{
@@ -552,7 +707,7 @@ func TestGetMetadataYAML_WithValidation(t *testing.T) {
 func TestUpload_SpecialCharacters(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "test", []string{"json", "yaml"}, tmpDir, false, false)
+	uploader := NewArtifactUploader(true, "test", []string{"json", "yaml"}, tmpDir, false, false, 0, false)
 
 	metadata := map[string]interface{}{
 		"common": map[string]interface{}{
@@ -597,7 +752,7 @@ func TestUpload_SpecialCharacters(t *testing.T) {
 func TestUpload_EmptyMetadata(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "test", []string{"json"}, tmpDir, false, false)
+	uploader := NewArtifactUploader(true, "test", []string{"json"}, tmpDir, false, false, 0, false)
 
 	metadata := map[string]interface{}{}
 
@@ -630,7 +785,7 @@ func TestUpload_DirectoryCreation(t *testing.T) {
 	tmpDir := t.TempDir()
 	nestedDir := filepath.Join(tmpDir, "nested", "deep", "path")
 
-	uploader := NewArtifactUploader(true, "test", []string{"json"}, nestedDir, false, false)
+	uploader := NewArtifactUploader(true, "test", []string{"json"}, nestedDir, false, false, 0, false)
 
 	metadata := map[string]interface{}{
 		"test": "directory creation",
@@ -657,7 +812,7 @@ func TestUpload_DirectoryCreation(t *testing.T) {
 func TestUpload_MultipleJobs(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	uploader := NewArtifactUploader(true, "shared", []string{"json"}, tmpDir, false, false)
+	uploader := NewArtifactUploader(true, "shared", []string{"json"}, tmpDir, false, false, 0, false)
 
 	jobs := []string{"job1", "job2", "job3"}
 	artifactNames := make(map[string]bool)
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// htmlStyle is the inline stylesheet embedded in the HTML report so the
+// page renders the same when opened straight from an artifact download.
+const htmlStyle = `body{font-family:-apple-system,"Segoe UI",Helvetica,Arial,sans-serif;margin:2em;color:#24292f}
+h1{font-size:1.6em}h2{font-size:1.2em;margin-top:1.6em;border-bottom:1px solid #d0d7de;padding-bottom:.3em}
+table{border-collapse:collapse;margin-top:.5em}
+th,td{border:1px solid #d0d7de;padding:.35em .8em;text-align:left;vertical-align:top}
+th{background:#f6f8fa}
+code{font-family:ui-monospace,SFMono-Regular,Menlo,monospace;font-size:.9em;word-break:break-all}
+`
+
+// GenerateHTML creates a self-contained HTML report of the metadata for
+// sharing outside of CI logs. Every value is HTML-escaped so descriptions
+// or dependency strings containing markup characters cannot break the page.
+func GenerateHTML(metadata interface{}) string {
+	var sb strings.Builder
+
+	metadataMap := convertToMap(metadata)
+	common, _ := metadataMap["common"].(map[string]interface{})
+
+	title := "Build Metadata"
+	if name, ok := common["project_name"].(string); ok && name != "" {
+		title = "Build Metadata: " + name
+	}
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n")
+	sb.WriteString("<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(title))
+	fmt.Fprintf(&sb, "<style>\n%s</style>\n", htmlStyle)
+	sb.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(title))
+
+	writeHTMLSection(&sb, "Project", common)
+	if build, ok := metadataMap["build"].(map[string]interface{}); ok {
+		writeHTMLSection(&sb, "Build", build)
+	}
+	if env, ok := metadataMap["environment"].(map[string]interface{}); ok {
+		writeHTMLSection(&sb, "Environment", env)
+	}
+	if langSpecific, ok := metadataMap["language_specific"].(map[string]interface{}); ok {
+		writeHTMLDependencies(&sb, langSpecific["dependencies"])
+	}
+
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// writeHTMLSection renders a key/value table for one metadata section,
+// sorted by key. Empty values are skipped; composite values are shown as
+// JSON.
+func writeHTMLSection(sb *strings.Builder, heading string, section map[string]interface{}) {
+	keys := make([]string, 0, len(section))
+	for k, v := range section {
+		if formatHTMLValue(v) != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(sb, "<h2>%s</h2>\n<table>\n", html.EscapeString(heading))
+	sb.WriteString("<tr><th>Key</th><th>Value</th></tr>\n")
+	for _, k := range keys {
+		fmt.Fprintf(sb, "<tr><td>%s</td><td><code>%s</code></td></tr>\n",
+			html.EscapeString(k), html.EscapeString(formatHTMLValue(section[k])))
+	}
+	sb.WriteString("</table>\n")
+}
+
+// writeHTMLDependencies renders the language-specific dependency list as
+// a table. Extractors store dependencies either as a name -> version map
+// or as a list of strings (or objects), so both shapes are handled.
+func writeHTMLDependencies(sb *strings.Builder, deps interface{}) {
+	var rows [][2]string
+
+	switch v := deps.(type) {
+	case map[string]interface{}:
+		for name, version := range v {
+			rows = append(rows, [2]string{name, formatHTMLValue(version)})
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+	case []interface{}:
+		for _, item := range v {
+			rows = append(rows, [2]string{formatHTMLValue(item), ""})
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	sb.WriteString("<h2>Dependencies</h2>\n<table>\n")
+	sb.WriteString("<tr><th>Dependency</th><th>Version</th></tr>\n")
+	for _, row := range rows {
+		fmt.Fprintf(sb, "<tr><td><code>%s</code></td><td>%s</td></tr>\n",
+			html.EscapeString(row[0]), html.EscapeString(row[1]))
+	}
+	sb.WriteString("</table>\n")
+}
+
+// formatHTMLValue renders a JSON-decoded value as display text: strings
+// as-is, scalars via fmt, and maps/slices as compact JSON.
+func formatHTMLValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return ""
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			return ""
+		}
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+	if jsonBytes, err := json.Marshal(v); err == nil {
+		return string(jsonBytes)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateHTML tests that the report is a complete page carrying the
+// project name, build data, and a dependency table
+func TestGenerateHTML(t *testing.T) {
+	metadata := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_type":    "javascript-npm",
+			"project_name":    "example-project",
+			"project_version": "1.0.0",
+		},
+		"build": map[string]interface{}{
+			"ci_platform": "github",
+		},
+		"language_specific": map[string]interface{}{
+			"dependencies": map[string]interface{}{
+				"react": "^18.2.0",
+			},
+		},
+	}
+
+	report := GenerateHTML(metadata)
+
+	if !strings.HasPrefix(report, "<!DOCTYPE html>") {
+		t.Error("HTML should start with a doctype")
+	}
+	for _, want := range []string{"<html", "</html>", "<style>", "example-project", "github", "<h2>Dependencies</h2>", "react", "^18.2.0"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("HTML should contain %q", want)
+		}
+	}
+}
+
+// TestGenerateHTML_EscapesValues tests that markup in metadata values is
+// escaped rather than injected into the page
+func TestGenerateHTML_EscapesValues(t *testing.T) {
+	metadata := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_name": "<script>alert(1)</script>",
+		},
+		"language_specific": map[string]interface{}{
+			"dependencies": []interface{}{"left-pad <1.0>"},
+		},
+	}
+
+	report := GenerateHTML(metadata)
+
+	if strings.Contains(report, "<script>") {
+		t.Error("HTML should escape markup in values")
+	}
+	if !strings.Contains(report, "&lt;script&gt;alert(1)&lt;/script&gt;") {
+		t.Error("HTML should contain the escaped project name")
+	}
+	if !strings.Contains(report, "left-pad &lt;1.0&gt;") {
+		t.Error("HTML should contain the escaped dependency")
+	}
+}
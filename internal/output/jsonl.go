@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// jsonlSections are the top-level metadata sections emitted as a single
+// JSON Lines record each, in output order.
+var jsonlSections = []string{"common", "build", "environment"}
+
+// GenerateJSONL renders the metadata as JSON Lines for log pipelines: one
+// compact object per top-level section (common, build, environment)
+// carrying its fields, then one object per language-specific key as
+// {"key": ..., "value": ...}. Every record has a "section" discriminator
+// naming where it came from. Language-specific records are sorted by key
+// so the stream is stable across runs.
+func GenerateJSONL(metadata interface{}) (string, error) {
+	var sb strings.Builder
+	metadataMap := convertToMap(metadata)
+
+	for _, name := range jsonlSections {
+		fields, ok := metadataMap[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		record := make(map[string]interface{}, len(fields)+1)
+		for key, value := range fields {
+			record[key] = value
+		}
+		record["section"] = name
+		if err := writeJSONLRecord(&sb, record); err != nil {
+			return "", err
+		}
+	}
+
+	languageSpecific, _ := metadataMap["language_specific"].(map[string]interface{})
+	keys := make([]string, 0, len(languageSpecific))
+	for key := range languageSpecific {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		record := map[string]interface{}{
+			"section": "language_specific",
+			"key":     key,
+			"value":   languageSpecific[key],
+		}
+		if err := writeJSONLRecord(&sb, record); err != nil {
+			return "", err
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// writeJSONLRecord appends record as one compact JSON line.
+func writeJSONLRecord(sb *strings.Builder, record map[string]interface{}) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %v record: %w", record["section"], err)
+	}
+	sb.Write(line)
+	sb.WriteByte('\n')
+	return nil
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerateJSONL tests that every line is standalone JSON carrying a
+// section discriminator, with one line per language-specific key
+func TestGenerateJSONL(t *testing.T) {
+	metadata := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_name":    "example-project",
+			"project_version": "1.0.0",
+		},
+		"build": map[string]interface{}{
+			"ci_platform": "github",
+		},
+		"environment": map[string]interface{}{
+			"runner_os": "Linux",
+		},
+		"language_specific": map[string]interface{}{
+			"dependencies": []string{"react"},
+			"build_tool":   "npm",
+		},
+	}
+
+	lines, err := GenerateJSONL(metadata)
+	if err != nil {
+		t.Fatalf("GenerateJSONL failed: %v", err)
+	}
+	if !strings.HasSuffix(lines, "\n") {
+		t.Error("JSONL output should end with a newline")
+	}
+
+	var sections []string
+	var keys []string
+	for _, line := range strings.Split(strings.TrimSuffix(lines, "\n"), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", line, err)
+		}
+		section, ok := record["section"].(string)
+		if !ok || section == "" {
+			t.Fatalf("line %q has no section discriminator", line)
+		}
+		sections = append(sections, section)
+		if section == "language_specific" {
+			keys = append(keys, record["key"].(string))
+		}
+		if section == "common" && record["project_name"] != "example-project" {
+			t.Errorf("common record lost its fields: %q", line)
+		}
+	}
+
+	wantSections := []string{"common", "build", "environment", "language_specific", "language_specific"}
+	if strings.Join(sections, ",") != strings.Join(wantSections, ",") {
+		t.Errorf("sections = %v, want %v", sections, wantSections)
+	}
+	if strings.Join(keys, ",") != "build_tool,dependencies" {
+		t.Errorf("language-specific keys = %v, want sorted [build_tool dependencies]", keys)
+	}
+}
+
+// TestUpload_JSONL tests that the jsonl artifact format writes metadata.jsonl
+func TestUpload_JSONL(t *testing.T) {
+	uploader := NewArtifactUploader(true, "test", []string{"jsonl"}, t.TempDir(), false, false, 0, false)
+
+	result, err := uploader.Upload(map[string]interface{}{
+		"common": map[string]interface{}{"project_name": "jsonl-project"},
+	}, "jsonl-job")
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	if len(result.Files) != 1 || result.Files[0] != "metadata.jsonl" {
+		t.Fatalf("Expected [metadata.jsonl], got %v", result.Files)
+	}
+
+	content, err := os.ReadFile(filepath.Join(result.Path, "metadata.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to read JSONL artifact: %v", err)
+	}
+	if !strings.Contains(string(content), `"section":"common"`) {
+		t.Errorf("JSONL artifact should contain the common record, got %q", content)
+	}
+}
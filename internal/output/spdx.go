@@ -0,0 +1,289 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// spdxNoAssertion is SPDX's value for a field the tool cannot vouch for
+	spdxNoAssertion = "NOASSERTION"
+	// spdxRootPackageID identifies the project's own package
+	spdxRootPackageID = "SPDXRef-Package-root"
+	// spdxCreatedLayout is the UTC timestamp form SPDX requires
+	spdxCreatedLayout = "2006-01-02T15:04:05Z"
+)
+
+var (
+	// spdxIDUnsafeRe matches characters not allowed in an SPDX identifier
+	spdxIDUnsafeRe = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+	// spdxExpressionRe matches a license expression built from SPDX
+	// identifiers joined by AND/OR/WITH, optionally parenthesized
+	spdxExpressionRe = regexp.MustCompile(`^\(?[A-Za-z0-9.+-]+\)?(\s+(AND|OR|WITH)\s+\(?[A-Za-z0-9.+-]+\)?)*$`)
+	// spdxDependencyNameRe matches the package name leading a dependency
+	// specifier such as requests[security]>=2.0 or serde@1.0
+	spdxDependencyNameRe = regexp.MustCompile(`^@?[A-Za-z0-9_.][A-Za-z0-9_./-]*`)
+)
+
+// spdxLicenseAliases maps common free-text license names, lower-cased, to
+// their SPDX identifiers.
+var spdxLicenseAliases = map[string]string{
+	"apache 2.0":                  "Apache-2.0",
+	"apache 2":                    "Apache-2.0",
+	"apache license 2.0":          "Apache-2.0",
+	"apache license, version 2.0": "Apache-2.0",
+	"apache software license":     "Apache-2.0",
+	"apache-2":                    "Apache-2.0",
+	"mit license":                 "MIT",
+	"the mit license":             "MIT",
+	"bsd 3-clause":                "BSD-3-Clause",
+	"new bsd license":             "BSD-3-Clause",
+	"bsd 2-clause":                "BSD-2-Clause",
+	"simplified bsd license":      "BSD-2-Clause",
+	"isc license":                 "ISC",
+	"gplv2":                       "GPL-2.0-only",
+	"gplv3":                       "GPL-3.0-only",
+	"lgplv3":                      "LGPL-3.0-only",
+	"mpl 2.0":                     "MPL-2.0",
+	"mozilla public license 2.0":  "MPL-2.0",
+	"eclipse public license 2.0":  "EPL-2.0",
+}
+
+// spdxPackage is one package entry of the SPDX document
+type spdxPackage struct {
+	id      string
+	name    string
+	version string
+	license string
+}
+
+// GenerateSPDX renders the metadata as an SPDX 2.3 tag-value document:
+// a root package from the common project name and version, declaring the
+// project license when it normalizes to an SPDX expression, and one
+// package per entry of language_specific "dependencies" (a name to
+// version map or a list), each related to the root with DEPENDS_ON.
+// Dependency licenses are not resolved and stay NOASSERTION.
+func GenerateSPDX(metadata interface{}) (string, error) {
+	metadataMap := convertToMap(metadata)
+	common, _ := metadataMap["common"].(map[string]interface{})
+	languageSpecific, _ := metadataMap["language_specific"].(map[string]interface{})
+
+	name, _ := common["project_name"].(string)
+	if name == "" {
+		return "", fmt.Errorf("SPDX output requires a project name")
+	}
+	version, _ := common["project_version"].(string)
+	license, _ := common["license"].(string)
+
+	packages := []spdxPackage{{
+		id:      spdxRootPackageID,
+		name:    name,
+		version: version,
+		license: NormalizeSPDXLicense(license),
+	}}
+	for i, dep := range spdxDependencies(languageSpecific["dependencies"]) {
+		dep.id = fmt.Sprintf("SPDXRef-Package-%d-%s", i+1, spdxIDUnsafeRe.ReplaceAllString(dep.name, "-"))
+		dep.license = spdxNoAssertion
+		packages = append(packages, dep)
+	}
+
+	var body strings.Builder
+	for _, pkg := range packages {
+		writeSPDXPackage(&body, pkg)
+	}
+	fmt.Fprintf(&body, "\nRelationship: SPDXRef-DOCUMENT DESCRIBES %s\n", spdxRootPackageID)
+	for _, pkg := range packages[1:] {
+		fmt.Fprintf(&body, "Relationship: %s DEPENDS_ON %s\n", spdxRootPackageID, pkg.id)
+	}
+
+	documentName := name
+	if version != "" {
+		documentName += "-" + version
+	}
+	// The namespace must be unique per document; deriving it from the
+	// package content keeps repeated runs over the same metadata stable
+	sum := sha256.Sum256([]byte(body.String()))
+
+	var sb strings.Builder
+	sb.WriteString("SPDXVersion: SPDX-2.3\n")
+	sb.WriteString("DataLicense: CC0-1.0\n")
+	sb.WriteString("SPDXID: SPDXRef-DOCUMENT\n")
+	fmt.Fprintf(&sb, "DocumentName: %s\n", documentName)
+	fmt.Fprintf(&sb, "DocumentNamespace: https://spdx.org/spdxdocs/%s-%s\n",
+		spdxIDUnsafeRe.ReplaceAllString(documentName, "-"), hex.EncodeToString(sum[:8]))
+	sb.WriteString("Creator: Tool: build-metadata-action\n")
+	fmt.Fprintf(&sb, "Created: %s\n", spdxCreated(common["build_timestamp"]))
+	sb.WriteString(body.String())
+	return sb.String(), nil
+}
+
+// NormalizeSPDXLicense returns license as an SPDX license expression:
+// canonicalized when every identifier in it is on the SPDX license list,
+// mapped when it is a common free-text name, otherwise NOASSERTION.
+func NormalizeSPDXLicense(license string) string {
+	license = strings.TrimSpace(license)
+	if license == "" {
+		return spdxNoAssertion
+	}
+	if id, ok := spdxLicenseAliases[strings.ToLower(license)]; ok {
+		return id
+	}
+	if !spdxExpressionRe.MatchString(license) {
+		return spdxNoAssertion
+	}
+	if expression, ok := canonicalSPDXExpression(license); ok {
+		return expression
+	}
+	return spdxNoAssertion
+}
+
+// canonicalSPDXExpression rewrites each identifier of a structurally
+// valid expression in its SPDX list casing, reporting false when one is
+// not a known license (or, after WITH, exception) identifier.
+func canonicalSPDXExpression(expression string) (string, bool) {
+	fields := strings.Fields(expression)
+	for i, field := range fields {
+		if field == "AND" || field == "OR" || field == "WITH" {
+			continue
+		}
+		exception := i > 0 && fields[i-1] == "WITH"
+		ids := spdxLicenseIDs
+		if exception {
+			ids = spdxExceptionIDs
+		}
+		lead := len(field) - len(strings.TrimLeft(field, "("))
+		trail := len(field) - len(strings.TrimRight(field, ")"))
+		id := field[lead : len(field)-trail]
+		plus := strings.HasSuffix(id, "+")
+		id = strings.TrimSuffix(id, "+")
+		canonical, ok := ids[strings.ToLower(id)]
+		if !ok || (plus && exception) {
+			return "", false
+		}
+		if plus {
+			canonical += "+"
+		}
+		fields[i] = field[:lead] + canonical + field[len(field)-trail:]
+	}
+	return strings.Join(fields, " "), true
+}
+
+// writeSPDXPackage appends one package's tag-value fields
+func writeSPDXPackage(sb *strings.Builder, pkg spdxPackage) {
+	fmt.Fprintf(sb, "\nPackageName: %s\n", pkg.name)
+	fmt.Fprintf(sb, "SPDXID: %s\n", pkg.id)
+	if pkg.version != "" {
+		fmt.Fprintf(sb, "PackageVersion: %s\n", pkg.version)
+	}
+	fmt.Fprintf(sb, "PackageDownloadLocation: %s\n", spdxNoAssertion)
+	sb.WriteString("FilesAnalyzed: false\n")
+	fmt.Fprintf(sb, "PackageLicenseConcluded: %s\n", spdxNoAssertion)
+	fmt.Fprintf(sb, "PackageLicenseDeclared: %s\n", pkg.license)
+	fmt.Fprintf(sb, "PackageCopyrightText: %s\n", spdxNoAssertion)
+}
+
+// spdxDependencies converts the dependency shapes extractors store (a
+// name -> version map, or a list of strings or objects named as
+// DependencyName describes) into packages, sorted by name for maps and in declared order for lists.
+func spdxDependencies(deps interface{}) []spdxPackage {
+	var packages []spdxPackage
+	switch v := deps.(type) {
+	case map[string]interface{}:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			version, _ := v[name].(string)
+			packages = append(packages, spdxPackage{name: name, version: version})
+		}
+	case []interface{}:
+		for _, item := range v {
+			switch dep := item.(type) {
+			case string:
+				if name, version := SplitDependencySpec(dep); name != "" {
+					packages = append(packages, spdxPackage{name: name, version: version})
+				}
+			case map[string]interface{}:
+				if name := DependencyName(dep); name != "" {
+					packages = append(packages, spdxPackage{name: name, version: firstString(dep, "version", "Version")})
+				}
+			}
+		}
+	}
+	return packages
+}
+
+// DependencyName returns the package name of an object-shaped dependency
+// entry: group_id:artifact_id for Maven, group:name for Gradle, else its
+// name. A coordinate without a group is its artifact or name alone.
+func DependencyName(dep map[string]interface{}) string {
+	if artifact := firstString(dep, "artifact_id"); artifact != "" {
+		if group := firstString(dep, "group_id"); group != "" {
+			return group + ":" + artifact
+		}
+		return artifact
+	}
+	name := firstString(dep, "name", "Name")
+	if group := firstString(dep, "group"); group != "" && name != "" {
+		return group + ":" + name
+	}
+	return name
+}
+
+// SplitDependencySpec splits a dependency list entry into the package
+// name and version requirement: "requests[security]>=2.0; python_version
+// >= '3.8'" becomes requests and >=2.0, "serde@1.0 (optional)" serde and
+// 1.0. An entry with no leading name, a bare requirement such as
+// ^1.2.3, yields no name.
+func SplitDependencySpec(spec string) (string, string) {
+	spec, _, _ = strings.Cut(spec, ";")
+	spec = strings.TrimSpace(spec)
+	if at := strings.LastIndex(spec, "@"); at > 0 {
+		version, _, _ := strings.Cut(spec[at+1:], " ")
+		return spec[:at], version
+	}
+
+	name := spdxDependencyNameRe.FindString(spec)
+	if name == "" {
+		return "", ""
+	}
+	rest := strings.TrimSpace(spec[len(name):])
+	if strings.HasPrefix(rest, "[") {
+		if end := strings.Index(rest, "]"); end >= 0 {
+			rest = strings.TrimSpace(rest[end+1:])
+		}
+	}
+	rest = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(rest, "("), ")"))
+	return name, strings.TrimPrefix(rest, "==")
+}
+
+// firstString returns the first non-empty string value among keys
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := m[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// spdxCreated formats the build timestamp (RFC 3339 in the metadata) as
+// an SPDX creation time, falling back to the current time.
+func spdxCreated(timestamp interface{}) string {
+	if s, ok := timestamp.(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return t.UTC().Format(spdxCreatedLayout)
+		}
+	}
+	return time.Now().UTC().Format(spdxCreatedLayout)
+}
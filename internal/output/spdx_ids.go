@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package output
+
+import "strings"
+
+// spdxLicenseIDs are the SPDX license list identifiers a declared
+// license expression may use. It covers the licenses package manifests
+// declare in practice; anything else is reported as NOASSERTION rather
+// than passed through as an unverified identifier.
+var spdxLicenseIDs = canonicalSPDXIDs(
+	"0BSD", "AFL-3.0", "AGPL-3.0-only", "AGPL-3.0-or-later", "Apache-1.1",
+	"Apache-2.0", "APSL-2.0", "Artistic-1.0", "Artistic-2.0", "BlueOak-1.0.0",
+	"BSD-1-Clause", "BSD-2-Clause", "BSD-2-Clause-Patent", "BSD-3-Clause",
+	"BSD-3-Clause-Clear", "BSD-4-Clause", "BSL-1.0", "BUSL-1.1", "CAL-1.0",
+	"CC-BY-3.0", "CC-BY-4.0", "CC-BY-SA-3.0", "CC-BY-SA-4.0", "CC-BY-NC-4.0",
+	"CC-BY-NC-SA-4.0", "CC0-1.0", "CDDL-1.0", "CDDL-1.1", "CECILL-2.1",
+	"ECL-2.0", "EPL-1.0", "EPL-2.0", "EUPL-1.1", "EUPL-1.2", "GFDL-1.3-only",
+	"GFDL-1.3-or-later", "GPL-1.0-only", "GPL-1.0-or-later", "GPL-2.0-only",
+	"GPL-2.0-or-later", "GPL-3.0-only", "GPL-3.0-or-later", "HPND", "ISC",
+	"LGPL-2.0-only", "LGPL-2.0-or-later", "LGPL-2.1-only", "LGPL-2.1-or-later",
+	"LGPL-3.0-only", "LGPL-3.0-or-later", "LPPL-1.3c", "MIT", "MIT-0",
+	"MIT-CMU", "MPL-1.1", "MPL-2.0", "MPL-2.0-no-copyleft-exception",
+	"MS-PL", "MS-RL", "MulanPSL-2.0", "NCSA", "ODbL-1.0", "OFL-1.1",
+	"OpenSSL", "OSL-3.0", "PHP-3.0", "PHP-3.01", "PostgreSQL", "PSF-2.0",
+	"Python-2.0", "Ruby", "SSPL-1.0", "Unicode-3.0", "Unicode-DFS-2016",
+	"Unlicense", "UPL-1.0", "Vim", "W3C", "WTFPL", "X11", "Zlib", "ZPL-2.1",
+	// Deprecated identifiers still common in older manifests
+	"AGPL-3.0", "GPL-2.0", "GPL-3.0", "LGPL-2.1", "LGPL-3.0",
+)
+
+// spdxExceptionIDs are the SPDX license exception identifiers allowed
+// after WITH.
+var spdxExceptionIDs = canonicalSPDXIDs(
+	"Autoconf-exception-3.0", "Bison-exception-2.2", "Classpath-exception-2.0",
+	"GCC-exception-3.1", "LLVM-exception", "OpenJDK-assembly-exception-1.0",
+	"Qt-LGPL-exception-1.1", "Universal-FOSS-exception-1.0",
+)
+
+// canonicalSPDXIDs indexes identifiers by their lower-cased form, since
+// SPDX matches identifiers case-insensitively.
+func canonicalSPDXIDs(ids ...string) map[string]string {
+	index := make(map[string]string, len(ids))
+	for _, id := range ids {
+		index[strings.ToLower(id)] = id
+	}
+	return index
+}
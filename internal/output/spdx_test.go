@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGenerateSPDX tests that the document carries the fields SPDX 2.3
+// requires, a root package from the common metadata, and one related
+// package per dependency
+func TestGenerateSPDX(t *testing.T) {
+	metadata := map[string]interface{}{
+		"common": map[string]interface{}{
+			"project_name":    "example-project",
+			"project_version": "1.0.0",
+			"license":         "Apache License 2.0",
+			"build_timestamp": "2026-03-01T12:30:00.123456Z",
+		},
+		"language_specific": map[string]interface{}{
+			"dependencies": []string{"requests[security]>=2.31; python_version >= '3.8'", "click", "^1.2.3"},
+		},
+	}
+
+	document, err := GenerateSPDX(metadata)
+	if err != nil {
+		t.Fatalf("GenerateSPDX failed: %v", err)
+	}
+
+	for _, field := range []string{
+		"SPDXVersion: SPDX-2.3\n",
+		"DataLicense: CC0-1.0\n",
+		"SPDXID: SPDXRef-DOCUMENT\n",
+		"DocumentName: example-project-1.0.0\n",
+		"DocumentNamespace: https://spdx.org/spdxdocs/example-project-1.0.0-",
+		"Creator: Tool: build-metadata-action\n",
+		"Created: 2026-03-01T12:30:00Z\n",
+		"PackageName: example-project\nSPDXID: SPDXRef-Package-root\nPackageVersion: 1.0.0\n",
+		"PackageLicenseDeclared: Apache-2.0\n",
+		"PackageName: requests\nSPDXID: SPDXRef-Package-1-requests\nPackageVersion: >=2.31\n",
+		"PackageName: click\nSPDXID: SPDXRef-Package-2-click\n",
+		"Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-root\n",
+		"Relationship: SPDXRef-Package-root DEPENDS_ON SPDXRef-Package-2-click\n",
+	} {
+		if !strings.Contains(document, field) {
+			t.Errorf("SPDX document lacks %q:\n%s", field, document)
+		}
+	}
+
+	// Every package needs the mandatory package fields
+	packages := strings.Count(document, "PackageName: ")
+	for _, field := range []string{"PackageDownloadLocation: ", "FilesAnalyzed: ", "PackageLicenseConcluded: ", "PackageLicenseDeclared: ", "PackageCopyrightText: "} {
+		if got := strings.Count(document, field); got != packages {
+			t.Errorf("%d packages but %d %q fields", packages, got, field)
+		}
+	}
+
+	again, _ := GenerateSPDX(metadata)
+	if again != document {
+		t.Error("SPDX document differs between runs over the same metadata")
+	}
+}
+
+// TestGenerateSPDX_DependencyMap tests name -> version dependency maps
+func TestGenerateSPDX_DependencyMap(t *testing.T) {
+	metadata := map[string]interface{}{
+		"common": map[string]interface{}{"project_name": "web"},
+		"language_specific": map[string]interface{}{
+			"dependencies": map[string]interface{}{"react": "^18.2.0", "axios": "1.6.0"},
+		},
+	}
+
+	document, err := GenerateSPDX(metadata)
+	if err != nil {
+		t.Fatalf("GenerateSPDX failed: %v", err)
+	}
+	if !strings.Contains(document, "PackageName: axios\nSPDXID: SPDXRef-Package-1-axios\nPackageVersion: 1.6.0\n") {
+		t.Errorf("map dependencies not rendered in name order:\n%s", document)
+	}
+	if !strings.Contains(document, "DocumentName: web\n") || !strings.Contains(document, "PackageLicenseDeclared: NOASSERTION\n") {
+		t.Errorf("unversioned, unlicensed project not rendered as expected:\n%s", document)
+	}
+}
+
+// TestGenerateSPDX_CoordinateDependencies tests that Maven and Gradle
+// dependency objects are named by their group and artifact
+func TestGenerateSPDX_CoordinateDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		dep  map[string]interface{}
+		want string
+	}{
+		{
+			name: "maven",
+			dep:  map[string]interface{}{"group_id": "com.google.guava", "artifact_id": "guava", "version": "33.0.0-jre", "scope": "compile"},
+			want: "PackageName: com.google.guava:guava\nSPDXID: SPDXRef-Package-1-com.google.guava-guava\nPackageVersion: 33.0.0-jre\n",
+		},
+		{
+			name: "gradle",
+			dep:  map[string]interface{}{"configuration": "implementation", "group": "org.slf4j", "name": "slf4j-api", "version": "2.0.9"},
+			want: "PackageName: org.slf4j:slf4j-api\nSPDXID: SPDXRef-Package-1-org.slf4j-slf4j-api\nPackageVersion: 2.0.9\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := map[string]interface{}{
+				"common": map[string]interface{}{"project_name": "app"},
+				"language_specific": map[string]interface{}{
+					"dependencies": []interface{}{tt.dep},
+				},
+			}
+			document, err := GenerateSPDX(metadata)
+			if err != nil {
+				t.Fatalf("GenerateSPDX failed: %v", err)
+			}
+			if !strings.Contains(document, tt.want) {
+				t.Errorf("dependency not rendered as %q:\n%s", tt.want, document)
+			}
+		})
+	}
+}
+
+// TestGenerateSPDX_RequiresName tests that a nameless project is refused
+func TestGenerateSPDX_RequiresName(t *testing.T) {
+	if _, err := GenerateSPDX(map[string]interface{}{"common": map[string]interface{}{}}); err == nil {
+		t.Error("GenerateSPDX should fail without a project name")
+	}
+}
+
+func TestNormalizeSPDXLicense(t *testing.T) {
+	tests := map[string]string{
+		"MIT":                 "MIT",
+		"mit license":         "MIT",
+		"Apache-2.0 OR MIT":   "Apache-2.0 OR MIT",
+		"(MIT OR Apache-2.0)": "(MIT OR Apache-2.0)",
+		"GPL-2.0-only WITH Classpath-exception-2.0": "GPL-2.0-only WITH Classpath-exception-2.0",
+		"apache-2.0 OR mit":                         "Apache-2.0 OR MIT",
+		"GPL-2.0+":                                  "GPL-2.0+",
+		"":                                          "NOASSERTION",
+		"Proprietary, see LICENSE":                  "NOASSERTION",
+		"Proprietary":                               "NOASSERTION",
+		"UNKNOWN":                                   "NOASSERTION",
+		"see-LICENSE":                               "NOASSERTION",
+		"MIT WITH Apache-2.0":                       "NOASSERTION",
+	}
+	for input, want := range tests {
+		if got := NormalizeSPDXLicense(input); got != want {
+			t.Errorf("NormalizeSPDXLicense(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSplitDependencySpec(t *testing.T) {
+	tests := []struct {
+		spec, name, version string
+	}{
+		{"requests>=2.0", "requests", ">=2.0"},
+		{"requests[socks] (>=2.0,<3)", "requests", ">=2.0,<3"},
+		{"django==4.2; python_version >= '3.10'", "django", "4.2"},
+		{"click", "click", ""},
+		{"golang.org/x/mod@v0.14.0", "golang.org/x/mod", "v0.14.0"},
+		{"serde@1.0 (optional) [derive]", "serde", "1.0"},
+		{"@types/node@^20.0.0", "@types/node", "^20.0.0"},
+		{"^1.2.3", "", ""},
+	}
+	for _, tt := range tests {
+		name, version := SplitDependencySpec(tt.spec)
+		if name != tt.name || version != tt.version {
+			t.Errorf("SplitDependencySpec(%q) = %q, %q, want %q, %q", tt.spec, name, version, tt.name, tt.version)
+		}
+	}
+}
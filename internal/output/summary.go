@@ -415,8 +415,11 @@ func writeTerraformRows(sb *strings.Builder, metadata map[string]interface{}) {
 	writeStringRows(sb, metadata, []stringRow{
 		{"terraform_version", "Terraform Version", false},
 	})
-	if isOpenTofu, ok := metadata["is_opentofu"].(bool); ok && isOpenTofu {
+	switch metadata["iac_engine"] {
+	case "opentofu":
 		sb.WriteString("| Engine | OpenTofu |\n")
+	case "both":
+		sb.WriteString("| Engine | Terraform and OpenTofu |\n")
 	}
 }
 
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+// Package toolversions reads asdf's .tool-versions file, which pins the
+// versions of several tools (python, nodejs, ruby, golang, ...) in one
+// place.
+package toolversions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the name of the file in the project directory, also the
+// value extractors record as the version source when they use it
+const FileName = ".tool-versions"
+
+// Parse reads .tool-versions content into a tool name -> version map.
+// A line lists a tool and one or more versions, the first preferred,
+// so only that one is kept; comments and blank lines are skipped, and
+// the first line naming a tool wins.
+func Parse(content string) map[string]string {
+	versions := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		if _, seen := versions[fields[0]]; !seen {
+			versions[fields[0]] = fields[1]
+		}
+	}
+	return versions
+}
+
+// Read parses the .tool-versions file in dir.
+func Read(dir string) (map[string]string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(content)), nil
+}
+
+// Lookup returns the version .tool-versions in dir pins for the first of
+// tools it lists, trying each name in turn since plugins differ in
+// naming (e.g. golang and go). "system" and the ref: and path: forms
+// name no version and are passed over.
+func Lookup(dir string, tools ...string) (string, bool) {
+	versions, err := Read(dir)
+	if err != nil {
+		return "", false
+	}
+	for _, tool := range tools {
+		version, ok := versions[tool]
+		if !ok || version == "system" || strings.HasPrefix(version, "ref:") || strings.HasPrefix(version, "path:") {
+			continue
+		}
+		return version, true
+	}
+	return "", false
+}
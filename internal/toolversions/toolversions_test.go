@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package toolversions
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sample = `# Runtimes
+python 3.12.1 3.11.7
+nodejs 20.11.0 # LTS
+ruby   3.3.0
+
+golang system
+python 3.10.0
+rust ref:1a2b3c
+`
+
+func TestParse(t *testing.T) {
+	want := map[string]string{
+		"python": "3.12.1",
+		"nodejs": "20.11.0",
+		"ruby":   "3.3.0",
+		"golang": "system",
+		"rust":   "ref:1a2b3c",
+	}
+	if got := Parse(sample); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %v, want %v", got, want)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(sample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tools   []string
+		version string
+		ok      bool
+	}{
+		{[]string{"python"}, "3.12.1", true},
+		{[]string{"node", "nodejs"}, "20.11.0", true},
+		{[]string{"golang", "go"}, "", false},
+		{[]string{"rust"}, "", false},
+		{[]string{"java"}, "", false},
+	}
+	for _, tt := range tests {
+		version, ok := Lookup(dir, tt.tools...)
+		if version != tt.version || ok != tt.ok {
+			t.Errorf("Lookup(%v) = %q, %v, want %q, %v", tt.tools, version, ok, tt.version, tt.ok)
+		}
+	}
+
+	if _, ok := Lookup(t.TempDir(), "python"); ok {
+		t.Error("Lookup() without a .tool-versions file found a version")
+	}
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// changelogFiles are the changelogs ExtractChangelogVersion reads, in
+// order of preference
+var changelogFiles = []string{"CHANGELOG.md", "CHANGES.md"}
+
+// changelogVersionRe matches a Keep a Changelog release heading such as
+// "## [1.4.0] - 2026-03-02", capturing the version. The brackets and a
+// leading "v" are optional; "## [Unreleased]" does not match.
+var changelogVersionRe = regexp.MustCompile(`^##\s+\[?v?(\d+\.\d+\.\d+(?:[-+][0-9A-Za-z.+-]+)?)\]?(?:\s|$)`)
+
+// ExtractChangelogVersion returns the version of the newest release in
+// the project's Keep a Changelog file: the first "## [x.y.z]" heading,
+// skipping an [Unreleased] section above it. The source is the
+// changelog's file name.
+func ExtractChangelogVersion(projectPath string) (*VersionInfo, bool) {
+	for _, name := range changelogFiles {
+		content, err := os.ReadFile(filepath.Join(projectPath, name))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			if m := changelogVersionRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				return &VersionInfo{Version: m[1], Source: name}, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package version
+
+import "testing"
+
+func TestExtractChangelogVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		content    string
+		wantOK     bool
+		wantVer    string
+		wantSource string
+	}{
+		{
+			name: "standard changelog",
+			file: "CHANGELOG.md",
+			content: `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## [1.4.0] - 2026-03-02
+
+### Added
+
+- Widget support
+
+## [1.3.2] - 2026-01-15
+`,
+			wantOK:     true,
+			wantVer:    "1.4.0",
+			wantSource: "CHANGELOG.md",
+		},
+		{
+			name: "unreleased section first",
+			file: "CHANGELOG.md",
+			content: `# Changelog
+
+## [Unreleased]
+
+### Changed
+
+- Faster widgets
+
+## [2.0.0-rc.1] - 2026-04-01
+
+## [1.9.0] - 2026-02-01
+`,
+			wantOK:     true,
+			wantVer:    "2.0.0-rc.1",
+			wantSource: "CHANGELOG.md",
+		},
+		{
+			name:       "CHANGES.md without brackets",
+			file:       "CHANGES.md",
+			content:    "# Changes\n\n## v0.3.1\n\n- Fix\n",
+			wantOK:     true,
+			wantVer:    "0.3.1",
+			wantSource: "CHANGES.md",
+		},
+		{
+			name:    "only unreleased",
+			file:    "CHANGELOG.md",
+			content: "# Changelog\n\n## [Unreleased]\n\n- Work in progress\n",
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, tt.file, tt.content)
+
+			info, ok := ExtractChangelogVersion(dir)
+			if ok != tt.wantOK {
+				t.Fatalf("ExtractChangelogVersion() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if info.Version != tt.wantVer || info.Source != tt.wantSource {
+				t.Errorf("ExtractChangelogVersion() = %q from %q, want %q from %q", info.Version, info.Source, tt.wantVer, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestExtractChangelogVersionMissingFile(t *testing.T) {
+	if info, ok := ExtractChangelogVersion(t.TempDir()); ok {
+		t.Errorf("ExtractChangelogVersion() = %+v, want no version without a changelog", info)
+	}
+}
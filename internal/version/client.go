@@ -238,17 +238,45 @@ func extractJavaVersion(projectPath, projectType string) (*VersionInfo, error) {
 	return extractFallback(projectPath)
 }
 
-// extractMavenVersion extracts version from Maven pom.xml
+// extractMavenVersion extracts version from Maven pom.xml. A
+// .flattened-pom.xml written by the flatten-maven-plugin is preferred
+// when it holds a concrete version, since pom.xml then typically carries
+// an unexpanded CI-friendly placeholder such as ${revision}.
 func extractMavenVersion(projectPath string) (*VersionInfo, error) {
+	if content, err := os.ReadFile(filepath.Join(projectPath, ".flattened-pom.xml")); err == nil {
+		if version, ok := scanMavenVersion(string(content)); ok && !strings.Contains(version, "$\u007b") {
+			return &VersionInfo{
+				Version:   version,
+				Source:    ".flattened-pom.xml",
+				IsDynamic: isMavenDynamicVersion(version),
+			}, nil
+		}
+	}
+
 	pomPath := filepath.Join(projectPath, "pom.xml")
 	content, err := os.ReadFile(pomPath)
 	if err != nil {
 		return extractFallback(projectPath)
 	}
 
+	if version, ok := scanMavenVersion(string(content)); ok {
+		return &VersionInfo{
+			Version:   version,
+			Source:    "pom.xml",
+			IsDynamic: isMavenDynamicVersion(version),
+		}, nil
+	}
+
+	return extractFallback(projectPath)
+}
+
+// scanMavenVersion returns the project's own <version> from POM content,
+// skipping the <parent> block and stopping at sections that hold unrelated
+// versions. ok is false when the POM declares no version of its own.
+func scanMavenVersion(content string) (string, bool) {
 	inProject := false
 	inParent := false
-	for _, line := range strings.Split(string(content), "\n") {
+	for _, line := range strings.Split(content, "\n") {
 		line = strings.TrimSpace(line)
 		if strings.Contains(line, "<project") {
 			inProject = true
@@ -268,18 +296,13 @@ func extractMavenVersion(projectPath string) (*VersionInfo, error) {
 			break
 		}
 		if version, ok := parseMavenVersionLine(line, inProject && !inParent); ok {
-			return &VersionInfo{
-				Version:   version,
-				Source:    "pom.xml",
-				IsDynamic: isMavenDynamicVersion(version),
-			}, nil
+			return version, true
 		}
 		if strings.Contains(line, "</parent>") {
 			inParent = false
 		}
 	}
-
-	return extractFallback(projectPath)
+	return "", false
 }
 
 // isMavenVersionBoundary reports whether the line opens a POM section that
@@ -467,17 +490,28 @@ func ensureTagsAreFetched(projectPath string) {
 	_ = cmd.Run() // Ignore errors - this is best-effort
 }
 
-// extractFromGit extracts version from git tags as a fallback
+// extractFromGit extracts version from git tags as a fallback. In a
+// shallow clone the tags below the history cut-off are unreachable from
+// HEAD, so the newest reachable tag could understate the version; there
+// only a tag on HEAD itself is used, as in a tag-triggered release
+// build, and otherwise the commit fallback.
 func extractFromGit(projectPath string) (*VersionInfo, error) {
-	// Ensure tags are fetched (important for CI environments)
-	ensureTagsAreFetched(projectPath)
+	var output []byte
+	var err error
+	if IsShallowClone(projectPath) {
+		cmd := exec.Command("git", "-C", projectPath, "describe", "--tags", "--exact-match", "HEAD")
+		output, err = cmd.CombinedOutput()
+	} else {
+		// Ensure tags are fetched (important for CI environments)
+		ensureTagsAreFetched(projectPath)
 
-	// Try to get the latest git tag
-	cmd := exec.Command("git", "-C", projectPath, "describe", "--tags", "--abbrev=0")
-	output, err := cmd.CombinedOutput()
+		// Try to get the latest git tag
+		cmd := exec.Command("git", "-C", projectPath, "describe", "--tags", "--abbrev=0")
+		output, err = cmd.CombinedOutput()
+	}
 	if err != nil {
-		// If no tags, try to get a short commit hash
-		cmd = exec.Command("git", "-C", projectPath, "rev-parse", "--short", "HEAD")
+		// If no usable tags, try to get a short commit hash
+		cmd := exec.Command("git", "-C", projectPath, "rev-parse", "--short", "HEAD")
 		output, err = cmd.CombinedOutput()
 		if err != nil {
 			return &VersionInfo{
@@ -227,6 +227,41 @@ func TestExtractFallbackPrefersVersionProperties(t *testing.T) {
 	}
 }
 
+func TestExtractMavenVersionPrefersFlattenedPOM(t *testing.T) {
+	// The raw pom.xml carries the CI-friendly ${revision} placeholder;
+	// the flatten-maven-plugin output holds the concrete version
+	dir := t.TempDir()
+	writeFile(t, dir, "pom.xml", "<project>\n  <artifactId>app</artifactId>\n  <version>${revision}</version>\n</project>\n")
+	writeFile(t, dir, ".flattened-pom.xml", "<project>\n  <artifactId>app</artifactId>\n  <version>4.0.2</version>\n</project>\n")
+
+	info, err := extractMavenVersion(dir)
+	if err != nil {
+		t.Fatalf("extractMavenVersion() error = %v", err)
+	}
+	if info.Version != "4.0.2" {
+		t.Errorf("Version = %q, want 4.0.2", info.Version)
+	}
+	if info.Source != ".flattened-pom.xml" {
+		t.Errorf("Source = %q, want .flattened-pom.xml", info.Source)
+	}
+}
+
+func TestExtractMavenVersionFallsBackToPOM(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pom.xml", "<project>\n  <artifactId>app</artifactId>\n  <version>1.1.0</version>\n</project>\n")
+
+	info, err := extractMavenVersion(dir)
+	if err != nil {
+		t.Fatalf("extractMavenVersion() error = %v", err)
+	}
+	if info.Version != "1.1.0" {
+		t.Errorf("Version = %q, want 1.1.0", info.Version)
+	}
+	if info.Source != "pom.xml" {
+		t.Errorf("Source = %q, want pom.xml", info.Source)
+	}
+}
+
 func TestIsPlaceholderVersion(t *testing.T) {
 	placeholders := []string{"", "0.0.0"}
 	for _, v := range placeholders {
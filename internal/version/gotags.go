@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package version
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// goTagSemverRe matches a Go module release tag: v-prefixed semver
+	// with optional pre-release and build metadata
+	goTagSemverRe = regexp.MustCompile(`^v(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+	// goModuleMajorSuffixRe matches the /vN major-version suffix of a
+	// module path
+	goModuleMajorSuffixRe = regexp.MustCompile(`/v([2-9]|[1-9]\d+)$`)
+)
+
+// GoModuleVersionFromTags resolves a Go module's version from the newest
+// v-prefixed semver tag reachable from HEAD. A module path ending in /vN
+// only considers vN.x.y tags; any other module only considers v0 and v1,
+// matching how the go command maps tags to module versions. Modules in
+// a repository subdirectory use tags carrying that directory as a prefix
+// (e.g. "tools/v1.2.0"). The returned version has the "v" stripped.
+func GoModuleVersionFromTags(projectPath, modulePath string) (*VersionInfo, error) {
+	return goModuleVersionFromTags(projectPath, modulePath, "--merged", "reachable from")
+}
+
+// GoModuleVersionFromHeadTags is GoModuleVersionFromTags restricted to
+// tags pointing at HEAD itself. It needs no history, so it stays correct
+// in a shallow clone, where the newest reachable tag may be cut off.
+func GoModuleVersionFromHeadTags(projectPath, modulePath string) (*VersionInfo, error) {
+	return goModuleVersionFromTags(projectPath, modulePath, "--points-at", "at")
+}
+
+// goModuleVersionFromTags selects the module's tag among those that
+// git tag lists with filter ("--merged" or "--points-at") HEAD; relation
+// describes that filter in the error when no tag matches.
+func goModuleVersionFromTags(projectPath, modulePath, filter, relation string) (*VersionInfo, error) {
+	prefixOutput, err := exec.Command("git", "-C", projectPath, "rev-parse", "--show-prefix").Output()
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository: %w", err)
+	}
+	tagPrefix := strings.TrimSpace(string(prefixOutput))
+
+	output, err := exec.Command("git", "-C", projectPath, "tag", filter, "HEAD", "--list", tagPrefix+"v*").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git tags: %w", err)
+	}
+
+	tag, ok := selectGoModuleTag(strings.Fields(string(output)), tagPrefix, goModuleMajor(modulePath))
+	if !ok {
+		return nil, fmt.Errorf("no semver tag for module %s %s HEAD", modulePath, relation)
+	}
+
+	return &VersionInfo{
+		Version: strings.TrimPrefix(strings.TrimPrefix(tag, tagPrefix), "v"),
+		Source:  "git tag",
+		Tags:    []string{tag},
+	}, nil
+}
+
+// goModuleMajor returns the major version a module path admits: N for a
+// /vN suffix, otherwise 1 (which also admits v0 tags).
+func goModuleMajor(modulePath string) int {
+	if matches := goModuleMajorSuffixRe.FindStringSubmatch(modulePath); matches != nil {
+		major, _ := strconv.Atoi(matches[1])
+		return major
+	}
+	return 1
+}
+
+// selectGoModuleTag returns the highest semver tag (after removing
+// tagPrefix) whose major version belongs to the module.
+func selectGoModuleTag(tags []string, tagPrefix string, major int) (string, bool) {
+	var best string
+	var bestParts []string
+	for _, tag := range tags {
+		name, ok := strings.CutPrefix(tag, tagPrefix)
+		if !ok {
+			continue
+		}
+		parts := goTagSemverRe.FindStringSubmatch(name)
+		if parts == nil {
+			continue
+		}
+		tagMajor, _ := strconv.Atoi(parts[1])
+		if tagMajor != major && !(major == 1 && tagMajor == 0) {
+			continue
+		}
+		if bestParts == nil || compareSemverParts(parts, bestParts) > 0 {
+			best, bestParts = tag, parts
+		}
+	}
+	return best, bestParts != nil
+}
+
+// compareSemverParts orders two goTagSemverRe matches by semver
+// precedence: numeric major.minor.patch, then a release above any of its
+// pre-releases, then pre-release identifiers field by field.
+func compareSemverParts(a, b []string) int {
+	for i := 1; i <= 3; i++ {
+		x, _ := strconv.Atoi(a[i])
+		y, _ := strconv.Atoi(b[i])
+		if x != y {
+			return x - y
+		}
+	}
+
+	switch {
+	case a[4] == b[4]:
+		return 0
+	case a[4] == "":
+		return 1
+	case b[4] == "":
+		return -1
+	}
+
+	aFields, bFields := strings.Split(a[4], "."), strings.Split(b[4], ".")
+	for i := 0; i < len(aFields) && i < len(bFields); i++ {
+		if c := comparePrereleaseField(aFields[i], bFields[i]); c != 0 {
+			return c
+		}
+	}
+	return len(aFields) - len(bFields)
+}
+
+// comparePrereleaseField compares numeric identifiers numerically (and
+// below alphanumeric ones) and the rest lexically.
+func comparePrereleaseField(a, b string) int {
+	x, aErr := strconv.Atoi(a)
+	y, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return x - y
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package version
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// runGit runs a git command in dir with a fixed identity, failing the test
+// on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	base := []string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}
+	if output, err := exec.Command("git", append(base, args...)...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+// newTaggedGoRepo creates a git repository holding go.mod for modulePath,
+// with one commit per tag.
+func newTaggedGoRepo(t *testing.T, modulePath string, tags ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--quiet")
+	writeFile(t, dir, "go.mod", "module "+modulePath+"\n\ngo 1.24\n")
+	for _, tag := range tags {
+		writeFile(t, dir, "CHANGES", tag+"\n")
+		runGit(t, dir, "add", "-A")
+		runGit(t, dir, "commit", "--quiet", "-m", "release "+tag)
+		runGit(t, dir, "tag", tag)
+	}
+	return dir
+}
+
+func TestGoModuleVersionFromTags(t *testing.T) {
+	dir := newTaggedGoRepo(t, "example.com/tagged", "v1.0.0", "v1.1.0")
+
+	info, err := GoModuleVersionFromTags(dir, "example.com/tagged")
+	if err != nil {
+		t.Fatalf("GoModuleVersionFromTags() error = %v", err)
+	}
+	if info.Version != "1.1.0" || info.Source != "git tag" {
+		t.Errorf("got version %q from %q, want 1.1.0 from git tag", info.Version, info.Source)
+	}
+}
+
+func TestGoModuleVersionFromTagsIgnoresUnreachableTags(t *testing.T) {
+	dir := newTaggedGoRepo(t, "example.com/tagged", "v1.0.0", "v1.1.0")
+	runGit(t, dir, "checkout", "--quiet", "v1.0.0")
+
+	info, err := GoModuleVersionFromTags(dir, "example.com/tagged")
+	if err != nil {
+		t.Fatalf("GoModuleVersionFromTags() error = %v", err)
+	}
+	if info.Version != "1.0.0" {
+		t.Errorf("version = %q, want 1.0.0 (v1.1.0 is not reachable from HEAD)", info.Version)
+	}
+}
+
+func TestGoModuleVersionFromTagsMajorSuffix(t *testing.T) {
+	dir := newTaggedGoRepo(t, "example.com/tagged/v2", "v1.0.0", "v2.0.0", "v1.1.0")
+
+	info, err := GoModuleVersionFromTags(dir, "example.com/tagged/v2")
+	if err != nil {
+		t.Fatalf("GoModuleVersionFromTags() error = %v", err)
+	}
+	if info.Version != "2.0.0" {
+		t.Errorf("version = %q, want 2.0.0 for a /v2 module", info.Version)
+	}
+
+	// Without the suffix the module cannot be v2, so v1.1.0 wins
+	info, err = GoModuleVersionFromTags(dir, "example.com/tagged")
+	if err != nil {
+		t.Fatalf("GoModuleVersionFromTags() error = %v", err)
+	}
+	if info.Version != "1.1.0" {
+		t.Errorf("version = %q, want 1.1.0 for a module without /vN", info.Version)
+	}
+}
+
+func TestGoModuleVersionFromTagsSubdirectoryModule(t *testing.T) {
+	dir := newTaggedGoRepo(t, "example.com/root", "v1.5.0")
+	toolsDir := filepath.Join(dir, "tools")
+	if err := os.MkdirAll(toolsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, toolsDir, "go.mod", "module example.com/root/tools\n")
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "--quiet", "-m", "add tools")
+	runGit(t, dir, "tag", "tools/v0.3.0")
+
+	info, err := GoModuleVersionFromTags(toolsDir, "example.com/root/tools")
+	if err != nil {
+		t.Fatalf("GoModuleVersionFromTags() error = %v", err)
+	}
+	if info.Version != "0.3.0" {
+		t.Errorf("version = %q, want 0.3.0 from the tools/ prefixed tag", info.Version)
+	}
+}
+
+func TestGoModuleVersionFromTagsNoTags(t *testing.T) {
+	dir := newTaggedGoRepo(t, "example.com/tagged", "release-1")
+
+	if _, err := GoModuleVersionFromTags(dir, "example.com/tagged"); err == nil {
+		t.Error("expected an error when no semver tag exists")
+	}
+}
+
+func TestSelectGoModuleTag(t *testing.T) {
+	tags := []string{"v1.2.0", "v1.10.0-rc.1", "v1.9.0", "v1.10.0-rc.2", "v1.10.0-beta", "v3.0.0", "1.11.0", "v1.10"}
+
+	got, ok := selectGoModuleTag(tags, "", 1)
+	if !ok || got != "v1.10.0-rc.2" {
+		t.Errorf("selectGoModuleTag() = %q, %v, want v1.10.0-rc.2", got, ok)
+	}
+
+	got, ok = selectGoModuleTag(append(tags, "v1.10.0"), "", 1)
+	if !ok || got != "v1.10.0" {
+		t.Errorf("selectGoModuleTag() = %q, %v, want the v1.10.0 release over its pre-releases", got, ok)
+	}
+
+	if _, ok := selectGoModuleTag(tags, "", 2); ok {
+		t.Error("selectGoModuleTag() found a tag for major 2, want none")
+	}
+}
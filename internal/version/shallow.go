@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsShallowClone reports whether projectPath lies in a shallow git clone,
+// such as the fetch-depth: 1 checkout CI runs by default. Git records
+// the history cut-off in a shallow file inside the git directory; the
+// nearest .git entry above projectPath is checked, following the gitdir
+// pointer of a worktree or submodule .git file.
+func IsShallowClone(projectPath string) bool {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return false
+	}
+	for {
+		gitPath := filepath.Join(dir, ".git")
+		if info, err := os.Stat(gitPath); err == nil {
+			gitDir := gitPath
+			if !info.IsDir() {
+				if gitDir = readGitDirFile(gitPath); gitDir == "" {
+					return false
+				}
+			}
+			return hasShallowFile(gitDir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
+// readGitDirFile returns the git directory a "gitdir: <path>" .git file
+// points at, resolved against the file's directory, or "" when the file
+// is not in that form.
+func readGitDirFile(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	return gitDir
+}
+
+// hasShallowFile checks gitDir for the shallow marker, and for a linked
+// worktree also the common directory that holds it.
+func hasShallowFile(gitDir string) bool {
+	if _, err := os.Stat(filepath.Join(gitDir, "shallow")); err == nil {
+		return true
+	}
+	content, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return false
+	}
+	commonDir := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	_, err = os.Stat(filepath.Join(commonDir, "shallow"))
+	return err == nil
+}
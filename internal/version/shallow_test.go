@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsShallowClone(t *testing.T) {
+	root := t.TempDir()
+	project := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if IsShallowClone(project) {
+		t.Error("IsShallowClone() = true without a shallow marker")
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ".git", "shallow"), []byte("0123456789abcdef0123456789abcdef01234567\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !IsShallowClone(project) {
+		t.Error("IsShallowClone() = false for a subdirectory of a shallow clone")
+	}
+	if !IsShallowClone(root) {
+		t.Error("IsShallowClone() = false at the root of a shallow clone")
+	}
+}
+
+func TestIsShallowCloneWorktree(t *testing.T) {
+	root := t.TempDir()
+	common := filepath.Join(root, "main", ".git")
+	worktreeGitDir := filepath.Join(common, "worktrees", "feature")
+	worktree := filepath.Join(root, "feature")
+	for _, dir := range []string{worktreeGitDir, worktree} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+worktreeGitDir+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktreeGitDir, "commondir"), []byte("../..\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if IsShallowClone(worktree) {
+		t.Error("IsShallowClone() = true without a shallow marker")
+	}
+	if err := os.WriteFile(filepath.Join(common, "shallow"), []byte("0123456789abcdef0123456789abcdef01234567\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !IsShallowClone(worktree) {
+		t.Error("IsShallowClone() = false for a worktree of a shallow clone")
+	}
+}
+
+func TestIsShallowCloneOutsideRepository(t *testing.T) {
+	if IsShallowClone(t.TempDir()) {
+		t.Error("IsShallowClone() = true outside a git repository")
+	}
+}
+
+func TestExtractFromGitSkipsTagsInShallowClone(t *testing.T) {
+	dir := newTaggedGoRepo(t, "example.com/tagged", "v1.0.0", "v1.1.0")
+	writeFile(t, dir, "CHANGES", "unreleased\n")
+	runGit(t, dir, "commit", "--quiet", "-am", "unreleased change")
+
+	info, err := extractFromGit(dir)
+	if err != nil || info.Source != "git-tag" || info.Version != "1.1.0" {
+		t.Fatalf("extractFromGit() = %+v, %v, want 1.1.0 from git-tag", info, err)
+	}
+
+	// The v1.1.0 commit is within the cloned depth, so only the shallow
+	// guard keeps describe from reporting it
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(t, dir, "clone", "--quiet", "--depth", "2", "file://"+dir, clone)
+	info, err = extractFromGit(clone)
+	if err != nil {
+		t.Fatalf("extractFromGit() error = %v", err)
+	}
+	if info.Source != "git-commit" {
+		t.Errorf("extractFromGit() in a shallow clone = %+v, want the git-commit fallback", info)
+	}
+}
+
+func TestExtractFromGitUsesHeadTagInShallowClone(t *testing.T) {
+	dir := newTaggedGoRepo(t, "example.com/tagged", "v1.0.0", "v1.1.0")
+
+	// A tag-triggered release build checks out the tagged commit itself
+	clone := filepath.Join(t.TempDir(), "clone")
+	runGit(t, dir, "clone", "--quiet", "--depth", "1", "--branch", "v1.1.0", "file://"+dir, clone)
+	info, err := extractFromGit(clone)
+	if err != nil || info.Source != "git-tag" || info.Version != "1.1.0" {
+		t.Errorf("extractFromGit() in a shallow clone of a tag = %+v, %v, want 1.1.0 from git-tag", info, err)
+	}
+
+	info, err = GoModuleVersionFromHeadTags(clone, "example.com/tagged")
+	if err != nil || info.Version != "1.1.0" {
+		t.Errorf("GoModuleVersionFromHeadTags() = %+v, %v, want 1.1.0", info, err)
+	}
+}
+
+func TestGoModuleVersionFromHeadTagsIgnoresAncestorTags(t *testing.T) {
+	dir := newTaggedGoRepo(t, "example.com/tagged", "v1.0.0")
+	writeFile(t, dir, "CHANGES", "unreleased\n")
+	runGit(t, dir, "commit", "--quiet", "-am", "unreleased change")
+
+	if info, err := GoModuleVersionFromHeadTags(dir, "example.com/tagged"); err == nil {
+		t.Errorf("GoModuleVersionFromHeadTags() = %+v, want no tag on HEAD", info)
+	}
+}
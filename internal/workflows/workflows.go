@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+// Package workflows records what a repository's GitHub Actions workflows
+// use: the actions their steps run and the reusable workflows their jobs
+// call.
+package workflows
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Report summarizes the workflow files of a repository
+type Report struct {
+	// WorkflowCount is the number of workflow files found
+	WorkflowCount int
+	// ActionsUsed lists every distinct "uses:" reference, sorted, e.g.
+	// "actions/checkout@v4" or "org/repo/.github/workflows/ci.yml@main"
+	ActionsUsed []string
+	// Dir is the .github/workflows directory read, "" when none exists
+	Dir string
+}
+
+// workflow holds the parts of a workflow file that reference actions
+type workflow struct {
+	Jobs map[string]struct {
+		Uses  string `yaml:"uses"`
+		Steps []struct {
+			Uses string `yaml:"uses"`
+		} `yaml:"steps"`
+	} `yaml:"jobs"`
+}
+
+// Collect reads the .github/workflows/*.yml and *.yaml files of the
+// repository containing projectPath: the nearest .github/workflows in
+// projectPath or a parent, stopping at the directory holding .git. Files
+// that fail to parse still count but contribute no references.
+func Collect(projectPath string) Report {
+	var report Report
+	report.Dir = findWorkflowsDir(projectPath)
+	if report.Dir == "" {
+		return report
+	}
+
+	var files []string
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, _ := filepath.Glob(filepath.Join(report.Dir, pattern))
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+
+	seen := make(map[string]bool)
+	for _, file := range files {
+		report.WorkflowCount++
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var wf workflow
+		if err := yaml.Unmarshal(content, &wf); err != nil {
+			continue
+		}
+		for _, job := range wf.Jobs {
+			if job.Uses != "" {
+				seen[job.Uses] = true
+			}
+			for _, step := range job.Steps {
+				if step.Uses != "" {
+					seen[step.Uses] = true
+				}
+			}
+		}
+	}
+
+	report.ActionsUsed = make([]string, 0, len(seen))
+	for ref := range seen {
+		report.ActionsUsed = append(report.ActionsUsed, ref)
+	}
+	sort.Strings(report.ActionsUsed)
+	return report
+}
+
+// findWorkflowsDir returns the first .github/workflows directory found
+// walking up from projectPath, or "" once the repository root (or the
+// filesystem root) is passed without one.
+func findWorkflowsDir(projectPath string) string {
+	dir, err := filepath.Abs(projectPath)
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, ".github", "workflows")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: 2026 The Linux Foundation
+
+package workflows
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const ciWorkflow = `name: CI
+on: [push]
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+      - run: go test ./...
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: golangci/golangci-lint-action@v6
+`
+
+const releaseWorkflow = `name: Release
+on:
+  push:
+    tags: ["v*"]
+jobs:
+  build:
+    uses: lfreleng-actions/reusable-workflows/.github/workflows/release.yaml@main
+    secrets: inherit
+  publish:
+    needs: build
+    runs-on: ubuntu-latest
+    steps:
+      - uses: ./.github/actions/publish
+      - uses: docker://alpine:3.20
+`
+
+func TestCollect(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(root, ".github", "workflows", "ci.yml"), ciWorkflow)
+	writeFile(t, filepath.Join(root, ".github", "workflows", "release.yaml"), releaseWorkflow)
+	writeFile(t, filepath.Join(root, ".github", "workflows", "broken.yml"), "jobs: [\n")
+	writeFile(t, filepath.Join(root, ".github", "workflows", "README.md"), "not a workflow\n")
+
+	// A project below the repository root uses the root's workflows
+	project := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(project, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	report := Collect(project)
+	if report.WorkflowCount != 3 {
+		t.Errorf("WorkflowCount = %d, want 3", report.WorkflowCount)
+	}
+	want := []string{
+		"./.github/actions/publish",
+		"actions/checkout@v4",
+		"actions/setup-go@v5",
+		"docker://alpine:3.20",
+		"golangci/golangci-lint-action@v6",
+		"lfreleng-actions/reusable-workflows/.github/workflows/release.yaml@main",
+	}
+	if !reflect.DeepEqual(report.ActionsUsed, want) {
+		t.Errorf("ActionsUsed = %v, want %v", report.ActionsUsed, want)
+	}
+}
+
+func TestCollectStopsAtRepositoryRoot(t *testing.T) {
+	outer := t.TempDir()
+	writeFile(t, filepath.Join(outer, ".github", "workflows", "ci.yml"), ciWorkflow)
+	repo := filepath.Join(outer, "repo")
+	writeFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+
+	if report := Collect(repo); report.Dir != "" || report.WorkflowCount != 0 || len(report.ActionsUsed) != 0 {
+		t.Errorf("Collect() = %+v, want nothing outside the repository", report)
+	}
+}